@@ -13,6 +13,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -26,10 +27,19 @@ import (
 	"github.com/harusys/super-shiharai-kun/internal/controller/middleware"
 	"github.com/harusys/super-shiharai-kun/internal/domain/service"
 	"github.com/harusys/super-shiharai-kun/internal/infrastructure/database"
+	"github.com/harusys/super-shiharai-kun/internal/infrastructure/mail"
 	"github.com/harusys/super-shiharai-kun/internal/infrastructure/persistence"
 	"github.com/harusys/super-shiharai-kun/internal/infrastructure/security"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/approval"
 	"github.com/harusys/super-shiharai-kun/internal/usecase/auth"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/company"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/invitation"
 	"github.com/harusys/super-shiharai-kun/internal/usecase/invoice"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/mfa"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/postalcode"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/recurring"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/vendorimport"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/vendors"
 	"github.com/harusys/super-shiharai-kun/pkg/ctxutil"
 )
 
@@ -63,18 +73,117 @@ func run() error {
 	slog.Info("connected to database")
 
 	// Initialize repositories
+	transactor := persistence.NewTransactor(pool)
 	userRepo := persistence.NewUserRepository(pool)
 	vendorRepo := persistence.NewVendorRepository(pool)
 	bankAccountRepo := persistence.NewVendorBankAccountRepository(pool)
 	invoiceRepo := persistence.NewInvoiceRepository(pool)
+	approvalPolicyRepo := persistence.NewApprovalPolicyRepository(pool)
+	scheduleRepo := persistence.NewRecurringInvoiceScheduleRepository(pool)
+	postalCodeRepo := persistence.NewPostalCodeRepository(pool)
+	companyRepo := persistence.NewCompanyRepository(pool)
+	companyAuditLogRepo := persistence.NewCompanyAuditLogRepository(pool)
+	invitationRepo := persistence.NewInvitationRepository(pool)
+	membershipRepo := persistence.NewMembershipRepository(pool)
+	refreshTokenRepo := persistence.NewRefreshTokenRepository(pool)
+	mfaRepo := persistence.NewUserMFARepository(pool)
+	revocationRepo := persistence.NewCachedTokenRevocationRepository(
+		persistence.NewTokenRevocationRepository(pool),
+		cfg.TokenRevocationCacheTTL,
+	)
 
 	// Initialize services
-	jwtService := security.NewJWTService(cfg.JWTSecret)
+	jwtKeys, err := security.LoadKeySet(cfg.JWTSigningKeyFile, cfg.JWTVerificationKeyFiles)
+	if err != nil {
+		return fmt.Errorf("failed to load JWT keys: %w", err)
+	}
+
+	jwtService := security.NewJWTService(jwtKeys)
 	calculator := service.NewInvoiceCalculator()
+	withholdingCalc := service.NewWithholdingTaxCalculator()
+
+	mailer, err := newMailer(cfg)
+	if err != nil {
+		return err
+	}
 
 	// Initialize usecases
-	authUsecase := auth.NewUsecase(userRepo, jwtService)
-	invoiceUsecase := invoice.NewUsecase(invoiceRepo, vendorRepo, bankAccountRepo, calculator)
+	invoiceUsecase := invoice.NewUsecase(
+		transactor,
+		invoiceRepo,
+		vendorRepo,
+		bankAccountRepo,
+		approvalPolicyRepo,
+		userRepo,
+		calculator,
+		withholdingCalc,
+	)
+	approvalUsecase := approval.NewUsecase(approvalPolicyRepo, userRepo)
+	postalCodeUsecase := postalcode.NewUsecase(postalCodeRepo)
+	companyUsecase := company.NewUsecase(
+		transactor,
+		companyRepo,
+		companyAuditLogRepo,
+		userRepo,
+		postalCodeUsecase,
+	)
+	invitationUsecase := invitation.NewUsecase(
+		transactor,
+		invitationRepo,
+		userRepo,
+		membershipRepo,
+		companyRepo,
+		mailer,
+		cfg.AppBaseURL,
+		cfg.InvitationTTL,
+	)
+	mfaUsecase := mfa.NewUsecase(
+		transactor,
+		mfaRepo,
+		userRepo,
+		membershipRepo,
+		companyRepo,
+		companyAuditLogRepo,
+		refreshTokenRepo,
+		revocationRepo,
+	)
+	authUsecase := auth.NewUsecase(
+		transactor,
+		userRepo,
+		membershipRepo,
+		refreshTokenRepo,
+		revocationRepo,
+		companyUsecase,
+		invitationUsecase,
+		mfaUsecase,
+		jwtService,
+	)
+	vendorUsecase := vendors.NewUsecase(
+		transactor,
+		vendorRepo,
+		bankAccountRepo,
+		invoiceRepo,
+		userRepo,
+		postalCodeUsecase,
+		mailer,
+		cfg.BankAccountCoolingOff,
+	)
+	importUsecase := vendorimport.NewUsecase(
+		transactor,
+		vendorRepo,
+		bankAccountRepo,
+		userRepo,
+		postalCodeUsecase,
+		mailer,
+		cfg.BankAccountCoolingOff,
+	)
+	recurringUsecase := recurring.NewUsecase(
+		scheduleRepo,
+		vendorRepo,
+		bankAccountRepo,
+		invoiceUsecase,
+		cfg.RecurringInvoiceLeadDays,
+	)
 
 	// Setup gin router
 	gin.SetMode(gin.ReleaseMode)
@@ -86,9 +195,16 @@ func run() error {
 
 	// Setup routes
 	controller.SetupRoutes(r, &controller.RouterConfig{
-		AuthUsecase:    authUsecase,
-		InvoiceUsecase: invoiceUsecase,
-		JWTService:     jwtService,
+		AuthUsecase:       authUsecase,
+		InvoiceUsecase:    invoiceUsecase,
+		RecurringUsecase:  recurringUsecase,
+		ApprovalUsecase:   approvalUsecase,
+		VendorUsecase:     vendorUsecase,
+		ImportUsecase:     importUsecase,
+		PostalCodeUsecase: postalCodeUsecase,
+		CompanyUsecase:    companyUsecase,
+		InvitationUsecase: invitationUsecase,
+		MFAUsecase:        mfaUsecase,
 	})
 
 	// Health check endpoint
@@ -123,6 +239,28 @@ func run() error {
 	}
 }
 
+var errUnknownMailDriver = errors.New("unknown mail driver")
+
+// newMailer creates the Mailer selected by cfg.MailDriver.
+//
+//nolint:ireturn // the driver decides the implementation
+func newMailer(cfg *config.Config) (mail.Mailer, error) {
+	switch cfg.MailDriver {
+	case "outbox":
+		return mail.NewFileOutbox(cfg.MailOutboxDir, cfg.MailFrom), nil
+	case "smtp":
+		return mail.NewSMTPMailer(
+			cfg.SMTPHost,
+			cfg.SMTPPort,
+			cfg.SMTPUsername,
+			cfg.SMTPPassword,
+			cfg.MailFrom,
+		), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", errUnknownMailDriver, cfg.MailDriver)
+	}
+}
+
 func ginLogger() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		ctx := c.Request.Context()
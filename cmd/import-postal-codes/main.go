@@ -0,0 +1,66 @@
+// Package main provides a one-shot command that imports Japan Post's postal code
+// dataset (KEN_ALL.CSV) into the postal code table, replacing its contents.
+// Run it again whenever Japan Post publishes an update.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/harusys/super-shiharai-kun/internal/config"
+	"github.com/harusys/super-shiharai-kun/internal/infrastructure/database"
+	"github.com/harusys/super-shiharai-kun/internal/infrastructure/persistence"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/postalcode"
+)
+
+var errUsage = errors.New("usage: import-postal-codes <path to KEN_ALL.CSV>")
+
+func main() {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	slog.SetDefault(logger)
+
+	if err := run(); err != nil {
+		slog.Error("application error", "error", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	ctx := context.Background()
+
+	if len(os.Args) != 2 { //nolint:mnd // program name and file path
+		return errUsage
+	}
+
+	data, err := os.ReadFile(os.Args[1]) //nolint:gosec // path given by the operator
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	// Load configuration
+	cfg, err := config.LoadBatch()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Initialize database connection
+	pool, err := database.NewPool(ctx, cfg.DatabaseURL())
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer pool.Close()
+
+	postalCodeUsecase := postalcode.NewUsecase(persistence.NewPostalCodeRepository(pool))
+
+	count, err := postalCodeUsecase.Import(ctx, data)
+	if err != nil {
+		return fmt.Errorf("failed to import postal codes: %w", err)
+	}
+
+	slog.Info("postal codes imported", "count", count)
+
+	return nil
+}
@@ -0,0 +1,61 @@
+// Package main provides a one-shot command that deletes the records of expired
+// revoked access tokens and refresh tokens.
+// It is intended to be run periodically (e.g. daily by cron).
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/harusys/super-shiharai-kun/internal/config"
+	"github.com/harusys/super-shiharai-kun/internal/infrastructure/database"
+	"github.com/harusys/super-shiharai-kun/internal/infrastructure/persistence"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/tokenpurge"
+)
+
+func main() {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	slog.SetDefault(logger)
+
+	if err := run(); err != nil {
+		slog.Error("application error", "error", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	ctx := context.Background()
+
+	// Load configuration
+	cfg, err := config.LoadBatch()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Initialize database connection
+	pool, err := database.NewPool(ctx, cfg.DatabaseURL())
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer pool.Close()
+
+	// Initialize usecase
+	purgeUsecase := tokenpurge.NewUsecase(
+		persistence.NewTokenRevocationRepository(pool),
+		persistence.NewRefreshTokenRepository(pool),
+	)
+
+	result, err := purgeUsecase.Purge(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to purge expired tokens: %w", err)
+	}
+
+	slog.Info("expired tokens purged",
+		"revoked_tokens", result.RevokedTokens,
+		"refresh_tokens", result.RefreshTokens,
+	)
+
+	return nil
+}
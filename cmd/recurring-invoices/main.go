@@ -0,0 +1,85 @@
+// Package main provides a one-shot command that materializes recurring invoices.
+// It is intended to be run periodically (e.g. daily by cron).
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/harusys/super-shiharai-kun/internal/config"
+	"github.com/harusys/super-shiharai-kun/internal/domain/service"
+	"github.com/harusys/super-shiharai-kun/internal/infrastructure/database"
+	"github.com/harusys/super-shiharai-kun/internal/infrastructure/persistence"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/invoice"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/recurring"
+)
+
+func main() {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	slog.SetDefault(logger)
+
+	if err := run(); err != nil {
+		slog.Error("application error", "error", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	// Schedules of every company are materialized, so row-level security must
+	// not narrow the queries down to a single company.
+	ctx := database.WithAllCompanies(context.Background())
+
+	// Load configuration
+	cfg, err := config.LoadBatch()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Initialize database connection
+	pool, err := database.NewPool(ctx, cfg.DatabaseURL())
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer pool.Close()
+
+	// Initialize repositories
+	transactor := persistence.NewTransactor(pool)
+	userRepo := persistence.NewUserRepository(pool)
+	vendorRepo := persistence.NewVendorRepository(pool)
+	bankAccountRepo := persistence.NewVendorBankAccountRepository(pool)
+	invoiceRepo := persistence.NewInvoiceRepository(pool)
+	approvalPolicyRepo := persistence.NewApprovalPolicyRepository(pool)
+	scheduleRepo := persistence.NewRecurringInvoiceScheduleRepository(pool)
+
+	// Initialize usecases
+	invoiceUsecase := invoice.NewUsecase(
+		transactor,
+		invoiceRepo,
+		vendorRepo,
+		bankAccountRepo,
+		approvalPolicyRepo,
+		userRepo,
+		service.NewInvoiceCalculator(),
+		service.NewWithholdingTaxCalculator(),
+	)
+	recurringUsecase := recurring.NewUsecase(
+		scheduleRepo,
+		vendorRepo,
+		bankAccountRepo,
+		invoiceUsecase,
+		cfg.RecurringInvoiceLeadDays,
+	)
+
+	result, err := recurringUsecase.Materialize(ctx)
+	if result != nil {
+		slog.Info("recurring invoices materialized", "count", len(result.Invoices))
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to materialize recurring invoices: %w", err)
+	}
+
+	return nil
+}
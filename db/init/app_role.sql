@@ -0,0 +1,12 @@
+-- アプリケーション用ロール
+-- テーブルの所有者（マイグレーションを実行する postgres）とスーパーユーザーには
+-- 行レベルセキュリティが適用されないため、アプリケーションはこのロールで接続する。
+CREATE ROLE app LOGIN PASSWORD 'app' NOSUPERUSER NOBYPASSRLS;
+
+GRANT USAGE ON SCHEMA public TO app;
+
+-- マイグレーションで作成されるテーブル・シーケンスへの権限
+ALTER DEFAULT PRIVILEGES FOR ROLE postgres IN SCHEMA public
+    GRANT SELECT, INSERT, UPDATE, DELETE ON TABLES TO app;
+ALTER DEFAULT PRIVILEGES FOR ROLE postgres IN SCHEMA public
+    GRANT USAGE, SELECT ON SEQUENCES TO app;
@@ -0,0 +1,16 @@
+-- name: GetApprovalPolicyByCompanyID :one
+SELECT * FROM approval_policies WHERE company_id = $1;
+
+-- name: UpsertApprovalPolicy :one
+INSERT INTO approval_policies (
+    company_id,
+    amount_threshold,
+    require_for_new_vendors
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (company_id) DO UPDATE SET
+    amount_threshold = EXCLUDED.amount_threshold,
+    require_for_new_vendors = EXCLUDED.require_for_new_vendors,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
@@ -7,9 +7,11 @@ INSERT INTO companies (
     representative_name,
     phone_number,
     zip_code,
-    address
+    address,
+    name_kana,
+    mfa_required
 ) VALUES (
-    $1, $2, $3, $4, $5
+    $1, $2, $3, $4, $5, $6, $7
 ) RETURNING *;
 
 -- name: UpdateCompany :one
@@ -19,6 +21,8 @@ UPDATE companies SET
     phone_number = $4,
     zip_code = $5,
     address = $6,
+    name_kana = $7,
+    mfa_required = $8,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
 RETURNING *;
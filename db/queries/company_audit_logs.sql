@@ -0,0 +1,14 @@
+-- name: CreateCompanyAuditLog :one
+INSERT INTO company_audit_logs (
+    company_id,
+    user_id,
+    changes
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
+
+-- name: GetCompanyAuditLogsByCompanyID :many
+SELECT * FROM company_audit_logs
+WHERE company_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT $2;
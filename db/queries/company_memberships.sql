@@ -0,0 +1,15 @@
+-- name: GetCompanyMembershipsByUserID :many
+SELECT m.*, c.name AS company_name
+FROM company_memberships m
+JOIN companies c ON c.id = m.company_id
+WHERE m.user_id = $1
+ORDER BY m.created_at, m.company_id;
+
+-- name: CreateCompanyMembership :one
+INSERT INTO company_memberships (
+    user_id,
+    company_id,
+    role
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
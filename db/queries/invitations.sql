@@ -0,0 +1,33 @@
+-- name: GetInvitationByTokenHash :one
+SELECT * FROM invitations WHERE token_hash = $1;
+
+-- name: GetPendingInvitationsByCompanyID :many
+SELECT * FROM invitations
+WHERE company_id = $1 AND accepted_at IS NULL
+ORDER BY created_at DESC, id DESC;
+
+-- name: CreateInvitation :one
+INSERT INTO invitations (
+    company_id,
+    email,
+    role,
+    token_hash,
+    invited_by,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: AcceptInvitation :one
+UPDATE invitations SET
+    accepted_at = $2
+WHERE id = $1 AND accepted_at IS NULL
+RETURNING *;
+
+-- name: DeletePendingInvitation :execrows
+DELETE FROM invitations
+WHERE id = $1 AND company_id = $2 AND accepted_at IS NULL;
+
+-- name: DeletePendingInvitationsByEmail :exec
+DELETE FROM invitations
+WHERE company_id = $1 AND email = $2 AND accepted_at IS NULL;
@@ -26,12 +26,73 @@ INSERT INTO invoices (
     tax,
     tax_rate,
     total_amount,
+    withholding_tax,
+    transfer_amount,
     due_date,
-    status
+    status,
+    vendor_registration_number,
+    recurring_invoice_schedule_id,
+    vendor_invoice_number,
+    duplicate_override,
+    created_by
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19
 ) RETURNING *;
 
+-- name: LockVendorInvoices :exec
+-- Serializes creating the vendor's invoices and changing its bank accounts
+-- until the end of the transaction.
+SELECT pg_advisory_xact_lock(hashtextextended('vendor-invoices:' || sqlc.arg(vendor_id)::BIGINT, 0));
+
+-- name: FindDuplicateInvoices :many
+-- Invoices that failed to be paid ('error') or were rejected are not considered duplicates.
+-- When both invoices carry the vendor's invoice number, the number alone decides.
+SELECT * FROM invoices
+WHERE company_id = sqlc.arg(company_id)
+  AND vendor_id = sqlc.arg(vendor_id)
+  AND status NOT IN ('error', 'rejected')
+  AND (
+      (
+          payment_amount = sqlc.arg(payment_amount)
+          AND issue_date = sqlc.arg(issue_date)
+          AND due_date = sqlc.arg(due_date)
+          AND (
+              vendor_invoice_number IS NULL
+              OR sqlc.narg(vendor_invoice_number)::VARCHAR IS NULL
+          )
+      )
+      OR vendor_invoice_number = sqlc.narg(vendor_invoice_number)::VARCHAR
+  )
+ORDER BY id;
+
+-- name: ExistsApprovedInvoiceByVendorID :one
+-- Whether the vendor has an invoice that was cleared for payment.
+SELECT EXISTS(
+    SELECT 1 FROM invoices
+    WHERE vendor_id = $1
+      AND status IN ('pending', 'processing', 'paid')
+);
+
+-- name: ExistsUnpaidInvoiceByVendorBankAccountID :one
+-- Whether an invoice that has not been paid yet still transfers to the bank account.
+SELECT EXISTS(
+    SELECT 1 FROM invoices
+    WHERE vendor_bank_account_id = $1
+      AND status IN ('awaiting_approval', 'pending', 'processing')
+);
+
+-- name: ReviewInvoice :one
+UPDATE invoices SET
+    status = $3,
+    reviewed_by = $4,
+    reviewed_at = $5,
+    rejection_reason = $6,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+  AND company_id = $2
+  AND status = 'awaiting_approval'
+RETURNING *;
+
 -- name: UpdateInvoiceStatus :one
 UPDATE invoices SET
     status = $2,
@@ -44,3 +105,63 @@ SELECT COUNT(*) FROM invoices
 WHERE company_id = $1
   AND due_date >= $2
   AND due_date <= $3;
+
+-- name: GetWithholdingSummariesByCompanyIDAndDateRange :many
+-- Tax is only withheld when an invoice is paid, so unpaid invoices are left
+-- out. The payment date is not recorded; paid invoices count in the year
+-- they were due.
+SELECT
+    v.id AS vendor_id,
+    v.name AS vendor_name,
+    COUNT(i.id) AS invoice_count,
+    SUM(i.payment_amount)::BIGINT AS payment_amount,
+    SUM(i.withholding_tax)::BIGINT AS withholding_tax
+FROM invoices i
+JOIN vendors v ON v.id = i.vendor_id
+WHERE i.company_id = $1
+  AND i.due_date >= $2
+  AND i.due_date <= $3
+  AND i.withholding_tax > 0
+  AND i.status = 'paid'
+GROUP BY v.id, v.name
+ORDER BY v.id;
+
+-- name: GetVendorPaymentSummary :one
+SELECT
+    COUNT(i.id) AS invoice_count,
+    COALESCE(SUM(i.payment_amount), 0)::BIGINT AS payment_amount,
+    COALESCE(SUM(i.withholding_tax), 0)::BIGINT AS withholding_tax,
+    COALESCE(SUM(i.transfer_amount), 0)::BIGINT AS transfer_amount,
+    COALESCE(ROUND(AVG(i.payment_amount)), 0)::BIGINT AS average_payment_amount,
+    MIN(i.due_date)::DATE AS first_payment_date,
+    MAX(i.due_date)::DATE AS last_payment_date
+FROM vendors v
+JOIN invoices i ON i.vendor_id = v.id
+WHERE v.id = $1
+  AND v.company_id = $2
+  AND i.status = 'paid';
+
+-- name: GetVendorMonthlyPayments :many
+SELECT
+    DATE_TRUNC('month', i.due_date)::DATE AS month,
+    COUNT(i.id) AS invoice_count,
+    SUM(i.payment_amount)::BIGINT AS payment_amount,
+    SUM(i.transfer_amount)::BIGINT AS transfer_amount
+FROM vendors v
+JOIN invoices i ON i.vendor_id = v.id
+WHERE v.id = $1
+  AND v.company_id = $2
+  AND i.status = 'paid'
+GROUP BY month
+ORDER BY month;
+
+-- name: CountVendorInvoicesByStatus :many
+SELECT
+    i.status,
+    COUNT(i.id) AS invoice_count
+FROM vendors v
+JOIN invoices i ON i.vendor_id = v.id
+WHERE v.id = $1
+  AND v.company_id = $2
+GROUP BY i.status
+ORDER BY i.status;
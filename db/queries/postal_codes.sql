@@ -0,0 +1,24 @@
+-- name: GetPostalCodesByCode :many
+SELECT * FROM postal_codes WHERE code = $1 ORDER BY id;
+
+-- name: ExistsPostalCode :one
+SELECT EXISTS(SELECT 1 FROM postal_codes WHERE code = $1);
+
+-- name: CountPostalCodes :one
+SELECT COUNT(*) FROM postal_codes;
+
+-- name: DeleteAllPostalCodes :exec
+DELETE FROM postal_codes;
+
+-- name: CreatePostalCodes :copyfrom
+INSERT INTO postal_codes (
+    code,
+    prefecture,
+    city,
+    town,
+    prefecture_kana,
+    city_kana,
+    town_kana
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+);
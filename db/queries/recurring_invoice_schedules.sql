@@ -0,0 +1,49 @@
+-- name: GetRecurringInvoiceScheduleByIDAndCompanyID :one
+SELECT * FROM recurring_invoice_schedules WHERE id = $1 AND company_id = $2;
+
+-- name: GetRecurringInvoiceSchedulesByCompanyID :many
+SELECT * FROM recurring_invoice_schedules WHERE company_id = $1 ORDER BY id;
+
+-- name: GetDueRecurringInvoiceSchedules :many
+SELECT * FROM recurring_invoice_schedules
+WHERE next_due_date <= $1
+  AND (end_date IS NULL OR next_due_date <= end_date)
+ORDER BY next_due_date ASC, id ASC;
+
+-- name: CreateRecurringInvoiceSchedule :one
+INSERT INTO recurring_invoice_schedules (
+    company_id,
+    vendor_id,
+    vendor_bank_account_id,
+    payment_amount,
+    day_of_month,
+    end_of_month,
+    start_date,
+    end_date,
+    next_due_date
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING *;
+
+-- name: UpdateRecurringInvoiceSchedule :one
+UPDATE recurring_invoice_schedules SET
+    vendor_id = $3,
+    vendor_bank_account_id = $4,
+    payment_amount = $5,
+    day_of_month = $6,
+    end_of_month = $7,
+    start_date = $8,
+    end_date = $9,
+    next_due_date = $10,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND company_id = $2
+RETURNING *;
+
+-- name: UpdateRecurringInvoiceScheduleNextDueDate :exec
+UPDATE recurring_invoice_schedules SET
+    next_due_date = $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1;
+
+-- name: DeleteRecurringInvoiceSchedule :execrows
+DELETE FROM recurring_invoice_schedules WHERE id = $1 AND company_id = $2;
@@ -0,0 +1,32 @@
+-- name: GetRefreshTokenByTokenHash :one
+SELECT * FROM refresh_tokens WHERE token_hash = $1;
+
+-- name: CreateRefreshToken :one
+INSERT INTO refresh_tokens (
+    user_id,
+    company_id,
+    family_id,
+    token_hash,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: RotateRefreshToken :one
+UPDATE refresh_tokens SET
+    rotated_at = $2
+WHERE id = $1 AND rotated_at IS NULL AND revoked_at IS NULL
+RETURNING *;
+
+-- name: RevokeRefreshTokenFamily :exec
+UPDATE refresh_tokens SET
+    revoked_at = $2
+WHERE family_id = $1 AND revoked_at IS NULL;
+
+-- name: RevokeRefreshTokensByUserID :exec
+UPDATE refresh_tokens SET
+    revoked_at = $2
+WHERE user_id = $1 AND revoked_at IS NULL;
+
+-- name: DeleteExpiredRefreshTokens :execrows
+DELETE FROM refresh_tokens WHERE expires_at < $1;
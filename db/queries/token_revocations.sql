@@ -0,0 +1,30 @@
+-- name: IsTokenRevoked :one
+SELECT
+    EXISTS (SELECT 1 FROM revoked_tokens r WHERE r.jti = sqlc.arg(jti))
+    OR EXISTS (
+        SELECT 1 FROM user_token_revocations u
+        WHERE u.user_id = sqlc.arg(user_id) AND u.revoked_before > sqlc.arg(issued_at)::TIMESTAMPTZ
+    ) AS revoked;
+
+-- name: RevokeToken :exec
+INSERT INTO revoked_tokens (
+    jti,
+    user_id,
+    expires_at
+) VALUES (
+    $1, $2, $3
+) ON CONFLICT (jti) DO NOTHING;
+
+-- name: RevokeUserTokens :exec
+INSERT INTO user_token_revocations (
+    user_id,
+    revoked_before
+) VALUES (
+    $1, $2
+) ON CONFLICT (user_id) DO UPDATE SET
+    revoked_before = GREATEST(user_token_revocations.revoked_before, EXCLUDED.revoked_before);
+
+
+-- name: DeleteExpiredRevokedTokens :execrows
+-- A revoked token only has to be remembered until it expires.
+DELETE FROM revoked_tokens WHERE expires_at < $1;
@@ -0,0 +1,81 @@
+-- name: GetUserMFAByUserID :one
+SELECT * FROM user_mfa WHERE user_id = $1;
+
+-- name: StartUserMFAEnrollment :one
+-- A pending enrolment gets the new secret; an enabled one is left untouched.
+INSERT INTO user_mfa (
+    user_id,
+    secret
+) VALUES (
+    $1, $2
+)
+ON CONFLICT (user_id) DO UPDATE SET
+    secret = EXCLUDED.secret,
+    last_used_step = 0,
+    failed_attempts = 0,
+    locked_until = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE user_mfa.enabled_at IS NULL
+RETURNING *;
+
+-- name: EnableUserMFA :one
+UPDATE user_mfa SET
+    enabled_at = $2,
+    last_used_step = $3,
+    updated_at = CURRENT_TIMESTAMP
+WHERE user_id = $1 AND enabled_at IS NULL
+RETURNING *;
+
+-- name: UseUserMFAStep :one
+-- Codes of the time step last used or an earlier one are not accepted again.
+UPDATE user_mfa SET
+    last_used_step = $2,
+    failed_attempts = 0,
+    locked_until = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE user_id = $1 AND last_used_step < $2
+RETURNING *;
+
+-- name: ReserveUserMFAAttempt :one
+-- Counts an attempt as failed before the code is checked, unless verification
+-- is locked at sqlc.arg(now), so that concurrent attempts cannot get past the
+-- lock. Verification is locked until locked_until once max_attempts attempts
+-- are counted; an accepted code clears the count.
+UPDATE user_mfa SET
+    failed_attempts = failed_attempts + 1,
+    locked_until = CASE
+        WHEN failed_attempts + 1 >= sqlc.arg(max_attempts)::INTEGER THEN sqlc.arg(locked_until)::TIMESTAMPTZ
+        ELSE NULL
+    END,
+    updated_at = CURRENT_TIMESTAMP
+WHERE user_id = $1
+  AND enabled_at IS NOT NULL
+  AND (locked_until IS NULL OR locked_until <= sqlc.arg(now)::TIMESTAMPTZ)
+RETURNING *;
+
+-- name: ClearUserMFAFailures :exec
+UPDATE user_mfa SET
+    failed_attempts = 0,
+    locked_until = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE user_id = $1;
+
+-- name: DeleteUserMFA :exec
+DELETE FROM user_mfa WHERE user_id = $1;
+
+-- name: DeleteMFARecoveryCodesByUserID :exec
+DELETE FROM mfa_recovery_codes WHERE user_id = $1;
+
+-- name: CreateMFARecoveryCode :exec
+INSERT INTO mfa_recovery_codes (
+    user_id,
+    code_hash
+) VALUES (
+    $1, $2
+);
+
+-- name: UseMFARecoveryCode :one
+UPDATE mfa_recovery_codes SET
+    used_at = $3
+WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+RETURNING *;
@@ -1,20 +1,32 @@
--- name: GetUserByID :one
-SELECT * FROM users WHERE id = $1;
+-- name: GetUserByIDAndCompanyID :one
+SELECT sqlc.embed(u), m.company_id, m.role
+FROM users u
+JOIN company_memberships m ON m.user_id = u.id
+WHERE u.id = $1 AND m.company_id = $2;
 
 -- name: GetUserByEmail :one
-SELECT * FROM users WHERE email = $1;
+-- The user is returned in the company they joined first.
+SELECT sqlc.embed(u), m.company_id, m.role
+FROM users u
+JOIN company_memberships m ON m.user_id = u.id
+WHERE u.email = $1
+ORDER BY m.created_at, m.company_id
+LIMIT 1;
 
 -- name: GetUsersByCompanyID :many
-SELECT * FROM users WHERE company_id = $1 ORDER BY id;
+SELECT sqlc.embed(u), m.company_id, m.role
+FROM users u
+JOIN company_memberships m ON m.user_id = u.id
+WHERE m.company_id = $1
+ORDER BY u.id;
 
 -- name: CreateUser :one
 INSERT INTO users (
-    company_id,
     name,
     email,
     password_hash
 ) VALUES (
-    $1, $2, $3, $4
+    $1, $2, $3
 ) RETURNING *;
 
 -- name: UpdateUser :one
@@ -31,5 +43,10 @@ UPDATE users SET
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1;
 
+-- name: LockUser :exec
+-- Adding the user to a company waits for the lock, since the membership
+-- references the user.
+SELECT id FROM users WHERE id = $1 FOR UPDATE;
+
 -- name: ExistsUserByEmail :one
 SELECT EXISTS(SELECT 1 FROM users WHERE email = $1);
@@ -7,15 +7,24 @@ SELECT * FROM vendor_bank_accounts WHERE vendor_id = $1 ORDER BY id;
 -- name: GetVendorBankAccountByIDAndVendorID :one
 SELECT * FROM vendor_bank_accounts WHERE id = $1 AND vendor_id = $2;
 
+-- name: GetDefaultVendorBankAccountByVendorID :one
+SELECT * FROM vendor_bank_accounts WHERE vendor_id = $1 AND is_default;
+
 -- name: CreateVendorBankAccount :one
 INSERT INTO vendor_bank_accounts (
     vendor_id,
     bank_name,
     branch_name,
     account_number,
-    account_holder_name
+    account_holder_name,
+    status,
+    changed_by,
+    cooling_off_until,
+    is_default,
+    bank_code,
+    branch_code
 ) VALUES (
-    $1, $2, $3, $4, $5
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
 ) RETURNING *;
 
 -- name: UpdateVendorBankAccount :one
@@ -24,6 +33,50 @@ UPDATE vendor_bank_accounts SET
     branch_name = $3,
     account_number = $4,
     account_holder_name = $5,
+    status = $6,
+    changed_by = $7,
+    cooling_off_until = $8,
+    bank_code = $9,
+    branch_code = $10,
+    verified_by = NULL,
+    verified_at = NULL,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
 RETURNING *;
+
+-- name: VerifyVendorBankAccount :one
+UPDATE vendor_bank_accounts SET
+    status = 'verified',
+    verified_by = $2,
+    verified_at = $3,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+  AND status = 'pending_verification'
+  -- Only verify the version the verifier looked at: a change in between
+  -- (possibly by the verifier themselves) restarts verification
+  AND changed_by = $4
+  AND updated_at = $5
+RETURNING *;
+
+-- name: ClearDefaultVendorBankAccount :exec
+UPDATE vendor_bank_accounts SET
+    is_default = FALSE,
+    updated_at = CURRENT_TIMESTAMP
+WHERE vendor_id = $1 AND is_default;
+
+-- name: SetDefaultVendorBankAccount :execrows
+UPDATE vendor_bank_accounts SET
+    is_default = TRUE,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND vendor_id = $2;
+
+-- name: UpdateVendorBankAccountArchivedAt :one
+UPDATE vendor_bank_accounts SET
+    archived_at = $3,
+    is_default = $4,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND vendor_id = $2
+RETURNING *;
+
+-- name: DeleteVendorBankAccount :execrows
+DELETE FROM vendor_bank_accounts WHERE id = $1 AND vendor_id = $2;
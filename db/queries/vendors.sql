@@ -4,9 +4,34 @@ SELECT * FROM vendors WHERE id = $1;
 -- name: GetVendorsByCompanyID :many
 SELECT * FROM vendors WHERE company_id = $1 ORDER BY id;
 
+-- name: SearchVendorsByCompanyID :many
+SELECT * FROM vendors
+WHERE company_id = sqlc.arg(company_id)
+  AND (
+    sqlc.narg(name)::TEXT IS NULL
+    OR name ILIKE '%' || sqlc.narg(name)::TEXT || '%'
+    OR name_kana LIKE '%' || sqlc.narg(name_kana)::TEXT || '%'
+  )
+  AND (sqlc.arg(include_archived)::BOOLEAN OR archived_at IS NULL)
+ORDER BY name_kana IS NULL, name_kana COLLATE "C", name, id
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: CountVendorsByCompanyID :one
+SELECT COUNT(*) FROM vendors
+WHERE company_id = sqlc.arg(company_id)
+  AND (
+    sqlc.narg(name)::TEXT IS NULL
+    OR name ILIKE '%' || sqlc.narg(name)::TEXT || '%'
+    OR name_kana LIKE '%' || sqlc.narg(name_kana)::TEXT || '%'
+  )
+  AND (sqlc.arg(include_archived)::BOOLEAN OR archived_at IS NULL);
+
 -- name: GetVendorByIDAndCompanyID :one
 SELECT * FROM vendors WHERE id = $1 AND company_id = $2;
 
+-- name: GetVendorByCompanyIDAndExternalCode :one
+SELECT * FROM vendors WHERE company_id = $1 AND external_code = $2;
+
 -- name: CreateVendor :one
 INSERT INTO vendors (
     company_id,
@@ -14,9 +39,13 @@ INSERT INTO vendors (
     representative_name,
     phone_number,
     zip_code,
-    address
+    address,
+    registration_number,
+    withholding_tax_applicable,
+    external_code,
+    name_kana
 ) VALUES (
-    $1, $2, $3, $4, $5, $6
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
 ) RETURNING *;
 
 -- name: UpdateVendor :one
@@ -26,6 +55,17 @@ UPDATE vendors SET
     phone_number = $4,
     zip_code = $5,
     address = $6,
+    registration_number = $7,
+    withholding_tax_applicable = $8,
+    external_code = $9,
+    name_kana = $10,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
 RETURNING *;
+
+-- name: UpdateVendorArchivedAt :one
+UPDATE vendors SET
+    archived_at = $3,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND company_id = $2
+RETURNING *;
@@ -2,25 +2,58 @@
 -- sqldef (psqldef) 用
 
 -- ステータス型
--- pending=未処理, processing=処理中, paid=支払済, error=エラー
-CREATE TYPE invoice_status AS ENUM ('pending', 'processing', 'paid', 'error');
+-- pending=未処理, processing=処理中, paid=支払済, error=エラー,
+-- awaiting_approval=承認待ち, rejected=差し戻し
+CREATE TYPE invoice_status AS ENUM ('pending', 'processing', 'paid', 'error', 'awaiting_approval', 'rejected');
+
+-- ユーザー権限型
+-- admin=管理者, accountant=経理担当, approver=承認者, viewer=閲覧のみ
+CREATE TYPE user_role AS ENUM ('admin', 'accountant', 'approver', 'viewer');
+
+-- 振込先口座の確認状況型
+-- pending_verification=確認待ち（登録・変更直後）, verified=確認済み
+CREATE TYPE bank_account_status AS ENUM ('pending_verification', 'verified');
+
+-- 郵便番号テーブル（日本郵便の KEN_ALL.CSV から取り込む。1つの郵便番号に複数の町域がある）
+CREATE TABLE postal_codes (
+    id BIGSERIAL PRIMARY KEY,
+    code VARCHAR(7) NOT NULL CHECK (code ~ '^[0-9]{7}$'), -- 郵便番号 (ハイフンなし7桁)
+    prefecture VARCHAR(10) NOT NULL,      -- 都道府県
+    city VARCHAR(100) NOT NULL,           -- 市区町村
+    town VARCHAR(255) NOT NULL,           -- 町域 (空文字=町域なし)
+    prefecture_kana VARCHAR(20) NOT NULL, -- 都道府県カナ
+    city_kana VARCHAR(200) NOT NULL,      -- 市区町村カナ
+    town_kana VARCHAR(500) NOT NULL       -- 町域カナ
+);
+
+CREATE INDEX idx_postal_codes_code ON postal_codes(code);
 
 -- 企業テーブル
 CREATE TABLE companies (
     id BIGSERIAL PRIMARY KEY,
-    name VARCHAR(255) NOT NULL,                -- 法人名
+    name VARCHAR(255) NOT NULL,                -- 法人名 (NFKC正規化済み)
+    name_kana VARCHAR(255),                    -- 法人名フリガナ (全角カタカナ。NULL=未登録)
     representative_name VARCHAR(255) NOT NULL, -- 代表者名
     phone_number VARCHAR(16) NOT NULL,         -- 電話番号 (E.164形式: +81312345678)
     zip_code VARCHAR(10) NOT NULL,             -- 郵便番号
     address VARCHAR(500) NOT NULL,             -- 住所
+    mfa_required BOOLEAN NOT NULL DEFAULT FALSE, -- 所属ユーザーに2要素認証を必須にする
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- 承認ポリシーテーブル（企業ごとの請求書承認ルール。行がなければ承認不要）
+CREATE TABLE approval_policies (
+    company_id BIGINT PRIMARY KEY REFERENCES companies(id) ON DELETE CASCADE, -- 企業ID
+    amount_threshold BIGINT CHECK (amount_threshold > 0), -- この支払金額以上の請求書は承認が必要 (NULL=金額による承認不要)
+    require_for_new_vendors BOOLEAN NOT NULL DEFAULT FALSE, -- 初めて支払う取引先の請求書は承認が必要
     created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
 );
 
--- ユーザーテーブル（企業に紐づく）
+-- ユーザーテーブル（所属企業は company_memberships で管理）
 CREATE TABLE users (
     id BIGSERIAL PRIMARY KEY,
-    company_id BIGINT NOT NULL REFERENCES companies(id) ON DELETE CASCADE, -- 所属企業ID
     name VARCHAR(255) NOT NULL,          -- 氏名
     email VARCHAR(255) NOT NULL UNIQUE,  -- メールアドレス
     password_hash VARCHAR(255) NOT NULL, -- パスワードハッシュ (bcrypt)
@@ -28,37 +61,168 @@ CREATE TABLE users (
     updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
 );
 
-CREATE INDEX idx_users_company_id ON users(company_id);
 CREATE INDEX idx_users_email ON users(email);
 
+-- 企業メンバーシップテーブル（ユーザーは複数の企業に所属でき、企業ごとに権限を持つ）
+CREATE TABLE company_memberships (
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,        -- ユーザーID
+    company_id BIGINT NOT NULL REFERENCES companies(id) ON DELETE CASCADE, -- 企業ID
+    role user_role NOT NULL, -- 企業内での権限
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, company_id)
+);
+
+CREATE INDEX idx_company_memberships_company_id ON company_memberships(company_id);
+
+-- リフレッシュトークンテーブル（トークン自体は保存せずハッシュのみ。使用のたびに新しいトークンへ交換する）
+-- 認証前に参照するため、行レベルセキュリティの対象外
+CREATE TABLE refresh_tokens (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,        -- ユーザーID
+    company_id BIGINT NOT NULL REFERENCES companies(id) ON DELETE CASCADE, -- トークンの操作対象企業ID
+    family_id VARCHAR(64) NOT NULL,          -- 系列ID (ログインごとに発行し、交換後のトークンに引き継ぐ)
+    token_hash VARCHAR(64) NOT NULL UNIQUE,  -- リフレッシュトークンのSHA-256 (16進)
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL, -- 有効期限
+    rotated_at TIMESTAMP WITH TIME ZONE,     -- 新しいトークンへ交換した日時 (NULL=未使用)
+    revoked_at TIMESTAMP WITH TIME ZONE,     -- 無効化日時 (NULL=有効)
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_refresh_tokens_user_id ON refresh_tokens(user_id);
+CREATE INDEX idx_refresh_tokens_family_id ON refresh_tokens(family_id);
+CREATE INDEX idx_refresh_tokens_expires_at ON refresh_tokens(expires_at);
+
+-- 失効したアクセストークンテーブル（ログアウトしたトークン）
+CREATE TABLE revoked_tokens (
+    jti VARCHAR(64) PRIMARY KEY,                                    -- トークンID
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE, -- ユーザーID
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL, -- トークンの有効期限
+    revoked_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_revoked_tokens_expires_at ON revoked_tokens(expires_at);
+
+-- ユーザー単位のトークン失効テーブル（すべての端末からのログアウト・パスワード変更）
+CREATE TABLE user_token_revocations (
+    user_id BIGINT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE, -- ユーザーID
+    revoked_before TIMESTAMP WITH TIME ZONE NOT NULL -- この日時より前に発行されたトークンは無効
+);
+
+-- 2要素認証（TOTP）テーブル（enabled_at が NULL の間は登録の確認待ち）
+-- 認証前に参照するため、行レベルセキュリティの対象外
+CREATE TABLE user_mfa (
+    user_id BIGINT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE, -- ユーザーID
+    secret VARCHAR(64) NOT NULL,                -- TOTP シークレット (Base32)
+    enabled_at TIMESTAMP WITH TIME ZONE,        -- 有効化日時 (NULL=登録の確認待ち)
+    last_used_step BIGINT NOT NULL DEFAULT 0,   -- 最後に使用したコードの時間ステップ (同じコードの再使用を防ぐ)
+    failed_attempts INTEGER NOT NULL DEFAULT 0, -- 連続して検証に失敗した回数
+    locked_until TIMESTAMP WITH TIME ZONE,      -- この日時まで検証を受け付けない (NULL=ロックなし)
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- 2要素認証のリカバリーコードテーブル（コード自体は保存せずハッシュのみ。各コードは1回のみ使用可）
+CREATE TABLE mfa_recovery_codes (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE, -- ユーザーID
+    code_hash VARCHAR(64) NOT NULL,      -- リカバリーコードのSHA-256 (16進)
+    used_at TIMESTAMP WITH TIME ZONE,    -- 使用日時 (NULL=未使用)
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_mfa_recovery_codes_user_id_code_hash ON mfa_recovery_codes(user_id, code_hash);
+
+-- ユーザー招待テーブル
+CREATE TABLE invitations (
+    id BIGSERIAL PRIMARY KEY,
+    company_id BIGINT NOT NULL REFERENCES companies(id) ON DELETE CASCADE, -- 招待元企業ID
+    email VARCHAR(255) NOT NULL,             -- 招待先メールアドレス
+    role user_role NOT NULL,                 -- 登録時の権限
+    token_hash VARCHAR(64) NOT NULL UNIQUE,  -- 招待トークンのSHA-256 (16進)
+    invited_by BIGINT REFERENCES users(id) ON DELETE SET NULL, -- 招待したユーザーID (NULL=削除済みユーザー)
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL, -- 有効期限
+    accepted_at TIMESTAMP WITH TIME ZONE,    -- 承諾日時 (NULL=未承諾)
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_invitations_company_id ON invitations(company_id);
+
+-- 企業情報の変更履歴テーブル（管理者によるユーザーの2要素認証のリセットも記録する）
+CREATE TABLE company_audit_logs (
+    id BIGSERIAL PRIMARY KEY,
+    company_id BIGINT NOT NULL REFERENCES companies(id) ON DELETE CASCADE, -- 企業ID
+    user_id BIGINT REFERENCES users(id) ON DELETE SET NULL, -- 変更したユーザーID (NULL=削除済みユーザー)
+    changes JSONB NOT NULL, -- 変更内容 ([{"field": "address", "before": "...", "after": "..."}])
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_company_audit_logs_company_id_created_at ON company_audit_logs(company_id, created_at);
+
 -- 取引先テーブル（企業に紐づく）
 CREATE TABLE vendors (
     id BIGSERIAL PRIMARY KEY,
     company_id BIGINT NOT NULL REFERENCES companies(id) ON DELETE CASCADE, -- 所属企業ID
-    name VARCHAR(255) NOT NULL,                -- 法人名
+    external_code VARCHAR(50),                 -- 取引先コード (会計ソフト等で使っている既存のコード。CSV取込で同一取引先の判定に使う)
+    name VARCHAR(255) NOT NULL,                -- 法人名 (NFKC正規化済み)
+    name_kana VARCHAR(255),                    -- 法人名フリガナ (全角カタカナ。NULL=未登録。一覧はフリガナ順)
     representative_name VARCHAR(255) NOT NULL, -- 代表者名
     phone_number VARCHAR(16) NOT NULL,         -- 電話番号 (E.164形式: +81312345678)
     zip_code VARCHAR(10) NOT NULL,             -- 郵便番号
     address VARCHAR(500) NOT NULL,             -- 住所
+    registration_number VARCHAR(14) CHECK (registration_number ~ '^T[0-9]{13}$'), -- 適格請求書発行事業者登録番号 (NULL=未登録)
+    withholding_tax_applicable BOOLEAN NOT NULL DEFAULT FALSE, -- 源泉徴収対象 (個人事業主への報酬等)
+    archived_at TIMESTAMP WITH TIME ZONE,      -- アーカイブ日時 (NULL=有効。アーカイブ済みの取引先には請求書を作成できない)
     created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
 );
 
 CREATE INDEX idx_vendors_company_id ON vendors(company_id);
+CREATE UNIQUE INDEX idx_vendors_company_id_external_code ON vendors(company_id, external_code);
+CREATE INDEX idx_vendors_company_id_name_kana ON vendors(company_id, name_kana COLLATE "C");
 
 -- 取引先銀行口座テーブル（取引先に紐づく）
 CREATE TABLE vendor_bank_accounts (
     id BIGSERIAL PRIMARY KEY,
     vendor_id BIGINT NOT NULL REFERENCES vendors(id) ON DELETE CASCADE, -- 取引先ID
+    bank_code VARCHAR(4) CHECK (bank_code ~ '^[0-9]{4}$'),     -- 金融機関コード (NULL=未登録)
     bank_name VARCHAR(255) NOT NULL,           -- 銀行名
+    branch_code VARCHAR(3) CHECK (branch_code ~ '^[0-9]{3}$'), -- 支店コード (NULL=未登録)
     branch_name VARCHAR(255) NOT NULL,         -- 支店名
     account_number VARCHAR(20) NOT NULL,       -- 口座番号
     account_holder_name VARCHAR(255) NOT NULL, -- 口座名義
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,  -- 既定の振込先 (取引先ごとに1つ)
+    status bank_account_status NOT NULL DEFAULT 'pending_verification', -- 確認状況
+    changed_by BIGINT REFERENCES users(id) ON DELETE SET NULL,  -- 登録・変更したユーザーID (このユーザーは確認できない)
+    cooling_off_until TIMESTAMP WITH TIME ZONE,                 -- この日時までは確認済みでも支払不可
+    verified_by BIGINT REFERENCES users(id) ON DELETE SET NULL, -- 確認したユーザーID
+    verified_at TIMESTAMP WITH TIME ZONE,                       -- 確認日時
+    archived_at TIMESTAMP WITH TIME ZONE,                       -- アーカイブ日時 (NULL=有効)
     created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
 );
 
 CREATE INDEX idx_vendor_bank_accounts_vendor_id ON vendor_bank_accounts(vendor_id);
+CREATE UNIQUE INDEX idx_vendor_bank_accounts_vendor_id_default ON vendor_bank_accounts(vendor_id) WHERE is_default;
+
+-- 定期請求スケジュールテーブル（家賃・サブスクリプション等の毎月定額の支払い）
+CREATE TABLE recurring_invoice_schedules (
+    id BIGSERIAL PRIMARY KEY,
+    company_id BIGINT NOT NULL REFERENCES companies(id) ON DELETE CASCADE,              -- 企業ID
+    vendor_id BIGINT NOT NULL REFERENCES vendors(id) ON DELETE CASCADE,                 -- 取引先ID
+    vendor_bank_account_id BIGINT NOT NULL REFERENCES vendor_bank_accounts(id) ON DELETE RESTRICT, -- 振込先銀行口座ID
+    payment_amount BIGINT NOT NULL CHECK (payment_amount > 0),   -- 支払金額
+    day_of_month SMALLINT CHECK (day_of_month BETWEEN 1 AND 31), -- 支払日 (月末より後の日は月末に丸める)
+    end_of_month BOOLEAN NOT NULL DEFAULT FALSE,                 -- 月末払い
+    start_date DATE NOT NULL,                                    -- 開始日
+    end_date DATE,                                               -- 終了日 (NULL=無期限)
+    next_due_date DATE NOT NULL,                                 -- 次回支払期日
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((day_of_month IS NULL) = end_of_month)
+);
+
+CREATE INDEX idx_recurring_invoice_schedules_company_id ON recurring_invoice_schedules(company_id);
+CREATE INDEX idx_recurring_invoice_schedules_next_due_date ON recurring_invoice_schedules(next_due_date);
 
 -- 請求書テーブル（企業・取引先に紐づく）
 CREATE TABLE invoices (
@@ -73,8 +237,18 @@ CREATE TABLE invoices (
     tax BIGINT NOT NULL CHECK (tax >= 0),                        -- 消費税 (fee * tax_rate)
     tax_rate DECIMAL(5, 4) NOT NULL DEFAULT 0.10,                -- 消費税率 (デフォルト: 10%)
     total_amount BIGINT NOT NULL CHECK (total_amount > 0),       -- 請求金額 (payment_amount + fee + tax)
+    withholding_tax BIGINT NOT NULL DEFAULT 0 CHECK (withholding_tax >= 0), -- 源泉徴収税額
+    transfer_amount BIGINT NOT NULL CHECK (transfer_amount > 0), -- 振込金額 (payment_amount - withholding_tax)
     due_date DATE NOT NULL,                                      -- 支払期日
     status invoice_status NOT NULL DEFAULT 'pending',            -- ステータス
+    vendor_registration_number VARCHAR(14),                      -- 作成時点の取引先登録番号 (NULL=適格請求書以外)
+    recurring_invoice_schedule_id BIGINT REFERENCES recurring_invoice_schedules(id) ON DELETE SET NULL, -- 生成元の定期請求スケジュールID
+    vendor_invoice_number VARCHAR(50),                           -- 取引先の請求書番号
+    duplicate_override BOOLEAN NOT NULL DEFAULT FALSE,           -- 重複の可能性を承知の上で登録したか
+    created_by BIGINT REFERENCES users(id) ON DELETE SET NULL,   -- 作成者ID (NULL=定期請求などシステム作成)
+    reviewed_by BIGINT REFERENCES users(id) ON DELETE SET NULL,  -- 承認・差し戻しをしたユーザーID
+    reviewed_at TIMESTAMP WITH TIME ZONE,                        -- 承認・差し戻し日時
+    rejection_reason VARCHAR(500),                               -- 差し戻し理由
     created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
 );
@@ -84,3 +258,67 @@ CREATE INDEX idx_invoices_company_id ON invoices(company_id);
 CREATE INDEX idx_invoices_due_date ON invoices(due_date);
 CREATE INDEX idx_invoices_company_due_date ON invoices(company_id, due_date);
 CREATE INDEX idx_invoices_status ON invoices(status);
+-- 重複請求書の検出用インデックス
+CREATE INDEX idx_invoices_company_id_vendor_id ON invoices(company_id, vendor_id);
+-- 定期請求の二重生成防止
+CREATE UNIQUE INDEX idx_invoices_recurring_invoice_schedule_id_due_date ON invoices(recurring_invoice_schedule_id, due_date);
+
+-- 行レベルセキュリティ（テナント分離）
+-- アプリケーションは接続ごとに app.company_id（認証済みの企業ID）を設定し、
+-- 企業に紐づく行はその企業のものしか読み書きできないようにする。
+-- 定期請求のバッチなど企業をまたぐ処理は app.all_companies = 'on' を設定する。
+-- どちらも設定されていない接続からは、企業に紐づく行は一切見えない。
+-- ユーザー本人の行は、app.user_id（ログイン中のユーザーID）を設定すると企業をまたいで参照できる。
+-- ※スーパーユーザーと BYPASSRLS 権限を持つロールには適用されない
+ALTER TABLE approval_policies ENABLE ROW LEVEL SECURITY;
+ALTER TABLE approval_policies FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON approval_policies
+    USING (company_id = NULLIF(current_setting('app.company_id', true), '')::BIGINT
+        OR current_setting('app.all_companies', true) = 'on');
+
+-- 企業メンバーシップは、本人のものであれば他の企業の分も参照できる（所属企業の一覧や企業の切り替えに使う）。
+-- 追加・変更は企業の中でのみ行える
+ALTER TABLE company_memberships ENABLE ROW LEVEL SECURITY;
+ALTER TABLE company_memberships FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON company_memberships
+    USING (company_id = NULLIF(current_setting('app.company_id', true), '')::BIGINT
+        OR user_id = NULLIF(current_setting('app.user_id', true), '')::BIGINT
+        OR current_setting('app.all_companies', true) = 'on')
+    WITH CHECK (company_id = NULLIF(current_setting('app.company_id', true), '')::BIGINT
+        OR current_setting('app.all_companies', true) = 'on');
+
+ALTER TABLE invitations ENABLE ROW LEVEL SECURITY;
+ALTER TABLE invitations FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON invitations
+    USING (company_id = NULLIF(current_setting('app.company_id', true), '')::BIGINT
+        OR current_setting('app.all_companies', true) = 'on');
+
+ALTER TABLE company_audit_logs ENABLE ROW LEVEL SECURITY;
+ALTER TABLE company_audit_logs FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON company_audit_logs
+    USING (company_id = NULLIF(current_setting('app.company_id', true), '')::BIGINT
+        OR current_setting('app.all_companies', true) = 'on');
+
+ALTER TABLE vendors ENABLE ROW LEVEL SECURITY;
+ALTER TABLE vendors FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON vendors
+    USING (company_id = NULLIF(current_setting('app.company_id', true), '')::BIGINT
+        OR current_setting('app.all_companies', true) = 'on');
+
+-- 振込先銀行口座は、見える取引先の口座のみ
+ALTER TABLE vendor_bank_accounts ENABLE ROW LEVEL SECURITY;
+ALTER TABLE vendor_bank_accounts FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON vendor_bank_accounts
+    USING (EXISTS (SELECT 1 FROM vendors v WHERE v.id = vendor_id));
+
+ALTER TABLE recurring_invoice_schedules ENABLE ROW LEVEL SECURITY;
+ALTER TABLE recurring_invoice_schedules FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON recurring_invoice_schedules
+    USING (company_id = NULLIF(current_setting('app.company_id', true), '')::BIGINT
+        OR current_setting('app.all_companies', true) = 'on');
+
+ALTER TABLE invoices ENABLE ROW LEVEL SECURITY;
+ALTER TABLE invoices FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON invoices
+    USING (company_id = NULLIF(current_setting('app.company_id', true), '')::BIGINT
+        OR current_setting('app.all_companies', true) = 'on');
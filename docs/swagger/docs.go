@@ -15,9 +15,14 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/auth/login": {
-            "post": {
-                "description": "メールアドレスとパスワードで認証し、JWTトークンを発行します",
+        "/approval-policy": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "企業の請求書承認ポリシーを取得します",
                 "consumes": [
                     "application/json"
                 ],
@@ -25,17 +30,55 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "approval-policy"
                 ],
-                "summary": "ログイン",
+                "summary": "承認ポリシー取得",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_approval.PolicyResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_approval.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_approval.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "企業の請求書承認ポリシーを更新します。管理者権限が必要です。\namount_threshold 以上の請求書、require_for_new_vendors が true の場合は初めて支払う取引先の請求書が承認待ちになります。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "approval-policy"
+                ],
+                "summary": "承認ポリシー更新",
                 "parameters": [
                     {
-                        "description": "ログインリクエスト",
+                        "description": "承認ポリシー更新リクエスト",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_controller_auth.LoginRequest"
+                            "$ref": "#/definitions/internal_controller_approval.UpdatePolicyRequest"
                         }
                     }
                 ],
@@ -43,33 +86,39 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_controller_auth.TokenResponse"
+                            "$ref": "#/definitions/internal_controller_approval.PolicyResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/internal_controller_auth.ErrorResponse"
+                            "$ref": "#/definitions/internal_controller_approval.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/internal_controller_auth.ErrorResponse"
+                            "$ref": "#/definitions/internal_controller_approval.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_approval.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/internal_controller_auth.ErrorResponse"
+                            "$ref": "#/definitions/internal_controller_approval.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/auth/refresh": {
+        "/auth/accept-invitation": {
             "post": {
-                "description": "リフレッシュトークンを使用して新しいアクセストークンを取得します",
+                "description": "招待メールのトークンで、招待元の企業に招待時の権限のユーザーを登録し、JWTトークンを発行します。\nトークンは1回のみ使用でき、有効期限を過ぎたものや取り消されたものは使用できません。\n招待先のメールアドレスに既にアカウントがある場合は、ユーザーを作成せずに既存のパスワードで本人確認して招待元の企業に追加します（氏名は不要）。発行されるトークンの操作対象は招待元の企業です。\n2要素認証が必要な場合は、ログインと同様にトークンの代わりにMFAトークンを返します。",
                 "consumes": [
                     "application/json"
                 ],
@@ -79,23 +128,23 @@ const docTemplate = `{
                 "tags": [
                     "auth"
                 ],
-                "summary": "トークン更新",
+                "summary": "招待承諾",
                 "parameters": [
                     {
-                        "description": "トークン更新リクエスト",
+                        "description": "招待承諾リクエスト",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_controller_auth.RefreshTokenRequest"
+                            "$ref": "#/definitions/internal_controller_auth.AcceptInvitationRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/internal_controller_auth.TokenResponse"
+                            "$ref": "#/definitions/internal_controller_auth.LoginResponse"
                         }
                     },
                     "400": {
@@ -104,8 +153,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_controller_auth.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_auth.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/internal_controller_auth.ErrorResponse"
                         }
@@ -113,9 +168,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/register": {
+        "/auth/change-password": {
             "post": {
-                "description": "新規ユーザーを登録し、JWTトークンを発行します",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ログイン中のユーザーのパスワードを変更します。発行済みのすべてのトークンは無効になり、新しいトークンを発行します。",
                 "consumes": [
                     "application/json"
                 ],
@@ -125,21 +185,21 @@ const docTemplate = `{
                 "tags": [
                     "auth"
                 ],
-                "summary": "ユーザー登録",
+                "summary": "パスワード変更",
                 "parameters": [
                     {
-                        "description": "登録リクエスト",
+                        "description": "パスワード変更リクエスト",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_controller_auth.RegisterRequest"
+                            "$ref": "#/definitions/internal_controller_auth.ChangePasswordRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/internal_controller_auth.TokenResponse"
                         }
@@ -150,8 +210,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_controller_auth.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/internal_controller_auth.ErrorResponse"
                         }
@@ -165,14 +225,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/invoices": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "指定期間内に支払いが発生する請求書データの一覧を取得します",
+        "/auth/login": {
+            "post": {
+                "description": "メールアドレスとパスワードで認証し、JWTトークンを発行します。\n2要素認証を有効にしているユーザーと、2要素認証を必須にしている企業のユーザーには、トークンの代わりに mfa_required と有効期限5分のMFAトークンを返します。MFAトークンと認証コードを POST /auth/mfa/verify に送るとトークンを発行します。\nmfa_enrollment_required の場合は、先に POST /auth/mfa/enroll で認証アプリを登録します。",
                 "consumes": [
                     "application/json"
                 ],
@@ -180,60 +235,56 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "invoices"
+                    "auth"
                 ],
-                "summary": "請求書一覧取得",
+                "summary": "ログイン",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "開始日 (YYYY-MM-DD)",
-                        "name": "start_date",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "終了日 (YYYY-MM-DD)",
-                        "name": "end_date",
-                        "in": "query"
+                        "description": "ログインリクエスト",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_auth.LoginRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/internal_controller_invoice.Response"
-                            }
+                            "$ref": "#/definitions/internal_controller_auth.LoginResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/internal_controller_invoice.ErrorResponse"
+                            "$ref": "#/definitions/internal_controller_auth.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/internal_controller_invoice.ErrorResponse"
+                            "$ref": "#/definitions/internal_controller_auth.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/internal_controller_invoice.ErrorResponse"
+                            "$ref": "#/definitions/internal_controller_auth.ErrorResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/logout": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "新しい請求書データを作成します。手数料・消費税は自動計算されます。",
+                "description": "使用中のアクセストークンと、同じログインから発行されたリフレッシュトークンを無効にします。",
                 "consumes": [
                     "application/json"
                 ],
@@ -241,62 +292,126 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "invoices"
+                    "auth"
                 ],
-                "summary": "請求書作成",
+                "summary": "ログアウト",
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_auth.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_auth.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/logout-all": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ログイン中のユーザーに発行済みのすべてのアクセストークンとリフレッシュトークンを無効にします。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "すべての端末からログアウト",
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_auth.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_auth.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/mfa/enroll": {
+            "post": {
+                "description": "2要素認証を必須にしている企業で未登録のユーザーが、ログインで返されたMFAトークンで認証アプリを登録します。\nprovisioning_uri をQRコードで表示して認証アプリで読み取り、表示されたコードを POST /auth/mfa/verify に送ると登録が完了します。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "ログイン時の2要素認証の登録",
                 "parameters": [
                     {
-                        "description": "請求書作成リクエスト",
+                        "description": "2要素認証登録リクエスト",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_controller_invoice.CreateRequest"
+                            "$ref": "#/definitions/internal_controller_auth.MFAEnrollRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_controller_invoice.Response"
+                            "$ref": "#/definitions/internal_controller_auth.MFAEnrollmentResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/internal_controller_invoice.ErrorResponse"
+                            "$ref": "#/definitions/internal_controller_auth.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/internal_controller_invoice.ErrorResponse"
+                            "$ref": "#/definitions/internal_controller_auth.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
-                            "$ref": "#/definitions/internal_controller_invoice.ErrorResponse"
+                            "$ref": "#/definitions/internal_controller_auth.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/internal_controller_invoice.ErrorResponse"
+                            "$ref": "#/definitions/internal_controller_auth.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/invoices/{id}": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "指定IDの請求書データを取得します",
+        "/auth/mfa/verify": {
+            "post": {
+                "description": "ログインで返されたMFAトークンと、認証アプリの6桁のコードまたはリカバリーコードで認証し、JWTトークンを発行します。\n認証コードとリカバリーコードはそれぞれ1回のみ使用できます。MFAトークンも1回のログインにしか使用できません。\n認証アプリを登録中のユーザーは、最初のコードで登録が完了し、リカバリーコードが1回だけ返されます。\n5回続けて認証に失敗すると、15分間は認証できません。",
                 "consumes": [
                     "application/json"
                 ],
@@ -304,58 +419,3718 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "invoices"
+                    "auth"
                 ],
-                "summary": "請求書詳細取得",
+                "summary": "2要素認証",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "請求書ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "2要素認証リクエスト",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_auth.MFAVerifyRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_controller_invoice.Response"
+                            "$ref": "#/definitions/internal_controller_auth.LoginResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/internal_controller_invoice.ErrorResponse"
+                            "$ref": "#/definitions/internal_controller_auth.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/internal_controller_invoice.ErrorResponse"
+                            "$ref": "#/definitions/internal_controller_auth.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
-                            "$ref": "#/definitions/internal_controller_invoice.ErrorResponse"
+                            "$ref": "#/definitions/internal_controller_auth.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_auth.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/internal_controller_invoice.ErrorResponse"
+                            "$ref": "#/definitions/internal_controller_auth.ErrorResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "internal_controller_auth.ErrorResponse": {
-            "type": "object",
-            "properties": {
-                "details": {
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "リフレッシュトークンを使用して新しいアクセストークンとリフレッシュトークンを取得します。\n使用したリフレッシュトークンは無効になります。使用済みのリフレッシュトークンを再度送信すると、同じログインから発行されたすべてのリフレッシュトークンが無効になります。\n企業が2要素認証を必須にした後、2要素認証を登録していないユーザーは更新できません（403）。再度ログインして登録してください。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "トークン更新",
+                "parameters": [
+                    {
+                        "description": "トークン更新リクエスト",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_auth.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_auth.TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_auth.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_auth.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_auth.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/register": {
+            "post": {
+                "description": "新規企業と最初の管理者ユーザーを同一トランザクションで登録し、JWTトークンを発行します。\n既存の企業にユーザーを追加する場合は、企業の管理者からの招待が必要です。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "企業・ユーザー登録",
+                "parameters": [
+                    {
+                        "description": "登録リクエスト",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_auth.RegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_auth.TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_auth.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_auth.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_auth.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/switch-company": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "所属している別の企業を操作対象とするトークンを発行します。新しいトークンのロールは切り替え先の企業での権限になります。\n切り替え先の企業が2要素認証を必須にしている場合は、2要素認証の登録が必要です（403）。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "企業の切り替え",
+                "parameters": [
+                    {
+                        "description": "企業切り替えリクエスト",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_auth.SwitchCompanyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_auth.TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_auth.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_auth.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_auth.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_auth.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/company": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ログインユーザーの企業情報を取得します。管理者権限が必要です。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "company"
+                ],
+                "summary": "企業情報取得",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_company.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_company.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_company.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_company.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ログインユーザーの企業情報を部分更新します。管理者権限が必要です。\n指定した項目のみ更新され、変更内容は変更履歴に記録されます。電話番号は E.164 形式に正規化され、郵便番号は存在チェックされます。\n` + "`" + `mfa_required` + "`" + ` を true にすると、所属ユーザー全員にログイン時の2要素認証が必須になります。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "company"
+                ],
+                "summary": "企業情報更新",
+                "parameters": [
+                    {
+                        "description": "企業情報更新リクエスト",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_company.UpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_company.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_company.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_company.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_company.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_company.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/company/audit-logs": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "企業情報の変更履歴を新しい順に最大100件取得します。管理者権限が必要です。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "company"
+                ],
+                "summary": "企業情報変更履歴",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_company.AuditLogListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_company.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_company.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_company.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/company/users/{id}/mfa": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "認証アプリとリカバリーコードを紛失したユーザーの2要素認証を解除します。管理者権限が必要です。\n解除されたユーザーは、次回のログイン時にパスワードのみでログインでき、改めて登録できます。企業が2要素認証を必須にしている場合は、ログイン時に登録が必要です。\n2要素認証はユーザー単位のため、他の企業にも所属するユーザーはリセットできません（403）。リセットしたユーザーの発行済みトークンは無効になり、リセットは企業の変更履歴に記録されます。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mfa"
+                ],
+                "summary": "2要素認証のリセット",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ユーザーID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_mfa.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_mfa.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_mfa.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_mfa.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_mfa.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/invitations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "承諾されていない招待を新しい順に取得します（有効期限切れを含む）。管理者権限が必要です。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "invitations"
+                ],
+                "summary": "招待一覧",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invitation.ListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invitation.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invitation.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invitation.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定したメールアドレスに、自社への招待メールを送信します。管理者権限が必要です。\n招待メールには1回のみ使用できる有効期限付きのトークンが含まれます。同じメールアドレスへ再度招待すると、以前の招待は無効になります。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "invitations"
+                ],
+                "summary": "ユーザー招待",
+                "parameters": [
+                    {
+                        "description": "招待リクエスト",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invitation.CreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invitation.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invitation.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invitation.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invitation.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invitation.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invitation.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/invitations/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "承諾されていない招待を取り消します。取り消した招待のトークンは使用できなくなります。管理者権限が必要です。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "invitations"
+                ],
+                "summary": "招待取り消し",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "招待ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invitation.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invitation.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invitation.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invitation.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invitation.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/invoices": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定期間内に支払いが発生する請求書データの一覧を取得します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "invoices"
+                ],
+                "summary": "請求書一覧取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "開始日 (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "終了日 (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/internal_controller_invoice.Response"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invoice.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invoice.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invoice.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "新しい請求書データを作成します。手数料・消費税は自動計算されます。\nvendor_bank_account_id を省略すると取引先の既定口座に振り込みます（既定口座がない場合は 400）。\n同じ取引先・金額・発行日・支払期日（または同じ取引先請求書番号）の請求書が既にある場合は 409 を返します。allow_duplicate を指定すると登録し、その旨を記録します。\n企業の承認ポリシーに該当する請求書は承認待ち (awaiting_approval) で作成され、承認されるまで支払われません。\n管理者または経理担当者の権限が必要です。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "invoices"
+                ],
+                "summary": "請求書作成",
+                "parameters": [
+                    {
+                        "description": "請求書作成リクエスト",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invoice.CreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invoice.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invoice.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invoice.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invoice.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invoice.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invoice.DuplicateErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invoice.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/invoices/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定IDの請求書データを取得します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "invoices"
+                ],
+                "summary": "請求書詳細取得",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "請求書ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invoice.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invoice.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invoice.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invoice.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invoice.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/invoices/{id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "承認待ちの請求書を承認し、支払対象にします。承認者権限が必要で、作成者本人は承認できません。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "invoices"
+                ],
+                "summary": "請求書承認",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "請求書ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invoice.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invoice.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invoice.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invoice.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invoice.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invoice.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invoice.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/invoices/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "承認待ちの請求書を差し戻します。承認者権限が必要で、作成者本人は差し戻せません。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "invoices"
+                ],
+                "summary": "請求書差し戻し",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "請求書ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "差し戻しリクエスト",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invoice.RejectRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invoice.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invoice.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invoice.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invoice.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invoice.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invoice.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invoice.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/companies": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ログイン中のユーザーが所属している企業と、各企業での権限を取得します。現在のトークンの操作対象の企業には active が付きます。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "所属企業一覧",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_auth.CompanyListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_auth.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_auth.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/mfa/activate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "認証アプリに表示されたコードで登録を確認し、2要素認証を有効にします。以降のログインでは認証コードが必要になります。\n認証アプリを紛失した場合に使用するリカバリーコードを返します。リカバリーコードはハッシュ化して保存されるため、表示されるのはこの1回だけです。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mfa"
+                ],
+                "summary": "2要素認証の有効化",
+                "parameters": [
+                    {
+                        "description": "有効化リクエスト",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_mfa.ActivateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_mfa.RecoveryCodesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_mfa.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_mfa.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_mfa.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_mfa.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/mfa/enroll": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ログイン中のユーザーの2要素認証（TOTP）の登録を開始し、シークレットとQRコード用のプロビジョニングURIを返します。\nprovisioning_uri をQRコードで表示して認証アプリで読み取り、表示されたコードを POST /me/mfa/activate に送ると登録が完了します。\n登録が完了するまでは、再度呼び出すと新しいシークレットで登録をやり直します。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mfa"
+                ],
+                "summary": "2要素認証の登録開始",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_mfa.EnrollmentResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_mfa.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_mfa.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_mfa.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/postal-codes/{code}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "郵便番号から住所（都道府県・市区町村・町域）を検索します。1つの郵便番号が複数の町域にまたがる場合は全件を返します。\n郵便番号は「100-0001」「1000001」のどちらの形式でも指定できます。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "postal-codes"
+                ],
+                "summary": "郵便番号検索",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "郵便番号",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_postalcode.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_postalcode.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_postalcode.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_postalcode.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_postalcode.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/recurring-invoices": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "企業の定期請求スケジュールの一覧を取得します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recurring-invoices"
+                ],
+                "summary": "定期請求スケジュール一覧取得",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/internal_controller_recurring.Response"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_recurring.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_recurring.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "毎月定額の請求書を自動作成するスケジュールを登録します。day_of_month と end_of_month はどちらか一方を指定します。\n管理者または経理担当者の権限が必要です。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recurring-invoices"
+                ],
+                "summary": "定期請求スケジュール作成",
+                "parameters": [
+                    {
+                        "description": "定期請求スケジュール作成リクエスト",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_recurring.ScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_recurring.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_recurring.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_recurring.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_recurring.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_recurring.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_recurring.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/recurring-invoices/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定IDの定期請求スケジュールを取得します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recurring-invoices"
+                ],
+                "summary": "定期請求スケジュール詳細取得",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "定期請求スケジュールID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_recurring.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_recurring.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_recurring.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_recurring.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_recurring.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定IDの定期請求スケジュールを更新します。次回支払期日は更新後のルールで再計算されます。\n管理者または経理担当者の権限が必要です。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recurring-invoices"
+                ],
+                "summary": "定期請求スケジュール更新",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "定期請求スケジュールID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "定期請求スケジュール更新リクエスト",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_recurring.ScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_recurring.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_recurring.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_recurring.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_recurring.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_recurring.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_recurring.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定IDの定期請求スケジュールを削除します。作成済みの請求書は削除されません。\n管理者または経理担当者の権限が必要です。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recurring-invoices"
+                ],
+                "summary": "定期請求スケジュール削除",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "定期請求スケジュールID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_recurring.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_recurring.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_recurring.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_recurring.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_recurring.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/withholding-tax": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定年に支払期日を迎える支払済みの請求書の源泉徴収税額を取引先ごとに集計します。未払いの請求書は源泉徴収されていないため含みません",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "源泉徴収レポート取得",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "対象年 (YYYY)",
+                        "name": "year",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invoice.WithholdingReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invoice.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invoice.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_invoice.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vendors": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "企業の取引先の一覧をページ単位で取得します。name で取引先名の部分一致検索ができます。アーカイブ済みの取引先は include_archived=true を指定した場合のみ返します。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vendors"
+                ],
+                "summary": "取引先一覧取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "取引先名（部分一致）",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "ページ番号（1始まり、最大10000）",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "1ページあたりの件数（最大100）",
+                        "name": "per_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "アーカイブ済みの取引先も返す",
+                        "name": "include_archived",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "新しい取引先を登録します\n管理者または経理担当者の権限が必要です。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vendors"
+                ],
+                "summary": "取引先作成",
+                "parameters": [
+                    {
+                        "description": "取引先作成リクエスト",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.VendorRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vendors/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "取引先と振込先口座をCSVファイル（UTF-8 または Shift_JIS）から一括で登録・更新します。external_code が一致する取引先は更新し、なければ新規作成します。全行を1つのトランザクションで保存し、1行でもエラーがあれば何も保存せず 422 で行ごとのエラーを返します。dry_run=true を指定すると保存せずに結果をプレビューします。\n管理者権限が必要です。",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vendors"
+                ],
+                "summary": "取引先CSV一括取込",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "取込ファイル（CSV、最大10MB）",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "保存せずに結果をプレビューする",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendorimport.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendorimport.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendorimport.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendorimport.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendorimport.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendorimport.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendorimport.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vendors/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定IDの取引先を取得します",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vendors"
+                ],
+                "summary": "取引先詳細取得",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "取引先ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定IDの取引先を更新します\n管理者または経理担当者の権限が必要です。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vendors"
+                ],
+                "summary": "取引先更新",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "取引先ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "取引先更新リクエスト",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.VendorRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vendors/{id}/archive": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "取引先をアーカイブします。アーカイブ済みの取引先には請求書を作成できず、一覧にも表示されません。作成済みの請求書は引き続き参照できます。\n管理者または経理担当者の権限が必要です。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vendors"
+                ],
+                "summary": "取引先アーカイブ",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "取引先ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vendors/{id}/bank-accounts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "取引先の振込先口座の一覧を取得します。口座番号は下3桁以外をマスクして返します。reveal=true を指定するとマスクせずに返します（管理者・経理担当者のみ）。アーカイブ済みの口座は include_archived=true を指定した場合のみ返します。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vendors"
+                ],
+                "summary": "取引先口座一覧取得",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "取引先ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "口座番号をマスクせずに返す",
+                        "name": "reveal",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "アーカイブ済みの口座も返す",
+                        "name": "include_archived",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/internal_controller_vendors.BankAccountResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "取引先に振込先口座を登録します。登録した口座は確認待ち（pending_verification）となり、企業の管理者に通知されます。取引先の最初の口座は既定口座になります。レスポンスの口座番号はマスクされます。\n管理者権限が必要です。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vendors"
+                ],
+                "summary": "取引先口座作成",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "取引先ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "取引先口座作成リクエスト",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.BankAccountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.BankAccountResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vendors/{id}/bank-accounts/{bank_account_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "取引先の振込先口座を取得します。口座番号は下3桁以外をマスクして返します。reveal=true を指定するとマスクせずに返します（管理者・経理担当者のみ）。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vendors"
+                ],
+                "summary": "取引先口座詳細取得",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "取引先ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "口座ID",
+                        "name": "bank_account_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "口座番号をマスクせずに返す",
+                        "name": "reveal",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.BankAccountResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "取引先の振込先口座を更新します。更新した口座は再び確認待ち（pending_verification）となり、企業の管理者に通知されます。レスポンスの口座番号はマスクされます。\n未払いの請求書の振込先になっている口座は更新できません（409）。\n管理者権限が必要です。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vendors"
+                ],
+                "summary": "取引先口座更新",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "取引先ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "口座ID",
+                        "name": "bank_account_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "取引先口座更新リクエスト",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.BankAccountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.BankAccountResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "取引先の振込先口座を削除します。請求書や定期請求スケジュールの振込先になっている口座は削除できません。\n管理者権限が必要です。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vendors"
+                ],
+                "summary": "取引先口座削除",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "取引先ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "口座ID",
+                        "name": "bank_account_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vendors/{id}/bank-accounts/{bank_account_id}/archive": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "取引先の振込先口座をアーカイブします。アーカイブ済みの口座を振込先とする請求書は作成できません。既定口座だった場合は既定口座が解除されます。\n管理者権限が必要です。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vendors"
+                ],
+                "summary": "取引先口座アーカイブ",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "取引先ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "口座ID",
+                        "name": "bank_account_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.BankAccountResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vendors/{id}/bank-accounts/{bank_account_id}/default": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定した口座を取引先の既定口座にします。振込先口座を省略して作成した請求書は既定口座に振り込みます。アーカイブ済みの口座は既定口座にできません（409）。\n管理者権限が必要です。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vendors"
+                ],
+                "summary": "取引先既定口座設定",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "取引先ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "口座ID",
+                        "name": "bank_account_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vendors/{id}/bank-accounts/{bank_account_id}/unarchive": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "アーカイブ済みの振込先口座を有効に戻します。既定口座には戻りません。\n管理者権限が必要です。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vendors"
+                ],
+                "summary": "取引先口座アーカイブ解除",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "取引先ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "口座ID",
+                        "name": "bank_account_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.BankAccountResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vendors/{id}/bank-accounts/{bank_account_id}/verify": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "確認待ちの振込先口座を確認済みにします。口座を登録・変更したユーザー以外の管理者・経理担当者が行います。確認済みでも待機期間が終わるまでは支払できません。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vendors"
+                ],
+                "summary": "取引先口座確認",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "取引先ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "口座ID",
+                        "name": "bank_account_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.BankAccountResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vendors/{id}/stats": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "指定IDの取引先への支払実績（累計・月別の支払金額、支払件数、初回・最終支払日、平均支払金額）とステータス別の請求書件数を返します。\n支払実績は支払済み（paid）の請求書を支払期日で集計します。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vendors"
+                ],
+                "summary": "取引先支払統計取得",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "取引先ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.StatsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vendors/{id}/unarchive": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "アーカイブ済みの取引先を有効に戻します\n管理者または経理担当者の権限が必要です。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vendors"
+                ],
+                "summary": "取引先アーカイブ解除",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "取引先ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_vendors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "internal_controller_approval.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "details": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controller_approval.PolicyResponse": {
+            "type": "object",
+            "properties": {
+                "amount_threshold": {
+                    "description": "AmountThreshold is the payment amount at or above which approval is required (0=no amount rule).",
+                    "type": "integer"
+                },
+                "require_for_new_vendors": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "internal_controller_approval.UpdatePolicyRequest": {
+            "type": "object",
+            "properties": {
+                "amount_threshold": {
+                    "description": "AmountThreshold is the payment amount at or above which approval is required (0=no amount rule).",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "require_for_new_vendors": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "internal_controller_auth.AcceptInvitationRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "token"
+            ],
+            "properties": {
+                "name": {
+                    "description": "Name is required only when the invited email has no account yet.",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 72,
+                    "minLength": 8
+                },
+                "token": {
+                    "description": "Token is the invitation token from the emailed link.",
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "internal_controller_auth.ChangePasswordRequest": {
+            "type": "object",
+            "required": [
+                "current_password",
+                "new_password"
+            ],
+            "properties": {
+                "current_password": {
+                    "type": "string"
+                },
+                "new_password": {
+                    "type": "string",
+                    "maxLength": 72,
+                    "minLength": 8
+                }
+            }
+        },
+        "internal_controller_auth.CompanyListResponse": {
+            "type": "object",
+            "properties": {
+                "companies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_controller_auth.CompanyResponse"
+                    }
+                }
+            }
+        },
+        "internal_controller_auth.CompanyResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "Active is true for the company of the token used for the request.",
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "role": {
+                    "description": "Role is the user's role in the company.",
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controller_auth.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "details": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controller_auth.LoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controller_auth.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "expires_in": {
+                    "type": "integer"
+                },
+                "mfa_enrollment_required": {
+                    "description": "MFAEnrollmentRequired is true when the company requires two-factor\nauthentication and the user has to enrol with POST /auth/mfa/enroll\nbefore giving a code.",
+                    "type": "boolean"
+                },
+                "mfa_required": {
+                    "description": "MFARequired is true when the tokens are issued only after the second\nfactor is given to POST /auth/mfa/verify with the MFA token.",
+                    "type": "boolean"
+                },
+                "mfa_token": {
+                    "type": "string"
+                },
+                "mfa_token_expires_in": {
+                    "type": "integer"
+                },
+                "recovery_codes": {
+                    "description": "RecoveryCodes are returned only once, when the login completed enrolment.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "token_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controller_auth.MFAEnrollRequest": {
+            "type": "object",
+            "required": [
+                "mfa_token"
+            ],
+            "properties": {
+                "mfa_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controller_auth.MFAEnrollmentResponse": {
+            "type": "object",
+            "properties": {
+                "provisioning_uri": {
+                    "description": "ProvisioningURI is the otpauth:// URI to show as a QR code for the\nauthenticator app to scan.",
+                    "type": "string"
+                },
+                "secret": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controller_auth.MFAVerifyRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "mfa_token"
+            ],
+            "properties": {
+                "code": {
+                    "description": "Code is a 6-digit TOTP code or a recovery code.",
+                    "type": "string",
+                    "maxLength": 20
+                },
+                "mfa_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controller_auth.RefreshTokenRequest": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controller_auth.RegisterCompanyRequest": {
+            "type": "object",
+            "required": [
+                "address",
+                "name",
+                "phone_number",
+                "representative_name",
+                "zip_code"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "name_kana": {
+                    "description": "NameKana is the reading of the name in katakana or hiragana.",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "phone_number": {
+                    "description": "PhoneNumber is normalized to E.164, e.g. \"03-1234-5678\" is stored as \"+81312345678\".",
+                    "type": "string",
+                    "maxLength": 30
+                },
+                "representative_name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "zip_code": {
+                    "type": "string",
+                    "maxLength": 10
+                }
+            }
+        },
+        "internal_controller_auth.RegisterRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "name",
+                "password"
+            ],
+            "properties": {
+                "company": {
+                    "$ref": "#/definitions/internal_controller_auth.RegisterCompanyRequest"
+                },
+                "email": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 72,
+                    "minLength": 8
+                }
+            }
+        },
+        "internal_controller_auth.SwitchCompanyRequest": {
+            "type": "object",
+            "required": [
+                "company_id"
+            ],
+            "properties": {
+                "company_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_controller_auth.TokenResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "expires_in": {
+                    "type": "integer"
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "token_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controller_company.AuditLogListResponse": {
+            "type": "object",
+            "properties": {
+                "audit_logs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_controller_company.AuditLogResponse"
+                    }
+                }
+            }
+        },
+        "internal_controller_company.AuditLogResponse": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_controller_company.FieldChangeResponse"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "description": "UserID is the user who made the change (omitted if the user has been deleted).",
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_controller_company.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "details": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controller_company.FieldChangeResponse": {
+            "type": "object",
+            "properties": {
+                "after": {
+                    "type": "string"
+                },
+                "before": {
+                    "type": "string"
+                },
+                "field": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controller_company.Response": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "mfa_required": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "name_kana": {
+                    "type": "string"
+                },
+                "phone_number": {
+                    "type": "string"
+                },
+                "phone_number_display": {
+                    "description": "PhoneNumberDisplay is the phone number in domestic format, e.g. \"03-1234-5678\".",
+                    "type": "string"
+                },
+                "representative_name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "zip_code": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controller_company.UpdateRequest": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "mfa_required": {
+                    "description": "MFARequired requires every member to log in with two-factor authentication.",
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "name_kana": {
+                    "description": "NameKana is the reading of the name in katakana or hiragana. An empty string clears it.",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "phone_number": {
+                    "description": "PhoneNumber is normalized to E.164, e.g. \"03-1234-5678\" is stored as \"+81312345678\".",
+                    "type": "string",
+                    "maxLength": 30
+                },
+                "representative_name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "zip_code": {
+                    "type": "string",
+                    "maxLength": 10
+                }
+            }
+        },
+        "internal_controller_invitation.CreateRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "role"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "role": {
+                    "description": "Role is the role the invited user gets on accepting.",
+                    "type": "string",
+                    "enum": [
+                        "admin",
+                        "accountant",
+                        "approver",
+                        "viewer"
+                    ]
+                }
+            }
+        },
+        "internal_controller_invitation.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "details": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controller_invitation.ListResponse": {
+            "type": "object",
+            "properties": {
+                "invitations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_controller_invitation.Response"
+                    }
+                }
+            }
+        },
+        "internal_controller_invitation.Response": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "invited_by": {
+                    "description": "InvitedBy is the user who sent the invitation (omitted if the user has been deleted).",
+                    "type": "integer"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controller_invoice.CreateRequest": {
+            "type": "object",
+            "required": [
+                "due_date",
+                "issue_date",
+                "payment_amount",
+                "vendor_id"
+            ],
+            "properties": {
+                "allow_duplicate": {
+                    "description": "AllowDuplicate registers the invoice even if it looks like a duplicate.",
+                    "type": "boolean"
+                },
+                "due_date": {
+                    "type": "string"
+                },
+                "issue_date": {
+                    "type": "string"
+                },
+                "payment_amount": {
+                    "type": "integer"
+                },
+                "vendor_bank_account_id": {
+                    "description": "VendorBankAccountID defaults to the vendor's default bank account when omitted.",
+                    "type": "integer"
+                },
+                "vendor_id": {
+                    "type": "integer"
+                },
+                "vendor_invoice_number": {
+                    "type": "string",
+                    "maxLength": 50
+                }
+            }
+        },
+        "internal_controller_invoice.DuplicateErrorResponse": {
+            "type": "object",
+            "properties": {
+                "duplicate_invoice_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controller_invoice.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "details": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controller_invoice.RejectRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "internal_controller_invoice.Response": {
+            "type": "object",
+            "properties": {
+                "company_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "description": "CreatedBy is the user who created the invoice (omitted for system-created invoices).",
+                    "type": "integer"
+                },
+                "due_date": {
+                    "type": "string"
+                },
+                "duplicate_override": {
+                    "description": "DuplicateOverride is true if the invoice was registered despite looking\nlike a duplicate of an existing invoice.",
+                    "type": "boolean"
+                },
+                "fee": {
+                    "type": "integer"
+                },
+                "fee_rate": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "issue_date": {
+                    "type": "string"
+                },
+                "payment_amount": {
+                    "type": "integer"
+                },
+                "rejection_reason": {
+                    "type": "string"
+                },
+                "reviewed_at": {
+                    "type": "string"
+                },
+                "reviewed_by": {
+                    "description": "ReviewedBy and ReviewedAt record who approved or rejected the invoice and when.",
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tax": {
+                    "type": "integer"
+                },
+                "tax_rate": {
+                    "type": "string"
+                },
+                "total_amount": {
+                    "type": "integer"
+                },
+                "transfer_amount": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "vendor_bank_account_id": {
+                    "type": "integer"
+                },
+                "vendor_id": {
+                    "type": "integer"
+                },
+                "vendor_invoice_number": {
+                    "type": "string"
+                },
+                "vendor_qualified_invoice_issuer": {
+                    "description": "VendorQualifiedInvoiceIssuer is false for invoices from unregistered vendors,\nwhose consumption tax is not eligible for the full input tax credit.",
+                    "type": "boolean"
+                },
+                "vendor_registration_number": {
+                    "description": "VendorRegistrationNumber is the vendor's qualified invoice issuer number\nrecorded when the invoice was created.",
+                    "type": "string"
+                },
+                "withholding_tax": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_controller_invoice.VendorWithholdingResponse": {
+            "type": "object",
+            "properties": {
+                "invoice_count": {
+                    "type": "integer"
+                },
+                "payment_amount": {
+                    "type": "integer"
+                },
+                "vendor_id": {
+                    "type": "integer"
+                },
+                "vendor_name": {
+                    "type": "string"
+                },
+                "withholding_tax": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_controller_invoice.WithholdingReportResponse": {
+            "type": "object",
+            "properties": {
+                "total_payment_amount": {
+                    "type": "integer"
+                },
+                "total_withholding_tax": {
+                    "type": "integer"
+                },
+                "vendors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_controller_invoice.VendorWithholdingResponse"
+                    }
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_controller_mfa.ActivateRequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "description": "Code is the 6-digit code shown by the authenticator app.",
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controller_mfa.EnrollmentResponse": {
+            "type": "object",
+            "properties": {
+                "provisioning_uri": {
+                    "description": "ProvisioningURI is the otpauth:// URI to show as a QR code for the\nauthenticator app to scan.",
+                    "type": "string"
+                },
+                "secret": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controller_mfa.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "details": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controller_mfa.RecoveryCodesResponse": {
+            "type": "object",
+            "properties": {
+                "recovery_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "internal_controller_postalcode.AddressResponse": {
+            "type": "object",
+            "properties": {
+                "city": {
+                    "type": "string"
+                },
+                "city_kana": {
+                    "type": "string"
+                },
+                "prefecture": {
+                    "type": "string"
+                },
+                "prefecture_kana": {
+                    "type": "string"
+                },
+                "town": {
+                    "description": "市区町村全域の郵便番号では空文字",
+                    "type": "string"
+                },
+                "town_kana": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controller_postalcode.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controller_postalcode.Response": {
+            "type": "object",
+            "properties": {
+                "addresses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_controller_postalcode.AddressResponse"
+                    }
+                },
+                "zip_code": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controller_recurring.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "details": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controller_recurring.Response": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "day_of_month": {
+                    "type": "integer"
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "end_of_month": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "next_due_date": {
+                    "type": "string"
+                },
+                "payment_amount": {
+                    "type": "integer"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "vendor_bank_account_id": {
+                    "type": "integer"
+                },
+                "vendor_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_controller_recurring.ScheduleRequest": {
+            "type": "object",
+            "required": [
+                "payment_amount",
+                "start_date",
+                "vendor_bank_account_id",
+                "vendor_id"
+            ],
+            "properties": {
+                "day_of_month": {
+                    "type": "integer",
+                    "maximum": 31,
+                    "minimum": 1
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "end_of_month": {
+                    "type": "boolean"
+                },
+                "payment_amount": {
+                    "type": "integer"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "vendor_bank_account_id": {
+                    "type": "integer"
+                },
+                "vendor_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_controller_vendorimport.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "details": {
                     "type": "object",
                     "additionalProperties": {
                         "type": "string"
@@ -366,105 +4141,156 @@ const docTemplate = `{
                 }
             }
         },
-        "internal_controller_auth.LoginRequest": {
+        "internal_controller_vendorimport.Response": {
             "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
             "properties": {
-                "email": {
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_controller_vendorimport.RowErrorResponse"
+                    }
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_controller_vendorimport.RowResponse"
+                    }
+                }
+            }
+        },
+        "internal_controller_vendorimport.RowErrorResponse": {
+            "type": "object",
+            "properties": {
+                "column": {
                     "type": "string"
                 },
-                "password": {
+                "message": {
                     "type": "string"
+                },
+                "row": {
+                    "type": "integer"
                 }
             }
         },
-        "internal_controller_auth.RefreshTokenRequest": {
+        "internal_controller_vendorimport.RowResponse": {
             "type": "object",
-            "required": [
-                "refresh_token"
-            ],
             "properties": {
-                "refresh_token": {
+                "bank_account": {
+                    "description": "BankAccount is omitted if the row has no bank account.",
+                    "type": "string"
+                },
+                "external_code": {
                     "type": "string"
+                },
+                "row": {
+                    "type": "integer"
+                },
+                "vendor": {
+                    "description": "created, updated, unchanged",
+                    "type": "string"
+                },
+                "vendor_id": {
+                    "description": "ドライランで新規作成される取引先は省略",
+                    "type": "integer"
                 }
             }
         },
-        "internal_controller_auth.RegisterRequest": {
+        "internal_controller_vendors.BankAccountRequest": {
             "type": "object",
             "required": [
-                "company_id",
-                "email",
-                "name",
-                "password"
+                "account_holder_name",
+                "account_number",
+                "bank_name",
+                "branch_name"
             ],
             "properties": {
-                "company_id": {
-                    "type": "integer"
-                },
-                "email": {
+                "account_holder_name": {
                     "type": "string",
                     "maxLength": 255
                 },
-                "name": {
+                "account_number": {
                     "type": "string",
-                    "maxLength": 255,
-                    "minLength": 1
+                    "maxLength": 20
                 },
-                "password": {
+                "bank_code": {
+                    "type": "string"
+                },
+                "bank_name": {
                     "type": "string",
-                    "maxLength": 72,
-                    "minLength": 8
+                    "maxLength": 255
+                },
+                "branch_code": {
+                    "type": "string"
+                },
+                "branch_name": {
+                    "type": "string",
+                    "maxLength": 255
                 }
             }
         },
-        "internal_controller_auth.TokenResponse": {
+        "internal_controller_vendors.BankAccountResponse": {
             "type": "object",
             "properties": {
-                "access_token": {
+                "account_holder_name": {
                     "type": "string"
                 },
-                "expires_in": {
-                    "type": "integer"
+                "account_number": {
+                    "description": "reveal 指定時以外はマスク済み (例: ****567)",
+                    "type": "string"
                 },
-                "refresh_token": {
+                "archived_at": {
                     "type": "string"
                 },
-                "token_type": {
+                "bank_code": {
                     "type": "string"
-                }
-            }
-        },
-        "internal_controller_invoice.CreateRequest": {
-            "type": "object",
-            "required": [
-                "due_date",
-                "issue_date",
-                "payment_amount",
-                "vendor_bank_account_id",
-                "vendor_id"
-            ],
-            "properties": {
-                "due_date": {
+                },
+                "bank_name": {
                     "type": "string"
                 },
-                "issue_date": {
+                "branch_code": {
                     "type": "string"
                 },
-                "payment_amount": {
+                "branch_name": {
+                    "type": "string"
+                },
+                "changed_by": {
                     "type": "integer"
                 },
-                "vendor_bank_account_id": {
+                "cooling_off_until": {
+                    "description": "CoolingOffUntil is when the account becomes payable once verified.",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
                     "type": "integer"
                 },
+                "is_default": {
+                    "type": "boolean"
+                },
+                "status": {
+                    "description": "pending_verification, verified",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
                 "vendor_id": {
                     "type": "integer"
+                },
+                "verified_at": {
+                    "type": "string"
+                },
+                "verified_by": {
+                    "type": "integer"
                 }
             }
         },
-        "internal_controller_invoice.ErrorResponse": {
+        "internal_controller_vendors.ErrorResponse": {
             "type": "object",
             "properties": {
                 "details": {
@@ -478,53 +4304,189 @@ const docTemplate = `{
                 }
             }
         },
-        "internal_controller_invoice.Response": {
+        "internal_controller_vendors.ListResponse": {
             "type": "object",
             "properties": {
-                "company_id": {
+                "page": {
                     "type": "integer"
                 },
-                "created_at": {
+                "per_page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "vendors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_controller_vendors.Response"
+                    }
+                }
+            }
+        },
+        "internal_controller_vendors.MonthlyPaymentResponse": {
+            "type": "object",
+            "properties": {
+                "invoice_count": {
+                    "type": "integer"
+                },
+                "month": {
+                    "description": "例: 2024-03",
                     "type": "string"
                 },
-                "due_date": {
+                "payment_amount": {
+                    "type": "integer"
+                },
+                "transfer_amount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_controller_vendors.Response": {
+            "type": "object",
+            "properties": {
+                "address": {
                     "type": "string"
                 },
-                "fee": {
+                "archived_at": {
+                    "type": "string"
+                },
+                "company_id": {
                     "type": "integer"
                 },
-                "fee_rate": {
+                "created_at": {
+                    "type": "string"
+                },
+                "external_code": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "issue_date": {
+                "name": {
                     "type": "string"
                 },
-                "payment_amount": {
-                    "type": "integer"
+                "name_kana": {
+                    "type": "string"
                 },
-                "status": {
+                "phone_number": {
                     "type": "string"
                 },
-                "tax": {
-                    "type": "integer"
+                "phone_number_display": {
+                    "description": "PhoneNumberDisplay is the phone number in domestic format, e.g. \"03-1234-5678\".",
+                    "type": "string"
                 },
-                "tax_rate": {
+                "qualified_invoice_issuer": {
+                    "type": "boolean"
+                },
+                "registration_number": {
                     "type": "string"
                 },
-                "total_amount": {
-                    "type": "integer"
+                "representative_name": {
+                    "type": "string"
                 },
                 "updated_at": {
                     "type": "string"
                 },
-                "vendor_bank_account_id": {
+                "withholding_tax_applicable": {
+                    "type": "boolean"
+                },
+                "zip_code": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controller_vendors.StatsResponse": {
+            "type": "object",
+            "properties": {
+                "average_payment_amount": {
                     "type": "integer"
                 },
-                "vendor_id": {
+                "first_payment_date": {
+                    "description": "支払実績がない場合は null",
+                    "type": "string"
+                },
+                "invoice_counts": {
+                    "description": "InvoiceCounts is the number of invoices in each status, including zeros.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "last_payment_date": {
+                    "description": "支払実績がない場合は null",
+                    "type": "string"
+                },
+                "monthly": {
+                    "description": "Monthly lists the months with payments, oldest first.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_controller_vendors.MonthlyPaymentResponse"
+                    }
+                },
+                "paid_invoice_count": {
+                    "type": "integer"
+                },
+                "payment_amount": {
                     "type": "integer"
+                },
+                "transfer_amount": {
+                    "type": "integer"
+                },
+                "withholding_tax": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_controller_vendors.VendorRequest": {
+            "type": "object",
+            "required": [
+                "address",
+                "name",
+                "phone_number",
+                "representative_name",
+                "zip_code"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "external_code": {
+                    "description": "ExternalCode is the vendor's code in the customer's existing system, used to\nmatch vendors in CSV imports.",
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "name_kana": {
+                    "description": "NameKana is the reading of the name in katakana or hiragana, used to sort vendors.",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "phone_number": {
+                    "description": "PhoneNumber is normalized to E.164, e.g. \"03-1234-5678\" is stored as \"+81312345678\".",
+                    "type": "string",
+                    "maxLength": 30
+                },
+                "registration_number": {
+                    "description": "RegistrationNumber is the qualified invoice issuer number, e.g. \"T1234567890123\".",
+                    "type": "string",
+                    "maxLength": 20
+                },
+                "representative_name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "withholding_tax_applicable": {
+                    "type": "boolean"
+                },
+                "zip_code": {
+                    "type": "string",
+                    "maxLength": 10
                 }
             }
         }
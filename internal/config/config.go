@@ -2,20 +2,59 @@ package config
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/caarlos0/env/v11"
 )
 
-// Config holds application configuration.
-type Config struct {
+// DatabaseConfig holds database connection configuration.
+type DatabaseConfig struct {
 	DBHost     string `env:"DB_HOST"              envDefault:"localhost"`
 	DBPort     int    `env:"DB_PORT"              envDefault:"5432"`
 	DBUser     string `env:"DB_USER,required"`
 	DBPassword string `env:"DB_PASSWORD,required"`
 	DBName     string `env:"DB_NAME,required"`
 	DBSSLMode  string `env:"DB_SSLMODE"           envDefault:"disable"`
-	JWTSecret  string `env:"JWT_SECRET,required"`
-	Port       int    `env:"PORT"                 envDefault:"8080"`
+}
+
+// Config holds application configuration.
+type Config struct {
+	DatabaseConfig
+
+	Port                     int `env:"PORT"                        envDefault:"8080"`
+	RecurringInvoiceLeadDays int `env:"RECURRING_INVOICE_LEAD_DAYS" envDefault:"7"`
+	// JWTSigningKeyFile is the PEM private key, RSA or Ed25519, tokens are
+	// signed with.
+	JWTSigningKeyFile string `env:"JWT_SIGNING_KEY_FILE,required"`
+	// JWTVerificationKeyFiles are PEM public keys tokens are verified with
+	// besides the signing key, such as the previous signing key after a
+	// rotation.
+	JWTVerificationKeyFiles []string `env:"JWT_VERIFICATION_KEY_FILES" envSeparator:","`
+	// BankAccountCoolingOff is how long a new or changed vendor bank account
+	// cannot be paid into.
+	BankAccountCoolingOff time.Duration `env:"BANK_ACCOUNT_COOLING_OFF" envDefault:"72h"`
+	MailFrom              string        `env:"MAIL_FROM"                envDefault:"noreply@super-shiharai-kun.com"`
+	// MailDriver selects how mail is delivered: "outbox" writes .eml files to
+	// MailOutboxDir, "smtp" sends through the SMTP server.
+	MailDriver    string `env:"MAIL_DRIVER"     envDefault:"outbox"`
+	MailOutboxDir string `env:"MAIL_OUTBOX_DIR" envDefault:"mail-outbox"`
+	SMTPHost      string `env:"SMTP_HOST"       envDefault:"localhost"`
+	SMTPPort      int    `env:"SMTP_PORT"       envDefault:"587"`
+	SMTPUsername  string `env:"SMTP_USERNAME"`
+	SMTPPassword  string `env:"SMTP_PASSWORD"`
+	// AppBaseURL is the URL of the web app, used for links in email.
+	AppBaseURL    string        `env:"APP_BASE_URL"   envDefault:"http://localhost:3000"`
+	InvitationTTL time.Duration `env:"INVITATION_TTL" envDefault:"168h"`
+	// TokenRevocationCacheTTL is how long a token is remembered as not revoked.
+	// A logout on another API server takes effect within this time.
+	TokenRevocationCacheTTL time.Duration `env:"TOKEN_REVOCATION_CACHE_TTL" envDefault:"30s"`
+}
+
+// BatchConfig holds configuration for batch commands.
+type BatchConfig struct {
+	DatabaseConfig
+
+	RecurringInvoiceLeadDays int `env:"RECURRING_INVOICE_LEAD_DAYS" envDefault:"7"`
 }
 
 // Load loads configuration from environment variables.
@@ -28,8 +67,18 @@ func Load() (*Config, error) {
 	return cfg, nil
 }
 
+// LoadBatch loads batch command configuration from environment variables.
+func LoadBatch() (*BatchConfig, error) {
+	cfg := &BatchConfig{}
+	if err := env.Parse(cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+
+	return cfg, nil
+}
+
 // DatabaseURL returns the PostgreSQL connection string.
-func (c *Config) DatabaseURL() string {
+func (c *DatabaseConfig) DatabaseURL() string {
 	return fmt.Sprintf(
 		"postgres://%s:%s@%s:%d/%s?sslmode=%s",
 		c.DBUser, c.DBPassword, c.DBHost, c.DBPort, c.DBName, c.DBSSLMode,
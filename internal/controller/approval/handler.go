@@ -0,0 +1,115 @@
+package approval
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/harusys/super-shiharai-kun/internal/controller/middleware"
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/approval"
+)
+
+// Handler handles approval policy endpoints.
+type Handler struct {
+	usecase   approval.Usecase
+	validator *validator.Validate
+}
+
+// NewHandler creates a new Handler.
+func NewHandler(usecase approval.Usecase, validator *validator.Validate) *Handler {
+	return &Handler{
+		usecase:   usecase,
+		validator: validator,
+	}
+}
+
+// GetPolicy handles getting the approval policy.
+//
+//	@Summary		承認ポリシー取得
+//	@Description	企業の請求書承認ポリシーを取得します
+//	@Tags			approval-policy
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	PolicyResponse
+//	@Failure		401	{object}	ErrorResponse
+//	@Failure		500	{object}	ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/approval-policy [get]
+func (h *Handler) GetPolicy(c *gin.Context) {
+	companyID := middleware.GetCompanyID(c)
+
+	policy, err := h.usecase.GetPolicy(c.Request.Context(), companyID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, NewErrorResponse("internal server error"))
+
+		return
+	}
+
+	c.JSON(http.StatusOK, ToPolicyResponse(policy))
+}
+
+// UpdatePolicy handles updating the approval policy.
+//
+//	@Summary		承認ポリシー更新
+//	@Description	企業の請求書承認ポリシーを更新します。管理者権限が必要です。
+//	@Description	amount_threshold 以上の請求書、require_for_new_vendors が true の場合は初めて支払う取引先の請求書が承認待ちになります。
+//	@Tags			approval-policy
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		UpdatePolicyRequest	true	"承認ポリシー更新リクエスト"
+//	@Success		200		{object}	PolicyResponse
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		401		{object}	ErrorResponse
+//	@Failure		403		{object}	ErrorResponse
+//	@Failure		500		{object}	ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/approval-policy [put]
+func (h *Handler) UpdatePolicy(c *gin.Context) {
+	var req UpdatePolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("invalid request body"))
+
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, NewValidationErrorResponse(formatValidationErrors(err)))
+
+		return
+	}
+
+	policy, err := h.usecase.UpdatePolicy(c.Request.Context(), &approval.UpdatePolicyInput{
+		CompanyID:            middleware.GetCompanyID(c),
+		UserID:               middleware.GetUserID(c),
+		AmountThreshold:      req.AmountThreshold,
+		RequireForNewVendors: req.RequireForNewVendors,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrForbidden) {
+			c.JSON(http.StatusForbidden, NewErrorResponse(err.Error()))
+
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, NewErrorResponse("internal server error"))
+
+		return
+	}
+
+	c.JSON(http.StatusOK, ToPolicyResponse(policy))
+}
+
+func formatValidationErrors(err error) map[string]string {
+	details := make(map[string]string)
+
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		for _, e := range validationErrs {
+			details[e.Field()] = e.Tag()
+		}
+	}
+
+	return details
+}
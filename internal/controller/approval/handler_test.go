@@ -0,0 +1,113 @@
+package approval_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/harusys/super-shiharai-kun/internal/controller/approval"
+	"github.com/harusys/super-shiharai-kun/internal/controller/middleware"
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+	usecase "github.com/harusys/super-shiharai-kun/internal/usecase/approval"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/approval/mock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func setupRouter(handler *approval.Handler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+
+	// Mock auth middleware to inject user_id and company_id
+	r.Use(func(c *gin.Context) {
+		c.Set(middleware.UserIDKey, int64(10))
+		c.Set(middleware.CompanyIDKey, int64(1))
+		c.Next()
+	})
+
+	r.GET("/approval-policy", handler.GetPolicy)
+	r.PUT("/approval-policy", handler.UpdatePolicy)
+
+	return r
+}
+
+func TestHandler_UpdatePolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		body       map[string]any
+		prepare    func(m *mock.MockUsecase)
+		wantStatus int
+	}{
+		{
+			name: "success",
+			body: map[string]any{
+				"amount_threshold":        1000000,
+				"require_for_new_vendors": true,
+			},
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					UpdatePolicy(gomock.Any(), &usecase.UpdatePolicyInput{
+						CompanyID:            1,
+						UserID:               10,
+						AmountThreshold:      1000000,
+						RequireForNewVendors: true,
+					}).
+					Return(&entity.ApprovalPolicy{
+						CompanyID:            1,
+						AmountThreshold:      1000000,
+						RequireForNewVendors: true,
+					}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "not an admin",
+			body: map[string]any{"amount_threshold": 1000000},
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					UpdatePolicy(gomock.Any(), gomock.Any()).
+					Return(nil, domain.ErrForbidden)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "invalid request - negative threshold",
+			body:       map[string]any{"amount_threshold": -1},
+			prepare:    func(_ *mock.MockUsecase) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mock.NewMockUsecase(ctrl)
+			tt.prepare(mockUsecase)
+
+			handler := approval.NewHandler(mockUsecase, validator.New())
+			r := setupRouter(handler)
+
+			body, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(http.MethodPut, "/approval-policy", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,8 @@
+package approval
+
+// UpdatePolicyRequest is the request body for updating the approval policy.
+type UpdatePolicyRequest struct {
+	// AmountThreshold is the payment amount at or above which approval is required (0=no amount rule).
+	AmountThreshold      int64 `json:"amount_threshold"        validate:"min=0"`
+	RequireForNewVendors bool  `json:"require_for_new_vendors"`
+}
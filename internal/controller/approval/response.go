@@ -0,0 +1,41 @@
+package approval
+
+import (
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+)
+
+// PolicyResponse is the response body for the approval policy.
+type PolicyResponse struct {
+	// AmountThreshold is the payment amount at or above which approval is required (0=no amount rule).
+	AmountThreshold      int64 `json:"amount_threshold"`
+	RequireForNewVendors bool  `json:"require_for_new_vendors"`
+}
+
+// ToPolicyResponse converts an entity.ApprovalPolicy to PolicyResponse.
+func ToPolicyResponse(policy *entity.ApprovalPolicy) *PolicyResponse {
+	return &PolicyResponse{
+		AmountThreshold:      policy.AmountThreshold,
+		RequireForNewVendors: policy.RequireForNewVendors,
+	}
+}
+
+// ErrorResponse is the standard error response body.
+type ErrorResponse struct {
+	Error   string            `json:"error"`
+	Details map[string]string `json:"details,omitempty"`
+}
+
+// NewErrorResponse creates a new ErrorResponse.
+func NewErrorResponse(message string) *ErrorResponse {
+	return &ErrorResponse{
+		Error: message,
+	}
+}
+
+// NewValidationErrorResponse creates a new ErrorResponse for validation errors.
+func NewValidationErrorResponse(details map[string]string) *ErrorResponse {
+	return &ErrorResponse{
+		Error:   "validation error",
+		Details: details,
+	}
+}
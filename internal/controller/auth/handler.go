@@ -3,11 +3,16 @@ package auth
 import (
 	"errors"
 	"net/http"
-	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
+	"github.com/harusys/super-shiharai-kun/internal/controller/middleware"
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/harusys/super-shiharai-kun/internal/infrastructure/security"
 	"github.com/harusys/super-shiharai-kun/internal/usecase/auth"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/company"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/invitation"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/mfa"
 )
 
 // Handler handles authentication endpoints.
@@ -24,10 +29,11 @@ func NewHandler(usecase auth.Usecase, validator *validator.Validate) *Handler {
 	}
 }
 
-// Register handles user registration.
+// Register handles signing up a new company.
 //
-//	@Summary		ユーザー登録
-//	@Description	新規ユーザーを登録し、JWTトークンを発行します
+//	@Summary		企業・ユーザー登録
+//	@Description	新規企業と最初の管理者ユーザーを同一トランザクションで登録し、JWTトークンを発行します。
+//	@Description	既存の企業にユーザーを追加する場合は、企業の管理者からの招待が必要です。
 //	@Tags			auth
 //	@Accept			json
 //	@Produce		json
@@ -52,42 +58,98 @@ func (h *Handler) Register(c *gin.Context) {
 	}
 
 	input := &auth.RegisterInput{
-		CompanyID: req.CompanyID,
-		Name:      req.Name,
-		Email:     req.Email,
-		Password:  req.Password,
+		Company: company.CreateInput{
+			Name:               req.Company.Name,
+			NameKana:           req.Company.NameKana,
+			RepresentativeName: req.Company.RepresentativeName,
+			PhoneNumber:        req.Company.PhoneNumber,
+			ZipCode:            req.Company.ZipCode,
+			Address:            req.Company.Address,
+		},
+		Name:     req.Name,
+		Email:    req.Email,
+		Password: req.Password,
 	}
 
 	tokenPair, err := h.usecase.Register(c.Request.Context(), input)
 	if err != nil {
-		if errors.Is(err, auth.ErrEmailAlreadyExists) {
+		switch {
+		case errors.Is(err, auth.ErrEmailAlreadyExists):
 			c.JSON(http.StatusConflict, NewErrorResponse("email already exists"))
-
-			return
+		case errors.Is(err, domain.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, NewErrorResponse(err.Error()))
+		default:
+			c.JSON(http.StatusInternalServerError, NewErrorResponse("internal server error"))
 		}
 
-		c.JSON(http.StatusInternalServerError, NewErrorResponse("internal server error"))
+		return
+	}
+
+	c.JSON(http.StatusCreated, ToTokenResponse(tokenPair))
+}
+
+// AcceptInvitation handles accepting an invitation.
+//
+//	@Summary		招待承諾
+//	@Description	招待メールのトークンで、招待元の企業に招待時の権限のユーザーを登録し、JWTトークンを発行します。
+//	@Description	トークンは1回のみ使用でき、有効期限を過ぎたものや取り消されたものは使用できません。
+//	@Description	招待先のメールアドレスに既にアカウントがある場合は、ユーザーを作成せずに既存のパスワードで本人確認して招待元の企業に追加します（氏名は不要）。発行されるトークンの操作対象は招待元の企業です。
+//	@Description	2要素認証が必要な場合は、ログインと同様にトークンの代わりにMFAトークンを返します。
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		AcceptInvitationRequest	true	"招待承諾リクエスト"
+//	@Success		201		{object}	LoginResponse
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		409		{object}	ErrorResponse
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/auth/accept-invitation [post]
+func (h *Handler) AcceptInvitation(c *gin.Context) {
+	var req AcceptInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("invalid request body"))
+
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, NewValidationErrorResponse(formatValidationErrors(err)))
 
 		return
 	}
 
-	c.JSON(http.StatusCreated, &TokenResponse{
-		AccessToken:  tokenPair.AccessToken,
-		TokenType:    "Bearer",
-		ExpiresIn:    int64(time.Until(tokenPair.AccessTokenExpiresAt).Seconds()),
-		RefreshToken: tokenPair.RefreshToken,
+	result, err := h.usecase.AcceptInvitation(c.Request.Context(), &invitation.AcceptInput{
+		Token:    req.Token,
+		Name:     req.Name,
+		Password: req.Password,
 	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, NewErrorResponse(err.Error()))
+		case errors.Is(err, domain.ErrAlreadyExists):
+			c.JSON(http.StatusConflict, NewErrorResponse("email already exists"))
+		default:
+			c.JSON(http.StatusInternalServerError, NewErrorResponse("internal server error"))
+		}
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, ToLoginResponse(result))
 }
 
 // Login handles user login.
 //
 //	@Summary		ログイン
-//	@Description	メールアドレスとパスワードで認証し、JWTトークンを発行します
+//	@Description	メールアドレスとパスワードで認証し、JWTトークンを発行します。
+//	@Description	2要素認証を有効にしているユーザーと、2要素認証を必須にしている企業のユーザーには、トークンの代わりに mfa_required と有効期限5分のMFAトークンを返します。MFAトークンと認証コードを POST /auth/mfa/verify に送るとトークンを発行します。
+//	@Description	mfa_enrollment_required の場合は、先に POST /auth/mfa/enroll で認証アプリを登録します。
 //	@Tags			auth
 //	@Accept			json
 //	@Produce		json
 //	@Param			request	body		LoginRequest	true	"ログインリクエスト"
-//	@Success		200		{object}	TokenResponse
+//	@Success		200		{object}	LoginResponse
 //	@Failure		400		{object}	ErrorResponse
 //	@Failure		401		{object}	ErrorResponse
 //	@Failure		500		{object}	ErrorResponse
@@ -111,7 +173,7 @@ func (h *Handler) Login(c *gin.Context) {
 		Password: req.Password,
 	}
 
-	tokenPair, err := h.usecase.Login(c.Request.Context(), input)
+	result, err := h.usecase.Login(c.Request.Context(), input)
 	if err != nil {
 		if errors.Is(err, auth.ErrInvalidCredentials) {
 			c.JSON(http.StatusUnauthorized, NewErrorResponse("invalid credentials"))
@@ -124,18 +186,96 @@ func (h *Handler) Login(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, &TokenResponse{
-		AccessToken:  tokenPair.AccessToken,
-		TokenType:    "Bearer",
-		ExpiresIn:    int64(time.Until(tokenPair.AccessTokenExpiresAt).Seconds()),
-		RefreshToken: tokenPair.RefreshToken,
-	})
+	c.JSON(http.StatusOK, ToLoginResponse(result))
+}
+
+// VerifyMFA handles giving the second factor of a login.
+//
+//	@Summary		2要素認証
+//	@Description	ログインで返されたMFAトークンと、認証アプリの6桁のコードまたはリカバリーコードで認証し、JWTトークンを発行します。
+//	@Description	認証コードとリカバリーコードはそれぞれ1回のみ使用できます。MFAトークンも1回のログインにしか使用できません。
+//	@Description	認証アプリを登録中のユーザーは、最初のコードで登録が完了し、リカバリーコードが1回だけ返されます。
+//	@Description	5回続けて認証に失敗すると、15分間は認証できません。
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		MFAVerifyRequest	true	"2要素認証リクエスト"
+//	@Success		200		{object}	LoginResponse
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		401		{object}	ErrorResponse
+//	@Failure		409		{object}	ErrorResponse
+//	@Failure		429		{object}	ErrorResponse
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/auth/mfa/verify [post]
+func (h *Handler) VerifyMFA(c *gin.Context) {
+	var req MFAVerifyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("invalid request body"))
+
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, NewValidationErrorResponse(formatValidationErrors(err)))
+
+		return
+	}
+
+	result, err := h.usecase.VerifyMFA(c.Request.Context(), req.MFAToken, req.Code)
+	if err != nil {
+		respondMFAError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, ToLoginResponse(result))
+}
+
+// EnrollMFA handles enrolling in two-factor authentication while logging in.
+//
+//	@Summary		ログイン時の2要素認証の登録
+//	@Description	2要素認証を必須にしている企業で未登録のユーザーが、ログインで返されたMFAトークンで認証アプリを登録します。
+//	@Description	provisioning_uri をQRコードで表示して認証アプリで読み取り、表示されたコードを POST /auth/mfa/verify に送ると登録が完了します。
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		MFAEnrollRequest	true	"2要素認証登録リクエスト"
+//	@Success		200		{object}	MFAEnrollmentResponse
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		401		{object}	ErrorResponse
+//	@Failure		409		{object}	ErrorResponse
+//	@Failure		500		{object}	ErrorResponse
+//	@Router			/auth/mfa/enroll [post]
+func (h *Handler) EnrollMFA(c *gin.Context) {
+	var req MFAEnrollRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("invalid request body"))
+
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, NewValidationErrorResponse(formatValidationErrors(err)))
+
+		return
+	}
+
+	enrollment, err := h.usecase.EnrollMFA(c.Request.Context(), req.MFAToken)
+	if err != nil {
+		respondMFAError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, ToMFAEnrollmentResponse(enrollment))
 }
 
 // RefreshToken handles token refresh.
 //
 //	@Summary		トークン更新
-//	@Description	リフレッシュトークンを使用して新しいアクセストークンを取得します
+//	@Description	リフレッシュトークンを使用して新しいアクセストークンとリフレッシュトークンを取得します。
+//	@Description	使用したリフレッシュトークンは無効になります。使用済みのリフレッシュトークンを再度送信すると、同じログインから発行されたすべてのリフレッシュトークンが無効になります。
+//	@Description	企業が2要素認証を必須にした後、2要素認証を登録していないユーザーは更新できません（403）。再度ログインして登録してください。
 //	@Tags			auth
 //	@Accept			json
 //	@Produce		json
@@ -143,6 +283,7 @@ func (h *Handler) Login(c *gin.Context) {
 //	@Success		200		{object}	TokenResponse
 //	@Failure		400		{object}	ErrorResponse
 //	@Failure		401		{object}	ErrorResponse
+//	@Failure		403		{object}	ErrorResponse
 //	@Router			/auth/refresh [post]
 func (h *Handler) RefreshToken(c *gin.Context) {
 	var req RefreshTokenRequest
@@ -160,17 +301,217 @@ func (h *Handler) RefreshToken(c *gin.Context) {
 
 	tokenPair, err := h.usecase.RefreshToken(c.Request.Context(), req.RefreshToken)
 	if err != nil {
+		if errors.Is(err, auth.ErrMFARequired) {
+			c.JSON(http.StatusForbidden, NewErrorResponse(err.Error()))
+
+			return
+		}
+
 		c.JSON(http.StatusUnauthorized, NewErrorResponse("invalid or expired refresh token"))
 
 		return
 	}
 
-	c.JSON(http.StatusOK, &TokenResponse{
-		AccessToken:  tokenPair.AccessToken,
-		TokenType:    "Bearer",
-		ExpiresIn:    int64(time.Until(tokenPair.AccessTokenExpiresAt).Seconds()),
-		RefreshToken: tokenPair.RefreshToken,
+	c.JSON(http.StatusOK, ToTokenResponse(tokenPair))
+}
+
+// SwitchCompany handles switching the active company.
+//
+//	@Summary		企業の切り替え
+//	@Description	所属している別の企業を操作対象とするトークンを発行します。新しいトークンのロールは切り替え先の企業での権限になります。
+//	@Description	切り替え先の企業が2要素認証を必須にしている場合は、2要素認証の登録が必要です（403）。
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		SwitchCompanyRequest	true	"企業切り替えリクエスト"
+//	@Success		200		{object}	TokenResponse
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		401		{object}	ErrorResponse
+//	@Failure		403		{object}	ErrorResponse
+//	@Failure		500		{object}	ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/auth/switch-company [post]
+func (h *Handler) SwitchCompany(c *gin.Context) {
+	var req SwitchCompanyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("invalid request body"))
+
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, NewValidationErrorResponse(formatValidationErrors(err)))
+
+		return
+	}
+
+	tokenPair, err := h.usecase.SwitchCompany(
+		c.Request.Context(),
+		middleware.GetUserID(c),
+		req.CompanyID,
+	)
+	if err != nil {
+		if errors.Is(err, domain.ErrForbidden) {
+			c.JSON(http.StatusForbidden, NewErrorResponse(err.Error()))
+
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, NewErrorResponse("internal server error"))
+
+		return
+	}
+
+	c.JSON(http.StatusOK, ToTokenResponse(tokenPair))
+}
+
+// ListCompanies handles listing the companies the user belongs to.
+//
+//	@Summary		所属企業一覧
+//	@Description	ログイン中のユーザーが所属している企業と、各企業での権限を取得します。現在のトークンの操作対象の企業には active が付きます。
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	CompanyListResponse
+//	@Failure		401	{object}	ErrorResponse
+//	@Failure		500	{object}	ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/me/companies [get]
+func (h *Handler) ListCompanies(c *gin.Context) {
+	memberships, err := h.usecase.ListCompanies(c.Request.Context(), middleware.GetUserID(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, NewErrorResponse("internal server error"))
+
+		return
+	}
+
+	c.JSON(http.StatusOK, ToCompanyListResponse(memberships, middleware.GetCompanyID(c)))
+}
+
+// Logout handles logging out of the current session.
+//
+//	@Summary		ログアウト
+//	@Description	使用中のアクセストークンと、同じログインから発行されたリフレッシュトークンを無効にします。
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Success		204
+//	@Failure		401	{object}	ErrorResponse
+//	@Failure		500	{object}	ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/auth/logout [post]
+func (h *Handler) Logout(c *gin.Context) {
+	if err := h.usecase.Logout(c.Request.Context(), middleware.GetClaims(c)); err != nil {
+		c.JSON(http.StatusInternalServerError, NewErrorResponse("internal server error"))
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// LogoutAll handles logging out of every session.
+//
+//	@Summary		すべての端末からログアウト
+//	@Description	ログイン中のユーザーに発行済みのすべてのアクセストークンとリフレッシュトークンを無効にします。
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Success		204
+//	@Failure		401	{object}	ErrorResponse
+//	@Failure		500	{object}	ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/auth/logout-all [post]
+func (h *Handler) LogoutAll(c *gin.Context) {
+	if err := h.usecase.LogoutAll(c.Request.Context(), middleware.GetUserID(c)); err != nil {
+		c.JSON(http.StatusInternalServerError, NewErrorResponse("internal server error"))
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ChangePassword handles changing the password.
+//
+//	@Summary		パスワード変更
+//	@Description	ログイン中のユーザーのパスワードを変更します。発行済みのすべてのトークンは無効になり、新しいトークンを発行します。
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		ChangePasswordRequest	true	"パスワード変更リクエスト"
+//	@Success		200		{object}	TokenResponse
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		401		{object}	ErrorResponse
+//	@Failure		500		{object}	ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/auth/change-password [post]
+func (h *Handler) ChangePassword(c *gin.Context) {
+	var req ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("invalid request body"))
+
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, NewValidationErrorResponse(formatValidationErrors(err)))
+
+		return
+	}
+
+	tokenPair, err := h.usecase.ChangePassword(c.Request.Context(), &auth.ChangePasswordInput{
+		UserID:          middleware.GetUserID(c),
+		CompanyID:       middleware.GetCompanyID(c),
+		CurrentPassword: req.CurrentPassword,
+		NewPassword:     req.NewPassword,
 	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, NewErrorResponse(err.Error()))
+		case errors.Is(err, auth.ErrInvalidCredentials):
+			c.JSON(http.StatusUnauthorized, NewErrorResponse("invalid credentials"))
+		default:
+			c.JSON(http.StatusInternalServerError, NewErrorResponse("internal server error"))
+		}
+
+		return
+	}
+
+	c.JSON(http.StatusOK, ToTokenResponse(tokenPair))
+}
+
+// respondMFAError writes the response for an error of giving the second factor
+// of a login.
+func respondMFAError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, security.ErrInvalidToken),
+		errors.Is(err, security.ErrExpiredToken),
+		errors.Is(err, auth.ErrTokenRevoked),
+		errors.Is(err, auth.ErrInvalidCredentials):
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("invalid or expired MFA token"))
+	case errors.Is(err, mfa.ErrInvalidCode):
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("invalid code"))
+	case errors.Is(err, mfa.ErrLocked):
+		c.JSON(http.StatusTooManyRequests, NewErrorResponse(err.Error()))
+	case errors.Is(err, mfa.ErrAlreadyEnabled), errors.Is(err, mfa.ErrNotEnrolled):
+		c.JSON(http.StatusConflict, NewErrorResponse(err.Error()))
+	default:
+		c.JSON(http.StatusInternalServerError, NewErrorResponse("internal server error"))
+	}
+}
+
+// jwksMaxAge is how long clients may cache the JWKS. A new key must be
+// published at least this long before tokens are signed with it.
+const jwksMaxAge = "300"
+
+// JWKS serves the public keys access tokens are verified with, in JSON Web Key
+// Set format. It is served at /.well-known/jwks.json, outside the /api base
+// path, so it is not part of the Swagger documentation.
+func (h *Handler) JWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age="+jwksMaxAge)
+	c.JSON(http.StatusOK, h.usecase.JWKS())
 }
 
 func formatValidationErrors(err error) map[string]string {
@@ -11,13 +11,30 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 	"github.com/harusys/super-shiharai-kun/internal/controller/auth"
+	"github.com/harusys/super-shiharai-kun/internal/controller/middleware"
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+	"github.com/harusys/super-shiharai-kun/internal/domain/valueobject"
+	"github.com/harusys/super-shiharai-kun/internal/infrastructure/security"
 	usecase "github.com/harusys/super-shiharai-kun/internal/usecase/auth"
 	"github.com/harusys/super-shiharai-kun/internal/usecase/auth/mock"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/company"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/invitation"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/mfa"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
 
+func companyBody() map[string]any {
+	return map[string]any{
+		"name":                "テスト株式会社",
+		"representative_name": "山田太郎",
+		"phone_number":        "03-1234-5678",
+		"zip_code":            "100-0001",
+		"address":             "東京都千代田区千代田1-1",
+	}
+}
+
 func TestHandler_Register(t *testing.T) {
 	t.Parallel()
 
@@ -31,18 +48,24 @@ func TestHandler_Register(t *testing.T) {
 		{
 			name: "success",
 			body: map[string]any{
-				"company_id": 1,
-				"name":       "Test User",
-				"email":      "test@example.com",
-				"password":   "password123",
+				"company":  companyBody(),
+				"name":     "Test User",
+				"email":    "test@example.com",
+				"password": "password123",
 			},
 			prepare: func(m *mock.MockUsecase) {
 				m.EXPECT().
 					Register(gomock.Any(), &usecase.RegisterInput{
-						CompanyID: 1,
-						Name:      "Test User",
-						Email:     "test@example.com",
-						Password:  "password123",
+						Company: company.CreateInput{
+							Name:               "テスト株式会社",
+							RepresentativeName: "山田太郎",
+							PhoneNumber:        "03-1234-5678",
+							ZipCode:            "100-0001",
+							Address:            "東京都千代田区千代田1-1",
+						},
+						Name:     "Test User",
+						Email:    "test@example.com",
+						Password: "password123",
 					}).
 					Return(&usecase.TokenPair{
 						AccessToken:           "access-token",
@@ -61,10 +84,10 @@ func TestHandler_Register(t *testing.T) {
 		{
 			name: "email already exists",
 			body: map[string]any{
-				"company_id": 1,
-				"name":       "Test User",
-				"email":      "existing@example.com",
-				"password":   "password123",
+				"company":  companyBody(),
+				"name":     "Test User",
+				"email":    "existing@example.com",
+				"password": "password123",
 			},
 			prepare: func(m *mock.MockUsecase) {
 				m.EXPECT().
@@ -76,12 +99,43 @@ func TestHandler_Register(t *testing.T) {
 				"error": "email already exists",
 			},
 		},
+		{
+			name: "invalid company profile",
+			body: map[string]any{
+				"company":  companyBody(),
+				"name":     "Test User",
+				"email":    "test@example.com",
+				"password": "password123",
+			},
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					Register(gomock.Any(), gomock.Any()).
+					Return(nil, valueobject.ErrInvalidPhoneNumber)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody: map[string]any{
+				"error": valueobject.ErrInvalidPhoneNumber.Error(),
+			},
+		},
+		{
+			name: "invalid request - missing company",
+			body: map[string]any{
+				"name":     "Test User",
+				"email":    "test@example.com",
+				"password": "password123",
+			},
+			prepare:    func(_ *mock.MockUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantBody: map[string]any{
+				"error": "validation error",
+			},
+		},
 		{
 			name: "invalid request - missing email",
 			body: map[string]any{
-				"company_id": 1,
-				"name":       "Test User",
-				"password":   "password123",
+				"company":  companyBody(),
+				"name":     "Test User",
+				"password": "password123",
 			},
 			prepare:    func(_ *mock.MockUsecase) {},
 			wantStatus: http.StatusBadRequest,
@@ -92,10 +146,10 @@ func TestHandler_Register(t *testing.T) {
 		{
 			name: "invalid request - short password",
 			body: map[string]any{
-				"company_id": 1,
-				"name":       "Test User",
-				"email":      "test@example.com",
-				"password":   "short",
+				"company":  companyBody(),
+				"name":     "Test User",
+				"email":    "test@example.com",
+				"password": "short",
 			},
 			prepare:    func(_ *mock.MockUsecase) {},
 			wantStatus: http.StatusBadRequest,
@@ -144,6 +198,112 @@ func TestHandler_Register(t *testing.T) {
 	}
 }
 
+func TestHandler_AcceptInvitation(t *testing.T) {
+	t.Parallel()
+
+	body := map[string]any{
+		"token":    "invitation-token",
+		"name":     "New User",
+		"password": "password123",
+	}
+
+	tests := []struct {
+		name       string
+		body       map[string]any
+		prepare    func(m *mock.MockUsecase)
+		wantStatus int
+	}{
+		{
+			name: "success",
+			body: body,
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					AcceptInvitation(gomock.Any(), &invitation.AcceptInput{
+						Token:    "invitation-token",
+						Name:     "New User",
+						Password: "password123",
+					}).
+					Return(&usecase.LoginResult{
+						Tokens: &usecase.TokenPair{
+							AccessToken:  "access-token",
+							RefreshToken: "refresh-token",
+						},
+					}, nil)
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name: "invalid or expired token",
+			body: body,
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					AcceptInvitation(gomock.Any(), gomock.Any()).
+					Return(nil, invitation.ErrInvalidInvitation)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "already a member",
+			body: body,
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					AcceptInvitation(gomock.Any(), gomock.Any()).
+					Return(nil, invitation.ErrAlreadyMember)
+			},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name: "wrong password for an existing account",
+			body: body,
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					AcceptInvitation(gomock.Any(), gomock.Any()).
+					Return(nil, invitation.ErrPasswordMismatch)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid request - missing token",
+			body:       map[string]any{"name": "New User", "password": "password123"},
+			prepare:    func(_ *mock.MockUsecase) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mock.NewMockUsecase(ctrl)
+			tt.prepare(mockUsecase)
+
+			handler := auth.NewHandler(mockUsecase, validator.New())
+
+			gin.SetMode(gin.TestMode)
+
+			r := gin.New()
+			r.POST("/accept-invitation", handler.AcceptInvitation)
+
+			body, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(
+				http.MethodPost,
+				"/accept-invitation",
+				bytes.NewReader(body),
+			)
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
 func TestHandler_Login(t *testing.T) {
 	t.Parallel()
 
@@ -166,11 +326,13 @@ func TestHandler_Login(t *testing.T) {
 						Email:    "test@example.com",
 						Password: "password123",
 					}).
-					Return(&usecase.TokenPair{
-						AccessToken:           "access-token",
-						AccessTokenExpiresAt:  time.Date(2024, 1, 1, 10, 15, 0, 0, time.UTC),
-						RefreshToken:          "refresh-token",
-						RefreshTokenExpiresAt: time.Date(2024, 1, 8, 10, 0, 0, 0, time.UTC),
+					Return(&usecase.LoginResult{
+						Tokens: &usecase.TokenPair{
+							AccessToken:           "access-token",
+							AccessTokenExpiresAt:  time.Date(2024, 1, 1, 10, 15, 0, 0, time.UTC),
+							RefreshToken:          "refresh-token",
+							RefreshTokenExpiresAt: time.Date(2024, 1, 8, 10, 0, 0, 0, time.UTC),
+						},
 					}, nil)
 			},
 			wantStatus: http.StatusOK,
@@ -178,6 +340,30 @@ func TestHandler_Login(t *testing.T) {
 				"access_token":  "access-token",
 				"token_type":    "Bearer",
 				"refresh_token": "refresh-token",
+				"mfa_required":  false,
+			},
+		},
+		{
+			name: "two-factor authentication required",
+			body: map[string]any{
+				"email":    "test@example.com",
+				"password": "password123",
+			},
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					Login(gomock.Any(), gomock.Any()).
+					Return(&usecase.LoginResult{
+						MFAToken:              "mfa-token",
+						MFATokenExpiresAt:     time.Now().Add(5 * time.Minute),
+						MFAEnrollmentRequired: true,
+					}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody: map[string]any{
+				"mfa_required":            true,
+				"mfa_token":               "mfa-token",
+				"mfa_enrollment_required": true,
+				"access_token":            nil,
 			},
 		},
 		{
@@ -248,6 +434,213 @@ func TestHandler_Login(t *testing.T) {
 	}
 }
 
+func TestHandler_VerifyMFA(t *testing.T) {
+	t.Parallel()
+
+	body := map[string]any{"mfa_token": "mfa-token", "code": "123456"}
+
+	tests := []struct {
+		name       string
+		body       map[string]any
+		prepare    func(m *mock.MockUsecase)
+		wantStatus int
+		wantBody   map[string]any
+	}{
+		{
+			name: "success",
+			body: body,
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					VerifyMFA(gomock.Any(), "mfa-token", "123456").
+					Return(&usecase.LoginResult{
+						Tokens: &usecase.TokenPair{
+							AccessToken:  "access-token",
+							RefreshToken: "refresh-token",
+						},
+						RecoveryCodes: []string{"aaaaa-bbbbb"},
+					}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody: map[string]any{
+				"access_token":   "access-token",
+				"refresh_token":  "refresh-token",
+				"recovery_codes": []any{"aaaaa-bbbbb"},
+			},
+		},
+		{
+			name: "invalid code",
+			body: body,
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					VerifyMFA(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, mfa.ErrInvalidCode)
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantBody:   map[string]any{"error": "invalid code"},
+		},
+		{
+			name: "used MFA token",
+			body: body,
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					VerifyMFA(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, usecase.ErrTokenRevoked)
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantBody:   map[string]any{"error": "invalid or expired MFA token"},
+		},
+		{
+			name: "locked",
+			body: body,
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					VerifyMFA(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, mfa.ErrLocked)
+			},
+			wantStatus: http.StatusTooManyRequests,
+		},
+		{
+			name: "not enrolled",
+			body: body,
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					VerifyMFA(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, mfa.ErrNotEnrolled)
+			},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:       "missing code",
+			body:       map[string]any{"mfa_token": "mfa-token"},
+			prepare:    func(_ *mock.MockUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   map[string]any{"error": "validation error"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mock.NewMockUsecase(ctrl)
+			tt.prepare(mockUsecase)
+
+			handler := auth.NewHandler(mockUsecase, validator.New())
+
+			gin.SetMode(gin.TestMode)
+
+			r := gin.New()
+			r.POST("/mfa/verify", handler.VerifyMFA)
+
+			body, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(http.MethodPost, "/mfa/verify", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+
+			var resp map[string]any
+
+			err := json.Unmarshal(w.Body.Bytes(), &resp)
+			require.NoError(t, err)
+
+			for k, v := range tt.wantBody {
+				assert.Equal(t, v, resp[k])
+			}
+		})
+	}
+}
+
+func TestHandler_EnrollMFA(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		prepare    func(m *mock.MockUsecase)
+		wantStatus int
+		wantBody   map[string]any
+	}{
+		{
+			name: "success",
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					EnrollMFA(gomock.Any(), "mfa-token").
+					Return(&mfa.Enrollment{
+						Secret:          "SECRET",
+						ProvisioningURI: "otpauth://totp/example",
+					}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody: map[string]any{
+				"secret":           "SECRET",
+				"provisioning_uri": "otpauth://totp/example",
+			},
+		},
+		{
+			name: "expired MFA token",
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					EnrollMFA(gomock.Any(), "mfa-token").
+					Return(nil, security.ErrExpiredToken)
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "already enabled",
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					EnrollMFA(gomock.Any(), "mfa-token").
+					Return(nil, mfa.ErrAlreadyEnabled)
+			},
+			wantStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mock.NewMockUsecase(ctrl)
+			tt.prepare(mockUsecase)
+
+			handler := auth.NewHandler(mockUsecase, validator.New())
+
+			gin.SetMode(gin.TestMode)
+
+			r := gin.New()
+			r.POST("/mfa/enroll", handler.EnrollMFA)
+
+			body, _ := json.Marshal(map[string]any{"mfa_token": "mfa-token"})
+			req := httptest.NewRequest(http.MethodPost, "/mfa/enroll", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+
+			var resp map[string]any
+
+			err := json.Unmarshal(w.Body.Bytes(), &resp)
+			require.NoError(t, err)
+
+			for k, v := range tt.wantBody {
+				assert.Equal(t, v, resp[k])
+			}
+		})
+	}
+}
+
 func TestHandler_RefreshToken(t *testing.T) {
 	t.Parallel()
 
@@ -295,6 +688,18 @@ func TestHandler_RefreshToken(t *testing.T) {
 				"error": "invalid or expired refresh token",
 			},
 		},
+		{
+			name: "company requires two-factor authentication",
+			body: map[string]any{
+				"refresh_token": "valid-refresh-token",
+			},
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					RefreshToken(gomock.Any(), "valid-refresh-token").
+					Return(nil, usecase.ErrMFARequired)
+			},
+			wantStatus: http.StatusForbidden,
+		},
 		{
 			name:       "missing refresh token",
 			body:       map[string]any{},
@@ -344,3 +749,279 @@ func TestHandler_RefreshToken(t *testing.T) {
 		})
 	}
 }
+
+// setupMemberRouter registers handlers behind a fake auth middleware for
+// user 1 with company 1 active.
+// memberClaims returns the claims of the access token the member router
+// authenticates with.
+func memberClaims() *security.Claims {
+	return &security.Claims{UserID: 1, CompanyID: 1, SessionID: "family-1"}
+}
+
+func setupMemberRouter(handler *auth.Handler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set(middleware.UserIDKey, int64(1))
+		c.Set(middleware.CompanyIDKey, int64(1))
+		c.Set(middleware.ClaimsKey, memberClaims())
+		c.Next()
+	})
+	r.POST("/auth/switch-company", handler.SwitchCompany)
+	r.GET("/me/companies", handler.ListCompanies)
+	r.POST("/auth/logout", handler.Logout)
+	r.POST("/auth/logout-all", handler.LogoutAll)
+	r.POST("/auth/change-password", handler.ChangePassword)
+
+	return r
+}
+
+func TestHandler_SwitchCompany(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		body       map[string]any
+		prepare    func(m *mock.MockUsecase)
+		wantStatus int
+	}{
+		{
+			name: "success",
+			body: map[string]any{"company_id": 2},
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					SwitchCompany(gomock.Any(), int64(1), int64(2)).
+					Return(&usecase.TokenPair{
+						AccessToken:          "access-token",
+						AccessTokenExpiresAt: time.Now().Add(15 * time.Minute),
+						RefreshToken:         "refresh-token",
+					}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "not a member",
+			body: map[string]any{"company_id": 3},
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					SwitchCompany(gomock.Any(), int64(1), int64(3)).
+					Return(nil, usecase.ErrNotMember)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "invalid request - missing company_id",
+			body:       map[string]any{},
+			prepare:    func(_ *mock.MockUsecase) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mock.NewMockUsecase(ctrl)
+			tt.prepare(mockUsecase)
+
+			r := setupMemberRouter(auth.NewHandler(mockUsecase, validator.New()))
+
+			body, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(
+				http.MethodPost,
+				"/auth/switch-company",
+				bytes.NewReader(body),
+			)
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestHandler_ListCompanies(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mock.NewMockUsecase(ctrl)
+	mockUsecase.EXPECT().
+		ListCompanies(gomock.Any(), int64(1)).
+		Return([]*entity.Membership{
+			{UserID: 1, CompanyID: 1, CompanyName: "テスト株式会社", Role: entity.UserRoleAdmin},
+			{UserID: 1, CompanyID: 2, CompanyName: "グループ株式会社", Role: entity.UserRoleViewer},
+		}, nil)
+
+	r := setupMemberRouter(auth.NewHandler(mockUsecase, validator.New()))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/me/companies", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp auth.CompanyListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+
+	assert.Equal(t, []*auth.CompanyResponse{
+		{ID: 1, Name: "テスト株式会社", Role: "admin", Active: true},
+		{ID: 2, Name: "グループ株式会社", Role: "viewer", Active: false},
+	}, resp.Companies)
+}
+
+func TestHandler_Logout(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mock.NewMockUsecase(ctrl)
+	mockUsecase.EXPECT().Logout(gomock.Any(), memberClaims()).Return(nil)
+
+	r := setupMemberRouter(auth.NewHandler(mockUsecase, validator.New()))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/auth/logout", nil))
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func TestHandler_LogoutAll(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mock.NewMockUsecase(ctrl)
+	mockUsecase.EXPECT().LogoutAll(gomock.Any(), int64(1)).Return(nil)
+
+	r := setupMemberRouter(auth.NewHandler(mockUsecase, validator.New()))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/auth/logout-all", nil))
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func TestHandler_ChangePassword(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		body       map[string]any
+		prepare    func(m *mock.MockUsecase)
+		wantStatus int
+	}{
+		{
+			name: "success",
+			body: map[string]any{
+				"current_password": "password123",
+				"new_password":     "new-password123",
+			},
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					ChangePassword(gomock.Any(), &usecase.ChangePasswordInput{
+						UserID:          1,
+						CompanyID:       1,
+						CurrentPassword: "password123",
+						NewPassword:     "new-password123",
+					}).
+					Return(&usecase.TokenPair{
+						AccessToken:          "access-token",
+						AccessTokenExpiresAt: time.Now().Add(15 * time.Minute),
+						RefreshToken:         "refresh-token",
+					}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "wrong current password",
+			body: map[string]any{
+				"current_password": "wrong-password",
+				"new_password":     "new-password123",
+			},
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					ChangePassword(gomock.Any(), gomock.Any()).
+					Return(nil, usecase.ErrWrongPassword)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "invalid request - new password too short",
+			body: map[string]any{
+				"current_password": "password123",
+				"new_password":     "short",
+			},
+			prepare:    func(_ *mock.MockUsecase) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mock.NewMockUsecase(ctrl)
+			tt.prepare(mockUsecase)
+
+			r := setupMemberRouter(auth.NewHandler(mockUsecase, validator.New()))
+
+			body, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(
+				http.MethodPost,
+				"/auth/change-password",
+				bytes.NewReader(body),
+			)
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestHandler_JWKS(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mock.NewMockUsecase(ctrl)
+	mockUsecase.EXPECT().JWKS().Return(&security.JWKS{Keys: []security.JWK{
+		{KeyType: "OKP", KeyID: "key-1", Use: "sig", Algorithm: "EdDSA", Curve: "Ed25519", X: "x"},
+	}})
+
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.GET("/.well-known/jwks.json", auth.NewHandler(mockUsecase, validator.New()).JWKS)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "public, max-age=300", w.Header().Get("Cache-Control"))
+	assert.JSONEq(t, `{"keys":[{
+		"kty": "OKP",
+		"kid": "key-1",
+		"use": "sig",
+		"alg": "EdDSA",
+		"crv": "Ed25519",
+		"x": "x"
+	}]}`, w.Body.String())
+}
@@ -1,11 +1,24 @@
 package auth
 
-// RegisterRequest is the request body for user registration.
+// RegisterRequest is the request body for signing up a new company and its
+// first admin user.
 type RegisterRequest struct {
-	CompanyID int64  `json:"company_id" validate:"required,gt=0"`
-	Name      string `json:"name"       validate:"required,min=1,max=255"`
-	Email     string `json:"email"      validate:"required,email,max=255"`
-	Password  string `json:"password"   validate:"required,min=8,max=72"`
+	Company  RegisterCompanyRequest `json:"company"`
+	Name     string                 `json:"name"     validate:"required,min=1,max=255"`
+	Email    string                 `json:"email"    validate:"required,email,max=255"`
+	Password string                 `json:"password" validate:"required,min=8,max=72"`
+}
+
+// RegisterCompanyRequest is the profile of the company being signed up.
+type RegisterCompanyRequest struct {
+	Name string `json:"name" validate:"required,max=255"`
+	// NameKana is the reading of the name in katakana or hiragana.
+	NameKana           string `json:"name_kana"           validate:"omitempty,max=255"`
+	RepresentativeName string `json:"representative_name" validate:"required,max=255"`
+	// PhoneNumber is normalized to E.164, e.g. "03-1234-5678" is stored as "+81312345678".
+	PhoneNumber string `json:"phone_number" validate:"required,max=30"`
+	ZipCode     string `json:"zip_code"     validate:"required,max=10"`
+	Address     string `json:"address"      validate:"required,max=500"`
 }
 
 // LoginRequest is the request body for user login.
@@ -14,7 +27,40 @@ type LoginRequest struct {
 	Password string `json:"password" validate:"required"`
 }
 
+// MFAVerifyRequest is the request body for giving the second factor of a login.
+type MFAVerifyRequest struct {
+	MFAToken string `json:"mfa_token" validate:"required"`
+	// Code is a 6-digit TOTP code or a recovery code.
+	Code string `json:"code" validate:"required,max=20"`
+}
+
+// MFAEnrollRequest is the request body for enrolling in two-factor
+// authentication while logging in.
+type MFAEnrollRequest struct {
+	MFAToken string `json:"mfa_token" validate:"required"`
+}
+
 // RefreshTokenRequest is the request body for token refresh.
 type RefreshTokenRequest struct {
 	RefreshToken string `json:"refresh_token" validate:"required"`
 }
+
+// AcceptInvitationRequest is the request body for accepting an invitation.
+type AcceptInvitationRequest struct {
+	// Token is the invitation token from the emailed link.
+	Token string `json:"token" validate:"required,max=100"`
+	// Name is required only when the invited email has no account yet.
+	Name     string `json:"name"     validate:"omitempty,max=255"`
+	Password string `json:"password" validate:"required,min=8,max=72"`
+}
+
+// SwitchCompanyRequest is the request body for switching the active company.
+type SwitchCompanyRequest struct {
+	CompanyID int64 `json:"company_id" validate:"required,gt=0"`
+}
+
+// ChangePasswordRequest is the request body for changing the password.
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password"     validate:"required,min=8,max=72"`
+}
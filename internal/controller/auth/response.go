@@ -1,5 +1,13 @@
 package auth
 
+import (
+	"time"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/auth"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/mfa"
+)
+
 // TokenResponse is the response body containing authentication tokens.
 // Follows OAuth 2.0 (RFC 6749) standard format.
 type TokenResponse struct {
@@ -9,6 +17,100 @@ type TokenResponse struct {
 	RefreshToken string `json:"refresh_token"`
 }
 
+// ToTokenResponse converts a token pair to TokenResponse.
+func ToTokenResponse(tokenPair *auth.TokenPair) *TokenResponse {
+	return &TokenResponse{
+		AccessToken:  tokenPair.AccessToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(time.Until(tokenPair.AccessTokenExpiresAt).Seconds()),
+		RefreshToken: tokenPair.RefreshToken,
+	}
+}
+
+// LoginResponse is the response body for a login. It holds the tokens, or an
+// MFA token when the login has to give its second factor first.
+type LoginResponse struct {
+	*TokenResponse
+
+	// MFARequired is true when the tokens are issued only after the second
+	// factor is given to POST /auth/mfa/verify with the MFA token.
+	MFARequired       bool   `json:"mfa_required"`
+	MFAToken          string `json:"mfa_token,omitempty"`
+	MFATokenExpiresIn int64  `json:"mfa_token_expires_in,omitempty"`
+	// MFAEnrollmentRequired is true when the company requires two-factor
+	// authentication and the user has to enrol with POST /auth/mfa/enroll
+	// before giving a code.
+	MFAEnrollmentRequired bool `json:"mfa_enrollment_required,omitempty"`
+	// RecoveryCodes are returned only once, when the login completed enrolment.
+	RecoveryCodes []string `json:"recovery_codes,omitempty"`
+}
+
+// ToLoginResponse converts a login result to LoginResponse.
+func ToLoginResponse(result *auth.LoginResult) *LoginResponse {
+	if result.Tokens == nil {
+		return &LoginResponse{
+			MFARequired:           true,
+			MFAToken:              result.MFAToken,
+			MFATokenExpiresIn:     int64(time.Until(result.MFATokenExpiresAt).Seconds()),
+			MFAEnrollmentRequired: result.MFAEnrollmentRequired,
+		}
+	}
+
+	return &LoginResponse{
+		TokenResponse: ToTokenResponse(result.Tokens),
+		RecoveryCodes: result.RecoveryCodes,
+	}
+}
+
+// MFAEnrollmentResponse is the response body for a new TOTP enrolment.
+type MFAEnrollmentResponse struct {
+	Secret string `json:"secret"`
+	// ProvisioningURI is the otpauth:// URI to show as a QR code for the
+	// authenticator app to scan.
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// ToMFAEnrollmentResponse converts an enrolment to MFAEnrollmentResponse.
+func ToMFAEnrollmentResponse(enrollment *mfa.Enrollment) *MFAEnrollmentResponse {
+	return &MFAEnrollmentResponse{
+		Secret:          enrollment.Secret,
+		ProvisioningURI: enrollment.ProvisioningURI,
+	}
+}
+
+// CompanyResponse is a company the user belongs to.
+type CompanyResponse struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	// Role is the user's role in the company.
+	Role string `json:"role"`
+	// Active is true for the company of the token used for the request.
+	Active bool `json:"active"`
+}
+
+// CompanyListResponse is the response body for the user's companies.
+type CompanyListResponse struct {
+	Companies []*CompanyResponse `json:"companies"`
+}
+
+// ToCompanyListResponse converts memberships to CompanyListResponse.
+func ToCompanyListResponse(
+	memberships []*entity.Membership,
+	activeCompanyID int64,
+) *CompanyListResponse {
+	companies := make([]*CompanyResponse, len(memberships))
+	for i, m := range memberships {
+		companies[i] = &CompanyResponse{
+			ID:     m.CompanyID,
+			Name:   m.CompanyName,
+			Role:   string(m.Role),
+			Active: m.CompanyID == activeCompanyID,
+		}
+	}
+
+	return &CompanyListResponse{Companies: companies}
+}
+
 // ErrorResponse is the standard error response body.
 type ErrorResponse struct {
 	Error   string            `json:"error"`
@@ -0,0 +1,157 @@
+package company
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/harusys/super-shiharai-kun/internal/controller/middleware"
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/company"
+)
+
+// Handler handles company profile endpoints.
+type Handler struct {
+	usecase   company.Usecase
+	validator *validator.Validate
+}
+
+// NewHandler creates a new Handler.
+func NewHandler(usecase company.Usecase, validator *validator.Validate) *Handler {
+	return &Handler{
+		usecase:   usecase,
+		validator: validator,
+	}
+}
+
+// Get handles getting the company profile.
+//
+//	@Summary		企業情報取得
+//	@Description	ログインユーザーの企業情報を取得します。管理者権限が必要です。
+//	@Tags			company
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	Response
+//	@Failure		401	{object}	ErrorResponse
+//	@Failure		403	{object}	ErrorResponse
+//	@Failure		500	{object}	ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/company [get]
+func (h *Handler) Get(c *gin.Context) {
+	company, err := h.usecase.Get(
+		c.Request.Context(),
+		middleware.GetCompanyID(c),
+		middleware.GetUserID(c),
+	)
+	if err != nil {
+		handleError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, ToResponse(company))
+}
+
+// Update handles updating the company profile.
+//
+//	@Summary		企業情報更新
+//	@Description	ログインユーザーの企業情報を部分更新します。管理者権限が必要です。
+//	@Description	指定した項目のみ更新され、変更内容は変更履歴に記録されます。電話番号は E.164 形式に正規化され、郵便番号は存在チェックされます。
+//	@Description	`mfa_required` を true にすると、所属ユーザー全員にログイン時の2要素認証が必須になります。
+//	@Tags			company
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		UpdateRequest	true	"企業情報更新リクエスト"
+//	@Success		200		{object}	Response
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		401		{object}	ErrorResponse
+//	@Failure		403		{object}	ErrorResponse
+//	@Failure		500		{object}	ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/company [patch]
+func (h *Handler) Update(c *gin.Context) {
+	var req UpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("invalid request body"))
+
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, NewValidationErrorResponse(formatValidationErrors(err)))
+
+		return
+	}
+
+	company, err := h.usecase.Update(c.Request.Context(), &company.UpdateInput{
+		CompanyID:          middleware.GetCompanyID(c),
+		UserID:             middleware.GetUserID(c),
+		Name:               req.Name,
+		NameKana:           req.NameKana,
+		RepresentativeName: req.RepresentativeName,
+		PhoneNumber:        req.PhoneNumber,
+		ZipCode:            req.ZipCode,
+		Address:            req.Address,
+		MFARequired:        req.MFARequired,
+	})
+	if err != nil {
+		handleError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, ToResponse(company))
+}
+
+// ListAuditLogs handles listing the company profile changes.
+//
+//	@Summary		企業情報変更履歴
+//	@Description	企業情報の変更履歴を新しい順に最大100件取得します。管理者権限が必要です。
+//	@Tags			company
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	AuditLogListResponse
+//	@Failure		401	{object}	ErrorResponse
+//	@Failure		403	{object}	ErrorResponse
+//	@Failure		500	{object}	ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/company/audit-logs [get]
+func (h *Handler) ListAuditLogs(c *gin.Context) {
+	logs, err := h.usecase.ListAuditLogs(
+		c.Request.Context(),
+		middleware.GetCompanyID(c),
+		middleware.GetUserID(c),
+	)
+	if err != nil {
+		handleError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, ToAuditLogListResponse(logs))
+}
+
+func handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, NewErrorResponse(err.Error()))
+	case errors.Is(err, domain.ErrForbidden):
+		c.JSON(http.StatusForbidden, NewErrorResponse(err.Error()))
+	default:
+		c.JSON(http.StatusInternalServerError, NewErrorResponse("internal server error"))
+	}
+}
+
+func formatValidationErrors(err error) map[string]string {
+	details := make(map[string]string)
+
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		for _, e := range validationErrs {
+			details[e.Field()] = e.Tag()
+		}
+	}
+
+	return details
+}
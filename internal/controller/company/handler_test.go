@@ -0,0 +1,233 @@
+package company_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/harusys/super-shiharai-kun/internal/controller/company"
+	"github.com/harusys/super-shiharai-kun/internal/controller/middleware"
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+	"github.com/harusys/super-shiharai-kun/internal/domain/valueobject"
+	usecase "github.com/harusys/super-shiharai-kun/internal/usecase/company"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/company/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func setupRouter(handler *company.Handler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+
+	// Mock auth middleware to inject user_id and company_id
+	r.Use(func(c *gin.Context) {
+		c.Set(middleware.UserIDKey, int64(10))
+		c.Set(middleware.CompanyIDKey, int64(1))
+		c.Next()
+	})
+
+	r.GET("/company", handler.Get)
+	r.PATCH("/company", handler.Update)
+	r.GET("/company/audit-logs", handler.ListAuditLogs)
+
+	return r
+}
+
+func newCompany() *entity.Company {
+	return &entity.Company{
+		ID:                 1,
+		Name:               "テスト株式会社",
+		RepresentativeName: "山田太郎",
+		PhoneNumber:        "+81312345678",
+		ZipCode:            "100-0001",
+		Address:            "東京都千代田区千代田1-1",
+	}
+}
+
+func TestHandler_Get(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		prepare    func(m *mock.MockUsecase)
+		wantStatus int
+	}{
+		{
+			name: "success",
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().Get(gomock.Any(), int64(1), int64(10)).Return(newCompany(), nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "not an admin",
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().Get(gomock.Any(), int64(1), int64(10)).Return(nil, domain.ErrForbidden)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mock.NewMockUsecase(ctrl)
+			tt.prepare(mockUsecase)
+
+			r := setupRouter(company.NewHandler(mockUsecase, validator.New()))
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/company", nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestHandler_Get_Response(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mock.NewMockUsecase(ctrl)
+	mockUsecase.EXPECT().Get(gomock.Any(), int64(1), int64(10)).Return(newCompany(), nil)
+
+	r := setupRouter(company.NewHandler(mockUsecase, validator.New()))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/company", nil))
+
+	var resp company.Response
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+
+	assert.Equal(t, "+81312345678", resp.PhoneNumber)
+	assert.Equal(t, "03-1234-5678", resp.PhoneNumberDisplay)
+}
+
+func TestHandler_Update(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		body       map[string]any
+		prepare    func(m *mock.MockUsecase)
+		wantStatus int
+	}{
+		{
+			name: "success",
+			body: map[string]any{"phone_number": "03-9876-5432", "name_kana": ""},
+			prepare: func(m *mock.MockUsecase) {
+				phoneNumber := "03-9876-5432"
+				nameKana := ""
+
+				m.EXPECT().
+					Update(gomock.Any(), &usecase.UpdateInput{
+						CompanyID:   1,
+						UserID:      10,
+						NameKana:    &nameKana,
+						PhoneNumber: &phoneNumber,
+					}).
+					Return(newCompany(), nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "invalid phone number",
+			body: map[string]any{"phone_number": "03-1234"},
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Return(nil, valueobject.ErrInvalidPhoneNumber)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "not an admin",
+			body: map[string]any{"address": "東京都千代田区千代田1-2"},
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Return(nil, domain.ErrForbidden)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "invalid request - address too long",
+			body:       map[string]any{"address": strings.Repeat("あ", 501)},
+			prepare:    func(_ *mock.MockUsecase) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mock.NewMockUsecase(ctrl)
+			tt.prepare(mockUsecase)
+
+			r := setupRouter(company.NewHandler(mockUsecase, validator.New()))
+
+			body, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(http.MethodPatch, "/company", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestHandler_ListAuditLogs(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mock.NewMockUsecase(ctrl)
+	mockUsecase.EXPECT().
+		ListAuditLogs(gomock.Any(), int64(1), int64(10)).
+		Return([]*entity.CompanyAuditLog{{
+			ID:        1,
+			CompanyID: 1,
+			UserID:    10,
+			Changes: []*entity.CompanyFieldChange{
+				{Field: "address", Before: "東京都千代田区千代田1-1", After: "東京都千代田区千代田1-2"},
+			},
+		}}, nil)
+
+	r := setupRouter(company.NewHandler(mockUsecase, validator.New()))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/company/audit-logs", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp company.AuditLogListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+
+	require.Len(t, resp.AuditLogs, 1)
+	assert.Equal(t, int64(10), resp.AuditLogs[0].UserID)
+	assert.Equal(t, []*company.FieldChangeResponse{
+		{Field: "address", Before: "東京都千代田区千代田1-1", After: "東京都千代田区千代田1-2"},
+	}, resp.AuditLogs[0].Changes)
+}
@@ -0,0 +1,16 @@
+package company
+
+// UpdateRequest is the request body for updating the company profile. Omitted
+// fields are left unchanged.
+type UpdateRequest struct {
+	Name *string `json:"name" validate:"omitempty,max=255"`
+	// NameKana is the reading of the name in katakana or hiragana. An empty string clears it.
+	NameKana           *string `json:"name_kana"           validate:"omitempty,max=255"`
+	RepresentativeName *string `json:"representative_name" validate:"omitempty,max=255"`
+	// PhoneNumber is normalized to E.164, e.g. "03-1234-5678" is stored as "+81312345678".
+	PhoneNumber *string `json:"phone_number" validate:"omitempty,max=30"`
+	ZipCode     *string `json:"zip_code"     validate:"omitempty,max=10"`
+	Address     *string `json:"address"      validate:"omitempty,max=500"`
+	// MFARequired requires every member to log in with two-factor authentication.
+	MFARequired *bool `json:"mfa_required"`
+}
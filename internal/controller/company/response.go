@@ -0,0 +1,104 @@
+package company
+
+import (
+	"time"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+	"github.com/harusys/super-shiharai-kun/internal/domain/valueobject"
+)
+
+// Response is the response body for the company profile.
+type Response struct {
+	ID                 int64  `json:"id"`
+	Name               string `json:"name"`
+	NameKana           string `json:"name_kana,omitempty"`
+	RepresentativeName string `json:"representative_name"`
+	PhoneNumber        string `json:"phone_number"`
+	// PhoneNumberDisplay is the phone number in domestic format, e.g. "03-1234-5678".
+	PhoneNumberDisplay string    `json:"phone_number_display"`
+	ZipCode            string    `json:"zip_code"`
+	Address            string    `json:"address"`
+	MFARequired        bool      `json:"mfa_required"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+// ToResponse converts an entity.Company to Response.
+func ToResponse(c *entity.Company) *Response {
+	return &Response{
+		ID:                 c.ID,
+		Name:               c.Name,
+		NameKana:           c.NameKana,
+		RepresentativeName: c.RepresentativeName,
+		PhoneNumber:        c.PhoneNumber,
+		PhoneNumberDisplay: valueobject.PhoneNumber(c.PhoneNumber).Display(),
+		ZipCode:            c.ZipCode,
+		Address:            c.Address,
+		MFARequired:        c.MFARequired,
+		CreatedAt:          c.CreatedAt,
+		UpdatedAt:          c.UpdatedAt,
+	}
+}
+
+// AuditLogResponse is the response body for a change to the company profile.
+type AuditLogResponse struct {
+	ID int64 `json:"id"`
+	// UserID is the user who made the change (omitted if the user has been deleted).
+	UserID    int64                  `json:"user_id,omitempty"`
+	Changes   []*FieldChangeResponse `json:"changes"`
+	CreatedAt time.Time              `json:"created_at"`
+}
+
+// FieldChangeResponse is the response body for a change to one field.
+type FieldChangeResponse struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// AuditLogListResponse is the response body for the company profile changes.
+type AuditLogListResponse struct {
+	AuditLogs []*AuditLogResponse `json:"audit_logs"`
+}
+
+// ToAuditLogListResponse converts entity.CompanyAuditLogs to AuditLogListResponse.
+func ToAuditLogListResponse(logs []*entity.CompanyAuditLog) *AuditLogListResponse {
+	items := make([]*AuditLogResponse, len(logs))
+
+	for i, l := range logs {
+		changes := make([]*FieldChangeResponse, len(l.Changes))
+		for j, c := range l.Changes {
+			changes[j] = &FieldChangeResponse{Field: c.Field, Before: c.Before, After: c.After}
+		}
+
+		items[i] = &AuditLogResponse{
+			ID:        l.ID,
+			UserID:    l.UserID,
+			Changes:   changes,
+			CreatedAt: l.CreatedAt,
+		}
+	}
+
+	return &AuditLogListResponse{AuditLogs: items}
+}
+
+// ErrorResponse is the standard error response body.
+type ErrorResponse struct {
+	Error   string            `json:"error"`
+	Details map[string]string `json:"details,omitempty"`
+}
+
+// NewErrorResponse creates a new ErrorResponse.
+func NewErrorResponse(message string) *ErrorResponse {
+	return &ErrorResponse{
+		Error: message,
+	}
+}
+
+// NewValidationErrorResponse creates a new ErrorResponse for validation errors.
+func NewValidationErrorResponse(details map[string]string) *ErrorResponse {
+	return &ErrorResponse{
+		Error:   "validation error",
+		Details: details,
+	}
+}
@@ -0,0 +1,169 @@
+package invitation
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/harusys/super-shiharai-kun/internal/controller/middleware"
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/invitation"
+)
+
+// Handler handles invitation endpoints.
+type Handler struct {
+	usecase   invitation.Usecase
+	validator *validator.Validate
+}
+
+// NewHandler creates a new Handler.
+func NewHandler(usecase invitation.Usecase, validator *validator.Validate) *Handler {
+	return &Handler{
+		usecase:   usecase,
+		validator: validator,
+	}
+}
+
+// Create handles inviting a person to the company.
+//
+//	@Summary		ユーザー招待
+//	@Description	指定したメールアドレスに、自社への招待メールを送信します。管理者権限が必要です。
+//	@Description	招待メールには1回のみ使用できる有効期限付きのトークンが含まれます。同じメールアドレスへ再度招待すると、以前の招待は無効になります。
+//	@Tags			invitations
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		CreateRequest	true	"招待リクエスト"
+//	@Success		201		{object}	Response
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		401		{object}	ErrorResponse
+//	@Failure		403		{object}	ErrorResponse
+//	@Failure		409		{object}	ErrorResponse
+//	@Failure		500		{object}	ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/invitations [post]
+func (h *Handler) Create(c *gin.Context) {
+	var req CreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("invalid request body"))
+
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, NewValidationErrorResponse(formatValidationErrors(err)))
+
+		return
+	}
+
+	created, err := h.usecase.Create(c.Request.Context(), &invitation.CreateInput{
+		CompanyID: middleware.GetCompanyID(c),
+		UserID:    middleware.GetUserID(c),
+		Email:     req.Email,
+		Role:      entity.UserRole(req.Role),
+	})
+	if err != nil {
+		handleError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, ToResponse(created))
+}
+
+// List handles listing the pending invitations.
+//
+//	@Summary		招待一覧
+//	@Description	承諾されていない招待を新しい順に取得します（有効期限切れを含む）。管理者権限が必要です。
+//	@Tags			invitations
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	ListResponse
+//	@Failure		401	{object}	ErrorResponse
+//	@Failure		403	{object}	ErrorResponse
+//	@Failure		500	{object}	ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/invitations [get]
+func (h *Handler) List(c *gin.Context) {
+	invitations, err := h.usecase.List(
+		c.Request.Context(),
+		middleware.GetCompanyID(c),
+		middleware.GetUserID(c),
+	)
+	if err != nil {
+		handleError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, ToListResponse(invitations))
+}
+
+// Revoke handles revoking a pending invitation.
+//
+//	@Summary		招待取り消し
+//	@Description	承諾されていない招待を取り消します。取り消した招待のトークンは使用できなくなります。管理者権限が必要です。
+//	@Tags			invitations
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	int	true	"招待ID"
+//	@Success		204
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		401	{object}	ErrorResponse
+//	@Failure		403	{object}	ErrorResponse
+//	@Failure		404	{object}	ErrorResponse
+//	@Failure		500	{object}	ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/invitations/{id} [delete]
+func (h *Handler) Revoke(c *gin.Context) {
+	invitationID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("invalid invitation id"))
+
+		return
+	}
+
+	err = h.usecase.Revoke(
+		c.Request.Context(),
+		middleware.GetCompanyID(c),
+		middleware.GetUserID(c),
+		invitationID,
+	)
+	if err != nil {
+		handleError(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, NewErrorResponse(err.Error()))
+	case errors.Is(err, domain.ErrForbidden):
+		c.JSON(http.StatusForbidden, NewErrorResponse(err.Error()))
+	case errors.Is(err, domain.ErrNotFound):
+		c.JSON(http.StatusNotFound, NewErrorResponse("invitation not found"))
+	case errors.Is(err, domain.ErrAlreadyExists):
+		c.JSON(http.StatusConflict, NewErrorResponse(err.Error()))
+	default:
+		c.JSON(http.StatusInternalServerError, NewErrorResponse("internal server error"))
+	}
+}
+
+func formatValidationErrors(err error) map[string]string {
+	details := make(map[string]string)
+
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		for _, e := range validationErrs {
+			details[e.Field()] = e.Tag()
+		}
+	}
+
+	return details
+}
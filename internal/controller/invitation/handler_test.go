@@ -0,0 +1,206 @@
+package invitation_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/harusys/super-shiharai-kun/internal/controller/invitation"
+	"github.com/harusys/super-shiharai-kun/internal/controller/middleware"
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+	usecase "github.com/harusys/super-shiharai-kun/internal/usecase/invitation"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/invitation/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func setupRouter(handler *invitation.Handler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+
+	// Mock auth middleware to inject user_id and company_id
+	r.Use(func(c *gin.Context) {
+		c.Set(middleware.UserIDKey, int64(10))
+		c.Set(middleware.CompanyIDKey, int64(1))
+		c.Next()
+	})
+
+	r.POST("/invitations", handler.Create)
+	r.GET("/invitations", handler.List)
+	r.DELETE("/invitations/:id", handler.Revoke)
+
+	return r
+}
+
+func TestHandler_Create(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		body       map[string]any
+		prepare    func(m *mock.MockUsecase)
+		wantStatus int
+	}{
+		{
+			name: "success",
+			body: map[string]any{"email": "new@example.com", "role": "approver"},
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					Create(gomock.Any(), &usecase.CreateInput{
+						CompanyID: 1,
+						UserID:    10,
+						Email:     "new@example.com",
+						Role:      entity.UserRoleApprover,
+					}).
+					Return(&entity.Invitation{
+						ID:        5,
+						Email:     "new@example.com",
+						Role:      entity.UserRoleApprover,
+						TokenHash: "secret-hash",
+					}, nil)
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name: "already a member",
+			body: map[string]any{"email": "user@example.com", "role": "viewer"},
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil, usecase.ErrAlreadyMember)
+			},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name: "not an admin",
+			body: map[string]any{"email": "new@example.com", "role": "viewer"},
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil, domain.ErrForbidden)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "invalid request - unknown role",
+			body:       map[string]any{"email": "new@example.com", "role": "owner"},
+			prepare:    func(_ *mock.MockUsecase) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mock.NewMockUsecase(ctrl)
+			tt.prepare(mockUsecase)
+
+			r := setupRouter(invitation.NewHandler(mockUsecase, validator.New()))
+
+			body, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(http.MethodPost, "/invitations", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.NotContains(t, w.Body.String(), "secret-hash")
+		})
+	}
+}
+
+func TestHandler_List(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mock.NewMockUsecase(ctrl)
+	mockUsecase.EXPECT().
+		List(gomock.Any(), int64(1), int64(10)).
+		Return([]*entity.Invitation{
+			{ID: 5, Email: "new@example.com", Role: entity.UserRoleViewer, InvitedBy: 10},
+		}, nil)
+
+	r := setupRouter(invitation.NewHandler(mockUsecase, validator.New()))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/invitations", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp invitation.ListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+
+	require.Len(t, resp.Invitations, 1)
+	assert.Equal(t, "new@example.com", resp.Invitations[0].Email)
+	assert.Equal(t, "viewer", resp.Invitations[0].Role)
+}
+
+func TestHandler_Revoke(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		path       string
+		prepare    func(m *mock.MockUsecase)
+		wantStatus int
+	}{
+		{
+			name: "success",
+			path: "/invitations/5",
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().Revoke(gomock.Any(), int64(1), int64(10), int64(5)).Return(nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name: "not found",
+			path: "/invitations/5",
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					Revoke(gomock.Any(), int64(1), int64(10), int64(5)).
+					Return(domain.ErrNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "invalid id",
+			path:       "/invitations/abc",
+			prepare:    func(_ *mock.MockUsecase) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mock.NewMockUsecase(ctrl)
+			tt.prepare(mockUsecase)
+
+			r := setupRouter(invitation.NewHandler(mockUsecase, validator.New()))
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, tt.path, nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
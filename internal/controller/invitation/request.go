@@ -0,0 +1,8 @@
+package invitation
+
+// CreateRequest is the request body for inviting a person to the company.
+type CreateRequest struct {
+	Email string `json:"email" validate:"required,email,max=255"`
+	// Role is the role the invited user gets on accepting.
+	Role string `json:"role" validate:"required,oneof=admin accountant approver viewer"`
+}
@@ -0,0 +1,67 @@
+package invitation
+
+import (
+	"time"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+)
+
+// Response is the response body for an invitation. The token is only sent by
+// email and never returned.
+type Response struct {
+	ID    int64  `json:"id"`
+	Email string `json:"email"`
+	Role  string `json:"role"`
+	// InvitedBy is the user who sent the invitation (omitted if the user has been deleted).
+	InvitedBy int64     `json:"invited_by,omitempty"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ToResponse converts an entity.Invitation to Response.
+func ToResponse(i *entity.Invitation) *Response {
+	return &Response{
+		ID:        i.ID,
+		Email:     i.Email,
+		Role:      string(i.Role),
+		InvitedBy: i.InvitedBy,
+		ExpiresAt: i.ExpiresAt,
+		CreatedAt: i.CreatedAt,
+	}
+}
+
+// ListResponse is the response body for the pending invitations.
+type ListResponse struct {
+	Invitations []*Response `json:"invitations"`
+}
+
+// ToListResponse converts entity.Invitations to ListResponse.
+func ToListResponse(invitations []*entity.Invitation) *ListResponse {
+	items := make([]*Response, len(invitations))
+	for i, inv := range invitations {
+		items[i] = ToResponse(inv)
+	}
+
+	return &ListResponse{Invitations: items}
+}
+
+// ErrorResponse is the standard error response body.
+type ErrorResponse struct {
+	Error   string            `json:"error"`
+	Details map[string]string `json:"details,omitempty"`
+}
+
+// NewErrorResponse creates a new ErrorResponse.
+func NewErrorResponse(message string) *ErrorResponse {
+	return &ErrorResponse{
+		Error: message,
+	}
+}
+
+// NewValidationErrorResponse creates a new ErrorResponse for validation errors.
+func NewValidationErrorResponse(details map[string]string) *ErrorResponse {
+	return &ErrorResponse{
+		Error:   "validation error",
+		Details: details,
+	}
+}
@@ -31,6 +31,10 @@ func NewHandler(usecase invoice.Usecase, validator *validator.Validate) *Handler
 //
 //	@Summary		請求書作成
 //	@Description	新しい請求書データを作成します。手数料・消費税は自動計算されます。
+//	@Description	vendor_bank_account_id を省略すると取引先の既定口座に振り込みます（既定口座がない場合は 400）。
+//	@Description	同じ取引先・金額・発行日・支払期日（または同じ取引先請求書番号）の請求書が既にある場合は 409 を返します。allow_duplicate を指定すると登録し、その旨を記録します。
+//	@Description	企業の承認ポリシーに該当する請求書は承認待ち (awaiting_approval) で作成され、承認されるまで支払われません。
+//	@Description	管理者または経理担当者の権限が必要です。
 //	@Tags			invoices
 //	@Accept			json
 //	@Produce		json
@@ -38,7 +42,9 @@ func NewHandler(usecase invoice.Usecase, validator *validator.Validate) *Handler
 //	@Success		201		{object}	Response
 //	@Failure		400		{object}	ErrorResponse
 //	@Failure		401		{object}	ErrorResponse
+//	@Failure		403		{object}	ErrorResponse
 //	@Failure		404		{object}	ErrorResponse
+//	@Failure		409		{object}	DuplicateErrorResponse
 //	@Failure		500		{object}	ErrorResponse
 //	@Security		BearerAuth
 //	@Router			/invoices [post]
@@ -79,16 +85,35 @@ func (h *Handler) Create(c *gin.Context) {
 		IssueDate:           issueDate,
 		PaymentAmount:       req.PaymentAmount,
 		DueDate:             dueDate,
+		VendorInvoiceNumber: req.VendorInvoiceNumber,
+		AllowDuplicate:      req.AllowDuplicate,
+		CreatedBy:           middleware.GetUserID(c),
 	}
 
 	inv, err := h.usecase.Create(c.Request.Context(), input)
 	if err != nil {
+		var dupErr *domain.DuplicateInvoiceError
+		if errors.As(err, &dupErr) {
+			c.JSON(http.StatusConflict, &DuplicateErrorResponse{
+				Error:               "possible duplicate invoice",
+				DuplicateInvoiceIDs: dupErr.InvoiceIDs,
+			})
+
+			return
+		}
+
 		if errors.Is(err, domain.ErrNotFound) {
 			c.JSON(http.StatusNotFound, NewErrorResponse("vendor or bank account not found"))
 
 			return
 		}
 
+		if errors.Is(err, domain.ErrInvalidInput) {
+			c.JSON(http.StatusBadRequest, NewErrorResponse(err.Error()))
+
+			return
+		}
+
 		c.JSON(http.StatusInternalServerError, NewErrorResponse("internal server error"))
 
 		return
@@ -192,6 +217,154 @@ func (h *Handler) GetByID(c *gin.Context) {
 	c.JSON(http.StatusOK, ToResponse(inv))
 }
 
+// Approve handles approving an invoice awaiting approval.
+//
+//	@Summary		請求書承認
+//	@Description	承認待ちの請求書を承認し、支払対象にします。承認者権限が必要で、作成者本人は承認できません。
+//	@Tags			invoices
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"請求書ID"
+//	@Success		200	{object}	Response
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		401	{object}	ErrorResponse
+//	@Failure		403	{object}	ErrorResponse
+//	@Failure		404	{object}	ErrorResponse
+//	@Failure		409	{object}	ErrorResponse
+//	@Failure		500	{object}	ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/invoices/{id}/approve [post]
+func (h *Handler) Approve(c *gin.Context) {
+	companyID := middleware.GetCompanyID(c)
+	userID := middleware.GetUserID(c)
+
+	invoiceID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("invalid invoice id"))
+
+		return
+	}
+
+	inv, err := h.usecase.Approve(c.Request.Context(), companyID, invoiceID, userID)
+	if err != nil {
+		h.handleReviewError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, ToResponse(inv))
+}
+
+// Reject handles rejecting an invoice awaiting approval.
+//
+//	@Summary		請求書差し戻し
+//	@Description	承認待ちの請求書を差し戻します。承認者権限が必要で、作成者本人は差し戻せません。
+//	@Tags			invoices
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int				true	"請求書ID"
+//	@Param			request	body		RejectRequest	true	"差し戻しリクエスト"
+//	@Success		200		{object}	Response
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		401		{object}	ErrorResponse
+//	@Failure		403		{object}	ErrorResponse
+//	@Failure		404		{object}	ErrorResponse
+//	@Failure		409		{object}	ErrorResponse
+//	@Failure		500		{object}	ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/invoices/{id}/reject [post]
+func (h *Handler) Reject(c *gin.Context) {
+	companyID := middleware.GetCompanyID(c)
+	userID := middleware.GetUserID(c)
+
+	invoiceID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("invalid invoice id"))
+
+		return
+	}
+
+	var req RejectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("invalid request body"))
+
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, NewValidationErrorResponse(formatValidationErrors(err)))
+
+		return
+	}
+
+	inv, err := h.usecase.Reject(c.Request.Context(), &invoice.RejectInput{
+		CompanyID:  companyID,
+		InvoiceID:  invoiceID,
+		ApproverID: userID,
+		Reason:     req.Reason,
+	})
+	if err != nil {
+		h.handleReviewError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, ToResponse(inv))
+}
+
+func (h *Handler) handleReviewError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrForbidden):
+		c.JSON(http.StatusForbidden, NewErrorResponse(err.Error()))
+	case errors.Is(err, domain.ErrNotFound):
+		c.JSON(http.StatusNotFound, NewErrorResponse("invoice not found"))
+	case errors.Is(err, domain.ErrInvalidState):
+		c.JSON(http.StatusConflict, NewErrorResponse("invoice is not awaiting approval"))
+	default:
+		c.JSON(http.StatusInternalServerError, NewErrorResponse("internal server error"))
+	}
+}
+
+// WithholdingReport handles the yearly withholding tax report.
+//
+//	@Summary		源泉徴収レポート取得
+//	@Description	指定年に支払期日を迎える支払済みの請求書の源泉徴収税額を取引先ごとに集計します。未払いの請求書は源泉徴収されていないため含みません
+//	@Tags			reports
+//	@Accept			json
+//	@Produce		json
+//	@Param			year	query		int	true	"対象年 (YYYY)"
+//	@Success		200		{object}	WithholdingReportResponse
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		401		{object}	ErrorResponse
+//	@Failure		500		{object}	ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/reports/withholding-tax [get]
+func (h *Handler) WithholdingReport(c *gin.Context) {
+	companyID := middleware.GetCompanyID(c)
+
+	var req WithholdingReportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("invalid year"))
+
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, NewValidationErrorResponse(formatValidationErrors(err)))
+
+		return
+	}
+
+	report, err := h.usecase.GetWithholdingReport(c.Request.Context(), companyID, req.Year)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, NewErrorResponse("internal server error"))
+
+		return
+	}
+
+	c.JSON(http.StatusOK, ToWithholdingReportResponse(report))
+}
+
 func formatValidationErrors(err error) map[string]string {
 	details := make(map[string]string)
 
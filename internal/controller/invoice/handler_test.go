@@ -27,8 +27,9 @@ func setupRouter(handler *invoice.Handler) *gin.Engine {
 
 	r := gin.New()
 
-	// Mock auth middleware to inject company_id
+	// Mock auth middleware to inject user_id and company_id
 	r.Use(func(c *gin.Context) {
+		c.Set(middleware.UserIDKey, int64(20))
 		c.Set(middleware.CompanyIDKey, int64(1))
 		c.Next()
 	})
@@ -36,6 +37,9 @@ func setupRouter(handler *invoice.Handler) *gin.Engine {
 	r.POST("/invoices", handler.Create)
 	r.GET("/invoices", handler.List)
 	r.GET("/invoices/:id", handler.GetByID)
+	r.POST("/invoices/:id/approve", handler.Approve)
+	r.POST("/invoices/:id/reject", handler.Reject)
+	r.GET("/reports/withholding-tax", handler.WithholdingReport)
 
 	return r
 }
@@ -71,6 +75,7 @@ func TestHandler_Create(t *testing.T) {
 						IssueDate:           issueDate,
 						PaymentAmount:       10000,
 						DueDate:             dueDate,
+						CreatedBy:           20,
 					}).
 					Return(&entity.Invoice{
 						ID:                  1,
@@ -107,6 +112,23 @@ func TestHandler_Create(t *testing.T) {
 			wantStatus: http.StatusNotFound,
 			wantError:  "vendor or bank account not found",
 		},
+		{
+			name: "possible duplicate",
+			body: map[string]any{
+				"vendor_id":              1,
+				"vendor_bank_account_id": 1,
+				"issue_date":             "2024-01-15",
+				"payment_amount":         10000,
+				"due_date":               "2024-02-15",
+			},
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil, &domain.DuplicateInvoiceError{InvoiceIDs: []int64{5, 8}})
+			},
+			wantStatus: http.StatusConflict,
+			wantError:  "possible duplicate invoice",
+		},
 		{
 			name: "invalid request - missing vendor_id",
 			body: map[string]any{
@@ -329,3 +351,207 @@ func TestHandler_GetByID(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_WithholdingReport(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		query      string
+		prepare    func(m *mock.MockUsecase)
+		wantStatus int
+		wantTotal  float64
+	}{
+		{
+			name:  "success",
+			query: "?year=2024",
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					GetWithholdingReport(gomock.Any(), int64(1), 2024).
+					Return(&usecase.WithholdingReport{
+						Year: 2024,
+						Vendors: []*entity.WithholdingSummary{
+							{VendorID: 1, PaymentAmount: 100000, WithholdingTax: 10210},
+							{VendorID: 2, PaymentAmount: 200000, WithholdingTax: 20420},
+						},
+					}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantTotal:  30630,
+		},
+		{
+			name:       "missing year",
+			query:      "",
+			prepare:    func(_ *mock.MockUsecase) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid year",
+			query:      "?year=abc",
+			prepare:    func(_ *mock.MockUsecase) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mock.NewMockUsecase(ctrl)
+			tt.prepare(mockUsecase)
+
+			handler := invoice.NewHandler(mockUsecase, validator.New())
+			r := setupRouter(handler)
+
+			req := httptest.NewRequest(http.MethodGet, "/reports/withholding-tax"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+
+			if tt.wantStatus == http.StatusOK {
+				var resp map[string]any
+
+				err := json.Unmarshal(w.Body.Bytes(), &resp)
+				require.NoError(t, err)
+				assert.InDelta(t, tt.wantTotal, resp["total_withholding_tax"], 0)
+			}
+		})
+	}
+}
+
+func TestHandler_Approve(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		prepare    func(m *mock.MockUsecase)
+		wantStatus int
+	}{
+		{
+			name: "success",
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					Approve(gomock.Any(), int64(1), int64(1), int64(20)).
+					Return(&entity.Invoice{
+						ID:         1,
+						CompanyID:  1,
+						FeeRate:    decimal.NewFromInt(0),
+						TaxRate:    decimal.NewFromInt(0),
+						Status:     entity.InvoiceStatusPending,
+						ReviewedBy: 20,
+					}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "creator or non-approver",
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					Approve(gomock.Any(), int64(1), int64(1), int64(20)).
+					Return(nil, domain.ErrForbidden)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "not awaiting approval",
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					Approve(gomock.Any(), int64(1), int64(1), int64(20)).
+					Return(nil, domain.ErrInvalidState)
+			},
+			wantStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mock.NewMockUsecase(ctrl)
+			tt.prepare(mockUsecase)
+
+			handler := invoice.NewHandler(mockUsecase, validator.New())
+			r := setupRouter(handler)
+
+			req := httptest.NewRequest(http.MethodPost, "/invoices/1/approve", nil)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestHandler_Reject(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		body       map[string]any
+		prepare    func(m *mock.MockUsecase)
+		wantStatus int
+	}{
+		{
+			name: "success",
+			body: map[string]any{"reason": "wrong amount"},
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					Reject(gomock.Any(), &usecase.RejectInput{
+						CompanyID:  1,
+						InvoiceID:  1,
+						ApproverID: 20,
+						Reason:     "wrong amount",
+					}).
+					Return(&entity.Invoice{
+						ID:              1,
+						CompanyID:       1,
+						FeeRate:         decimal.NewFromInt(0),
+						TaxRate:         decimal.NewFromInt(0),
+						Status:          entity.InvoiceStatusRejected,
+						RejectionReason: "wrong amount",
+					}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "invalid request - missing reason",
+			body:       map[string]any{},
+			prepare:    func(_ *mock.MockUsecase) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mock.NewMockUsecase(ctrl)
+			tt.prepare(mockUsecase)
+
+			handler := invoice.NewHandler(mockUsecase, validator.New())
+			r := setupRouter(handler)
+
+			body, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(http.MethodPost, "/invoices/1/reject", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
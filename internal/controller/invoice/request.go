@@ -2,11 +2,20 @@ package invoice
 
 // CreateRequest is the request body for creating an invoice.
 type CreateRequest struct {
-	VendorID            int64  `json:"vendor_id"              validate:"required,gt=0"`
-	VendorBankAccountID int64  `json:"vendor_bank_account_id" validate:"required,gt=0"`
+	VendorID int64 `json:"vendor_id" validate:"required,gt=0"`
+	// VendorBankAccountID defaults to the vendor's default bank account when omitted.
+	VendorBankAccountID int64  `json:"vendor_bank_account_id" validate:"omitempty,gt=0"`
 	IssueDate           string `json:"issue_date"             validate:"required,datetime=2006-01-02"`
 	PaymentAmount       int64  `json:"payment_amount"         validate:"required,gt=0"`
 	DueDate             string `json:"due_date"               validate:"required,datetime=2006-01-02"`
+	VendorInvoiceNumber string `json:"vendor_invoice_number"  validate:"omitempty,max=50"`
+	// AllowDuplicate registers the invoice even if it looks like a duplicate.
+	AllowDuplicate bool `json:"allow_duplicate"`
+}
+
+// RejectRequest is the request body for rejecting an invoice.
+type RejectRequest struct {
+	Reason string `json:"reason" validate:"required,max=500"`
 }
 
 // ListRequest is the query parameters for listing invoices.
@@ -14,3 +23,8 @@ type ListRequest struct {
 	StartDate string `query:"start_date" validate:"omitempty,datetime=2006-01-02"`
 	EndDate   string `query:"end_date"   validate:"omitempty,datetime=2006-01-02"`
 }
+
+// WithholdingReportRequest is the query parameters for the withholding tax report.
+type WithholdingReportRequest struct {
+	Year int `form:"year" validate:"required,min=2000,max=9999"`
+}
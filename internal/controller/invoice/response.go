@@ -4,45 +4,74 @@ import (
 	"time"
 
 	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/invoice"
 )
 
 // Response is the response body for an invoice.
 type Response struct {
-	ID                  int64     `json:"id"`
-	CompanyID           int64     `json:"company_id"`
-	VendorID            int64     `json:"vendor_id"`
-	VendorBankAccountID int64     `json:"vendor_bank_account_id"`
-	IssueDate           string    `json:"issue_date"`
-	PaymentAmount       int64     `json:"payment_amount"`
-	Fee                 int64     `json:"fee"`
-	FeeRate             string    `json:"fee_rate"`
-	Tax                 int64     `json:"tax"`
-	TaxRate             string    `json:"tax_rate"`
-	TotalAmount         int64     `json:"total_amount"`
-	DueDate             string    `json:"due_date"`
-	Status              string    `json:"status"`
-	CreatedAt           time.Time `json:"created_at"`
-	UpdatedAt           time.Time `json:"updated_at"`
+	ID                  int64  `json:"id"`
+	CompanyID           int64  `json:"company_id"`
+	VendorID            int64  `json:"vendor_id"`
+	VendorBankAccountID int64  `json:"vendor_bank_account_id"`
+	IssueDate           string `json:"issue_date"`
+	PaymentAmount       int64  `json:"payment_amount"`
+	Fee                 int64  `json:"fee"`
+	FeeRate             string `json:"fee_rate"`
+	Tax                 int64  `json:"tax"`
+	TaxRate             string `json:"tax_rate"`
+	TotalAmount         int64  `json:"total_amount"`
+	WithholdingTax      int64  `json:"withholding_tax"`
+	TransferAmount      int64  `json:"transfer_amount"`
+	DueDate             string `json:"due_date"`
+	Status              string `json:"status"`
+	// VendorRegistrationNumber is the vendor's qualified invoice issuer number
+	// recorded when the invoice was created.
+	VendorRegistrationNumber string `json:"vendor_registration_number,omitempty"`
+	// VendorQualifiedInvoiceIssuer is false for invoices from unregistered vendors,
+	// whose consumption tax is not eligible for the full input tax credit.
+	VendorQualifiedInvoiceIssuer bool   `json:"vendor_qualified_invoice_issuer"`
+	VendorInvoiceNumber          string `json:"vendor_invoice_number,omitempty"`
+	// DuplicateOverride is true if the invoice was registered despite looking
+	// like a duplicate of an existing invoice.
+	DuplicateOverride bool `json:"duplicate_override"`
+	// CreatedBy is the user who created the invoice (omitted for system-created invoices).
+	CreatedBy int64 `json:"created_by,omitempty"`
+	// ReviewedBy and ReviewedAt record who approved or rejected the invoice and when.
+	ReviewedBy      int64      `json:"reviewed_by,omitempty"`
+	ReviewedAt      *time.Time `json:"reviewed_at,omitempty"`
+	RejectionReason string     `json:"rejection_reason,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
 }
 
 // ToResponse converts an entity.Invoice to Response.
 func ToResponse(inv *entity.Invoice) *Response {
 	return &Response{
-		ID:                  inv.ID,
-		CompanyID:           inv.CompanyID,
-		VendorID:            inv.VendorID,
-		VendorBankAccountID: inv.VendorBankAccountID,
-		IssueDate:           inv.IssueDate.Format("2006-01-02"),
-		PaymentAmount:       inv.PaymentAmount,
-		Fee:                 inv.Fee,
-		FeeRate:             inv.FeeRate.String(),
-		Tax:                 inv.Tax,
-		TaxRate:             inv.TaxRate.String(),
-		TotalAmount:         inv.TotalAmount,
-		DueDate:             inv.DueDate.Format("2006-01-02"),
-		Status:              string(inv.Status),
-		CreatedAt:           inv.CreatedAt,
-		UpdatedAt:           inv.UpdatedAt,
+		ID:                           inv.ID,
+		CompanyID:                    inv.CompanyID,
+		VendorID:                     inv.VendorID,
+		VendorBankAccountID:          inv.VendorBankAccountID,
+		IssueDate:                    inv.IssueDate.Format("2006-01-02"),
+		PaymentAmount:                inv.PaymentAmount,
+		Fee:                          inv.Fee,
+		FeeRate:                      inv.FeeRate.String(),
+		Tax:                          inv.Tax,
+		TaxRate:                      inv.TaxRate.String(),
+		TotalAmount:                  inv.TotalAmount,
+		WithholdingTax:               inv.WithholdingTax,
+		TransferAmount:               inv.TransferAmount,
+		DueDate:                      inv.DueDate.Format("2006-01-02"),
+		Status:                       string(inv.Status),
+		VendorRegistrationNumber:     inv.VendorRegistrationNumber,
+		VendorQualifiedInvoiceIssuer: inv.IsFromQualifiedInvoiceIssuer(),
+		VendorInvoiceNumber:          inv.VendorInvoiceNumber,
+		DuplicateOverride:            inv.DuplicateOverride,
+		CreatedBy:                    inv.CreatedBy,
+		ReviewedBy:                   inv.ReviewedBy,
+		ReviewedAt:                   inv.ReviewedAt,
+		RejectionReason:              inv.RejectionReason,
+		CreatedAt:                    inv.CreatedAt,
+		UpdatedAt:                    inv.UpdatedAt,
 	}
 }
 
@@ -56,12 +85,57 @@ func ToResponses(invoices []*entity.Invoice) []*Response {
 	return responses
 }
 
+// WithholdingReportResponse is the response body for the yearly withholding tax report.
+type WithholdingReportResponse struct {
+	Year                int                          `json:"year"`
+	TotalPaymentAmount  int64                        `json:"total_payment_amount"`
+	TotalWithholdingTax int64                        `json:"total_withholding_tax"`
+	Vendors             []*VendorWithholdingResponse `json:"vendors"`
+}
+
+// VendorWithholdingResponse is the withholding tax total of a single vendor.
+type VendorWithholdingResponse struct {
+	VendorID       int64  `json:"vendor_id"`
+	VendorName     string `json:"vendor_name"`
+	InvoiceCount   int64  `json:"invoice_count"`
+	PaymentAmount  int64  `json:"payment_amount"`
+	WithholdingTax int64  `json:"withholding_tax"`
+}
+
+// ToWithholdingReportResponse converts an invoice.WithholdingReport to WithholdingReportResponse.
+func ToWithholdingReportResponse(report *invoice.WithholdingReport) *WithholdingReportResponse {
+	resp := &WithholdingReportResponse{
+		Year:    report.Year,
+		Vendors: make([]*VendorWithholdingResponse, len(report.Vendors)),
+	}
+
+	for i, v := range report.Vendors {
+		resp.TotalPaymentAmount += v.PaymentAmount
+		resp.TotalWithholdingTax += v.WithholdingTax
+		resp.Vendors[i] = &VendorWithholdingResponse{
+			VendorID:       v.VendorID,
+			VendorName:     v.VendorName,
+			InvoiceCount:   v.InvoiceCount,
+			PaymentAmount:  v.PaymentAmount,
+			WithholdingTax: v.WithholdingTax,
+		}
+	}
+
+	return resp
+}
+
 // ErrorResponse is the standard error response body.
 type ErrorResponse struct {
 	Error   string            `json:"error"`
 	Details map[string]string `json:"details,omitempty"`
 }
 
+// DuplicateErrorResponse is the error response body for a possible duplicate invoice.
+type DuplicateErrorResponse struct {
+	Error               string  `json:"error"`
+	DuplicateInvoiceIDs []int64 `json:"duplicate_invoice_ids"`
+}
+
 // NewErrorResponse creates a new ErrorResponse.
 func NewErrorResponse(message string) *ErrorResponse {
 	return &ErrorResponse{
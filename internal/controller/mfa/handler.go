@@ -0,0 +1,168 @@
+package mfa
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/harusys/super-shiharai-kun/internal/controller/middleware"
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/mfa"
+)
+
+// Handler handles two-factor authentication endpoints.
+type Handler struct {
+	usecase   mfa.Usecase
+	validator *validator.Validate
+}
+
+// NewHandler creates a new Handler.
+func NewHandler(usecase mfa.Usecase, validator *validator.Validate) *Handler {
+	return &Handler{
+		usecase:   usecase,
+		validator: validator,
+	}
+}
+
+// Enroll handles starting a TOTP enrolment.
+//
+//	@Summary		2要素認証の登録開始
+//	@Description	ログイン中のユーザーの2要素認証（TOTP）の登録を開始し、シークレットとQRコード用のプロビジョニングURIを返します。
+//	@Description	provisioning_uri をQRコードで表示して認証アプリで読み取り、表示されたコードを POST /me/mfa/activate に送ると登録が完了します。
+//	@Description	登録が完了するまでは、再度呼び出すと新しいシークレットで登録をやり直します。
+//	@Tags			mfa
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	EnrollmentResponse
+//	@Failure		401	{object}	ErrorResponse
+//	@Failure		409	{object}	ErrorResponse
+//	@Failure		500	{object}	ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/me/mfa/enroll [post]
+func (h *Handler) Enroll(c *gin.Context) {
+	enrollment, err := h.usecase.Enroll(
+		c.Request.Context(),
+		middleware.GetUserID(c),
+		middleware.GetCompanyID(c),
+	)
+	if err != nil {
+		handleError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, ToEnrollmentResponse(enrollment))
+}
+
+// Activate handles confirming a TOTP enrolment.
+//
+//	@Summary		2要素認証の有効化
+//	@Description	認証アプリに表示されたコードで登録を確認し、2要素認証を有効にします。以降のログインでは認証コードが必要になります。
+//	@Description	認証アプリを紛失した場合に使用するリカバリーコードを返します。リカバリーコードはハッシュ化して保存されるため、表示されるのはこの1回だけです。
+//	@Tags			mfa
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		ActivateRequest	true	"有効化リクエスト"
+//	@Success		200		{object}	RecoveryCodesResponse
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		401		{object}	ErrorResponse
+//	@Failure		409		{object}	ErrorResponse
+//	@Failure		500		{object}	ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/me/mfa/activate [post]
+func (h *Handler) Activate(c *gin.Context) {
+	var req ActivateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("invalid request body"))
+
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, NewValidationErrorResponse(formatValidationErrors(err)))
+
+		return
+	}
+
+	codes, err := h.usecase.Activate(c.Request.Context(), middleware.GetUserID(c), req.Code)
+	if err != nil {
+		handleError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, &RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// Reset handles resetting the two-factor authentication of a user.
+//
+//	@Summary		2要素認証のリセット
+//	@Description	認証アプリとリカバリーコードを紛失したユーザーの2要素認証を解除します。管理者権限が必要です。
+//	@Description	解除されたユーザーは、次回のログイン時にパスワードのみでログインでき、改めて登録できます。企業が2要素認証を必須にしている場合は、ログイン時に登録が必要です。
+//	@Description	2要素認証はユーザー単位のため、他の企業にも所属するユーザーはリセットできません（403）。リセットしたユーザーの発行済みトークンは無効になり、リセットは企業の変更履歴に記録されます。
+//	@Tags			mfa
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	int	true	"ユーザーID"
+//	@Success		204
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		401	{object}	ErrorResponse
+//	@Failure		403	{object}	ErrorResponse
+//	@Failure		404	{object}	ErrorResponse
+//	@Failure		500	{object}	ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/company/users/{id}/mfa [delete]
+func (h *Handler) Reset(c *gin.Context) {
+	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("invalid user id"))
+
+		return
+	}
+
+	err = h.usecase.Reset(
+		c.Request.Context(),
+		middleware.GetCompanyID(c),
+		middleware.GetUserID(c),
+		userID,
+	)
+	if err != nil {
+		handleError(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, mfa.ErrInvalidCode):
+		c.JSON(http.StatusUnauthorized, NewErrorResponse("invalid code"))
+	case errors.Is(err, domain.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, NewErrorResponse(err.Error()))
+	case errors.Is(err, domain.ErrForbidden):
+		c.JSON(http.StatusForbidden, NewErrorResponse(err.Error()))
+	case errors.Is(err, domain.ErrNotFound):
+		c.JSON(http.StatusNotFound, NewErrorResponse("user not found"))
+	case errors.Is(err, domain.ErrAlreadyExists), errors.Is(err, domain.ErrInvalidState):
+		c.JSON(http.StatusConflict, NewErrorResponse(err.Error()))
+	default:
+		c.JSON(http.StatusInternalServerError, NewErrorResponse("internal server error"))
+	}
+}
+
+func formatValidationErrors(err error) map[string]string {
+	details := make(map[string]string)
+
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		for _, e := range validationErrs {
+			details[e.Field()] = e.Tag()
+		}
+	}
+
+	return details
+}
@@ -0,0 +1,233 @@
+package mfa_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/harusys/super-shiharai-kun/internal/controller/mfa"
+	"github.com/harusys/super-shiharai-kun/internal/controller/middleware"
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	usecase "github.com/harusys/super-shiharai-kun/internal/usecase/mfa"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/mfa/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func setupRouter(handler *mfa.Handler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+
+	// Mock auth middleware to inject user_id and company_id
+	r.Use(func(c *gin.Context) {
+		c.Set(middleware.UserIDKey, int64(10))
+		c.Set(middleware.CompanyIDKey, int64(1))
+		c.Next()
+	})
+
+	r.POST("/me/mfa/enroll", handler.Enroll)
+	r.POST("/me/mfa/activate", handler.Activate)
+	r.DELETE("/company/users/:id/mfa", handler.Reset)
+
+	return r
+}
+
+func TestHandler_Enroll(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUsecase := mock.NewMockUsecase(ctrl)
+		mockUsecase.EXPECT().
+			Enroll(gomock.Any(), int64(10), int64(1)).
+			Return(&usecase.Enrollment{
+				Secret:          "SECRET",
+				ProvisioningURI: "otpauth://totp/example",
+			}, nil)
+
+		r := setupRouter(mfa.NewHandler(mockUsecase, validator.New()))
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/me/mfa/enroll", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var resp mfa.EnrollmentResponse
+
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, "SECRET", resp.Secret)
+		assert.Equal(t, "otpauth://totp/example", resp.ProvisioningURI)
+	})
+
+	t.Run("already enabled", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUsecase := mock.NewMockUsecase(ctrl)
+		mockUsecase.EXPECT().
+			Enroll(gomock.Any(), int64(10), int64(1)).
+			Return(nil, usecase.ErrAlreadyEnabled)
+
+		r := setupRouter(mfa.NewHandler(mockUsecase, validator.New()))
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/me/mfa/enroll", nil))
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
+
+func TestHandler_Activate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		body       map[string]any
+		prepare    func(m *mock.MockUsecase)
+		wantStatus int
+	}{
+		{
+			name: "success",
+			body: map[string]any{"code": "123456"},
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					Activate(gomock.Any(), int64(10), "123456").
+					Return([]string{"aaaaa-bbbbb"}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "wrong code",
+			body: map[string]any{"code": "123456"},
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					Activate(gomock.Any(), int64(10), "123456").
+					Return(nil, usecase.ErrInvalidCode)
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "not enrolled",
+			body: map[string]any{"code": "123456"},
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					Activate(gomock.Any(), int64(10), "123456").
+					Return(nil, usecase.ErrNotEnrolled)
+			},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:       "not a TOTP code",
+			body:       map[string]any{"code": "aaaaa-bbbbb"},
+			prepare:    func(_ *mock.MockUsecase) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mock.NewMockUsecase(ctrl)
+			tt.prepare(mockUsecase)
+
+			r := setupRouter(mfa.NewHandler(mockUsecase, validator.New()))
+
+			body, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(http.MethodPost, "/me/mfa/activate", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+
+			if tt.wantStatus == http.StatusOK {
+				var resp mfa.RecoveryCodesResponse
+
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				assert.Equal(t, []string{"aaaaa-bbbbb"}, resp.RecoveryCodes)
+			}
+		})
+	}
+}
+
+func TestHandler_Reset(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		path       string
+		prepare    func(m *mock.MockUsecase)
+		wantStatus int
+	}{
+		{
+			name: "success",
+			path: "/company/users/5/mfa",
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().Reset(gomock.Any(), int64(1), int64(10), int64(5)).Return(nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name: "user of another company",
+			path: "/company/users/5/mfa",
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					Reset(gomock.Any(), int64(1), int64(10), int64(5)).
+					Return(domain.ErrNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "not an admin",
+			path: "/company/users/5/mfa",
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					Reset(gomock.Any(), int64(1), int64(10), int64(5)).
+					Return(domain.ErrForbidden)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "invalid id",
+			path:       "/company/users/abc/mfa",
+			prepare:    func(_ *mock.MockUsecase) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mock.NewMockUsecase(ctrl)
+			tt.prepare(mockUsecase)
+
+			r := setupRouter(mfa.NewHandler(mockUsecase, validator.New()))
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, tt.path, nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,7 @@
+package mfa
+
+// ActivateRequest is the request body for confirming a TOTP enrolment.
+type ActivateRequest struct {
+	// Code is the 6-digit code shown by the authenticator app.
+	Code string `json:"code" validate:"required,len=6,numeric"`
+}
@@ -0,0 +1,48 @@
+package mfa
+
+import (
+	"github.com/harusys/super-shiharai-kun/internal/usecase/mfa"
+)
+
+// EnrollmentResponse is the response body for a new TOTP enrolment.
+type EnrollmentResponse struct {
+	Secret string `json:"secret"`
+	// ProvisioningURI is the otpauth:// URI to show as a QR code for the
+	// authenticator app to scan.
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// ToEnrollmentResponse converts an enrolment to EnrollmentResponse.
+func ToEnrollmentResponse(enrollment *mfa.Enrollment) *EnrollmentResponse {
+	return &EnrollmentResponse{
+		Secret:          enrollment.Secret,
+		ProvisioningURI: enrollment.ProvisioningURI,
+	}
+}
+
+// RecoveryCodesResponse is the response body holding the recovery codes of a
+// completed enrolment. The codes cannot be shown again.
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// ErrorResponse is the standard error response body.
+type ErrorResponse struct {
+	Error   string            `json:"error"`
+	Details map[string]string `json:"details,omitempty"`
+}
+
+// NewErrorResponse creates a new ErrorResponse.
+func NewErrorResponse(message string) *ErrorResponse {
+	return &ErrorResponse{
+		Error: message,
+	}
+}
+
+// NewValidationErrorResponse creates a new ErrorResponse for validation errors.
+func NewValidationErrorResponse(details map[string]string) *ErrorResponse {
+	return &ErrorResponse{
+		Error:   "validation error",
+		Details: details,
+	}
+}
@@ -1,11 +1,15 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+	"github.com/harusys/super-shiharai-kun/internal/infrastructure/database"
 	"github.com/harusys/super-shiharai-kun/internal/infrastructure/security"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/auth"
 )
 
 const (
@@ -13,6 +17,10 @@ const (
 	UserIDKey = "user_id"
 	// CompanyIDKey is the context key for company ID.
 	CompanyIDKey = "company_id"
+	// RoleKey is the context key for the user's role.
+	RoleKey = "role"
+	// ClaimsKey is the context key for the claims of the access token.
+	ClaimsKey = "claims"
 )
 
 // ErrorResponse is the standard error response body.
@@ -20,8 +28,9 @@ type ErrorResponse struct {
 	Error string `json:"error"`
 }
 
-// AuthMiddleware creates a JWT authentication middleware.
-func AuthMiddleware(jwtService *security.JWTService) gin.HandlerFunc {
+// AuthMiddleware creates a JWT authentication middleware. Tokens that have
+// been logged out are rejected.
+func AuthMiddleware(authUsecase auth.Usecase) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -45,11 +54,22 @@ func AuthMiddleware(jwtService *security.JWTService) gin.HandlerFunc {
 
 		token := parts[1]
 
-		claims, err := jwtService.ValidateToken(token)
+		claims, err := authUsecase.Authenticate(c.Request.Context(), token)
 		if err != nil {
+			if errors.Is(err, security.ErrInvalidToken) ||
+				errors.Is(err, security.ErrExpiredToken) ||
+				errors.Is(err, auth.ErrTokenRevoked) {
+				c.AbortWithStatusJSON(
+					http.StatusUnauthorized,
+					ErrorResponse{Error: "invalid or expired token"},
+				)
+
+				return
+			}
+
 			c.AbortWithStatusJSON(
-				http.StatusUnauthorized,
-				ErrorResponse{Error: "invalid or expired token"},
+				http.StatusInternalServerError,
+				ErrorResponse{Error: "internal server error"},
 			)
 
 			return
@@ -57,6 +77,14 @@ func AuthMiddleware(jwtService *security.JWTService) gin.HandlerFunc {
 
 		c.Set(UserIDKey, claims.UserID)
 		c.Set(CompanyIDKey, claims.CompanyID)
+		c.Set(RoleKey, claims.Role)
+		c.Set(ClaimsKey, claims)
+
+		// Scope every query of the request to the authenticated company and
+		// user, so that row-level security hides other companies' rows.
+		ctx := database.WithCompanyID(c.Request.Context(), claims.CompanyID)
+		c.Request = c.Request.WithContext(database.WithUserID(ctx, claims.UserID))
+
 		c.Next()
 	}
 }
@@ -80,3 +108,23 @@ func GetCompanyID(c *gin.Context) int64 {
 
 	return 0
 }
+
+// GetRole retrieves the user's role from the gin context.
+func GetRole(c *gin.Context) entity.UserRole {
+	role, _ := c.Get(RoleKey)
+	if r, ok := role.(entity.UserRole); ok {
+		return r
+	}
+
+	return ""
+}
+
+// GetClaims retrieves the claims of the access token from the gin context.
+func GetClaims(c *gin.Context) *security.Claims {
+	claims, _ := c.Get(ClaimsKey)
+	if cl, ok := claims.(*security.Claims); ok {
+		return cl
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package middleware_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/harusys/super-shiharai-kun/internal/controller/middleware"
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+	"github.com/harusys/super-shiharai-kun/internal/infrastructure/security"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/auth"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/auth/mock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAuthMiddleware(t *testing.T) {
+	t.Parallel()
+
+	claims := &security.Claims{
+		UserID:    1,
+		CompanyID: 2,
+		Role:      entity.UserRoleAccountant,
+		TokenType: security.TokenTypeAccess,
+	}
+
+	tests := []struct {
+		name       string
+		header     string
+		prepare    func(m *mock.MockUsecase)
+		wantStatus int
+	}{
+		{
+			name:   "valid token",
+			header: "Bearer access-token",
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().Authenticate(gomock.Any(), "access-token").Return(claims, nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:   "logged out token",
+			header: "Bearer access-token",
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					Authenticate(gomock.Any(), "access-token").
+					Return(nil, auth.ErrTokenRevoked)
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:   "invalid token",
+			header: "Bearer refresh-token",
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					Authenticate(gomock.Any(), "refresh-token").
+					Return(nil, security.ErrInvalidToken)
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:   "revocation store unavailable",
+			header: "Bearer access-token",
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					Authenticate(gomock.Any(), "access-token").
+					Return(nil, errors.New("db error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:       "missing header",
+			header:     "",
+			prepare:    func(_ *mock.MockUsecase) {},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "not a bearer token",
+			header:     "Basic access-token",
+			prepare:    func(_ *mock.MockUsecase) {},
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mock.NewMockUsecase(ctrl)
+			tt.prepare(mockUsecase)
+
+			gin.SetMode(gin.TestMode)
+
+			r := gin.New()
+			r.GET("/me", middleware.AuthMiddleware(mockUsecase), func(c *gin.Context) {
+				assert.Equal(t, int64(1), middleware.GetUserID(c))
+				assert.Equal(t, int64(2), middleware.GetCompanyID(c))
+				assert.Equal(t, entity.UserRoleAccountant, middleware.GetRole(c))
+				assert.Same(t, claims, middleware.GetClaims(c))
+				c.Status(http.StatusNoContent)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/me", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
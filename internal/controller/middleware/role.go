@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+)
+
+// RequireRole creates a middleware that only lets users with one of the given
+// roles through. It must run after AuthMiddleware, which puts the role from the
+// token into the context.
+func RequireRole(roles ...entity.UserRole) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !slices.Contains(roles, GetRole(c)) {
+			c.AbortWithStatusJSON(
+				http.StatusForbidden,
+				ErrorResponse{Error: "insufficient role"},
+			)
+
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,65 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/harusys/super-shiharai-kun/internal/controller/middleware"
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequireRole(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		role       any
+		wantStatus int
+	}{
+		{
+			name:       "allowed role",
+			role:       entity.UserRoleAccountant,
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "other role",
+			role:       entity.UserRoleViewer,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "no role in token",
+			role:       nil,
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			gin.SetMode(gin.TestMode)
+
+			r := gin.New()
+			r.Use(func(c *gin.Context) {
+				if tt.role != nil {
+					c.Set(middleware.RoleKey, tt.role)
+				}
+
+				c.Next()
+			})
+			r.POST(
+				"/invoices",
+				middleware.RequireRole(entity.UserRoleAdmin, entity.UserRoleAccountant),
+				func(c *gin.Context) { c.Status(http.StatusNoContent) },
+			)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/invoices", nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package postalcode
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/postalcode"
+)
+
+// Handler handles postal code endpoints.
+type Handler struct {
+	usecase postalcode.Usecase
+}
+
+// NewHandler creates a new Handler.
+func NewHandler(usecase postalcode.Usecase) *Handler {
+	return &Handler{
+		usecase: usecase,
+	}
+}
+
+// Lookup handles looking up the address of a postal code.
+//
+//	@Summary		郵便番号検索
+//	@Description	郵便番号から住所（都道府県・市区町村・町域）を検索します。1つの郵便番号が複数の町域にまたがる場合は全件を返します。
+//	@Description	郵便番号は「100-0001」「1000001」のどちらの形式でも指定できます。
+//	@Tags			postal-codes
+//	@Accept			json
+//	@Produce		json
+//	@Param			code	path		string	true	"郵便番号"
+//	@Success		200		{object}	Response
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		401		{object}	ErrorResponse
+//	@Failure		404		{object}	ErrorResponse
+//	@Failure		500		{object}	ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/postal-codes/{code} [get]
+func (h *Handler) Lookup(c *gin.Context) {
+	result, err := h.usecase.Lookup(c.Request.Context(), c.Param("code"))
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, NewErrorResponse(err.Error()))
+		case errors.Is(err, domain.ErrNotFound):
+			c.JSON(http.StatusNotFound, NewErrorResponse("postal code not found"))
+		default:
+			c.JSON(http.StatusInternalServerError, NewErrorResponse("internal server error"))
+		}
+
+		return
+	}
+
+	c.JSON(http.StatusOK, ToResponse(result))
+}
@@ -0,0 +1,141 @@
+package postalcode_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/harusys/super-shiharai-kun/internal/controller/postalcode"
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+	"github.com/harusys/super-shiharai-kun/internal/domain/valueobject"
+	usecase "github.com/harusys/super-shiharai-kun/internal/usecase/postalcode"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/postalcode/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func setupRouter(handler *postalcode.Handler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.GET("/postal-codes/:code", handler.Lookup)
+
+	return r
+}
+
+func TestHandler_Lookup(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		code       string
+		prepare    func(m *mock.MockUsecase)
+		wantStatus int
+	}{
+		{
+			name: "success",
+			code: "1000001",
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					Lookup(gomock.Any(), "1000001").
+					Return(&usecase.LookupResult{
+						ZipCode: "100-0001",
+						Areas:   []*entity.PostalCode{{Code: "1000001", Prefecture: "東京都"}},
+					}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "malformed",
+			code: "12345",
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().Lookup(gomock.Any(), "12345").Return(nil, valueobject.ErrInvalidZipCode)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "not found",
+			code: "9999999",
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().Lookup(gomock.Any(), "9999999").Return(nil, domain.ErrNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "internal error",
+			code: "1000001",
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().Lookup(gomock.Any(), "1000001").Return(nil, errors.New("db error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mock.NewMockUsecase(ctrl)
+			tt.prepare(mockUsecase)
+
+			router := setupRouter(postalcode.NewHandler(mockUsecase))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/postal-codes/"+tt.code, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestHandler_Lookup_Response(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mock.NewMockUsecase(ctrl)
+	mockUsecase.EXPECT().
+		Lookup(gomock.Any(), "1000001").
+		Return(&usecase.LookupResult{
+			ZipCode: "100-0001",
+			Areas: []*entity.PostalCode{{
+				Code:           "1000001",
+				Prefecture:     "東京都",
+				City:           "千代田区",
+				Town:           "千代田",
+				PrefectureKana: "トウキョウト",
+				CityKana:       "チヨダク",
+				TownKana:       "チヨダ",
+			}},
+		}, nil)
+
+	router := setupRouter(postalcode.NewHandler(mockUsecase))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/postal-codes/1000001", nil))
+
+	var resp postalcode.Response
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+
+	assert.Equal(t, postalcode.Response{
+		ZipCode: "100-0001",
+		Addresses: []*postalcode.AddressResponse{{
+			Prefecture:     "東京都",
+			City:           "千代田区",
+			Town:           "千代田",
+			PrefectureKana: "トウキョウト",
+			CityKana:       "チヨダク",
+			TownKana:       "チヨダ",
+		}},
+	}, resp)
+}
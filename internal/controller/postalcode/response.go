@@ -0,0 +1,51 @@
+package postalcode
+
+import "github.com/harusys/super-shiharai-kun/internal/usecase/postalcode"
+
+// Response is the response body for a postal code lookup.
+type Response struct {
+	ZipCode   string             `json:"zip_code"`
+	Addresses []*AddressResponse `json:"addresses"`
+}
+
+// AddressResponse is an area covered by a postal code.
+type AddressResponse struct {
+	Prefecture     string `json:"prefecture"`
+	City           string `json:"city"`
+	Town           string `json:"town"` // 市区町村全域の郵便番号では空文字
+	PrefectureKana string `json:"prefecture_kana"`
+	CityKana       string `json:"city_kana"`
+	TownKana       string `json:"town_kana"`
+}
+
+// ToResponse converts a postalcode.LookupResult to Response.
+func ToResponse(result *postalcode.LookupResult) *Response {
+	addresses := make([]*AddressResponse, len(result.Areas))
+	for i, a := range result.Areas {
+		addresses[i] = &AddressResponse{
+			Prefecture:     a.Prefecture,
+			City:           a.City,
+			Town:           a.Town,
+			PrefectureKana: a.PrefectureKana,
+			CityKana:       a.CityKana,
+			TownKana:       a.TownKana,
+		}
+	}
+
+	return &Response{
+		ZipCode:   result.ZipCode.String(),
+		Addresses: addresses,
+	}
+}
+
+// ErrorResponse is the standard error response body.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// NewErrorResponse creates a new ErrorResponse.
+func NewErrorResponse(message string) *ErrorResponse {
+	return &ErrorResponse{
+		Error: message,
+	}
+}
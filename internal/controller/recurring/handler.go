@@ -0,0 +1,284 @@
+package recurring
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/harusys/super-shiharai-kun/internal/controller/middleware"
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/recurring"
+)
+
+// Handler handles recurring invoice schedule endpoints.
+type Handler struct {
+	usecase   recurring.Usecase
+	validator *validator.Validate
+}
+
+// NewHandler creates a new Handler.
+func NewHandler(usecase recurring.Usecase, validator *validator.Validate) *Handler {
+	return &Handler{
+		usecase:   usecase,
+		validator: validator,
+	}
+}
+
+// Create handles recurring invoice schedule creation.
+//
+//	@Summary		定期請求スケジュール作成
+//	@Description	毎月定額の請求書を自動作成するスケジュールを登録します。day_of_month と end_of_month はどちらか一方を指定します。
+//	@Description	管理者または経理担当者の権限が必要です。
+//	@Tags			recurring-invoices
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		ScheduleRequest	true	"定期請求スケジュール作成リクエスト"
+//	@Success		201		{object}	Response
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		401		{object}	ErrorResponse
+//	@Failure		403		{object}	ErrorResponse
+//	@Failure		404		{object}	ErrorResponse
+//	@Failure		500		{object}	ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/recurring-invoices [post]
+func (h *Handler) Create(c *gin.Context) {
+	input, ok := h.bindScheduleInput(c)
+	if !ok {
+		return
+	}
+
+	schedule, err := h.usecase.Create(c.Request.Context(), input)
+	if err != nil {
+		h.handleWriteError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, ToResponse(schedule))
+}
+
+// List handles listing recurring invoice schedules.
+//
+//	@Summary		定期請求スケジュール一覧取得
+//	@Description	企業の定期請求スケジュールの一覧を取得します
+//	@Tags			recurring-invoices
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{array}		Response
+//	@Failure		401	{object}	ErrorResponse
+//	@Failure		500	{object}	ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/recurring-invoices [get]
+func (h *Handler) List(c *gin.Context) {
+	companyID := middleware.GetCompanyID(c)
+
+	schedules, err := h.usecase.List(c.Request.Context(), companyID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, NewErrorResponse("internal server error"))
+
+		return
+	}
+
+	c.JSON(http.StatusOK, ToResponses(schedules))
+}
+
+// GetByID handles getting a recurring invoice schedule by ID.
+//
+//	@Summary		定期請求スケジュール詳細取得
+//	@Description	指定IDの定期請求スケジュールを取得します
+//	@Tags			recurring-invoices
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"定期請求スケジュールID"
+//	@Success		200	{object}	Response
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		401	{object}	ErrorResponse
+//	@Failure		404	{object}	ErrorResponse
+//	@Failure		500	{object}	ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/recurring-invoices/{id} [get]
+func (h *Handler) GetByID(c *gin.Context) {
+	companyID := middleware.GetCompanyID(c)
+
+	scheduleID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("invalid schedule id"))
+
+		return
+	}
+
+	schedule, err := h.usecase.GetByID(c.Request.Context(), companyID, scheduleID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, NewErrorResponse("schedule not found"))
+
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, NewErrorResponse("internal server error"))
+
+		return
+	}
+
+	c.JSON(http.StatusOK, ToResponse(schedule))
+}
+
+// Update handles replacing a recurring invoice schedule.
+//
+//	@Summary		定期請求スケジュール更新
+//	@Description	指定IDの定期請求スケジュールを更新します。次回支払期日は更新後のルールで再計算されます。
+//	@Description	管理者または経理担当者の権限が必要です。
+//	@Tags			recurring-invoices
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int				true	"定期請求スケジュールID"
+//	@Param			request	body		ScheduleRequest	true	"定期請求スケジュール更新リクエスト"
+//	@Success		200		{object}	Response
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		401		{object}	ErrorResponse
+//	@Failure		403		{object}	ErrorResponse
+//	@Failure		404		{object}	ErrorResponse
+//	@Failure		500		{object}	ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/recurring-invoices/{id} [put]
+func (h *Handler) Update(c *gin.Context) {
+	scheduleID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("invalid schedule id"))
+
+		return
+	}
+
+	input, ok := h.bindScheduleInput(c)
+	if !ok {
+		return
+	}
+
+	schedule, err := h.usecase.Update(c.Request.Context(), scheduleID, input)
+	if err != nil {
+		h.handleWriteError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, ToResponse(schedule))
+}
+
+// Delete handles deleting a recurring invoice schedule.
+//
+//	@Summary		定期請求スケジュール削除
+//	@Description	指定IDの定期請求スケジュールを削除します。作成済みの請求書は削除されません。
+//	@Description	管理者または経理担当者の権限が必要です。
+//	@Tags			recurring-invoices
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	int	true	"定期請求スケジュールID"
+//	@Success		204
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		401	{object}	ErrorResponse
+//	@Failure		403	{object}	ErrorResponse
+//	@Failure		404	{object}	ErrorResponse
+//	@Failure		500	{object}	ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/recurring-invoices/{id} [delete]
+func (h *Handler) Delete(c *gin.Context) {
+	companyID := middleware.GetCompanyID(c)
+
+	scheduleID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("invalid schedule id"))
+
+		return
+	}
+
+	if err := h.usecase.Delete(c.Request.Context(), companyID, scheduleID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, NewErrorResponse("schedule not found"))
+
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, NewErrorResponse("internal server error"))
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// bindScheduleInput binds and validates the request body. On failure it writes
+// the error response and returns false.
+func (h *Handler) bindScheduleInput(c *gin.Context) (*recurring.ScheduleInput, bool) {
+	var req ScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("invalid request body"))
+
+		return nil, false
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, NewValidationErrorResponse(formatValidationErrors(err)))
+
+		return nil, false
+	}
+
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("invalid start_date format"))
+
+		return nil, false
+	}
+
+	input := &recurring.ScheduleInput{
+		CompanyID:           middleware.GetCompanyID(c),
+		VendorID:            req.VendorID,
+		VendorBankAccountID: req.VendorBankAccountID,
+		PaymentAmount:       req.PaymentAmount,
+		DayOfMonth:          req.DayOfMonth,
+		EndOfMonth:          req.EndOfMonth,
+		StartDate:           startDate,
+	}
+
+	if req.EndDate != "" {
+		endDate, err := time.Parse("2006-01-02", req.EndDate)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, NewErrorResponse("invalid end_date format"))
+
+			return nil, false
+		}
+
+		input.EndDate = &endDate
+	}
+
+	return input, true
+}
+
+func (h *Handler) handleWriteError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, NewErrorResponse(err.Error()))
+	case errors.Is(err, domain.ErrNotFound):
+		c.JSON(
+			http.StatusNotFound,
+			NewErrorResponse("schedule, vendor or bank account not found"),
+		)
+	default:
+		c.JSON(http.StatusInternalServerError, NewErrorResponse("internal server error"))
+	}
+}
+
+func formatValidationErrors(err error) map[string]string {
+	details := make(map[string]string)
+
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		for _, e := range validationErrs {
+			details[e.Field()] = e.Tag()
+		}
+	}
+
+	return details
+}
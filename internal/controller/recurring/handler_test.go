@@ -0,0 +1,240 @@
+package recurring_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/harusys/super-shiharai-kun/internal/controller/middleware"
+	"github.com/harusys/super-shiharai-kun/internal/controller/recurring"
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+	usecase "github.com/harusys/super-shiharai-kun/internal/usecase/recurring"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/recurring/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func setupRouter(handler *recurring.Handler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+
+	// Mock auth middleware to inject company_id
+	r.Use(func(c *gin.Context) {
+		c.Set(middleware.CompanyIDKey, int64(1))
+		c.Next()
+	})
+
+	r.POST("/recurring-invoices", handler.Create)
+	r.GET("/recurring-invoices", handler.List)
+	r.GET("/recurring-invoices/:id", handler.GetByID)
+	r.PUT("/recurring-invoices/:id", handler.Update)
+	r.DELETE("/recurring-invoices/:id", handler.Delete)
+
+	return r
+}
+
+func TestHandler_Create(t *testing.T) {
+	t.Parallel()
+
+	startDate := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		body       map[string]any
+		prepare    func(m *mock.MockUsecase)
+		wantStatus int
+		wantError  string
+	}{
+		{
+			name: "success",
+			body: map[string]any{
+				"vendor_id":              1,
+				"vendor_bank_account_id": 1,
+				"payment_amount":         100000,
+				"end_of_month":           true,
+				"start_date":             "2024-01-01",
+				"end_date":               "2024-12-31",
+			},
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					Create(gomock.Any(), &usecase.ScheduleInput{
+						CompanyID:           1,
+						VendorID:            1,
+						VendorBankAccountID: 1,
+						PaymentAmount:       100000,
+						EndOfMonth:          true,
+						StartDate:           startDate,
+						EndDate:             &endDate,
+					}).
+					Return(&entity.RecurringInvoiceSchedule{
+						ID:                  1,
+						CompanyID:           1,
+						VendorID:            1,
+						VendorBankAccountID: 1,
+						PaymentAmount:       100000,
+						EndOfMonth:          true,
+						StartDate:           startDate,
+						EndDate:             &endDate,
+						NextDueDate: time.Date(
+							2024,
+							time.January,
+							31,
+							0,
+							0,
+							0,
+							0,
+							time.UTC,
+						),
+					}, nil)
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name: "invalid schedule rule",
+			body: map[string]any{
+				"vendor_id":              1,
+				"vendor_bank_account_id": 1,
+				"payment_amount":         100000,
+				"start_date":             "2024-01-01",
+			},
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil, domain.ErrInvalidInput)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "vendor not found",
+			body: map[string]any{
+				"vendor_id":              999,
+				"vendor_bank_account_id": 1,
+				"payment_amount":         100000,
+				"day_of_month":           25,
+				"start_date":             "2024-01-01",
+			},
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil, domain.ErrNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+			wantError:  "schedule, vendor or bank account not found",
+		},
+		{
+			name: "invalid request - day_of_month out of range",
+			body: map[string]any{
+				"vendor_id":              1,
+				"vendor_bank_account_id": 1,
+				"payment_amount":         100000,
+				"day_of_month":           32,
+				"start_date":             "2024-01-01",
+			},
+			prepare:    func(_ *mock.MockUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantError:  "validation error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mock.NewMockUsecase(ctrl)
+			tt.prepare(mockUsecase)
+
+			handler := recurring.NewHandler(mockUsecase, validator.New())
+			r := setupRouter(handler)
+
+			body, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(
+				http.MethodPost,
+				"/recurring-invoices",
+				bytes.NewReader(body),
+			)
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+
+			if tt.wantError != "" {
+				var resp map[string]any
+
+				err := json.Unmarshal(w.Body.Bytes(), &resp)
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantError, resp["error"])
+			}
+		})
+	}
+}
+
+func TestHandler_Delete(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		id         string
+		prepare    func(m *mock.MockUsecase)
+		wantStatus int
+	}{
+		{
+			name: "success",
+			id:   "1",
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().Delete(gomock.Any(), int64(1), int64(1)).Return(nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name: "not found",
+			id:   "999",
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().Delete(gomock.Any(), int64(1), int64(999)).Return(domain.ErrNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "invalid id",
+			id:         "abc",
+			prepare:    func(_ *mock.MockUsecase) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mock.NewMockUsecase(ctrl)
+			tt.prepare(mockUsecase)
+
+			handler := recurring.NewHandler(mockUsecase, validator.New())
+			r := setupRouter(handler)
+
+			req := httptest.NewRequest(http.MethodDelete, "/recurring-invoices/"+tt.id, nil)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
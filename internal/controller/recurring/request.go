@@ -0,0 +1,13 @@
+package recurring
+
+// ScheduleRequest is the request body for creating or updating a recurring invoice schedule.
+// Exactly one of DayOfMonth and EndOfMonth must be specified.
+type ScheduleRequest struct {
+	VendorID            int64  `json:"vendor_id"              validate:"required,gt=0"`
+	VendorBankAccountID int64  `json:"vendor_bank_account_id" validate:"required,gt=0"`
+	PaymentAmount       int64  `json:"payment_amount"         validate:"required,gt=0"`
+	DayOfMonth          int    `json:"day_of_month"           validate:"omitempty,min=1,max=31"`
+	EndOfMonth          bool   `json:"end_of_month"`
+	StartDate           string `json:"start_date"             validate:"required,datetime=2006-01-02"`
+	EndDate             string `json:"end_date"               validate:"omitempty,datetime=2006-01-02"`
+}
@@ -0,0 +1,76 @@
+package recurring
+
+import (
+	"time"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+)
+
+// Response is the response body for a recurring invoice schedule.
+type Response struct {
+	ID                  int64     `json:"id"`
+	VendorID            int64     `json:"vendor_id"`
+	VendorBankAccountID int64     `json:"vendor_bank_account_id"`
+	PaymentAmount       int64     `json:"payment_amount"`
+	DayOfMonth          int       `json:"day_of_month,omitempty"`
+	EndOfMonth          bool      `json:"end_of_month"`
+	StartDate           string    `json:"start_date"`
+	EndDate             *string   `json:"end_date"`
+	NextDueDate         string    `json:"next_due_date"`
+	CreatedAt           time.Time `json:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at"`
+}
+
+// ToResponse converts an entity.RecurringInvoiceSchedule to Response.
+func ToResponse(s *entity.RecurringInvoiceSchedule) *Response {
+	resp := &Response{
+		ID:                  s.ID,
+		VendorID:            s.VendorID,
+		VendorBankAccountID: s.VendorBankAccountID,
+		PaymentAmount:       s.PaymentAmount,
+		DayOfMonth:          s.DayOfMonth,
+		EndOfMonth:          s.EndOfMonth,
+		StartDate:           s.StartDate.Format("2006-01-02"),
+		NextDueDate:         s.NextDueDate.Format("2006-01-02"),
+		CreatedAt:           s.CreatedAt,
+		UpdatedAt:           s.UpdatedAt,
+	}
+
+	if s.EndDate != nil {
+		endDate := s.EndDate.Format("2006-01-02")
+		resp.EndDate = &endDate
+	}
+
+	return resp
+}
+
+// ToResponses converts a slice of entity.RecurringInvoiceSchedule to a slice of Response.
+func ToResponses(schedules []*entity.RecurringInvoiceSchedule) []*Response {
+	responses := make([]*Response, len(schedules))
+	for i, s := range schedules {
+		responses[i] = ToResponse(s)
+	}
+
+	return responses
+}
+
+// ErrorResponse is the standard error response body.
+type ErrorResponse struct {
+	Error   string            `json:"error"`
+	Details map[string]string `json:"details,omitempty"`
+}
+
+// NewErrorResponse creates a new ErrorResponse.
+func NewErrorResponse(message string) *ErrorResponse {
+	return &ErrorResponse{
+		Error: message,
+	}
+}
+
+// NewValidationErrorResponse creates a new ErrorResponse for validation errors.
+func NewValidationErrorResponse(details map[string]string) *ErrorResponse {
+	return &ErrorResponse{
+		Error:   "validation error",
+		Details: details,
+	}
+}
@@ -4,21 +4,44 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 	_ "github.com/harusys/super-shiharai-kun/docs/swagger"
+	approvalctrl "github.com/harusys/super-shiharai-kun/internal/controller/approval"
 	authctrl "github.com/harusys/super-shiharai-kun/internal/controller/auth"
+	companyctrl "github.com/harusys/super-shiharai-kun/internal/controller/company"
+	invitationctrl "github.com/harusys/super-shiharai-kun/internal/controller/invitation"
 	invoicectrl "github.com/harusys/super-shiharai-kun/internal/controller/invoice"
+	mfactrl "github.com/harusys/super-shiharai-kun/internal/controller/mfa"
 	"github.com/harusys/super-shiharai-kun/internal/controller/middleware"
-	"github.com/harusys/super-shiharai-kun/internal/infrastructure/security"
+	postalcodectrl "github.com/harusys/super-shiharai-kun/internal/controller/postalcode"
+	recurringctrl "github.com/harusys/super-shiharai-kun/internal/controller/recurring"
+	vendorimportctrl "github.com/harusys/super-shiharai-kun/internal/controller/vendorimport"
+	vendorctrl "github.com/harusys/super-shiharai-kun/internal/controller/vendors"
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/approval"
 	"github.com/harusys/super-shiharai-kun/internal/usecase/auth"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/company"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/invitation"
 	"github.com/harusys/super-shiharai-kun/internal/usecase/invoice"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/mfa"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/postalcode"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/recurring"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/vendorimport"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/vendors"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
 // RouterConfig holds dependencies for setting up routes.
 type RouterConfig struct {
-	AuthUsecase    auth.Usecase
-	InvoiceUsecase invoice.Usecase
-	JWTService     *security.JWTService
+	AuthUsecase       auth.Usecase
+	InvoiceUsecase    invoice.Usecase
+	RecurringUsecase  recurring.Usecase
+	ApprovalUsecase   approval.Usecase
+	VendorUsecase     vendors.Usecase
+	ImportUsecase     vendorimport.Usecase
+	PostalCodeUsecase postalcode.Usecase
+	CompanyUsecase    company.Usecase
+	InvitationUsecase invitation.Usecase
+	MFAUsecase        mfa.Usecase
 }
 
 // SetupRoutes configures all API routes.
@@ -27,6 +50,17 @@ func SetupRoutes(r *gin.Engine, config *RouterConfig) {
 
 	authHandler := authctrl.NewHandler(config.AuthUsecase, validate)
 	invoiceHandler := invoicectrl.NewHandler(config.InvoiceUsecase, validate)
+	recurringHandler := recurringctrl.NewHandler(config.RecurringUsecase, validate)
+	approvalHandler := approvalctrl.NewHandler(config.ApprovalUsecase, validate)
+	vendorHandler := vendorctrl.NewHandler(config.VendorUsecase, validate)
+	importHandler := vendorimportctrl.NewHandler(config.ImportUsecase)
+	postalCodeHandler := postalcodectrl.NewHandler(config.PostalCodeUsecase)
+	companyHandler := companyctrl.NewHandler(config.CompanyUsecase, validate)
+	invitationHandler := invitationctrl.NewHandler(config.InvitationUsecase, validate)
+	mfaHandler := mfactrl.NewHandler(config.MFAUsecase, validate)
+
+	// Public keys for verifying access tokens (public)
+	r.GET("/.well-known/jwks.json", authHandler.JWKS)
 
 	api := r.Group("/api")
 
@@ -35,16 +69,114 @@ func SetupRoutes(r *gin.Engine, config *RouterConfig) {
 	authGroup.POST("/register", authHandler.Register)
 	authGroup.POST("/login", authHandler.Login)
 	authGroup.POST("/refresh", authHandler.RefreshToken)
+	authGroup.POST("/accept-invitation", authHandler.AcceptInvitation)
+	authGroup.POST("/mfa/verify", authHandler.VerifyMFA)
+	authGroup.POST("/mfa/enroll", authHandler.EnrollMFA)
 
 	// Protected routes
 	protected := api.Group("")
-	protected.Use(middleware.AuthMiddleware(config.JWTService))
+	protected.Use(middleware.AuthMiddleware(config.AuthUsecase))
+
+	// Every role can read. Writes are limited by role: accountants keep the
+	// books, approvers decide on invoices and only admins manage the company,
+	// its users and vendor bank accounts.
+	adminOnly := middleware.RequireRole(entity.UserRoleAdmin)
+	bookkeepers := middleware.RequireRole(entity.UserRoleAdmin, entity.UserRoleAccountant)
+	approvers := middleware.RequireRole(entity.UserRoleAdmin, entity.UserRoleApprover)
+
+	// Session and membership routes
+	protected.POST("/auth/switch-company", authHandler.SwitchCompany)
+	protected.POST("/auth/logout", authHandler.Logout)
+	protected.POST("/auth/logout-all", authHandler.LogoutAll)
+	protected.POST("/auth/change-password", authHandler.ChangePassword)
+	protected.GET("/me/companies", authHandler.ListCompanies)
+	protected.POST("/me/mfa/enroll", mfaHandler.Enroll)
+	protected.POST("/me/mfa/activate", mfaHandler.Activate)
+
+	// Company routes
+	companyGroup := protected.Group("/company", adminOnly)
+	companyGroup.GET("", companyHandler.Get)
+	companyGroup.PATCH("", companyHandler.Update)
+	companyGroup.GET("/audit-logs", companyHandler.ListAuditLogs)
+	companyGroup.DELETE("/users/:id/mfa", mfaHandler.Reset)
+
+	// Invitation routes
+	invitationGroup := protected.Group("/invitations", adminOnly)
+	invitationGroup.GET("", invitationHandler.List)
+	invitationGroup.POST("", invitationHandler.Create)
+	invitationGroup.DELETE("/:id", invitationHandler.Revoke)
+
+	// Vendor routes
+	vendorGroup := protected.Group("/vendors")
+	vendorGroup.GET("", vendorHandler.List)
+	vendorGroup.POST("", bookkeepers, vendorHandler.Create)
+	vendorGroup.POST("/import", adminOnly, importHandler.Import)
+	vendorGroup.GET("/:id", vendorHandler.GetByID)
+	vendorGroup.GET("/:id/stats", vendorHandler.GetStats)
+	vendorGroup.PUT("/:id", bookkeepers, vendorHandler.Update)
+	vendorGroup.POST("/:id/archive", bookkeepers, vendorHandler.Archive)
+	vendorGroup.POST("/:id/unarchive", bookkeepers, vendorHandler.Unarchive)
+	vendorGroup.GET("/:id/bank-accounts", vendorHandler.ListBankAccounts)
+	vendorGroup.POST("/:id/bank-accounts", adminOnly, vendorHandler.CreateBankAccount)
+	vendorGroup.GET("/:id/bank-accounts/:bank_account_id", vendorHandler.GetBankAccount)
+	vendorGroup.PUT(
+		"/:id/bank-accounts/:bank_account_id",
+		adminOnly,
+		vendorHandler.UpdateBankAccount,
+	)
+	vendorGroup.DELETE(
+		"/:id/bank-accounts/:bank_account_id",
+		adminOnly,
+		vendorHandler.DeleteBankAccount,
+	)
+	vendorGroup.POST(
+		"/:id/bank-accounts/:bank_account_id/verify",
+		bookkeepers,
+		vendorHandler.VerifyBankAccount,
+	)
+	vendorGroup.POST(
+		"/:id/bank-accounts/:bank_account_id/default",
+		adminOnly,
+		vendorHandler.SetDefaultBankAccount,
+	)
+	vendorGroup.POST(
+		"/:id/bank-accounts/:bank_account_id/archive",
+		adminOnly,
+		vendorHandler.ArchiveBankAccount,
+	)
+	vendorGroup.POST(
+		"/:id/bank-accounts/:bank_account_id/unarchive",
+		adminOnly,
+		vendorHandler.UnarchiveBankAccount,
+	)
+
+	// Postal code routes
+	protected.GET("/postal-codes/:code", postalCodeHandler.Lookup)
 
 	// Invoice routes
 	invoiceGroup := protected.Group("/invoices")
-	invoiceGroup.POST("", invoiceHandler.Create)
+	invoiceGroup.POST("", bookkeepers, invoiceHandler.Create)
 	invoiceGroup.GET("", invoiceHandler.List)
 	invoiceGroup.GET("/:id", invoiceHandler.GetByID)
+	invoiceGroup.POST("/:id/approve", approvers, invoiceHandler.Approve)
+	invoiceGroup.POST("/:id/reject", approvers, invoiceHandler.Reject)
+
+	// Approval policy routes
+	approvalPolicyGroup := protected.Group("/approval-policy")
+	approvalPolicyGroup.GET("", approvalHandler.GetPolicy)
+	approvalPolicyGroup.PUT("", adminOnly, approvalHandler.UpdatePolicy)
+
+	// Recurring invoice schedule routes
+	recurringGroup := protected.Group("/recurring-invoices")
+	recurringGroup.POST("", bookkeepers, recurringHandler.Create)
+	recurringGroup.GET("", recurringHandler.List)
+	recurringGroup.GET("/:id", recurringHandler.GetByID)
+	recurringGroup.PUT("/:id", bookkeepers, recurringHandler.Update)
+	recurringGroup.DELETE("/:id", bookkeepers, recurringHandler.Delete)
+
+	// Report routes
+	reportGroup := protected.Group("/reports")
+	reportGroup.GET("/withholding-tax", invoiceHandler.WithholdingReport)
 
 	// Swagger UI
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
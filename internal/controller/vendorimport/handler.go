@@ -0,0 +1,120 @@
+package vendorimport
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/harusys/super-shiharai-kun/internal/controller/middleware"
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/vendorimport"
+)
+
+// MaxFileSize is the maximum size of an import file in bytes.
+const MaxFileSize = 10 << 20
+
+// Handler handles vendor import endpoints.
+type Handler struct {
+	usecase vendorimport.Usecase
+}
+
+// NewHandler creates a new Handler.
+func NewHandler(usecase vendorimport.Usecase) *Handler {
+	return &Handler{
+		usecase: usecase,
+	}
+}
+
+// Import handles importing vendors and their bank accounts from CSV.
+//
+//	@Summary		取引先CSV一括取込
+//	@Description	取引先と振込先口座をCSVファイル（UTF-8 または Shift_JIS）から一括で登録・更新します。external_code が一致する取引先は更新し、なければ新規作成します。全行を1つのトランザクションで保存し、1行でもエラーがあれば何も保存せず 422 で行ごとのエラーを返します。dry_run=true を指定すると保存せずに結果をプレビューします。
+//	@Description	管理者権限が必要です。
+//	@Tags			vendors
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			file	formData	file	true	"取込ファイル（CSV、最大10MB）"
+//	@Param			dry_run	query		bool	false	"保存せずに結果をプレビューする"
+//	@Success		200		{object}	Response
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		401		{object}	ErrorResponse
+//	@Failure		403		{object}	ErrorResponse
+//	@Failure		413		{object}	ErrorResponse
+//	@Failure		422		{object}	Response
+//	@Failure		500		{object}	ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/vendors/import [post]
+func (h *Handler) Import(c *gin.Context) {
+	var req ImportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("invalid query parameters"))
+
+		return
+	}
+
+	data, ok := readFile(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.usecase.Import(c.Request.Context(), &vendorimport.Input{
+		CompanyID: middleware.GetCompanyID(c),
+		UserID:    middleware.GetUserID(c),
+		CSV:       data,
+		DryRun:    req.DryRun,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInput) {
+			c.JSON(http.StatusBadRequest, NewErrorResponse(err.Error()))
+
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, NewErrorResponse("internal server error"))
+
+		return
+	}
+
+	if len(result.Errors) > 0 {
+		c.JSON(http.StatusUnprocessableEntity, ToResponse(result))
+
+		return
+	}
+
+	c.JSON(http.StatusOK, ToResponse(result))
+}
+
+// readFile reads the uploaded file. On failure it writes the error response and
+// returns false.
+func readFile(c *gin.Context) ([]byte, bool) {
+	header, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("file is required"))
+
+		return nil, false
+	}
+
+	if header.Size > MaxFileSize {
+		c.JSON(http.StatusRequestEntityTooLarge, NewErrorResponse("file is too large"))
+
+		return nil, false
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, NewErrorResponse("internal server error"))
+
+		return nil, false
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, NewErrorResponse("internal server error"))
+
+		return nil, false
+	}
+
+	return data, true
+}
@@ -0,0 +1,176 @@
+package vendorimport_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/harusys/super-shiharai-kun/internal/controller/middleware"
+	"github.com/harusys/super-shiharai-kun/internal/controller/vendorimport"
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	usecase "github.com/harusys/super-shiharai-kun/internal/usecase/vendorimport"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/vendorimport/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func setupRouter(handler *vendorimport.Handler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+
+	// Mock auth middleware to inject user_id and company_id
+	r.Use(func(c *gin.Context) {
+		c.Set(middleware.UserIDKey, int64(20))
+		c.Set(middleware.CompanyIDKey, int64(1))
+		c.Next()
+	})
+
+	r.POST("/vendors/import", handler.Import)
+
+	return r
+}
+
+func newImportRequest(t *testing.T, query string, file []byte) *http.Request {
+	t.Helper()
+
+	var body bytes.Buffer
+
+	writer := multipart.NewWriter(&body)
+
+	if file != nil {
+		part, err := writer.CreateFormFile("file", "vendors.csv")
+		require.NoError(t, err)
+
+		_, err = part.Write(file)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/vendors/import"+query, &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	return req
+}
+
+func TestHandler_Import(t *testing.T) {
+	t.Parallel()
+
+	csv := []byte("external_code,name\n")
+
+	tests := []struct {
+		name       string
+		query      string
+		file       []byte
+		prepare    func(m *mock.MockUsecase)
+		wantStatus int
+	}{
+		{
+			name:  "success",
+			query: "?dry_run=true",
+			file:  csv,
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					Import(gomock.Any(), &usecase.Input{
+						CompanyID: 1,
+						UserID:    20,
+						CSV:       csv,
+						DryRun:    true,
+					}).
+					Return(&usecase.Result{
+						DryRun: true,
+						Rows: []*usecase.RowResult{{
+							Row:          2,
+							ExternalCode: "V001",
+							Vendor:       usecase.ActionCreated,
+						}},
+					}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "row errors",
+			file: csv,
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					Import(gomock.Any(), gomock.Any()).
+					Return(&usecase.Result{
+						Errors: []*usecase.RowError{{
+							Row:     2,
+							Column:  "phone_number",
+							Message: "must be in E.164 format, e.g. +81312345678",
+						}},
+					}, nil)
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "unreadable file",
+			file: csv,
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					Import(gomock.Any(), gomock.Any()).
+					Return(nil, domain.ErrInvalidInput)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "no file",
+			prepare:    func(_ *mock.MockUsecase) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mock.NewMockUsecase(ctrl)
+			tt.prepare(mockUsecase)
+
+			router := setupRouter(vendorimport.NewHandler(mockUsecase))
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, newImportRequest(t, tt.query, tt.file))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestHandler_Import_Response(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mock.NewMockUsecase(ctrl)
+	mockUsecase.EXPECT().
+		Import(gomock.Any(), gomock.Any()).
+		Return(&usecase.Result{
+			Errors: []*usecase.RowError{{Row: 3, Column: "bank_code", Message: "must be 4 digits"}},
+		}, nil)
+
+	router := setupRouter(vendorimport.NewHandler(mockUsecase))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newImportRequest(t, "", []byte("external_code\n")))
+
+	var resp vendorimport.Response
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+
+	assert.False(t, resp.DryRun)
+	assert.Empty(t, resp.Rows)
+	assert.Equal(t, []*vendorimport.RowErrorResponse{
+		{Row: 3, Column: "bank_code", Message: "must be 4 digits"},
+	}, resp.Errors)
+}
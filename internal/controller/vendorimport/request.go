@@ -0,0 +1,7 @@
+package vendorimport
+
+// ImportRequest is the query parameters for importing vendors.
+type ImportRequest struct {
+	// DryRun validates the file and previews the changes without saving them.
+	DryRun bool `form:"dry_run"`
+}
@@ -0,0 +1,65 @@
+package vendorimport
+
+import "github.com/harusys/super-shiharai-kun/internal/usecase/vendorimport"
+
+// Response is the response body for a vendor import.
+type Response struct {
+	DryRun bool                `json:"dry_run"`
+	Rows   []*RowResponse      `json:"rows"`
+	Errors []*RowErrorResponse `json:"errors,omitempty"`
+}
+
+// RowResponse reports what the import did, or would do, with a row.
+type RowResponse struct {
+	Row          int    `json:"row"`
+	ExternalCode string `json:"external_code"`
+	VendorID     int64  `json:"vendor_id,omitempty"` // ドライランで新規作成される取引先は省略
+	Vendor       string `json:"vendor"`              // created, updated, unchanged
+	// BankAccount is omitted if the row has no bank account.
+	BankAccount string `json:"bank_account,omitempty"` // created, updated, unchanged
+}
+
+// RowErrorResponse is a validation error in a row.
+type RowErrorResponse struct {
+	Row     int    `json:"row"`
+	Column  string `json:"column"`
+	Message string `json:"message"`
+}
+
+// ToResponse converts a vendorimport.Result to Response.
+func ToResponse(result *vendorimport.Result) *Response {
+	rows := make([]*RowResponse, len(result.Rows))
+	for i, r := range result.Rows {
+		rows[i] = &RowResponse{
+			Row:          r.Row,
+			ExternalCode: r.ExternalCode,
+			VendorID:     r.VendorID,
+			Vendor:       string(r.Vendor),
+			BankAccount:  string(r.BankAccount),
+		}
+	}
+
+	errs := make([]*RowErrorResponse, len(result.Errors))
+	for i, e := range result.Errors {
+		errs[i] = &RowErrorResponse{Row: e.Row, Column: e.Column, Message: e.Message}
+	}
+
+	return &Response{
+		DryRun: result.DryRun,
+		Rows:   rows,
+		Errors: errs,
+	}
+}
+
+// ErrorResponse is the standard error response body.
+type ErrorResponse struct {
+	Error   string            `json:"error"`
+	Details map[string]string `json:"details,omitempty"`
+}
+
+// NewErrorResponse creates a new ErrorResponse.
+func NewErrorResponse(message string) *ErrorResponse {
+	return &ErrorResponse{
+		Error: message,
+	}
+}
@@ -0,0 +1,813 @@
+package vendors
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/harusys/super-shiharai-kun/internal/controller/middleware"
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/vendors"
+)
+
+// Handler handles vendor endpoints.
+type Handler struct {
+	usecase   vendors.Usecase
+	validator *validator.Validate
+}
+
+// NewHandler creates a new Handler.
+func NewHandler(usecase vendors.Usecase, validator *validator.Validate) *Handler {
+	return &Handler{
+		usecase:   usecase,
+		validator: validator,
+	}
+}
+
+// List handles listing vendors.
+//
+//	@Summary		取引先一覧取得
+//	@Description	企業の取引先の一覧をページ単位で取得します。name で取引先名の部分一致検索ができます。アーカイブ済みの取引先は include_archived=true を指定した場合のみ返します。
+//	@Tags			vendors
+//	@Accept			json
+//	@Produce		json
+//	@Param			name				query		string	false	"取引先名（部分一致）"
+//	@Param			page				query		int		false	"ページ番号（1始まり、最大10000）"	default(1)
+//	@Param			per_page			query		int		false	"1ページあたりの件数（最大100）"		default(20)
+//	@Param			include_archived	query		bool	false	"アーカイブ済みの取引先も返す"
+//	@Success		200					{object}	ListResponse
+//	@Failure		400					{object}	ErrorResponse
+//	@Failure		401					{object}	ErrorResponse
+//	@Failure		500					{object}	ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/vendors [get]
+func (h *Handler) List(c *gin.Context) {
+	var req ListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("invalid query parameters"))
+
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, NewValidationErrorResponse(formatValidationErrors(err)))
+
+		return
+	}
+
+	result, err := h.usecase.List(c.Request.Context(), &vendors.ListInput{
+		CompanyID:       middleware.GetCompanyID(c),
+		Name:            req.Name,
+		Page:            req.Page,
+		PerPage:         req.PerPage,
+		IncludeArchived: req.IncludeArchived,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, NewErrorResponse("internal server error"))
+
+		return
+	}
+
+	c.JSON(http.StatusOK, ToListResponse(result))
+}
+
+// GetByID handles getting a vendor by ID.
+//
+//	@Summary		取引先詳細取得
+//	@Description	指定IDの取引先を取得します
+//	@Tags			vendors
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"取引先ID"
+//	@Success		200	{object}	Response
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		401	{object}	ErrorResponse
+//	@Failure		404	{object}	ErrorResponse
+//	@Failure		500	{object}	ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/vendors/{id} [get]
+func (h *Handler) GetByID(c *gin.Context) {
+	companyID := middleware.GetCompanyID(c)
+
+	vendorID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("invalid vendor id"))
+
+		return
+	}
+
+	v, err := h.usecase.GetByID(c.Request.Context(), companyID, vendorID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, NewErrorResponse("vendor not found"))
+
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, NewErrorResponse("internal server error"))
+
+		return
+	}
+
+	c.JSON(http.StatusOK, ToResponse(v))
+}
+
+// GetStats handles getting the payment statistics of a vendor.
+//
+//	@Summary		取引先支払統計取得
+//	@Description	指定IDの取引先への支払実績（累計・月別の支払金額、支払件数、初回・最終支払日、平均支払金額）とステータス別の請求書件数を返します。
+//	@Description	支払実績は支払済み（paid）の請求書を支払期日で集計します。
+//	@Tags			vendors
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"取引先ID"
+//	@Success		200	{object}	StatsResponse
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		401	{object}	ErrorResponse
+//	@Failure		404	{object}	ErrorResponse
+//	@Failure		500	{object}	ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/vendors/{id}/stats [get]
+func (h *Handler) GetStats(c *gin.Context) {
+	companyID := middleware.GetCompanyID(c)
+
+	vendorID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("invalid vendor id"))
+
+		return
+	}
+
+	stats, err := h.usecase.GetStats(c.Request.Context(), companyID, vendorID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, NewErrorResponse("vendor not found"))
+
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, NewErrorResponse("internal server error"))
+
+		return
+	}
+
+	c.JSON(http.StatusOK, ToStatsResponse(stats))
+}
+
+// Create handles vendor creation.
+//
+//	@Summary		取引先作成
+//	@Description	新しい取引先を登録します
+//	@Description	管理者または経理担当者の権限が必要です。
+//	@Tags			vendors
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		VendorRequest	true	"取引先作成リクエスト"
+//	@Success		201		{object}	Response
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		401		{object}	ErrorResponse
+//	@Failure		403		{object}	ErrorResponse
+//	@Failure		409		{object}	ErrorResponse
+//	@Failure		500		{object}	ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/vendors [post]
+func (h *Handler) Create(c *gin.Context) {
+	input, ok := h.bindVendorInput(c)
+	if !ok {
+		return
+	}
+
+	v, err := h.usecase.Create(c.Request.Context(), input)
+	if err != nil {
+		h.handleWriteError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, ToResponse(v))
+}
+
+// Update handles replacing a vendor.
+//
+//	@Summary		取引先更新
+//	@Description	指定IDの取引先を更新します
+//	@Description	管理者または経理担当者の権限が必要です。
+//	@Tags			vendors
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int				true	"取引先ID"
+//	@Param			request	body		VendorRequest	true	"取引先更新リクエスト"
+//	@Success		200		{object}	Response
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		401		{object}	ErrorResponse
+//	@Failure		403		{object}	ErrorResponse
+//	@Failure		404		{object}	ErrorResponse
+//	@Failure		409		{object}	ErrorResponse
+//	@Failure		500		{object}	ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/vendors/{id} [put]
+func (h *Handler) Update(c *gin.Context) {
+	vendorID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("invalid vendor id"))
+
+		return
+	}
+
+	input, ok := h.bindVendorInput(c)
+	if !ok {
+		return
+	}
+
+	v, err := h.usecase.Update(c.Request.Context(), vendorID, input)
+	if err != nil {
+		h.handleWriteError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, ToResponse(v))
+}
+
+// Archive handles archiving a vendor.
+//
+//	@Summary		取引先アーカイブ
+//	@Description	取引先をアーカイブします。アーカイブ済みの取引先には請求書を作成できず、一覧にも表示されません。作成済みの請求書は引き続き参照できます。
+//	@Description	管理者または経理担当者の権限が必要です。
+//	@Tags			vendors
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"取引先ID"
+//	@Success		200	{object}	Response
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		401	{object}	ErrorResponse
+//	@Failure		403	{object}	ErrorResponse
+//	@Failure		404	{object}	ErrorResponse
+//	@Failure		500	{object}	ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/vendors/{id}/archive [post]
+func (h *Handler) Archive(c *gin.Context) {
+	h.setArchived(c, true)
+}
+
+// Unarchive handles restoring an archived vendor.
+//
+//	@Summary		取引先アーカイブ解除
+//	@Description	アーカイブ済みの取引先を有効に戻します
+//	@Description	管理者または経理担当者の権限が必要です。
+//	@Tags			vendors
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"取引先ID"
+//	@Success		200	{object}	Response
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		401	{object}	ErrorResponse
+//	@Failure		403	{object}	ErrorResponse
+//	@Failure		404	{object}	ErrorResponse
+//	@Failure		500	{object}	ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/vendors/{id}/unarchive [post]
+func (h *Handler) Unarchive(c *gin.Context) {
+	h.setArchived(c, false)
+}
+
+func (h *Handler) setArchived(c *gin.Context, archived bool) {
+	vendorID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("invalid vendor id"))
+
+		return
+	}
+
+	v, err := h.usecase.SetArchived(
+		c.Request.Context(),
+		middleware.GetCompanyID(c),
+		vendorID,
+		archived,
+	)
+	if err != nil {
+		h.handleWriteError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, ToResponse(v))
+}
+
+// ListBankAccounts handles listing the bank accounts of a vendor.
+//
+//	@Summary		取引先口座一覧取得
+//	@Description	取引先の振込先口座の一覧を取得します。口座番号は下3桁以外をマスクして返します。reveal=true を指定するとマスクせずに返します（管理者・経理担当者のみ）。アーカイブ済みの口座は include_archived=true を指定した場合のみ返します。
+//	@Tags			vendors
+//	@Accept			json
+//	@Produce		json
+//	@Param			id					path		int		true	"取引先ID"
+//	@Param			reveal				query		bool	false	"口座番号をマスクせずに返す"
+//	@Param			include_archived	query		bool	false	"アーカイブ済みの口座も返す"
+//	@Success		200					{array}		BankAccountResponse
+//	@Failure		400					{object}	ErrorResponse
+//	@Failure		401					{object}	ErrorResponse
+//	@Failure		403					{object}	ErrorResponse
+//	@Failure		404					{object}	ErrorResponse
+//	@Failure		500					{object}	ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/vendors/{id}/bank-accounts [get]
+func (h *Handler) ListBankAccounts(c *gin.Context) {
+	query, ok := bindBankAccountQuery(c)
+	if !ok {
+		return
+	}
+
+	accounts, err := h.usecase.ListBankAccounts(c.Request.Context(), query)
+	if err != nil {
+		handleBankAccountError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, ToBankAccountResponses(accounts))
+}
+
+// GetBankAccount handles getting a bank account of a vendor.
+//
+//	@Summary		取引先口座詳細取得
+//	@Description	取引先の振込先口座を取得します。口座番号は下3桁以外をマスクして返します。reveal=true を指定するとマスクせずに返します（管理者・経理担当者のみ）。
+//	@Tags			vendors
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		int		true	"取引先ID"
+//	@Param			bank_account_id	path		int		true	"口座ID"
+//	@Param			reveal			query		bool	false	"口座番号をマスクせずに返す"
+//	@Success		200				{object}	BankAccountResponse
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		401				{object}	ErrorResponse
+//	@Failure		403				{object}	ErrorResponse
+//	@Failure		404				{object}	ErrorResponse
+//	@Failure		500				{object}	ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/vendors/{id}/bank-accounts/{bank_account_id} [get]
+func (h *Handler) GetBankAccount(c *gin.Context) {
+	bankAccountID, err := strconv.ParseInt(c.Param("bank_account_id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("invalid bank account id"))
+
+		return
+	}
+
+	query, ok := bindBankAccountQuery(c)
+	if !ok {
+		return
+	}
+
+	account, err := h.usecase.GetBankAccount(c.Request.Context(), query, bankAccountID)
+	if err != nil {
+		handleBankAccountError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, ToBankAccountResponse(account))
+}
+
+// CreateBankAccount handles adding a bank account to a vendor.
+//
+//	@Summary		取引先口座作成
+//	@Description	取引先に振込先口座を登録します。登録した口座は確認待ち（pending_verification）となり、企業の管理者に通知されます。取引先の最初の口座は既定口座になります。レスポンスの口座番号はマスクされます。
+//	@Description	管理者権限が必要です。
+//	@Tags			vendors
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int					true	"取引先ID"
+//	@Param			request	body		BankAccountRequest	true	"取引先口座作成リクエスト"
+//	@Success		201		{object}	BankAccountResponse
+//	@Failure		400		{object}	ErrorResponse
+//	@Failure		401		{object}	ErrorResponse
+//	@Failure		403		{object}	ErrorResponse
+//	@Failure		404		{object}	ErrorResponse
+//	@Failure		500		{object}	ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/vendors/{id}/bank-accounts [post]
+func (h *Handler) CreateBankAccount(c *gin.Context) {
+	input, ok := h.bindBankAccountInput(c)
+	if !ok {
+		return
+	}
+
+	account, err := h.usecase.CreateBankAccount(c.Request.Context(), input)
+	if err != nil {
+		handleBankAccountError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, ToBankAccountResponse(account))
+}
+
+// UpdateBankAccount handles replacing a bank account of a vendor.
+//
+//	@Summary		取引先口座更新
+//	@Description	取引先の振込先口座を更新します。更新した口座は再び確認待ち（pending_verification）となり、企業の管理者に通知されます。レスポンスの口座番号はマスクされます。
+//	@Description	未払いの請求書の振込先になっている口座は更新できません（409）。
+//	@Description	管理者権限が必要です。
+//	@Tags			vendors
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		int					true	"取引先ID"
+//	@Param			bank_account_id	path		int					true	"口座ID"
+//	@Param			request			body		BankAccountRequest	true	"取引先口座更新リクエスト"
+//	@Success		200				{object}	BankAccountResponse
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		401				{object}	ErrorResponse
+//	@Failure		403				{object}	ErrorResponse
+//	@Failure		404				{object}	ErrorResponse
+//	@Failure		409				{object}	ErrorResponse
+//	@Failure		500				{object}	ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/vendors/{id}/bank-accounts/{bank_account_id} [put]
+func (h *Handler) UpdateBankAccount(c *gin.Context) {
+	bankAccountID, err := strconv.ParseInt(c.Param("bank_account_id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("invalid bank account id"))
+
+		return
+	}
+
+	input, ok := h.bindBankAccountInput(c)
+	if !ok {
+		return
+	}
+
+	account, err := h.usecase.UpdateBankAccount(c.Request.Context(), bankAccountID, input)
+	if err != nil {
+		handleBankAccountError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, ToBankAccountResponse(account))
+}
+
+// VerifyBankAccount handles confirming a new or changed bank account.
+//
+//	@Summary		取引先口座確認
+//	@Description	確認待ちの振込先口座を確認済みにします。口座を登録・変更したユーザー以外の管理者・経理担当者が行います。確認済みでも待機期間が終わるまでは支払できません。
+//	@Tags			vendors
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		int	true	"取引先ID"
+//	@Param			bank_account_id	path		int	true	"口座ID"
+//	@Success		200				{object}	BankAccountResponse
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		401				{object}	ErrorResponse
+//	@Failure		403				{object}	ErrorResponse
+//	@Failure		404				{object}	ErrorResponse
+//	@Failure		409				{object}	ErrorResponse
+//	@Failure		500				{object}	ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/vendors/{id}/bank-accounts/{bank_account_id}/verify [post]
+func (h *Handler) VerifyBankAccount(c *gin.Context) {
+	vendorID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("invalid vendor id"))
+
+		return
+	}
+
+	bankAccountID, err := strconv.ParseInt(c.Param("bank_account_id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("invalid bank account id"))
+
+		return
+	}
+
+	account, err := h.usecase.VerifyBankAccount(
+		c.Request.Context(),
+		middleware.GetCompanyID(c),
+		vendorID,
+		bankAccountID,
+		middleware.GetUserID(c),
+	)
+	if err != nil {
+		handleBankAccountError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, ToBankAccountResponse(account))
+}
+
+// ArchiveBankAccount handles archiving a bank account of a vendor.
+//
+//	@Summary		取引先口座アーカイブ
+//	@Description	取引先の振込先口座をアーカイブします。アーカイブ済みの口座を振込先とする請求書は作成できません。既定口座だった場合は既定口座が解除されます。
+//	@Description	管理者権限が必要です。
+//	@Tags			vendors
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		int	true	"取引先ID"
+//	@Param			bank_account_id	path		int	true	"口座ID"
+//	@Success		200				{object}	BankAccountResponse
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		401				{object}	ErrorResponse
+//	@Failure		403				{object}	ErrorResponse
+//	@Failure		404				{object}	ErrorResponse
+//	@Failure		500				{object}	ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/vendors/{id}/bank-accounts/{bank_account_id}/archive [post]
+func (h *Handler) ArchiveBankAccount(c *gin.Context) {
+	h.setBankAccountArchived(c, true)
+}
+
+// UnarchiveBankAccount handles restoring an archived bank account of a vendor.
+//
+//	@Summary		取引先口座アーカイブ解除
+//	@Description	アーカイブ済みの振込先口座を有効に戻します。既定口座には戻りません。
+//	@Description	管理者権限が必要です。
+//	@Tags			vendors
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		int	true	"取引先ID"
+//	@Param			bank_account_id	path		int	true	"口座ID"
+//	@Success		200				{object}	BankAccountResponse
+//	@Failure		400				{object}	ErrorResponse
+//	@Failure		401				{object}	ErrorResponse
+//	@Failure		403				{object}	ErrorResponse
+//	@Failure		404				{object}	ErrorResponse
+//	@Failure		500				{object}	ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/vendors/{id}/bank-accounts/{bank_account_id}/unarchive [post]
+func (h *Handler) UnarchiveBankAccount(c *gin.Context) {
+	h.setBankAccountArchived(c, false)
+}
+
+func (h *Handler) setBankAccountArchived(c *gin.Context, archived bool) {
+	vendorID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("invalid vendor id"))
+
+		return
+	}
+
+	bankAccountID, err := strconv.ParseInt(c.Param("bank_account_id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("invalid bank account id"))
+
+		return
+	}
+
+	account, err := h.usecase.SetBankAccountArchived(
+		c.Request.Context(),
+		middleware.GetCompanyID(c),
+		vendorID,
+		bankAccountID,
+		archived,
+	)
+	if err != nil {
+		handleBankAccountError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, ToBankAccountResponse(account))
+}
+
+// SetDefaultBankAccount handles making a bank account the vendor's default.
+//
+//	@Summary		取引先既定口座設定
+//	@Description	指定した口座を取引先の既定口座にします。振込先口座を省略して作成した請求書は既定口座に振り込みます。アーカイブ済みの口座は既定口座にできません（409）。
+//	@Description	管理者権限が必要です。
+//	@Tags			vendors
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path	int	true	"取引先ID"
+//	@Param			bank_account_id	path	int	true	"口座ID"
+//	@Success		204
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		401	{object}	ErrorResponse
+//	@Failure		403	{object}	ErrorResponse
+//	@Failure		404	{object}	ErrorResponse
+//	@Failure		409	{object}	ErrorResponse
+//	@Failure		500	{object}	ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/vendors/{id}/bank-accounts/{bank_account_id}/default [post]
+func (h *Handler) SetDefaultBankAccount(c *gin.Context) {
+	vendorID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("invalid vendor id"))
+
+		return
+	}
+
+	bankAccountID, err := strconv.ParseInt(c.Param("bank_account_id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("invalid bank account id"))
+
+		return
+	}
+
+	err = h.usecase.SetDefaultBankAccount(
+		c.Request.Context(),
+		middleware.GetCompanyID(c),
+		vendorID,
+		bankAccountID,
+	)
+	if err != nil {
+		handleBankAccountError(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// DeleteBankAccount handles deleting a bank account of a vendor.
+//
+//	@Summary		取引先口座削除
+//	@Description	取引先の振込先口座を削除します。請求書や定期請求スケジュールの振込先になっている口座は削除できません。
+//	@Description	管理者権限が必要です。
+//	@Tags			vendors
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path	int	true	"取引先ID"
+//	@Param			bank_account_id	path	int	true	"口座ID"
+//	@Success		204
+//	@Failure		400	{object}	ErrorResponse
+//	@Failure		401	{object}	ErrorResponse
+//	@Failure		403	{object}	ErrorResponse
+//	@Failure		404	{object}	ErrorResponse
+//	@Failure		409	{object}	ErrorResponse
+//	@Failure		500	{object}	ErrorResponse
+//	@Security		BearerAuth
+//	@Router			/vendors/{id}/bank-accounts/{bank_account_id} [delete]
+func (h *Handler) DeleteBankAccount(c *gin.Context) {
+	vendorID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("invalid vendor id"))
+
+		return
+	}
+
+	bankAccountID, err := strconv.ParseInt(c.Param("bank_account_id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("invalid bank account id"))
+
+		return
+	}
+
+	err = h.usecase.DeleteBankAccount(
+		c.Request.Context(),
+		middleware.GetCompanyID(c),
+		vendorID,
+		bankAccountID,
+	)
+	if err != nil {
+		handleBankAccountError(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// bindVendorInput binds and validates the request body. On failure it writes
+// the error response and returns false.
+func (h *Handler) bindVendorInput(c *gin.Context) (*vendors.VendorInput, bool) {
+	var req VendorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("invalid request body"))
+
+		return nil, false
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, NewValidationErrorResponse(formatValidationErrors(err)))
+
+		return nil, false
+	}
+
+	return &vendors.VendorInput{
+		CompanyID:                middleware.GetCompanyID(c),
+		ExternalCode:             req.ExternalCode,
+		Name:                     req.Name,
+		NameKana:                 req.NameKana,
+		RepresentativeName:       req.RepresentativeName,
+		PhoneNumber:              req.PhoneNumber,
+		ZipCode:                  req.ZipCode,
+		Address:                  req.Address,
+		RegistrationNumber:       req.RegistrationNumber,
+		WithholdingTaxApplicable: req.WithholdingTaxApplicable,
+	}, true
+}
+
+// bindBankAccountQuery parses the vendor ID and query parameters. On failure it
+// writes the error response and returns false.
+func bindBankAccountQuery(c *gin.Context) (*vendors.BankAccountQuery, bool) {
+	vendorID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("invalid vendor id"))
+
+		return nil, false
+	}
+
+	var req BankAccountQueryRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("invalid query parameters"))
+
+		return nil, false
+	}
+
+	return &vendors.BankAccountQuery{
+		CompanyID:       middleware.GetCompanyID(c),
+		VendorID:        vendorID,
+		UserID:          middleware.GetUserID(c),
+		Reveal:          req.Reveal,
+		IncludeArchived: req.IncludeArchived,
+	}, true
+}
+
+// bindBankAccountInput binds and validates the vendor ID and request body. On
+// failure it writes the error response and returns false.
+func (h *Handler) bindBankAccountInput(c *gin.Context) (*vendors.BankAccountInput, bool) {
+	vendorID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("invalid vendor id"))
+
+		return nil, false
+	}
+
+	var req BankAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, NewErrorResponse("invalid request body"))
+
+		return nil, false
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, NewValidationErrorResponse(formatValidationErrors(err)))
+
+		return nil, false
+	}
+
+	return &vendors.BankAccountInput{
+		CompanyID:         middleware.GetCompanyID(c),
+		VendorID:          vendorID,
+		UserID:            middleware.GetUserID(c),
+		BankCode:          req.BankCode,
+		BankName:          req.BankName,
+		BranchCode:        req.BranchCode,
+		BranchName:        req.BranchName,
+		AccountNumber:     req.AccountNumber,
+		AccountHolderName: req.AccountHolderName,
+	}, true
+}
+
+func handleBankAccountError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrForbidden):
+		c.JSON(http.StatusForbidden, NewErrorResponse(err.Error()))
+	case errors.Is(err, domain.ErrNotFound):
+		c.JSON(http.StatusNotFound, NewErrorResponse("vendor or bank account not found"))
+	case errors.Is(err, domain.ErrInUse):
+		c.JSON(
+			http.StatusConflict,
+			NewErrorResponse(
+				"bank account is referenced by invoices or recurring invoice schedules",
+			),
+		)
+	case errors.Is(err, domain.ErrInvalidState):
+		c.JSON(http.StatusConflict, NewErrorResponse(err.Error()))
+	default:
+		c.JSON(http.StatusInternalServerError, NewErrorResponse("internal server error"))
+	}
+}
+
+func (h *Handler) handleWriteError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, NewErrorResponse(err.Error()))
+	case errors.Is(err, domain.ErrNotFound):
+		c.JSON(http.StatusNotFound, NewErrorResponse("vendor not found"))
+	case errors.Is(err, domain.ErrAlreadyExists):
+		c.JSON(
+			http.StatusConflict,
+			NewErrorResponse("external code is already used by another vendor"),
+		)
+	default:
+		c.JSON(http.StatusInternalServerError, NewErrorResponse("internal server error"))
+	}
+}
+
+func formatValidationErrors(err error) map[string]string {
+	details := make(map[string]string)
+
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		for _, e := range validationErrs {
+			details[e.Field()] = e.Tag()
+		}
+	}
+
+	return details
+}
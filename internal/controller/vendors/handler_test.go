@@ -0,0 +1,477 @@
+package vendors_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/harusys/super-shiharai-kun/internal/controller/middleware"
+	"github.com/harusys/super-shiharai-kun/internal/controller/vendors"
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+	usecase "github.com/harusys/super-shiharai-kun/internal/usecase/vendors"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/vendors/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func setupRouter(handler *vendors.Handler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+
+	// Mock auth middleware to inject company_id and user_id
+	r.Use(func(c *gin.Context) {
+		c.Set(middleware.CompanyIDKey, int64(1))
+		c.Set(middleware.UserIDKey, int64(20))
+		c.Next()
+	})
+
+	r.GET("/vendors", handler.List)
+	r.GET("/vendors/:id", handler.GetByID)
+	r.GET("/vendors/:id/stats", handler.GetStats)
+	r.POST("/vendors", handler.Create)
+	r.PUT("/vendors/:id", handler.Update)
+	r.GET("/vendors/:id/bank-accounts", handler.ListBankAccounts)
+	r.POST("/vendors/:id/bank-accounts", handler.CreateBankAccount)
+	r.DELETE("/vendors/:id/bank-accounts/:bank_account_id", handler.DeleteBankAccount)
+
+	return r
+}
+
+func validBody() map[string]any {
+	return map[string]any{
+		"name":                "株式会社テスト",
+		"representative_name": "山田太郎",
+		"phone_number":        "+81312345678",
+		"zip_code":            "100-0001",
+		"address":             "東京都千代田区千代田1-1",
+		"registration_number": "T7000012050002",
+	}
+}
+
+func TestHandler_List(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		query      string
+		prepare    func(m *mock.MockUsecase)
+		wantStatus int
+		wantTotal  float64
+	}{
+		{
+			name:  "success - search by name",
+			query: "?name=%E3%83%86%E3%82%B9%E3%83%88&page=2&per_page=10",
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					List(gomock.Any(), &usecase.ListInput{
+						CompanyID: 1,
+						Name:      "テスト",
+						Page:      2,
+						PerPage:   10,
+					}).
+					Return(&usecase.ListResult{
+						Vendors: []*entity.Vendor{{ID: 11, CompanyID: 1}},
+						Total:   11,
+						Page:    2,
+						PerPage: 10,
+					}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantTotal:  11,
+		},
+		{
+			name:       "invalid per_page",
+			query:      "?per_page=1000",
+			prepare:    func(_ *mock.MockUsecase) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid page",
+			query:      "?page=10001",
+			prepare:    func(_ *mock.MockUsecase) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mock.NewMockUsecase(ctrl)
+			tt.prepare(mockUsecase)
+
+			handler := vendors.NewHandler(mockUsecase, validator.New())
+			r := setupRouter(handler)
+
+			req := httptest.NewRequest(http.MethodGet, "/vendors"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+
+			if tt.wantStatus == http.StatusOK {
+				var resp map[string]any
+
+				err := json.Unmarshal(w.Body.Bytes(), &resp)
+				require.NoError(t, err)
+				assert.InDelta(t, tt.wantTotal, resp["total"], 0)
+				assert.Len(t, resp["vendors"], 1)
+			}
+		})
+	}
+}
+
+func TestHandler_Create(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		body       func() map[string]any
+		prepare    func(m *mock.MockUsecase)
+		wantStatus int
+		wantError  string
+	}{
+		{
+			name: "success",
+			body: validBody,
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					Create(gomock.Any(), &usecase.VendorInput{
+						CompanyID:          1,
+						Name:               "株式会社テスト",
+						RepresentativeName: "山田太郎",
+						PhoneNumber:        "+81312345678",
+						ZipCode:            "100-0001",
+						Address:            "東京都千代田区千代田1-1",
+						RegistrationNumber: "T7000012050002",
+					}).
+					Return(&entity.Vendor{ID: 1, CompanyID: 1, Name: "株式会社テスト"}, nil)
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name: "invalid registration number",
+			body: validBody,
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil, domain.ErrInvalidInput)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "external code already used",
+			body: func() map[string]any {
+				body := validBody()
+				body["external_code"] = "V001"
+
+				return body
+			},
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil, domain.ErrAlreadyExists)
+			},
+			wantStatus: http.StatusConflict,
+			wantError:  "external code is already used by another vendor",
+		},
+		{
+			name: "invalid request - missing name",
+			body: func() map[string]any {
+				body := validBody()
+				delete(body, "name")
+
+				return body
+			},
+			prepare:    func(_ *mock.MockUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantError:  "validation error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mock.NewMockUsecase(ctrl)
+			tt.prepare(mockUsecase)
+
+			handler := vendors.NewHandler(mockUsecase, validator.New())
+			r := setupRouter(handler)
+
+			body, _ := json.Marshal(tt.body())
+			req := httptest.NewRequest(http.MethodPost, "/vendors", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+
+			if tt.wantError != "" {
+				var resp map[string]any
+
+				err := json.Unmarshal(w.Body.Bytes(), &resp)
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantError, resp["error"])
+			}
+		})
+	}
+}
+
+func TestHandler_GetByID_NotFound(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mock.NewMockUsecase(ctrl)
+	mockUsecase.EXPECT().
+		GetByID(gomock.Any(), int64(1), int64(999)).
+		Return(nil, domain.ErrNotFound)
+
+	handler := vendors.NewHandler(mockUsecase, validator.New())
+	r := setupRouter(handler)
+
+	req := httptest.NewRequest(http.MethodGet, "/vendors/999", nil)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestHandler_GetStats(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		first := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+		last := time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)
+
+		mockUsecase := mock.NewMockUsecase(ctrl)
+		mockUsecase.EXPECT().
+			GetStats(gomock.Any(), int64(1), int64(5)).
+			Return(&entity.VendorPaymentStats{
+				PaidInvoiceCount:     3,
+				PaymentAmount:        300000,
+				TransferAmount:       300000,
+				AveragePaymentAmount: 100000,
+				FirstPaymentDate:     &first,
+				LastPaymentDate:      &last,
+				Monthly: []*entity.MonthlyPayment{
+					{
+						Month:          time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+						InvoiceCount:   2,
+						PaymentAmount:  200000,
+						TransferAmount: 200000,
+					},
+					{
+						Month:          time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+						InvoiceCount:   1,
+						PaymentAmount:  100000,
+						TransferAmount: 100000,
+					},
+				},
+				InvoiceCounts: map[entity.InvoiceStatus]int64{
+					entity.InvoiceStatusPaid:    3,
+					entity.InvoiceStatusPending: 1,
+				},
+			}, nil)
+
+		r := setupRouter(vendors.NewHandler(mockUsecase, validator.New()))
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/vendors/5/stats", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var resp vendors.StatsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+
+		assert.Equal(t, int64(300000), resp.PaymentAmount)
+		assert.Equal(t, "2024-01-31", *resp.FirstPaymentDate)
+		assert.Equal(t, "2024-02-29", *resp.LastPaymentDate)
+		assert.Equal(t, []*vendors.MonthlyPaymentResponse{
+			{Month: "2024-01", InvoiceCount: 2, PaymentAmount: 200000, TransferAmount: 200000},
+			{Month: "2024-02", InvoiceCount: 1, PaymentAmount: 100000, TransferAmount: 100000},
+		}, resp.Monthly)
+		assert.Equal(t, map[string]int64{
+			"awaiting_approval": 0,
+			"pending":           1,
+			"processing":        0,
+			"paid":              3,
+			"error":             0,
+			"rejected":          0,
+		}, resp.InvoiceCounts)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUsecase := mock.NewMockUsecase(ctrl)
+		mockUsecase.EXPECT().
+			GetStats(gomock.Any(), int64(1), int64(999)).
+			Return(nil, domain.ErrNotFound)
+
+		r := setupRouter(vendors.NewHandler(mockUsecase, validator.New()))
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/vendors/999/stats", nil))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestHandler_ListBankAccounts(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		query      string
+		prepare    func(m *mock.MockUsecase)
+		wantStatus int
+	}{
+		{
+			name:  "success - masked",
+			query: "",
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					ListBankAccounts(gomock.Any(), &usecase.BankAccountQuery{
+						CompanyID: 1,
+						VendorID:  5,
+						UserID:    20,
+					}).
+					Return([]*entity.VendorBankAccount{{ID: 10, AccountNumber: "****567"}}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:  "reveal forbidden",
+			query: "?reveal=true",
+			prepare: func(m *mock.MockUsecase) {
+				m.EXPECT().
+					ListBankAccounts(gomock.Any(), &usecase.BankAccountQuery{
+						CompanyID: 1,
+						VendorID:  5,
+						UserID:    20,
+						Reveal:    true,
+					}).
+					Return(nil, domain.ErrForbidden)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mock.NewMockUsecase(ctrl)
+			tt.prepare(mockUsecase)
+
+			handler := vendors.NewHandler(mockUsecase, validator.New())
+			r := setupRouter(handler)
+
+			req := httptest.NewRequest(http.MethodGet, "/vendors/5/bank-accounts"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestHandler_CreateBankAccount_ValidationError(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := vendors.NewHandler(mock.NewMockUsecase(ctrl), validator.New())
+	r := setupRouter(handler)
+
+	jsonBody, _ := json.Marshal(map[string]any{
+		"bank_name":           "テスト銀行",
+		"branch_name":         "本店",
+		"account_number":      "12-345",
+		"account_holder_name": "カ）テスト",
+	})
+	req := httptest.NewRequest(
+		http.MethodPost,
+		"/vendors/5/bank-accounts",
+		bytes.NewReader(jsonBody),
+	)
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestHandler_DeleteBankAccount(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{name: "success", wantStatus: http.StatusNoContent},
+		{name: "in use", err: domain.ErrInUse, wantStatus: http.StatusConflict},
+		{name: "not found", err: domain.ErrNotFound, wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mock.NewMockUsecase(ctrl)
+			mockUsecase.EXPECT().
+				DeleteBankAccount(gomock.Any(), int64(1), int64(5), int64(10)).
+				Return(tt.err)
+
+			handler := vendors.NewHandler(mockUsecase, validator.New())
+			r := setupRouter(handler)
+
+			req := httptest.NewRequest(http.MethodDelete, "/vendors/5/bank-accounts/10", nil)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package vendors
+
+// VendorRequest is the request body for creating or updating a vendor.
+type VendorRequest struct {
+	// ExternalCode is the vendor's code in the customer's existing system, used to
+	// match vendors in CSV imports.
+	ExternalCode string `json:"external_code" validate:"omitempty,max=50"`
+	Name         string `json:"name"          validate:"required,max=255"`
+	// NameKana is the reading of the name in katakana or hiragana, used to sort vendors.
+	NameKana           string `json:"name_kana"           validate:"omitempty,max=255"`
+	RepresentativeName string `json:"representative_name" validate:"required,max=255"`
+	// PhoneNumber is normalized to E.164, e.g. "03-1234-5678" is stored as "+81312345678".
+	PhoneNumber string `json:"phone_number" validate:"required,max=30"`
+	ZipCode     string `json:"zip_code"     validate:"required,max=10"`
+	Address     string `json:"address"      validate:"required,max=500"`
+	// RegistrationNumber is the qualified invoice issuer number, e.g. "T1234567890123".
+	RegistrationNumber       string `json:"registration_number"        validate:"omitempty,max=20"`
+	WithholdingTaxApplicable bool   `json:"withholding_tax_applicable"`
+}
+
+// ListRequest is the query parameters for listing vendors.
+type ListRequest struct {
+	// Name matches the name or kana reading.
+	Name    string `form:"name"     validate:"omitempty,max=255"`
+	Page    int    `form:"page"     validate:"omitempty,min=1,max=10000"`
+	PerPage int    `form:"per_page" validate:"omitempty,min=1,max=100"`
+	// IncludeArchived also returns archived vendors.
+	IncludeArchived bool `form:"include_archived"`
+}
+
+// BankAccountRequest is the request body for creating or updating a vendor bank account.
+type BankAccountRequest struct {
+	BankCode          string `json:"bank_code"           validate:"omitempty,numeric,len=4"`
+	BankName          string `json:"bank_name"           validate:"required,max=255"`
+	BranchCode        string `json:"branch_code"         validate:"omitempty,numeric,len=3"`
+	BranchName        string `json:"branch_name"         validate:"required,max=255"`
+	AccountNumber     string `json:"account_number"      validate:"required,numeric,max=20"`
+	AccountHolderName string `json:"account_holder_name" validate:"required,max=255"`
+}
+
+// BankAccountQueryRequest is the query parameters for reading vendor bank accounts.
+type BankAccountQueryRequest struct {
+	// Reveal returns account numbers unmasked (admin or accountant only).
+	Reveal bool `form:"reveal"`
+	// IncludeArchived also lists archived accounts.
+	IncludeArchived bool `form:"include_archived"`
+}
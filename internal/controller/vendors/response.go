@@ -0,0 +1,221 @@
+package vendors
+
+import (
+	"time"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+	"github.com/harusys/super-shiharai-kun/internal/domain/valueobject"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/vendors"
+)
+
+// Response is the response body for a vendor.
+type Response struct {
+	ID                 int64  `json:"id"`
+	CompanyID          int64  `json:"company_id"`
+	ExternalCode       string `json:"external_code,omitempty"`
+	Name               string `json:"name"`
+	NameKana           string `json:"name_kana,omitempty"`
+	RepresentativeName string `json:"representative_name"`
+	PhoneNumber        string `json:"phone_number"`
+	// PhoneNumberDisplay is the phone number in domestic format, e.g. "03-1234-5678".
+	PhoneNumberDisplay       string     `json:"phone_number_display"`
+	ZipCode                  string     `json:"zip_code"`
+	Address                  string     `json:"address"`
+	RegistrationNumber       string     `json:"registration_number,omitempty"`
+	QualifiedInvoiceIssuer   bool       `json:"qualified_invoice_issuer"`
+	WithholdingTaxApplicable bool       `json:"withholding_tax_applicable"`
+	ArchivedAt               *time.Time `json:"archived_at,omitempty"`
+	CreatedAt                time.Time  `json:"created_at"`
+	UpdatedAt                time.Time  `json:"updated_at"`
+}
+
+// ToResponse converts an entity.Vendor to Response.
+func ToResponse(v *entity.Vendor) *Response {
+	return &Response{
+		ID:                       v.ID,
+		CompanyID:                v.CompanyID,
+		ExternalCode:             v.ExternalCode,
+		Name:                     v.Name,
+		NameKana:                 v.NameKana,
+		RepresentativeName:       v.RepresentativeName,
+		PhoneNumber:              v.PhoneNumber,
+		PhoneNumberDisplay:       valueobject.PhoneNumber(v.PhoneNumber).Display(),
+		ZipCode:                  v.ZipCode,
+		Address:                  v.Address,
+		RegistrationNumber:       v.RegistrationNumber,
+		QualifiedInvoiceIssuer:   v.IsQualifiedInvoiceIssuer(),
+		WithholdingTaxApplicable: v.WithholdingTaxApplicable,
+		ArchivedAt:               v.ArchivedAt,
+		CreatedAt:                v.CreatedAt,
+		UpdatedAt:                v.UpdatedAt,
+	}
+}
+
+// ListResponse is the response body for a page of vendors.
+type ListResponse struct {
+	Vendors []*Response `json:"vendors"`
+	Total   int64       `json:"total"`
+	Page    int         `json:"page"`
+	PerPage int         `json:"per_page"`
+}
+
+// ToListResponse converts a vendors.ListResult to ListResponse.
+func ToListResponse(result *vendors.ListResult) *ListResponse {
+	items := make([]*Response, len(result.Vendors))
+	for i, v := range result.Vendors {
+		items[i] = ToResponse(v)
+	}
+
+	return &ListResponse{
+		Vendors: items,
+		Total:   result.Total,
+		Page:    result.Page,
+		PerPage: result.PerPage,
+	}
+}
+
+// StatsResponse is the response body for the payment statistics of a vendor.
+type StatsResponse struct {
+	PaidInvoiceCount     int64   `json:"paid_invoice_count"`
+	PaymentAmount        int64   `json:"payment_amount"`
+	WithholdingTax       int64   `json:"withholding_tax"`
+	TransferAmount       int64   `json:"transfer_amount"`
+	AveragePaymentAmount int64   `json:"average_payment_amount"`
+	FirstPaymentDate     *string `json:"first_payment_date"` // 支払実績がない場合は null
+	LastPaymentDate      *string `json:"last_payment_date"`  // 支払実績がない場合は null
+	// Monthly lists the months with payments, oldest first.
+	Monthly []*MonthlyPaymentResponse `json:"monthly"`
+	// InvoiceCounts is the number of invoices in each status, including zeros.
+	InvoiceCounts map[string]int64 `json:"invoice_counts"`
+}
+
+// MonthlyPaymentResponse is the total paid to a vendor in a month.
+type MonthlyPaymentResponse struct {
+	Month          string `json:"month"` // 例: 2024-03
+	InvoiceCount   int64  `json:"invoice_count"`
+	PaymentAmount  int64  `json:"payment_amount"`
+	TransferAmount int64  `json:"transfer_amount"`
+}
+
+// ToStatsResponse converts an entity.VendorPaymentStats to StatsResponse.
+func ToStatsResponse(stats *entity.VendorPaymentStats) *StatsResponse {
+	monthly := make([]*MonthlyPaymentResponse, len(stats.Monthly))
+	for i, m := range stats.Monthly {
+		monthly[i] = &MonthlyPaymentResponse{
+			Month:          m.Month.Format("2006-01"),
+			InvoiceCount:   m.InvoiceCount,
+			PaymentAmount:  m.PaymentAmount,
+			TransferAmount: m.TransferAmount,
+		}
+	}
+
+	counts := make(map[string]int64)
+	for _, status := range []entity.InvoiceStatus{
+		entity.InvoiceStatusAwaitingApproval,
+		entity.InvoiceStatusPending,
+		entity.InvoiceStatusProcessing,
+		entity.InvoiceStatusPaid,
+		entity.InvoiceStatusError,
+		entity.InvoiceStatusRejected,
+	} {
+		counts[string(status)] = stats.InvoiceCounts[status]
+	}
+
+	return &StatsResponse{
+		PaidInvoiceCount:     stats.PaidInvoiceCount,
+		PaymentAmount:        stats.PaymentAmount,
+		WithholdingTax:       stats.WithholdingTax,
+		TransferAmount:       stats.TransferAmount,
+		AveragePaymentAmount: stats.AveragePaymentAmount,
+		FirstPaymentDate:     formatDate(stats.FirstPaymentDate),
+		LastPaymentDate:      formatDate(stats.LastPaymentDate),
+		Monthly:              monthly,
+		InvoiceCounts:        counts,
+	}
+}
+
+func formatDate(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+
+	s := t.Format("2006-01-02")
+
+	return &s
+}
+
+// BankAccountResponse is the response body for a vendor bank account.
+type BankAccountResponse struct {
+	ID                int64  `json:"id"`
+	VendorID          int64  `json:"vendor_id"`
+	BankCode          string `json:"bank_code,omitempty"`
+	BankName          string `json:"bank_name"`
+	BranchCode        string `json:"branch_code,omitempty"`
+	BranchName        string `json:"branch_name"`
+	AccountNumber     string `json:"account_number"` // reveal 指定時以外はマスク済み (例: ****567)
+	AccountHolderName string `json:"account_holder_name"`
+	IsDefault         bool   `json:"is_default"`
+	Status            string `json:"status"` // pending_verification, verified
+	// CoolingOffUntil is when the account becomes payable once verified.
+	CoolingOffUntil *time.Time `json:"cooling_off_until,omitempty"`
+	ChangedBy       int64      `json:"changed_by,omitempty"`
+	VerifiedBy      int64      `json:"verified_by,omitempty"`
+	VerifiedAt      *time.Time `json:"verified_at,omitempty"`
+	ArchivedAt      *time.Time `json:"archived_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// ToBankAccountResponse converts an entity.VendorBankAccount to BankAccountResponse.
+func ToBankAccountResponse(a *entity.VendorBankAccount) *BankAccountResponse {
+	return &BankAccountResponse{
+		ID:                a.ID,
+		VendorID:          a.VendorID,
+		BankCode:          a.BankCode,
+		BankName:          a.BankName,
+		BranchCode:        a.BranchCode,
+		BranchName:        a.BranchName,
+		AccountNumber:     a.AccountNumber,
+		AccountHolderName: a.AccountHolderName,
+		IsDefault:         a.IsDefault,
+		Status:            string(a.Status),
+		CoolingOffUntil:   a.CoolingOffUntil,
+		ChangedBy:         a.ChangedBy,
+		VerifiedBy:        a.VerifiedBy,
+		VerifiedAt:        a.VerifiedAt,
+		ArchivedAt:        a.ArchivedAt,
+		CreatedAt:         a.CreatedAt,
+		UpdatedAt:         a.UpdatedAt,
+	}
+}
+
+// ToBankAccountResponses converts a slice of entity.VendorBankAccount to a slice of BankAccountResponse.
+func ToBankAccountResponses(accounts []*entity.VendorBankAccount) []*BankAccountResponse {
+	responses := make([]*BankAccountResponse, len(accounts))
+	for i, a := range accounts {
+		responses[i] = ToBankAccountResponse(a)
+	}
+
+	return responses
+}
+
+// ErrorResponse is the standard error response body.
+type ErrorResponse struct {
+	Error   string            `json:"error"`
+	Details map[string]string `json:"details,omitempty"`
+}
+
+// NewErrorResponse creates a new ErrorResponse.
+func NewErrorResponse(message string) *ErrorResponse {
+	return &ErrorResponse{
+		Error: message,
+	}
+}
+
+// NewValidationErrorResponse creates a new ErrorResponse for validation errors.
+func NewValidationErrorResponse(details map[string]string) *ErrorResponse {
+	return &ErrorResponse{
+		Error:   "validation error",
+		Details: details,
+	}
+}
@@ -7,3 +7,13 @@ const (
 	// DefaultTaxRateStr is the default tax rate (10%).
 	DefaultTaxRateStr = "0.10"
 )
+
+// Business rule constants for withholding tax (源泉徴収税) on fees paid to individuals.
+const (
+	// WithholdingTaxRateStr is the withholding tax rate up to the threshold (10.21%).
+	WithholdingTaxRateStr = "0.1021"
+	// WithholdingTaxHigherRateStr is the withholding tax rate above the threshold (20.42%).
+	WithholdingTaxHigherRateStr = "0.2042"
+	// WithholdingTaxThreshold is the payment amount above which the higher rate applies.
+	WithholdingTaxThreshold int64 = 1_000_000
+)
@@ -0,0 +1,23 @@
+package entity
+
+import "time"
+
+// ApprovalPolicy is a company's rule for which invoices need approval before payment.
+type ApprovalPolicy struct {
+	CompanyID int64
+	// AmountThreshold is the payment amount at or above which approval is required (0=no amount rule).
+	AmountThreshold int64
+	// RequireForNewVendors requires approval for vendors that have never been paid.
+	RequireForNewVendors bool
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+}
+
+// RequiresApproval reports whether an invoice needs approval under the policy.
+func (p *ApprovalPolicy) RequiresApproval(paymentAmount int64, isNewVendor bool) bool {
+	if p.AmountThreshold > 0 && paymentAmount >= p.AmountThreshold {
+		return true
+	}
+
+	return p.RequireForNewVendors && isNewVendor
+}
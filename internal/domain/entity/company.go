@@ -1,15 +1,78 @@
 package entity
 
-import "time"
+import (
+	"strconv"
+	"time"
+)
 
 // Company represents a company entity.
 type Company struct {
 	ID                 int64
 	Name               string
+	NameKana           string // 法人名フリガナ (空文字=未登録)
 	RepresentativeName string
 	PhoneNumber        string
 	ZipCode            string
 	Address            string
-	CreatedAt          time.Time
-	UpdatedAt          time.Time
+	// MFARequired requires every member to log in with two-factor
+	// authentication.
+	MFARequired bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// CompanyFieldChange is a change to one field of a company profile.
+type CompanyFieldChange struct {
+	Field  string // API field name, e.g. "phone_number"
+	Before string
+	After  string
+}
+
+// AuditFieldMFAReset is the field of the change an admin makes by resetting a
+// member's two-factor authentication. After is the member's user ID.
+const AuditFieldMFAReset = "mfa_reset"
+
+// CompanyAuditLog records a change to a company profile, or a reset of a
+// member's two-factor authentication.
+type CompanyAuditLog struct {
+	ID        int64
+	CompanyID int64
+	UserID    int64 // 変更したユーザーID (0=削除済みユーザー)
+	Changes   []*CompanyFieldChange
+	CreatedAt time.Time
+}
+
+// Diff returns the profile fields that differ between c and updated, in API
+// field order. It returns nil if nothing changed.
+func (c *Company) Diff(updated *Company) []*CompanyFieldChange {
+	fields := []struct {
+		name          string
+		before, after string
+	}{
+		{"name", c.Name, updated.Name},
+		{"name_kana", c.NameKana, updated.NameKana},
+		{"representative_name", c.RepresentativeName, updated.RepresentativeName},
+		{"phone_number", c.PhoneNumber, updated.PhoneNumber},
+		{"zip_code", c.ZipCode, updated.ZipCode},
+		{"address", c.Address, updated.Address},
+		{
+			"mfa_required",
+			strconv.FormatBool(c.MFARequired),
+			strconv.FormatBool(updated.MFARequired),
+		},
+	}
+
+	var changes []*CompanyFieldChange
+
+	for _, f := range fields {
+		if f.before != f.after {
+			changes = append(changes, &CompanyFieldChange{
+				Field:  f.name,
+				Before: f.before,
+				After:  f.after,
+			})
+		}
+	}
+
+	return changes
 }
@@ -0,0 +1,44 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompany_Diff(t *testing.T) {
+	t.Parallel()
+
+	company := &entity.Company{
+		ID:                 1,
+		Name:               "テスト株式会社",
+		RepresentativeName: "山田太郎",
+		PhoneNumber:        "+81312345678",
+		ZipCode:            "100-0001",
+		Address:            "東京都千代田区千代田1-1",
+	}
+
+	t.Run("changed fields", func(t *testing.T) {
+		t.Parallel()
+
+		updated := *company
+		updated.NameKana = "テストカブシキガイシャ"
+		updated.Address = "東京都千代田区千代田1-2"
+		updated.MFARequired = true
+
+		assert.Equal(t, []*entity.CompanyFieldChange{
+			{Field: "name_kana", Before: "", After: "テストカブシキガイシャ"},
+			{Field: "address", Before: "東京都千代田区千代田1-1", After: "東京都千代田区千代田1-2"},
+			{Field: "mfa_required", Before: "false", After: "true"},
+		}, company.Diff(&updated))
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		t.Parallel()
+
+		updated := *company
+
+		assert.Nil(t, company.Diff(&updated))
+	})
+}
@@ -0,0 +1,23 @@
+package entity
+
+import "time"
+
+// Invitation is an invitation for a person to join a company as a user. It is
+// accepted with a single-use token sent to the invited email address.
+type Invitation struct {
+	ID        int64
+	CompanyID int64
+	Email     string
+	Role      UserRole
+	// TokenHash is the SHA-256 of the token; the token itself is never stored.
+	TokenHash  string
+	InvitedBy  int64 // 招待したユーザーID (0=削除済みユーザー)
+	ExpiresAt  time.Time
+	AcceptedAt *time.Time
+	CreatedAt  time.Time
+}
+
+// CanAccept reports whether the invitation can still be accepted at now.
+func (i *Invitation) CanAccept(now time.Time) bool {
+	return i.AcceptedAt == nil && now.Before(i.ExpiresAt)
+}
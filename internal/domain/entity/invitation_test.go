@@ -0,0 +1,37 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvitation_CanAccept(t *testing.T) {
+	t.Parallel()
+
+	expiresAt := time.Date(2024, 3, 8, 10, 0, 0, 0, time.UTC)
+	acceptedAt := time.Date(2024, 3, 2, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		acceptedAt *time.Time
+		now        time.Time
+		want       bool
+	}{
+		{name: "pending", now: expiresAt.Add(-time.Second), want: true},
+		{name: "expired", now: expiresAt, want: false},
+		{name: "accepted", acceptedAt: &acceptedAt, now: acceptedAt, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			invitation := &entity.Invitation{ExpiresAt: expiresAt, AcceptedAt: tt.acceptedAt}
+
+			assert.Equal(t, tt.want, invitation.CanAccept(tt.now))
+		})
+	}
+}
@@ -1,8 +1,10 @@
 package entity
 
 import (
+	"fmt"
 	"time"
 
+	"github.com/harusys/super-shiharai-kun/internal/domain"
 	"github.com/shopspring/decimal"
 )
 
@@ -14,6 +16,9 @@ const (
 	InvoiceStatusProcessing InvoiceStatus = "processing"
 	InvoiceStatusPaid       InvoiceStatus = "paid"
 	InvoiceStatusError      InvoiceStatus = "error"
+	// InvoiceStatusAwaitingApproval invoices are not paid until approved.
+	InvoiceStatusAwaitingApproval InvoiceStatus = "awaiting_approval"
+	InvoiceStatusRejected         InvoiceStatus = "rejected"
 )
 
 // Invoice represents an invoice entity.
@@ -29,8 +34,107 @@ type Invoice struct {
 	Tax                 int64           // 消費税
 	TaxRate             decimal.Decimal // 消費税率 (default: 0.10)
 	TotalAmount         int64           // 請求金額 (payment_amount + fee + tax)
+	WithholdingTax      int64           // 源泉徴収税額
+	TransferAmount      int64           // 振込金額 (payment_amount - withholding_tax)
 	DueDate             time.Time       // 支払期日
 	Status              InvoiceStatus
-	CreatedAt           time.Time
-	UpdatedAt           time.Time
+	// VendorRegistrationNumber is the vendor's qualified invoice issuer number
+	// at the time the invoice was created (空文字=適格請求書発行事業者以外).
+	VendorRegistrationNumber string
+	// RecurringInvoiceScheduleID is the schedule that produced the invoice (0=created manually).
+	RecurringInvoiceScheduleID int64
+	// VendorInvoiceNumber is the number printed on the vendor's invoice (空文字=未入力).
+	VendorInvoiceNumber string
+	// DuplicateOverride records that the invoice was registered even though it
+	// looked like a duplicate of an existing invoice.
+	DuplicateOverride bool
+	// CreatedBy is the user who created the invoice (0=created by the system).
+	CreatedBy int64
+	// ReviewedBy and ReviewedAt record who approved or rejected the invoice and when.
+	ReviewedBy      int64
+	ReviewedAt      *time.Time
+	RejectionReason string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// IsFromQualifiedInvoiceIssuer reports whether the invoice was issued by a
+// qualified invoice issuer, which determines the input tax credit eligibility.
+func (i *Invoice) IsFromQualifiedInvoiceIssuer() bool {
+	return i.VendorRegistrationNumber != ""
+}
+
+// Approve clears an invoice awaiting approval for payment. The approver must
+// not be the user who created it.
+func (i *Invoice) Approve(approverID int64, at time.Time) error {
+	if err := i.checkReviewable(approverID); err != nil {
+		return err
+	}
+
+	i.Status = InvoiceStatusPending
+	i.ReviewedBy = approverID
+	i.ReviewedAt = &at
+
+	return nil
+}
+
+// Reject sends an invoice awaiting approval back with a reason. The approver must
+// not be the user who created it.
+func (i *Invoice) Reject(approverID int64, at time.Time, reason string) error {
+	if err := i.checkReviewable(approverID); err != nil {
+		return err
+	}
+
+	i.Status = InvoiceStatusRejected
+	i.ReviewedBy = approverID
+	i.ReviewedAt = &at
+	i.RejectionReason = reason
+
+	return nil
+}
+
+func (i *Invoice) checkReviewable(approverID int64) error {
+	if i.Status != InvoiceStatusAwaitingApproval {
+		return fmt.Errorf("%w: invoice is %s", domain.ErrInvalidState, i.Status)
+	}
+
+	if i.CreatedBy == approverID {
+		return fmt.Errorf("%w: invoice must be approved by a different user", domain.ErrForbidden)
+	}
+
+	return nil
+}
+
+// WithholdingSummary aggregates the withholding tax withheld from a vendor's invoices.
+type WithholdingSummary struct {
+	VendorID       int64
+	VendorName     string
+	InvoiceCount   int64
+	PaymentAmount  int64 // 支払金額合計
+	WithholdingTax int64 // 源泉徴収税額合計
+}
+
+// VendorPaymentStats aggregates the invoices of a vendor. Amounts, averages and
+// dates cover paid invoices only; a paid invoice's due date is its payment date.
+type VendorPaymentStats struct {
+	PaidInvoiceCount     int64
+	PaymentAmount        int64      // 支払金額合計
+	WithholdingTax       int64      // 源泉徴収税額合計
+	TransferAmount       int64      // 振込金額合計
+	AveragePaymentAmount int64      // 1件あたりの支払金額 (円未満四捨五入)
+	FirstPaymentDate     *time.Time // 初回支払日 (nil=支払実績なし)
+	LastPaymentDate      *time.Time // 最終支払日 (nil=支払実績なし)
+	// Monthly is the totals of the months with payments, oldest first.
+	Monthly []*MonthlyPayment
+	// InvoiceCounts is the number of invoices in each status, including unpaid
+	// ones. Statuses without invoices are omitted.
+	InvoiceCounts map[InvoiceStatus]int64
+}
+
+// MonthlyPayment is the total paid to a vendor in a month.
+type MonthlyPayment struct {
+	Month          time.Time // 月初日
+	InvoiceCount   int64
+	PaymentAmount  int64 // 支払金額合計
+	TransferAmount int64 // 振込金額合計
 }
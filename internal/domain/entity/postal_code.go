@@ -0,0 +1,13 @@
+package entity
+
+// PostalCode is an address area assigned to a Japanese postal code, as published
+// by Japan Post (KEN_ALL.CSV). A postal code may cover several towns.
+type PostalCode struct {
+	Code           string // 郵便番号 (ハイフンなし7桁)
+	Prefecture     string // 都道府県
+	City           string // 市区町村
+	Town           string // 町域 (空文字=町域なし)
+	PrefectureKana string
+	CityKana       string
+	TownKana       string
+}
@@ -0,0 +1,62 @@
+package entity
+
+import "time"
+
+// RecurringInvoiceSchedule represents a schedule that produces an invoice for
+// the same amount every month (rent, SaaS subscriptions, retainer fees, ...).
+type RecurringInvoiceSchedule struct {
+	ID                  int64
+	CompanyID           int64
+	VendorID            int64
+	VendorBankAccountID int64
+	PaymentAmount       int64      // 支払金額
+	DayOfMonth          int        // 支払日 (1-31, 0 when EndOfMonth)
+	EndOfMonth          bool       // 月末払い
+	StartDate           time.Time  // 開始日
+	EndDate             *time.Time // 終了日 (nil=無期限)
+	NextDueDate         time.Time  // 次回支払期日
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+// DueDateIn returns the due date of the schedule in the given month.
+// A day of month past the end of the month (e.g. 31 in February) falls on the last day.
+func (s *RecurringInvoiceSchedule) DueDateIn(year int, month time.Month) time.Time {
+	// Day 0 of the next month is the last day of this month
+	lastDay := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+
+	day := s.DayOfMonth
+	if s.EndOfMonth || day > lastDay {
+		day = lastDay
+	}
+
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// DueDateOnOrAfter returns the first due date of the schedule on or after date.
+func (s *RecurringInvoiceSchedule) DueDateOnOrAfter(date time.Time) time.Time {
+	due := s.DueDateIn(date.Year(), date.Month())
+	if due.Before(truncateToDate(date)) {
+		due = s.DueDateIn(date.Year(), date.Month()+1)
+	}
+
+	return due
+}
+
+// DueDateAfter returns the first due date of the schedule strictly after date.
+func (s *RecurringInvoiceSchedule) DueDateAfter(date time.Time) time.Time {
+	return s.DueDateOnOrAfter(truncateToDate(date).AddDate(0, 0, 1))
+}
+
+// IsActiveOn reports whether the schedule produces an invoice due on date.
+func (s *RecurringInvoiceSchedule) IsActiveOn(date time.Time) bool {
+	if date.Before(s.StartDate) {
+		return false
+	}
+
+	return s.EndDate == nil || !date.After(*s.EndDate)
+}
+
+func truncateToDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
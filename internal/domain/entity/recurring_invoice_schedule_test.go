@@ -0,0 +1,101 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestRecurringInvoiceSchedule_DueDateOnOrAfter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		schedule *entity.RecurringInvoiceSchedule
+		date     time.Time
+		want     time.Time
+	}{
+		{
+			name:     "day of month later in the same month",
+			schedule: &entity.RecurringInvoiceSchedule{DayOfMonth: 25},
+			date:     date(2024, time.January, 10),
+			want:     date(2024, time.January, 25),
+		},
+		{
+			name:     "day of month on the same day",
+			schedule: &entity.RecurringInvoiceSchedule{DayOfMonth: 25},
+			date:     date(2024, time.January, 25),
+			want:     date(2024, time.January, 25),
+		},
+		{
+			name:     "day of month already passed",
+			schedule: &entity.RecurringInvoiceSchedule{DayOfMonth: 5},
+			date:     date(2024, time.January, 10),
+			want:     date(2024, time.February, 5),
+		},
+		{
+			name:     "day of month clamped to end of February",
+			schedule: &entity.RecurringInvoiceSchedule{DayOfMonth: 31},
+			date:     date(2024, time.February, 1),
+			want:     date(2024, time.February, 29),
+		},
+		{
+			name:     "end of month",
+			schedule: &entity.RecurringInvoiceSchedule{EndOfMonth: true},
+			date:     date(2023, time.February, 1),
+			want:     date(2023, time.February, 28),
+		},
+		{
+			name:     "day of month already passed at year end",
+			schedule: &entity.RecurringInvoiceSchedule{DayOfMonth: 25},
+			date:     date(2024, time.December, 26),
+			want:     date(2025, time.January, 25),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, tt.schedule.DueDateOnOrAfter(tt.date))
+		})
+	}
+}
+
+func TestRecurringInvoiceSchedule_DueDateAfter(t *testing.T) {
+	t.Parallel()
+
+	schedule := &entity.RecurringInvoiceSchedule{EndOfMonth: true}
+
+	assert.Equal(
+		t,
+		date(2025, time.January, 31),
+		schedule.DueDateAfter(date(2024, time.December, 31)),
+	)
+	assert.Equal(
+		t,
+		date(2024, time.February, 29),
+		schedule.DueDateAfter(date(2024, time.January, 31)),
+	)
+}
+
+func TestRecurringInvoiceSchedule_IsActiveOn(t *testing.T) {
+	t.Parallel()
+
+	endDate := date(2024, time.June, 30)
+	schedule := &entity.RecurringInvoiceSchedule{
+		StartDate: date(2024, time.January, 1),
+		EndDate:   &endDate,
+	}
+
+	assert.False(t, schedule.IsActiveOn(date(2023, time.December, 31)))
+	assert.True(t, schedule.IsActiveOn(date(2024, time.January, 1)))
+	assert.True(t, schedule.IsActiveOn(date(2024, time.June, 30)))
+	assert.False(t, schedule.IsActiveOn(date(2024, time.July, 31)))
+}
@@ -0,0 +1,29 @@
+package entity
+
+import "time"
+
+// RefreshToken is an issued refresh token. Each token is exchanged for a new
+// one when used; the tokens descending from one login share a FamilyID.
+type RefreshToken struct {
+	ID        int64
+	UserID    int64
+	CompanyID int64
+	FamilyID  string
+	// TokenHash is the SHA-256 of the token; the token itself is never stored.
+	TokenHash string
+	ExpiresAt time.Time
+	RotatedAt *time.Time
+	RevokedAt *time.Time
+	CreatedAt time.Time
+}
+
+// IsRotated reports whether the token has already been exchanged. Presenting
+// a rotated token again means it has been stolen or replayed.
+func (t *RefreshToken) IsRotated() bool {
+	return t.RotatedAt != nil
+}
+
+// IsRevoked reports whether the token can no longer be used.
+func (t *RefreshToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
@@ -2,13 +2,67 @@ package entity
 
 import "time"
 
-// User represents a user entity belonging to a company.
+// UserRole represents the role of a user within a company.
+type UserRole string
+
+const (
+	UserRoleAdmin      UserRole = "admin"
+	UserRoleAccountant UserRole = "accountant"
+	UserRoleApprover   UserRole = "approver"
+	UserRoleViewer     UserRole = "viewer"
+)
+
+// IsValid reports whether r is a known role.
+func (r UserRole) IsValid() bool {
+	switch r {
+	case UserRoleAdmin, UserRoleAccountant, UserRoleApprover, UserRoleViewer:
+		return true
+	default:
+		return false
+	}
+}
+
+// User represents a user acting in one of the companies they belong to.
+// CompanyID and Role come from that company's membership; a user with several
+// memberships is read once per company.
 type User struct {
 	ID           int64
 	CompanyID    int64
 	Name         string
 	Email        string
 	PasswordHash string
+	Role         UserRole
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
 }
+
+// IsAdmin reports whether the user can manage company settings.
+func (u *User) IsAdmin() bool {
+	return u.Role == UserRoleAdmin
+}
+
+// CanApproveInvoices reports whether the user may approve or reject invoices.
+func (u *User) CanApproveInvoices() bool {
+	return u.Role == UserRoleAdmin || u.Role == UserRoleApprover
+}
+
+// CanRevealBankAccountNumbers reports whether the user may see vendor bank
+// account numbers unmasked.
+func (u *User) CanRevealBankAccountNumbers() bool {
+	return u.Role == UserRoleAdmin || u.Role == UserRoleAccountant
+}
+
+// CanVerifyBankAccounts reports whether the user may confirm new or changed
+// vendor bank accounts.
+func (u *User) CanVerifyBankAccounts() bool {
+	return u.Role == UserRoleAdmin || u.Role == UserRoleAccountant
+}
+
+// Membership represents a user's membership of a company.
+type Membership struct {
+	UserID      int64
+	CompanyID   int64
+	CompanyName string
+	Role        UserRole
+	CreatedAt   time.Time
+}
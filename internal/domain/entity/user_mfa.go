@@ -0,0 +1,31 @@
+package entity
+
+import "time"
+
+// UserMFA is a user's TOTP two-factor authentication. It is pending from
+// enrolment until the user confirms it with a code from their authenticator.
+type UserMFA struct {
+	UserID int64
+	// Secret is the Base32 TOTP secret shared with the authenticator.
+	Secret    string
+	EnabledAt *time.Time
+	// LastUsedStep is the time step of the last accepted code. A code is
+	// accepted only once, so codes of this step or earlier are refused.
+	LastUsedStep   int64
+	FailedAttempts int
+	LockedUntil    *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// IsEnabled reports whether enrolment has been confirmed, so that logging in
+// takes a code.
+func (m *UserMFA) IsEnabled() bool {
+	return m.EnabledAt != nil
+}
+
+// IsLocked reports whether verification is refused at now after too many
+// failed attempts.
+func (m *UserMFA) IsLocked(now time.Time) bool {
+	return m.LockedUntil != nil && now.Before(*m.LockedUntil)
+}
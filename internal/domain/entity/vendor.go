@@ -1,28 +1,182 @@
 package entity
 
-import "time"
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+)
+
+// visibleAccountNumberDigits is the number of trailing digits left unmasked.
+const visibleAccountNumberDigits = 3
 
 // Vendor represents a vendor (payment recipient) entity belonging to a company.
 type Vendor struct {
-	ID                 int64
-	CompanyID          int64
+	ID        int64
+	CompanyID int64
+	// ExternalCode is the vendor's code in the customer's existing system, e.g.
+	// their accounting software (空文字=未設定). It is unique within the company
+	// and identifies the vendor in CSV imports.
+	ExternalCode       string
 	Name               string
+	NameKana           string // 法人名フリガナ (空文字=未登録)
 	RepresentativeName string
 	PhoneNumber        string
 	ZipCode            string
 	Address            string
-	CreatedAt          time.Time
-	UpdatedAt          time.Time
+	RegistrationNumber string // 適格請求書発行事業者登録番号 (空文字=未登録)
+	// WithholdingTaxApplicable is true for vendors whose payments are subject to
+	// withholding tax (源泉徴収), e.g. design or writing fees to sole proprietors.
+	WithholdingTaxApplicable bool
+	// ArchivedAt is when the vendor was retired (nil=active). No new invoices can
+	// be created for an archived vendor.
+	ArchivedAt *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// IsArchived reports whether the vendor has been retired.
+func (v *Vendor) IsArchived() bool {
+	return v.ArchivedAt != nil
+}
+
+// Archive retires the vendor.
+func (v *Vendor) Archive(at time.Time) {
+	v.ArchivedAt = &at
+}
+
+// Unarchive makes the vendor active again.
+func (v *Vendor) Unarchive() {
+	v.ArchivedAt = nil
+}
+
+// IsQualifiedInvoiceIssuer reports whether the vendor is a registered
+// qualified invoice issuer (適格請求書発行事業者).
+func (v *Vendor) IsQualifiedInvoiceIssuer() bool {
+	return v.RegistrationNumber != ""
 }
 
+// BankAccountStatus represents whether a vendor bank account has been verified.
+type BankAccountStatus string
+
+const (
+	BankAccountStatusPendingVerification BankAccountStatus = "pending_verification"
+	BankAccountStatusVerified            BankAccountStatus = "verified"
+)
+
 // VendorBankAccount represents a bank account belonging to a vendor.
+//
+// A new or changed account is pending verification until a user other than the
+// one who changed it confirms it, and cannot be paid into before the cooling-off
+// period ends. This protects against fraudulent account changes right before a
+// payment (business email compromise).
 type VendorBankAccount struct {
 	ID                int64
 	VendorID          int64
+	BankCode          string // 金融機関コード (4桁、空文字=未登録)
 	BankName          string
+	BranchCode        string // 支店コード (3桁、空文字=未登録)
 	BranchName        string
 	AccountNumber     string
 	AccountHolderName string
-	CreatedAt         time.Time
-	UpdatedAt         time.Time
+	// IsDefault marks the account used when an invoice does not specify one.
+	IsDefault bool
+	Status    BankAccountStatus
+	// ChangedBy is the user who registered or last changed the account (0=unknown).
+	ChangedBy       int64
+	CoolingOffUntil *time.Time
+	// VerifiedBy and VerifiedAt record who confirmed the account and when.
+	VerifiedBy int64
+	VerifiedAt *time.Time
+	// ArchivedAt is when the account was retired (nil=active).
+	ArchivedAt *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// IsArchived reports whether the account has been retired.
+func (a *VendorBankAccount) IsArchived() bool {
+	return a.ArchivedAt != nil
+}
+
+// Archive retires the account. An archived account cannot be the default account.
+func (a *VendorBankAccount) Archive(at time.Time) {
+	a.ArchivedAt = &at
+	a.IsDefault = false
+}
+
+// Unarchive makes the account active again. It does not become the default
+// account again.
+func (a *VendorBankAccount) Unarchive() {
+	a.ArchivedAt = nil
+}
+
+// MarkChanged puts a new or changed account into pending verification with a
+// cooling-off period starting at the given time.
+func (a *VendorBankAccount) MarkChanged(userID int64, at time.Time, coolingOff time.Duration) {
+	until := at.Add(coolingOff)
+
+	a.Status = BankAccountStatusPendingVerification
+	a.ChangedBy = userID
+	a.CoolingOffUntil = &until
+	a.VerifiedBy = 0
+	a.VerifiedAt = nil
+}
+
+// Verify records the confirmation of the account by a user other than the one
+// who changed it.
+func (a *VendorBankAccount) Verify(verifierID int64, at time.Time) error {
+	if a.Status != BankAccountStatusPendingVerification {
+		return fmt.Errorf("%w: bank account is %s", domain.ErrInvalidState, a.Status)
+	}
+
+	// An account whose changer is unknown (e.g. the user was deleted) cannot be
+	// checked against the verifier and must be changed again to be verified
+	if a.ChangedBy == 0 {
+		return fmt.Errorf(
+			"%w: bank account has no known changer; update it to restart verification",
+			domain.ErrInvalidState,
+		)
+	}
+
+	if a.ChangedBy == verifierID {
+		return fmt.Errorf(
+			"%w: bank account must be verified by a different user",
+			domain.ErrForbidden,
+		)
+	}
+
+	a.Status = BankAccountStatusVerified
+	a.VerifiedBy = verifierID
+	a.VerifiedAt = &at
+
+	return nil
+}
+
+// IsPayable reports whether payments may be transferred to the account at the
+// given time, i.e. it has been verified and the cooling-off period has ended.
+func (a *VendorBankAccount) IsPayable(at time.Time) bool {
+	if a.Status != BankAccountStatusVerified {
+		return false
+	}
+
+	return a.CoolingOffUntil == nil || !at.Before(*a.CoolingOffUntil)
+}
+
+// Masked returns a copy of the account whose account number is masked except
+// for the last digits, e.g. "1234567" becomes "****567".
+func (a *VendorBankAccount) Masked() *VendorBankAccount {
+	masked := *a
+	masked.AccountNumber = MaskAccountNumber(a.AccountNumber)
+
+	return &masked
+}
+
+// MaskAccountNumber masks all but the last digits of a bank account number.
+func MaskAccountNumber(accountNumber string) string {
+	runes := []rune(accountNumber)
+	hidden := max(len(runes)-visibleAccountNumberDigits, 0)
+
+	return strings.Repeat("*", hidden) + string(runes[hidden:])
 }
@@ -0,0 +1,119 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaskAccountNumber(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		accountNumber string
+		want          string
+	}{
+		{name: "seven digits", accountNumber: "1234567", want: "****567"},
+		{name: "three digits", accountNumber: "567", want: "567"},
+		{name: "shorter than visible digits", accountNumber: "12", want: "12"},
+		{name: "empty", accountNumber: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, entity.MaskAccountNumber(tt.accountNumber))
+		})
+	}
+}
+
+func TestVendorBankAccount_Masked(t *testing.T) {
+	t.Parallel()
+
+	account := &entity.VendorBankAccount{ID: 1, AccountNumber: "1234567"}
+
+	masked := account.Masked()
+
+	assert.Equal(t, "****567", masked.AccountNumber)
+	assert.Equal(t, int64(1), masked.ID)
+	assert.Equal(t, "1234567", account.AccountNumber)
+}
+
+func TestVendorBankAccount_Verify(t *testing.T) {
+	t.Parallel()
+
+	changedAt := time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		verifierID int64
+		prepare    func(a *entity.VendorBankAccount)
+		wantErr    error
+	}{
+		{name: "second user", verifierID: 2},
+		{name: "same user", verifierID: 1, wantErr: domain.ErrForbidden},
+		{
+			name:       "already verified",
+			verifierID: 2,
+			prepare: func(a *entity.VendorBankAccount) {
+				a.Status = entity.BankAccountStatusVerified
+			},
+			wantErr: domain.ErrInvalidState,
+		},
+		{
+			name:       "changer unknown",
+			verifierID: 2,
+			prepare: func(a *entity.VendorBankAccount) {
+				a.ChangedBy = 0
+			},
+			wantErr: domain.ErrInvalidState,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			account := &entity.VendorBankAccount{ID: 10}
+			account.MarkChanged(1, changedAt, 24*time.Hour)
+
+			if tt.prepare != nil {
+				tt.prepare(account)
+			}
+
+			err := account.Verify(tt.verifierID, changedAt.Add(time.Hour))
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, entity.BankAccountStatusVerified, account.Status)
+			assert.Equal(t, tt.verifierID, account.VerifiedBy)
+		})
+	}
+}
+
+func TestVendorBankAccount_IsPayable(t *testing.T) {
+	t.Parallel()
+
+	changedAt := time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC)
+
+	account := &entity.VendorBankAccount{ID: 10}
+	account.MarkChanged(1, changedAt, 24*time.Hour)
+
+	assert.False(t, account.IsPayable(changedAt.Add(48*time.Hour)), "pending verification")
+
+	require.NoError(t, account.Verify(2, changedAt.Add(time.Hour)))
+
+	assert.False(t, account.IsPayable(changedAt.Add(time.Hour)), "within cooling-off period")
+	assert.True(t, account.IsPayable(changedAt.Add(24*time.Hour)), "after cooling-off period")
+}
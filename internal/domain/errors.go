@@ -1,6 +1,9 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // Domain errors.
 var (
@@ -10,4 +13,21 @@ var (
 	ErrUnauthorized      = errors.New("unauthorized")
 	ErrForbidden         = errors.New("forbidden")
 	ErrInvalidCredential = errors.New("invalid credential")
+	ErrDuplicateInvoice  = errors.New("possible duplicate invoice")
+	ErrInvalidState      = errors.New("invalid state")
+	ErrInUse             = errors.New("in use")
 )
+
+// DuplicateInvoiceError reports existing invoices that look identical to the one
+// being created. It matches ErrDuplicateInvoice with errors.Is.
+type DuplicateInvoiceError struct {
+	InvoiceIDs []int64
+}
+
+func (e *DuplicateInvoiceError) Error() string {
+	return fmt.Sprintf("%s: %v", ErrDuplicateInvoice, e.InvoiceIDs)
+}
+
+func (e *DuplicateInvoiceError) Unwrap() error {
+	return ErrDuplicateInvoice
+}
@@ -0,0 +1,16 @@
+//go:generate mockgen -source=$GOFILE -destination=mock/mock_$GOFILE -package=mock
+
+package repository
+
+import (
+	"context"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+)
+
+// ApprovalPolicyRepository defines the interface for approval policy data access.
+type ApprovalPolicyRepository interface {
+	// GetByCompanyID returns domain.ErrNotFound if the company has no policy.
+	GetByCompanyID(ctx context.Context, companyID int64) (*entity.ApprovalPolicy, error)
+	Upsert(ctx context.Context, policy *entity.ApprovalPolicy) (*entity.ApprovalPolicy, error)
+}
@@ -0,0 +1,20 @@
+//go:generate mockgen -source=$GOFILE -destination=mock/mock_$GOFILE -package=mock
+
+package repository
+
+import (
+	"context"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+)
+
+// CompanyAuditLogRepository defines the interface for company audit log data access.
+type CompanyAuditLogRepository interface {
+	Create(ctx context.Context, log *entity.CompanyAuditLog) (*entity.CompanyAuditLog, error)
+	// GetByCompanyID returns up to limit logs of a company, newest first.
+	GetByCompanyID(
+		ctx context.Context,
+		companyID int64,
+		limit int32,
+	) ([]*entity.CompanyAuditLog, error)
+}
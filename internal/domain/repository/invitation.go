@@ -0,0 +1,28 @@
+//go:generate mockgen -source=$GOFILE -destination=mock/mock_$GOFILE -package=mock
+
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+)
+
+// InvitationRepository defines the interface for invitation data access.
+type InvitationRepository interface {
+	// GetByTokenHash returns domain.ErrNotFound if no invitation has the token.
+	GetByTokenHash(ctx context.Context, tokenHash string) (*entity.Invitation, error)
+	// GetPendingByCompanyID returns the company's invitations that have not been
+	// accepted, including expired ones, newest first.
+	GetPendingByCompanyID(ctx context.Context, companyID int64) ([]*entity.Invitation, error)
+	Create(ctx context.Context, invitation *entity.Invitation) (*entity.Invitation, error)
+	// MarkAccepted returns domain.ErrInvalidState if the invitation has already
+	// been accepted.
+	MarkAccepted(ctx context.Context, id int64, acceptedAt time.Time) (*entity.Invitation, error)
+	// DeletePending returns domain.ErrNotFound if the company has no pending
+	// invitation with the ID.
+	DeletePending(ctx context.Context, id, companyID int64) error
+	// DeletePendingByEmail deletes the company's pending invitations to email.
+	DeletePendingByEmail(ctx context.Context, companyID int64, email string) error
+}
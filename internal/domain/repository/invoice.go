@@ -19,7 +19,34 @@ type InvoiceRepository interface {
 		companyID int64,
 		startDate, endDate time.Time,
 	) ([]*entity.Invoice, error)
+	// GetWithholdingSummariesByCompanyIDAndDateRange totals the withholding tax
+	// of the paid invoices due in the range per vendor.
+	GetWithholdingSummariesByCompanyIDAndDateRange(
+		ctx context.Context,
+		companyID int64,
+		startDate, endDate time.Time,
+	) ([]*entity.WithholdingSummary, error)
+	// GetVendorPaymentStats aggregates the invoices of the company's vendor.
+	GetVendorPaymentStats(
+		ctx context.Context,
+		vendorID, companyID int64,
+	) (*entity.VendorPaymentStats, error)
+	// FindDuplicates returns the invoices of the same company and vendor that look
+	// identical to the given one.
+	FindDuplicates(ctx context.Context, invoice *entity.Invoice) ([]*entity.Invoice, error)
+	// LockByVendorID makes other invoice creations and bank account changes of
+	// the vendor wait until the transaction carried by ctx ends.
+	LockByVendorID(ctx context.Context, vendorID int64) error
+	// ExistsApprovedByVendorID reports whether the vendor has an invoice that was
+	// cleared for payment.
+	ExistsApprovedByVendorID(ctx context.Context, vendorID int64) (bool, error)
+	// ExistsUnpaidByBankAccountID reports whether an invoice that has not been paid
+	// yet still transfers to the bank account.
+	ExistsUnpaidByBankAccountID(ctx context.Context, bankAccountID int64) (bool, error)
 	Create(ctx context.Context, invoice *entity.Invoice) (*entity.Invoice, error)
+	// Review saves the approval or rejection of an invoice awaiting approval.
+	// It returns domain.ErrNotFound if the invoice is no longer awaiting approval.
+	Review(ctx context.Context, invoice *entity.Invoice) (*entity.Invoice, error)
 	UpdateStatus(
 		ctx context.Context,
 		id int64,
@@ -0,0 +1,17 @@
+//go:generate mockgen -source=$GOFILE -destination=mock/mock_$GOFILE -package=mock
+
+package repository
+
+import (
+	"context"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+)
+
+// MembershipRepository defines the interface for company membership data access.
+type MembershipRepository interface {
+	// GetByUserID returns the memberships of a user in the order they joined.
+	GetByUserID(ctx context.Context, userID int64) ([]*entity.Membership, error)
+	// Create returns ErrAlreadyExists if the user is already a member.
+	Create(ctx context.Context, membership *entity.Membership) (*entity.Membership, error)
+}
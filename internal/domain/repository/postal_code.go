@@ -0,0 +1,22 @@
+//go:generate mockgen -source=$GOFILE -destination=mock/mock_$GOFILE -package=mock
+
+package repository
+
+import (
+	"context"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+)
+
+// PostalCodeRepository defines the interface for postal code data access.
+type PostalCodeRepository interface {
+	// GetByCode returns the areas of a postal code given as 7 digits without a
+	// hyphen, in dataset order.
+	GetByCode(ctx context.Context, code string) ([]*entity.PostalCode, error)
+	// Exists reports whether a postal code given as 7 digits is in the dataset.
+	Exists(ctx context.Context, code string) (bool, error)
+	// Count returns the number of areas in the dataset.
+	Count(ctx context.Context) (int64, error)
+	// ReplaceAll replaces the whole dataset and returns the number of areas saved.
+	ReplaceAll(ctx context.Context, codes []*entity.PostalCode) (int64, error)
+}
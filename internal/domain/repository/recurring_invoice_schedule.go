@@ -0,0 +1,31 @@
+//go:generate mockgen -source=$GOFILE -destination=mock/mock_$GOFILE -package=mock
+
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+)
+
+// RecurringInvoiceScheduleRepository defines the interface for recurring invoice schedule data access.
+type RecurringInvoiceScheduleRepository interface {
+	GetByIDAndCompanyID(
+		ctx context.Context,
+		id, companyID int64,
+	) (*entity.RecurringInvoiceSchedule, error)
+	GetByCompanyID(ctx context.Context, companyID int64) ([]*entity.RecurringInvoiceSchedule, error)
+	// GetDue returns active schedules whose next due date is on or before until.
+	GetDue(ctx context.Context, until time.Time) ([]*entity.RecurringInvoiceSchedule, error)
+	Create(
+		ctx context.Context,
+		schedule *entity.RecurringInvoiceSchedule,
+	) (*entity.RecurringInvoiceSchedule, error)
+	Update(
+		ctx context.Context,
+		schedule *entity.RecurringInvoiceSchedule,
+	) (*entity.RecurringInvoiceSchedule, error)
+	UpdateNextDueDate(ctx context.Context, id int64, nextDueDate time.Time) error
+	Delete(ctx context.Context, id, companyID int64) error
+}
@@ -0,0 +1,27 @@
+//go:generate mockgen -source=$GOFILE -destination=mock/mock_$GOFILE -package=mock
+
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+)
+
+// RefreshTokenRepository defines the interface for refresh token data access.
+type RefreshTokenRepository interface {
+	// GetByTokenHash returns domain.ErrNotFound if no refresh token has the hash.
+	GetByTokenHash(ctx context.Context, tokenHash string) (*entity.RefreshToken, error)
+	Create(ctx context.Context, token *entity.RefreshToken) (*entity.RefreshToken, error)
+	// MarkRotated returns domain.ErrInvalidState if the token has already been
+	// rotated or revoked.
+	MarkRotated(ctx context.Context, id int64, rotatedAt time.Time) (*entity.RefreshToken, error)
+	// RevokeFamily revokes every token of the family that is not revoked yet.
+	RevokeFamily(ctx context.Context, familyID string, revokedAt time.Time) error
+	// RevokeByUserID revokes every token of the user that is not revoked yet.
+	RevokeByUserID(ctx context.Context, userID int64, revokedAt time.Time) error
+	// DeleteExpired deletes the tokens that expired before the time and returns
+	// how many were deleted.
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
@@ -0,0 +1,24 @@
+//go:generate mockgen -source=$GOFILE -destination=mock/mock_$GOFILE -package=mock
+
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// TokenRevocationRepository defines the interface for the store of revoked
+// access tokens.
+type TokenRevocationRepository interface {
+	// Revoke revokes the token with the ID. The token only has to be
+	// remembered until it expires.
+	Revoke(ctx context.Context, tokenID string, userID int64, expiresAt time.Time) error
+	// RevokeUser revokes every token issued to the user before revokedBefore.
+	RevokeUser(ctx context.Context, userID int64, revokedBefore time.Time) error
+	// IsRevoked reports whether the token with the ID, issued to the user at
+	// issuedAt, has been revoked.
+	IsRevoked(ctx context.Context, tokenID string, userID int64, issuedAt time.Time) (bool, error)
+	// DeleteExpired forgets the revoked tokens that expired before the time and
+	// returns how many were deleted.
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
@@ -0,0 +1,13 @@
+//go:generate mockgen -source=$GOFILE -destination=mock/mock_$GOFILE -package=mock
+
+package repository
+
+import "context"
+
+// Transactor runs a unit of work in a single database transaction.
+type Transactor interface {
+	// WithinTransaction runs fn in a transaction. Repository calls made with the
+	// context passed to fn take part in the transaction, which is committed if fn
+	// returns nil and rolled back otherwise.
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -8,13 +8,21 @@ import (
 	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
 )
 
-// UserRepository defines the interface for user data access.
+// UserRepository defines the interface for user data access. Users are read
+// through their membership of a company.
 type UserRepository interface {
-	GetByID(ctx context.Context, id int64) (*entity.User, error)
+	// GetByIDAndCompanyID returns ErrNotFound if the user is not a member of the
+	// company.
+	GetByIDAndCompanyID(ctx context.Context, id, companyID int64) (*entity.User, error)
+	// GetByEmail returns the user in the company they joined first.
 	GetByEmail(ctx context.Context, email string) (*entity.User, error)
 	GetByCompanyID(ctx context.Context, companyID int64) ([]*entity.User, error)
+	// Create creates the user as a member of user.CompanyID with user.Role.
 	Create(ctx context.Context, user *entity.User) (*entity.User, error)
 	Update(ctx context.Context, user *entity.User) (*entity.User, error)
 	UpdatePassword(ctx context.Context, id int64, passwordHash string) error
 	ExistsByEmail(ctx context.Context, email string) (bool, error)
+	// LockByID makes changes to the user, including adding them to a company,
+	// wait until the transaction carried by ctx ends.
+	LockByID(ctx context.Context, id int64) error
 }
@@ -0,0 +1,48 @@
+//go:generate mockgen -source=$GOFILE -destination=mock/mock_$GOFILE -package=mock
+
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+)
+
+// UserMFARepository defines the interface for two-factor authentication data
+// access. Recovery codes are stored only as hashes.
+type UserMFARepository interface {
+	// GetByUserID returns domain.ErrNotFound if the user has not enrolled.
+	GetByUserID(ctx context.Context, userID int64) (*entity.UserMFA, error)
+	// StartEnrollment stores a pending enrolment with the secret, replacing a
+	// pending one. It returns domain.ErrAlreadyExists if the user's two-factor
+	// authentication is enabled.
+	StartEnrollment(ctx context.Context, userID int64, secret string) (*entity.UserMFA, error)
+	// Enable confirms a pending enrolment with the code of the time step. It
+	// returns domain.ErrInvalidState if there is no pending enrolment.
+	Enable(ctx context.Context, userID, step int64, enabledAt time.Time) error
+	// UseStep records that a code of the time step was accepted and clears the
+	// failed attempts. It returns domain.ErrInvalidState if a code of the step
+	// or a later one has already been accepted.
+	UseStep(ctx context.Context, userID, step int64) error
+	// ReserveAttempt counts an attempt as failed before its code is checked
+	// and returns the updated two-factor authentication. Reaching maxAttempts
+	// locks verification until lockedUntil. It returns domain.ErrInvalidState
+	// if verification is locked at now or the enrolment is not enabled.
+	ReserveAttempt(
+		ctx context.Context,
+		userID int64,
+		now time.Time,
+		maxAttempts int,
+		lockedUntil time.Time,
+	) (*entity.UserMFA, error)
+	// ClearFailures resets the failed attempts and the lock.
+	ClearFailures(ctx context.Context, userID int64) error
+	// Delete removes the user's two-factor authentication and recovery codes.
+	Delete(ctx context.Context, userID int64) error
+	// ReplaceRecoveryCodes replaces the user's recovery codes with codeHashes.
+	ReplaceRecoveryCodes(ctx context.Context, userID int64, codeHashes []string) error
+	// UseRecoveryCode marks the recovery code as used. It returns
+	// domain.ErrNotFound if the user has no unused code with the hash.
+	UseRecoveryCode(ctx context.Context, userID int64, codeHash string, usedAt time.Time) error
+}
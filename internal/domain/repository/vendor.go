@@ -8,13 +8,44 @@ import (
 	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
 )
 
+// VendorFilter narrows down a vendor search.
+type VendorFilter struct {
+	CompanyID int64
+	Name      string // 部分一致 (空文字=指定なし)
+	// NameKana is also matched against the kana reading, so a vendor matches if
+	// either its name contains Name or its reading contains NameKana.
+	NameKana string
+	// IncludeArchived also returns archived vendors.
+	IncludeArchived bool
+	Limit           int
+	Offset          int
+}
+
 // VendorRepository defines the interface for vendor data access.
 type VendorRepository interface {
 	GetByID(ctx context.Context, id int64) (*entity.Vendor, error)
 	GetByIDAndCompanyID(ctx context.Context, id, companyID int64) (*entity.Vendor, error)
 	GetByCompanyID(ctx context.Context, companyID int64) ([]*entity.Vendor, error)
+	// GetByExternalCode returns the company's vendor with the external code. It
+	// returns domain.ErrNotFound if there is none.
+	GetByExternalCode(
+		ctx context.Context,
+		companyID int64,
+		externalCode string,
+	) (*entity.Vendor, error)
+	// Search returns a page of the vendors matching the filter, ordered by kana
+	// reading. Vendors without a reading come last, ordered by name.
+	Search(ctx context.Context, filter *VendorFilter) ([]*entity.Vendor, error)
+	// Count returns the number of vendors matching the filter, ignoring Limit and Offset.
+	Count(ctx context.Context, filter *VendorFilter) (int64, error)
+	// Create creates a vendor. It returns domain.ErrAlreadyExists if another
+	// vendor of the company has the same external code.
 	Create(ctx context.Context, vendor *entity.Vendor) (*entity.Vendor, error)
+	// Update replaces a vendor. It returns domain.ErrAlreadyExists if another
+	// vendor of the company has the same external code.
 	Update(ctx context.Context, vendor *entity.Vendor) (*entity.Vendor, error)
+	// UpdateArchivedAt saves whether the vendor is archived.
+	UpdateArchivedAt(ctx context.Context, vendor *entity.Vendor) (*entity.Vendor, error)
 }
 
 // VendorBankAccountRepository defines the interface for vendor bank account data access.
@@ -22,6 +53,9 @@ type VendorBankAccountRepository interface {
 	GetByID(ctx context.Context, id int64) (*entity.VendorBankAccount, error)
 	GetByIDAndVendorID(ctx context.Context, id, vendorID int64) (*entity.VendorBankAccount, error)
 	GetByVendorID(ctx context.Context, vendorID int64) ([]*entity.VendorBankAccount, error)
+	// GetDefaultByVendorID returns the vendor's default bank account. It returns
+	// domain.ErrNotFound if the vendor has none.
+	GetDefaultByVendorID(ctx context.Context, vendorID int64) (*entity.VendorBankAccount, error)
 	Create(
 		ctx context.Context,
 		account *entity.VendorBankAccount,
@@ -30,4 +64,22 @@ type VendorBankAccountRepository interface {
 		ctx context.Context,
 		account *entity.VendorBankAccount,
 	) (*entity.VendorBankAccount, error)
+	// Verify saves the confirmation of a bank account pending verification.
+	// It returns domain.ErrInvalidState if the account is no longer pending
+	// verification or has been changed since it was read.
+	Verify(
+		ctx context.Context,
+		account *entity.VendorBankAccount,
+	) (*entity.VendorBankAccount, error)
+	// UpdateArchivedAt saves whether the account is archived, along with its
+	// default flag.
+	UpdateArchivedAt(
+		ctx context.Context,
+		account *entity.VendorBankAccount,
+	) (*entity.VendorBankAccount, error)
+	// SetDefault makes the bank account the vendor's only default account.
+	SetDefault(ctx context.Context, id, vendorID int64) error
+	// Delete deletes a bank account of the vendor. It returns domain.ErrInUse if
+	// invoices or recurring invoice schedules still refer to the account.
+	Delete(ctx context.Context, id, vendorID int64) error
 }
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+// WithholdingTaxCalculator provides withholding tax (源泉徴収税) calculation logic
+// for fees paid to individuals such as design or writing fees.
+type WithholdingTaxCalculator struct {
+	rate       decimal.Decimal
+	higherRate decimal.Decimal
+	threshold  int64
+}
+
+// NewWithholdingTaxCalculator creates a new WithholdingTaxCalculator with default rates.
+func NewWithholdingTaxCalculator() *WithholdingTaxCalculator {
+	return &WithholdingTaxCalculator{
+		rate:       decimal.RequireFromString(domain.WithholdingTaxRateStr),
+		higherRate: decimal.RequireFromString(domain.WithholdingTaxHigherRateStr),
+		threshold:  domain.WithholdingTaxThreshold,
+	}
+}
+
+// WithholdingResult holds the result of withholding tax calculation.
+type WithholdingResult struct {
+	PaymentAmount  int64 // 支払金額
+	WithholdingTax int64 // 源泉徴収税額
+	TransferAmount int64 // 振込金額 (payment_amount - withholding_tax)
+}
+
+// Calculate calculates the withholding tax on a payment amount.
+// Formula:
+//
+//	payment <= 1,000,000: tax = payment * 10.21%
+//	payment >  1,000,000: tax = (payment - 1,000,000) * 20.42% + 102,100
+//
+// Example: payment=1500000
+//
+//	tax = 500000 * 0.2042 + 1000000 * 0.1021 = 102100 + 102100 = 204200
+//	transfer = 1500000 - 204200 = 1295800
+func (c *WithholdingTaxCalculator) Calculate(paymentAmount int64) *WithholdingResult {
+	payment := decimal.NewFromInt(paymentAmount)
+	threshold := decimal.NewFromInt(c.threshold)
+
+	var tax decimal.Decimal
+	if payment.LessThanOrEqual(threshold) {
+		tax = payment.Mul(c.rate)
+	} else {
+		tax = payment.Sub(threshold).Mul(c.higherRate).Add(threshold.Mul(c.rate))
+	}
+
+	// Fractions of less than one yen are truncated
+	tax = tax.Truncate(0)
+
+	return &WithholdingResult{
+		PaymentAmount:  paymentAmount,
+		WithholdingTax: tax.IntPart(),
+		TransferAmount: payment.Sub(tax).IntPart(),
+	}
+}
+
+// NoWithholding returns a result without withholding for vendors it does not apply to.
+func NoWithholding(paymentAmount int64) *WithholdingResult {
+	return &WithholdingResult{
+		PaymentAmount:  paymentAmount,
+		WithholdingTax: 0,
+		TransferAmount: paymentAmount,
+	}
+}
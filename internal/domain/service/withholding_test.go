@@ -0,0 +1,80 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithholdingTaxCalculator_Calculate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		want *service.WithholdingResult
+	}{
+		{
+			name: "standard rate",
+			want: &service.WithholdingResult{
+				PaymentAmount:  100000,
+				WithholdingTax: 10210, // 100000 * 0.1021 = 10210
+				TransferAmount: 89790,
+			},
+		},
+		{
+			name: "standard rate with truncation",
+			want: &service.WithholdingResult{
+				PaymentAmount:  12345,
+				WithholdingTax: 1260, // 12345 * 0.1021 = 1260.4245 -> truncate to 1260
+				TransferAmount: 11085,
+			},
+		},
+		{
+			name: "exactly at threshold",
+			want: &service.WithholdingResult{
+				PaymentAmount:  1000000,
+				WithholdingTax: 102100, // 1000000 * 0.1021 = 102100
+				TransferAmount: 897900,
+			},
+		},
+		{
+			name: "above threshold",
+			want: &service.WithholdingResult{
+				PaymentAmount:  1500000,
+				WithholdingTax: 204200, // 500000 * 0.2042 + 102100 = 204200
+				TransferAmount: 1295800,
+			},
+		},
+		{
+			name: "above threshold with truncation",
+			want: &service.WithholdingResult{
+				PaymentAmount:  1000001,
+				WithholdingTax: 102100, // 1 * 0.2042 + 102100 = 102100.2042 -> truncate to 102100
+				TransferAmount: 897901,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			calc := service.NewWithholdingTaxCalculator()
+			result := calc.Calculate(tt.want.PaymentAmount)
+
+			assert.Equal(t, tt.want, result)
+		})
+	}
+}
+
+func TestNoWithholding(t *testing.T) {
+	t.Parallel()
+
+	want := &service.WithholdingResult{
+		PaymentAmount:  10000,
+		WithholdingTax: 0,
+		TransferAmount: 10000,
+	}
+	assert.Equal(t, want, service.NoWithholding(10000))
+}
@@ -0,0 +1,57 @@
+package valueobject
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/harusys/super-shiharai-kun/pkg/textutil"
+)
+
+// maxKanaLength is the maximum number of characters of a kana reading.
+const maxKanaLength = 255
+
+// ErrInvalidKana is returned when a kana reading contains characters other than
+// katakana.
+var ErrInvalidKana = fmt.Errorf("%w: kana reading must be katakana", domain.ErrInvalidInput)
+
+// Kana is the reading of a name (フリガナ) in full-width katakana, e.g.
+// "カブシキガイシャテスト". Lists are sorted by it.
+type Kana string
+
+// NewKana parses and validates a kana reading. Hiragana and half-width katakana
+// are converted to full-width katakana, so "かぶしきがいしゃ" and "ｶﾌﾞｼｷｶﾞｲｼｬ" are
+// both accepted. ASCII letters and digits are allowed for names such as "ABC".
+func NewKana(s string) (Kana, error) {
+	kana := textutil.ToKatakana(textutil.NormalizeName(s))
+
+	if kana == "" || utf8.RuneCountInString(kana) > maxKanaLength {
+		return "", ErrInvalidKana
+	}
+
+	for _, r := range kana {
+		if !isKanaRune(r) {
+			return "", ErrInvalidKana
+		}
+	}
+
+	return Kana(kana), nil
+}
+
+// String returns the kana reading.
+func (k Kana) String() string {
+	return string(k)
+}
+
+// isKanaRune reports whether r may appear in a kana reading: katakana, the
+// prolonged sound mark and middle dot, ASCII letters and digits, and spaces.
+func isKanaRune(r rune) bool {
+	switch {
+	case r >= 'ァ' && r <= 'ヺ', r == 'ー', r == '・', r == ' ':
+		return true
+	case r >= 'A' && r <= 'Z', r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+		return true
+	default:
+		return false
+	}
+}
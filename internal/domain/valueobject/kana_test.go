@@ -0,0 +1,49 @@
+package valueobject_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/harusys/super-shiharai-kun/internal/domain/valueobject"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewKana(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   string
+		want    valueobject.Kana
+		wantErr bool
+	}{
+		{name: "katakana", input: "カブシキガイシャテスト", want: "カブシキガイシャテスト"},
+		{name: "hiragana", input: "かぶしきがいしゃてすと", want: "カブシキガイシャテスト"},
+		{name: "half-width katakana", input: "ｶﾌﾞｼｷｶﾞｲｼｬﾃｽﾄ", want: "カブシキガイシャテスト"},
+		{name: "prolonged sound mark and spaces", input: "　スーパー　ショウジ ", want: "スーパー ショウジ"},
+		{name: "full-width alphanumerics", input: "ＡＢＣショウジ", want: "ABCショウジ"},
+		{name: "kanji", input: "株式会社テスト", wantErr: true},
+		{name: "symbols", input: "テスト(カ)", wantErr: true},
+		{name: "too long", input: strings.Repeat("ア", 256), wantErr: true},
+		{name: "blank", input: "　", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := valueobject.NewKana(tt.input)
+
+			if tt.wantErr {
+				require.ErrorIs(t, err, domain.ErrInvalidInput)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
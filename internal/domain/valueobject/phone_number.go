@@ -0,0 +1,139 @@
+package valueobject
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+)
+
+const (
+	// japanCountryCode is the E.164 country calling code of Japan.
+	japanCountryCode = "81"
+	// maxE164Digits is the maximum number of digits of an E.164 number.
+	maxE164Digits = 15
+	// minE164Digits is the shortest number we accept; shorter ones are typos.
+	minE164Digits = 8
+	// landlineDigits is the number of digits of a Japanese landline or
+	// free-dial number in domestic format, e.g. "0312345678".
+	landlineDigits = 10
+	// mobileDigits is the number of digits of a Japanese mobile or IP phone
+	// number in domestic format, e.g. "09012345678".
+	mobileDigits = 11
+)
+
+// ErrInvalidPhoneNumber is returned when a phone number is malformed.
+var ErrInvalidPhoneNumber = fmt.Errorf("%w: invalid phone number", domain.ErrInvalidInput)
+
+// PhoneNumber is a phone number stored in E.164 format, e.g. "+81312345678".
+type PhoneNumber string
+
+// NewPhoneNumber parses and validates a phone number. Japanese domestic
+// numbers such as "03-1234-5678" or "（０９０）１２３４－５６７８" are converted to
+// E.164; numbers with a "+" country code are accepted as they are, ignoring
+// the trunk prefix in "+81 (0)3-1234-5678".
+func NewPhoneNumber(s string) (PhoneNumber, error) {
+	normalized := stripPhoneSeparators(foldWidth(s))
+
+	if digits, ok := strings.CutPrefix(normalized, "+"); ok {
+		if national, ok := strings.CutPrefix(digits, japanCountryCode+"0"); ok {
+			digits = japanCountryCode + national
+		}
+
+		if len(digits) < minE164Digits || len(digits) > maxE164Digits ||
+			!isDigits(digits) || digits[0] == '0' {
+			return "", ErrInvalidPhoneNumber
+		}
+
+		return PhoneNumber("+" + digits), nil
+	}
+
+	if !isDomesticNumber(normalized) {
+		return "", ErrInvalidPhoneNumber
+	}
+
+	return PhoneNumber("+" + japanCountryCode + normalized[1:]), nil
+}
+
+// stripPhoneSeparators removes the spaces, hyphens, parentheses and dots used to
+// group the digits of a phone number.
+func stripPhoneSeparators(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '　', '-', '(', ')', '（', '）', '.':
+			return -1
+		case '＋':
+			return '+'
+		default:
+			return r
+		}
+	}, s)
+}
+
+// isDomesticNumber reports whether s is a Japanese phone number in domestic
+// format: a trunk prefix "0" followed by a non-zero digit, 10 digits in total,
+// or 11 digits for mobile, IP and 0800 free-dial numbers.
+func isDomesticNumber(s string) bool {
+	if !isDigits(s) || len(s) < 2 || s[0] != '0' || s[1] == '0' {
+		return false
+	}
+
+	switch len(s) {
+	case landlineDigits:
+		return true
+	case mobileDigits:
+		return hasAnyPrefix(s, "020", "050", "060", "070", "080", "090", "0800")
+	default:
+		return false
+	}
+}
+
+// String returns the phone number in E.164 format.
+func (p PhoneNumber) String() string {
+	return string(p)
+}
+
+// Display returns the phone number for display. Japanese numbers are written
+// in domestic format with hyphens, e.g. "03-1234-5678"; other numbers are
+// returned in E.164 format.
+//
+// Area codes are not looked up, so landline numbers outside Tokyo and Osaka are
+// split as "0XX-XXX-XXXX" even where the area code is longer.
+func (p PhoneNumber) Display() string {
+	national, ok := strings.CutPrefix(string(p), "+"+japanCountryCode)
+	if !ok {
+		return string(p)
+	}
+
+	national = "0" + national
+
+	switch {
+	case len(national) == mobileDigits && strings.HasPrefix(national, "0800"):
+		return hyphenate(national, 4, 3) //nolint:mnd // 0800-XXX-XXXX
+	case len(national) == mobileDigits:
+		return hyphenate(national, 3, 4) //nolint:mnd // 090-XXXX-XXXX
+	case len(national) != landlineDigits:
+		return string(p)
+	case hasAnyPrefix(national, "0120", "0570", "0990"):
+		return hyphenate(national, 4, 3) //nolint:mnd // 0120-XXX-XXX
+	case hasAnyPrefix(national, "03", "06"):
+		return hyphenate(national, 2, 4) //nolint:mnd // 03-XXXX-XXXX
+	default:
+		return hyphenate(national, 3, 3) //nolint:mnd // 0XX-XXX-XXXX
+	}
+}
+
+// hyphenate splits s into a first group of n1 digits, a second of n2 and the rest.
+func hyphenate(s string, n1, n2 int) string {
+	return s[:n1] + "-" + s[n1:n1+n2] + "-" + s[n1+n2:]
+}
+
+func hasAnyPrefix(s string, prefixes ...string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
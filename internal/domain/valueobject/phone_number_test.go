@@ -0,0 +1,84 @@
+package valueobject_test
+
+import (
+	"testing"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/harusys/super-shiharai-kun/internal/domain/valueobject"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPhoneNumber(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   string
+		want    valueobject.PhoneNumber
+		wantErr bool
+	}{
+		{name: "E.164", input: "+81312345678", want: "+81312345678"},
+		{name: "Tokyo landline", input: "03-1234-5678", want: "+81312345678"},
+		{name: "landline with parentheses", input: "045(123)4567", want: "+81451234567"},
+		{name: "full-width mobile", input: "０９０－１２３４－５６７８", want: "+819012345678"},
+		{name: "free dial", input: "0120-123-456", want: "+81120123456"},
+		{name: "0800 free dial", input: "0800-123-4567", want: "+818001234567"},
+		{
+			name:  "international with trunk prefix",
+			input: "+81 (0)3-1234-5678",
+			want:  "+81312345678",
+		},
+		{name: "full-width plus", input: "＋８１ ３ １２３４ ５６７８", want: "+81312345678"},
+		{name: "foreign number", input: "+1 415-555-0100", want: "+14155550100"},
+		{name: "missing trunk prefix", input: "312345678", wantErr: true},
+		{name: "too short", input: "03-1234-567", wantErr: true},
+		{name: "eleven-digit landline", input: "03-1234-56789", wantErr: true},
+		{name: "international call prefix", input: "010-1-415-555-0100", wantErr: true},
+		{name: "letters", input: "03-1234-ABCD", wantErr: true},
+		{name: "too long", input: "+1234567890123456", wantErr: true},
+		{name: "empty", input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := valueobject.NewPhoneNumber(tt.input)
+
+			if tt.wantErr {
+				require.ErrorIs(t, err, domain.ErrInvalidInput)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPhoneNumber_Display(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		phone valueobject.PhoneNumber
+		want  string
+	}{
+		{phone: "+81312345678", want: "03-1234-5678"},
+		{phone: "+81612345678", want: "06-1234-5678"},
+		{phone: "+81451234567", want: "045-123-4567"},
+		{phone: "+819012345678", want: "090-1234-5678"},
+		{phone: "+81120123456", want: "0120-123-456"},
+		{phone: "+818001234567", want: "0800-123-4567"},
+		{phone: "+14155550100", want: "+14155550100"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.phone), func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, tt.phone.Display())
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package valueobject
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+)
+
+const (
+	// registrationNumberPrefix is the fixed prefix of a qualified invoice issuer number.
+	registrationNumberPrefix = "T"
+	// registrationNumberDigits is the number of digits following the prefix.
+	registrationNumberDigits = 13
+	// checkDigitModulus is the modulus used by the corporate number check digit.
+	checkDigitModulus = 9
+)
+
+// ErrInvalidRegistrationNumber is returned when a registration number is malformed.
+var ErrInvalidRegistrationNumber = fmt.Errorf(
+	"%w: invalid registration number",
+	domain.ErrInvalidInput,
+)
+
+// RegistrationNumber is a qualified invoice issuer registration number
+// (適格請求書発行事業者登録番号), e.g. "T1234567890123".
+type RegistrationNumber string
+
+// NewRegistrationNumber parses and validates a registration number.
+// Surrounding whitespace and hyphens are ignored and a lower-case prefix is accepted.
+func NewRegistrationNumber(s string) (RegistrationNumber, error) {
+	normalized := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(s), "-", ""))
+
+	digits, ok := strings.CutPrefix(normalized, registrationNumberPrefix)
+	if !ok || len(digits) != registrationNumberDigits {
+		return "", ErrInvalidRegistrationNumber
+	}
+
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return "", ErrInvalidRegistrationNumber
+		}
+	}
+
+	if !validCheckDigit(digits) {
+		return "", ErrInvalidRegistrationNumber
+	}
+
+	return RegistrationNumber(normalized), nil
+}
+
+// String returns the registration number including the "T" prefix.
+func (n RegistrationNumber) String() string {
+	return string(n)
+}
+
+// validCheckDigit verifies the leading check digit of a 13-digit number.
+// The algorithm is the one defined for corporate numbers (法人番号):
+//
+//	check = 9 - (Σ Pn × Qn mod 9)
+//
+// where Pn is the n-th digit counted from the right of the remaining 12 digits
+// and Qn is 1 for odd n and 2 for even n.
+func validCheckDigit(digits string) bool {
+	sum := 0
+
+	for n := 1; n < len(digits); n++ {
+		p := int(digits[len(digits)-n] - '0')
+
+		q := 1
+		if n%2 == 0 {
+			q = 2
+		}
+
+		sum += p * q
+	}
+
+	want := checkDigitModulus - sum%checkDigitModulus
+
+	return int(digits[0]-'0') == want
+}
@@ -0,0 +1,75 @@
+package valueobject_test
+
+import (
+	"testing"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/harusys/super-shiharai-kun/internal/domain/valueobject"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRegistrationNumber(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   string
+		want    valueobject.RegistrationNumber
+		wantErr bool
+	}{
+		{
+			name:  "valid",
+			input: "T7000012050002",
+			want:  "T7000012050002",
+		},
+		{
+			name:  "lower-case prefix with hyphens and spaces",
+			input: " t5-0104-0108-9998 ",
+			want:  "T5010401089998",
+		},
+		{
+			name:    "wrong check digit",
+			input:   "T1000012050002",
+			wantErr: true,
+		},
+		{
+			name:    "missing prefix",
+			input:   "7000012050002",
+			wantErr: true,
+		},
+		{
+			name:    "too short",
+			input:   "T700001205000",
+			wantErr: true,
+		},
+		{
+			name:    "non-digit",
+			input:   "T70000120500A2",
+			wantErr: true,
+		},
+		{
+			name:    "empty",
+			input:   "",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := valueobject.NewRegistrationNumber(tt.input)
+
+			if tt.wantErr {
+				require.ErrorIs(t, err, valueobject.ErrInvalidRegistrationNumber)
+				require.ErrorIs(t, err, domain.ErrInvalidInput)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
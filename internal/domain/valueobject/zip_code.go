@@ -0,0 +1,84 @@
+package valueobject
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+)
+
+const (
+	// zipCodeDigits is the number of digits of a Japanese postal code.
+	zipCodeDigits = 7
+	// zipCodeHyphenIndex is where the hyphen goes in the display format.
+	zipCodeHyphenIndex = 3
+	// zipCodeMark is the postal mark that often precedes a written postal code.
+	zipCodeMark = "〒"
+)
+
+// ErrInvalidZipCode is returned when a postal code is malformed.
+var ErrInvalidZipCode = fmt.Errorf("%w: invalid zip code", domain.ErrInvalidInput)
+
+// ZipCode is a Japanese postal code (郵便番号), stored in the "100-0001" format.
+type ZipCode string
+
+// NewZipCode parses and validates a postal code. Full-width digits, a leading
+// postal mark and the hyphen are optional, e.g. "〒１００－０００１" and "1000001"
+// are both accepted.
+func NewZipCode(s string) (ZipCode, error) {
+	normalized := strings.TrimSpace(
+		strings.TrimPrefix(strings.TrimSpace(foldWidth(s)), zipCodeMark),
+	)
+	digits := strings.ReplaceAll(normalized, "-", "")
+
+	if len(digits) != zipCodeDigits || !isDigits(digits) {
+		return "", ErrInvalidZipCode
+	}
+
+	zipCode := digits[:zipCodeHyphenIndex] + "-" + digits[zipCodeHyphenIndex:]
+	if normalized != digits && normalized != zipCode {
+		return "", ErrInvalidZipCode
+	}
+
+	return ZipCode(zipCode), nil
+}
+
+// String returns the postal code in the "100-0001" format.
+func (z ZipCode) String() string {
+	return string(z)
+}
+
+// Digits returns the postal code without the hyphen, e.g. "1000001".
+func (z ZipCode) Digits() string {
+	return strings.ReplaceAll(string(z), "-", "")
+}
+
+// foldWidth converts full-width digits to ASCII and the dashes commonly typed
+// in Japanese text (－, ー, ‐, −) to "-".
+func foldWidth(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= '０' && r <= '９':
+			return '0' + (r - '０')
+		case r == '－' || r == 'ー' || r == '‐' || r == '−':
+			return '-'
+		default:
+			return r
+		}
+	}, s)
+}
+
+// isDigits reports whether s is a non-empty string of ASCII digits.
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
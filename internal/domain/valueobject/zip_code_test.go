@@ -0,0 +1,48 @@
+package valueobject_test
+
+import (
+	"testing"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/harusys/super-shiharai-kun/internal/domain/valueobject"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewZipCode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   string
+		want    valueobject.ZipCode
+		wantErr bool
+	}{
+		{name: "hyphenated", input: "100-0001", want: "100-0001"},
+		{name: "digits only", input: "1000001", want: "100-0001"},
+		{name: "full-width with postal mark", input: "〒１００－０００１", want: "100-0001"},
+		{name: "postal mark and spaces", input: " 〒 100-0001 ", want: "100-0001"},
+		{name: "hyphen in the wrong place", input: "1000-001", wantErr: true},
+		{name: "too short", input: "100-001", wantErr: true},
+		{name: "non-digit", input: "100-000A", wantErr: true},
+		{name: "empty", input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := valueobject.NewZipCode(tt.input)
+
+			if tt.wantErr {
+				require.ErrorIs(t, err, domain.ErrInvalidInput)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, "1000001", got.Digits())
+		})
+	}
+}
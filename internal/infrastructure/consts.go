@@ -10,6 +10,17 @@ const (
 	RefreshTokenExpiry = 7 * 24 * time.Hour
 	// BcryptCost is the cost parameter for bcrypt hashing.
 	BcryptCost = 12
+	// MFATokenExpiry is how long a login has to give its second factor.
+	MFATokenExpiry = 5 * time.Minute
+	// MFAIssuer is the name authenticator apps show for the account.
+	MFAIssuer = "スーパー支払い君.com"
+	// MFAMaxFailedAttempts is the number of wrong codes in a row after which
+	// verification is locked for MFALockDuration.
+	MFAMaxFailedAttempts = 5
+	// MFALockDuration is how long verification is locked.
+	MFALockDuration = 15 * time.Minute
+	// MFARecoveryCodeCount is the number of recovery codes issued at enrolment.
+	MFARecoveryCodeCount = 10
 )
 
 // Database connection pool constants.
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/harusys/super-shiharai-kun/internal/infrastructure"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -19,6 +20,16 @@ func NewPool(ctx context.Context, databaseURL string) (*pgxpool.Pool, error) {
 	config.MaxConns = infrastructure.DBMaxConns
 	config.MinConns = infrastructure.DBMinConns
 
+	// Row-level security reads the tenant from session settings, which are set
+	// on every acquire so that a connection never keeps a previous tenant.
+	config.PrepareConn = func(ctx context.Context, conn *pgx.Conn) (bool, error) {
+		if err := setTenant(ctx, conn); err != nil {
+			return false, err
+		}
+
+		return true, nil
+	}
+
 	pool, err := pgxpool.NewWithConfig(ctx, config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create connection pool: %w", err)
@@ -0,0 +1,74 @@
+package database
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// tenantKey is the context key of the tenant the database is accessed for.
+type tenantKey struct{}
+
+// tenant selects the rows visible through row-level security.
+type tenant struct {
+	companyID    int64
+	allCompanies bool
+	userID       int64
+}
+
+// WithCompanyID returns a context whose queries only see and write rows of the
+// company, whatever their WHERE clauses say.
+func WithCompanyID(ctx context.Context, companyID int64) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant{companyID: companyID})
+}
+
+// WithAllCompanies returns a context whose queries see rows of every company.
+// It is only for work not done on behalf of a company, such as batch jobs and
+// lookups by a secret token.
+func WithAllCompanies(ctx context.Context) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant{allCompanies: true})
+}
+
+// WithUserID returns a context whose queries also see the user's own rows of
+// tables that belong to users rather than companies, such as their memberships
+// of other companies. The company of ctx stays as it is.
+func WithUserID(ctx context.Context, userID int64) context.Context {
+	t, _ := ctx.Value(tenantKey{}).(tenant)
+	t.userID = userID
+
+	return context.WithValue(ctx, tenantKey{}, t)
+}
+
+// setTenant applies the tenant of ctx to the session of conn. Queries without
+// a tenant see no company-scoped rows at all.
+func setTenant(ctx context.Context, conn *pgx.Conn) error {
+	t, _ := ctx.Value(tenantKey{}).(tenant)
+
+	companyID := ""
+	if t.companyID != 0 {
+		companyID = strconv.FormatInt(t.companyID, 10)
+	}
+
+	allCompanies := "off"
+	if t.allCompanies {
+		allCompanies = "on"
+	}
+
+	userID := ""
+	if t.userID != 0 {
+		userID = strconv.FormatInt(t.userID, 10)
+	}
+
+	_, err := conn.Exec(
+		ctx,
+		`SELECT set_config('app.company_id', $1, false),
+			set_config('app.all_companies', $2, false),
+			set_config('app.user_id', $3, false)`,
+		companyID,
+		allCompanies,
+		userID,
+	)
+
+	return err
+}
@@ -0,0 +1,17 @@
+//go:generate mockgen -source=$GOFILE -destination=mock/mock_$GOFILE -package=mock
+
+package mail
+
+import "context"
+
+// Message is a plain text email.
+type Message struct {
+	To      []string
+	Subject string
+	Body    string
+}
+
+// Mailer sends email.
+type Mailer interface {
+	Send(ctx context.Context, msg *Message) error
+}
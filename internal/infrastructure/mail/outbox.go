@@ -0,0 +1,69 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/harusys/super-shiharai-kun/pkg/ctxutil"
+)
+
+// outboxDirPerm is the permission of the outbox directory.
+const outboxDirPerm = 0o750
+
+// FileOutbox is a Mailer that writes each message to a .eml file in a directory
+// instead of sending it. It is meant for development and for environments where
+// another process delivers the files.
+type FileOutbox struct {
+	dir  string
+	from string
+}
+
+// NewFileOutbox creates a new FileOutbox writing to dir.
+func NewFileOutbox(dir, from string) *FileOutbox {
+	return &FileOutbox{
+		dir:  dir,
+		from: from,
+	}
+}
+
+// Send writes the message to a new file in the outbox directory.
+func (o *FileOutbox) Send(ctx context.Context, msg *Message) error {
+	if err := os.MkdirAll(o.dir, outboxDirPerm); err != nil {
+		return fmt.Errorf("failed to create outbox directory: %w", err)
+	}
+
+	now := ctxutil.Now(ctx)
+
+	f, err := os.CreateTemp(o.dir, now.Format("20060102T150405")+"-*.eml")
+	if err != nil {
+		return fmt.Errorf("failed to create outbox file: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(format(o.from, msg, now)); err != nil {
+		return fmt.Errorf("failed to write outbox file: %w", err)
+	}
+
+	return f.Close()
+}
+
+// format renders the message in RFC 5322 format.
+func format(from string, msg *Message, date time.Time) string {
+	var b strings.Builder
+
+	b.WriteString("From: " + from + "\r\n")
+	b.WriteString("To: " + strings.Join(msg.To, ", ") + "\r\n")
+	b.WriteString("Subject: " + mime.BEncoding.Encode("UTF-8", msg.Subject) + "\r\n")
+	b.WriteString("Date: " + date.Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+
+	return b.String()
+}
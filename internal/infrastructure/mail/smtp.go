@@ -0,0 +1,45 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+
+	"github.com/harusys/super-shiharai-kun/pkg/ctxutil"
+)
+
+// SMTPMailer is a Mailer that sends messages through an SMTP server. The
+// connection is upgraded with STARTTLS when the server supports it.
+type SMTPMailer struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+// NewSMTPMailer creates a new SMTPMailer. Authentication is skipped if username
+// is empty.
+func NewSMTPMailer(host string, port int, username, password, from string) *SMTPMailer {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &SMTPMailer{
+		addr: net.JoinHostPort(host, strconv.Itoa(port)),
+		auth: auth,
+		from: from,
+	}
+}
+
+// Send sends the message to all of its recipients.
+func (m *SMTPMailer) Send(ctx context.Context, msg *Message) error {
+	data := format(m.from, msg, ctxutil.Now(ctx))
+
+	if err := smtp.SendMail(m.addr, m.auth, m.from, msg.To, []byte(data)); err != nil {
+		return fmt.Errorf("failed to send mail: %w", err)
+	}
+
+	return nil
+}
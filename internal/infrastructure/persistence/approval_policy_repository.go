@@ -0,0 +1,71 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+	"github.com/harusys/super-shiharai-kun/internal/domain/repository"
+	"github.com/harusys/super-shiharai-kun/internal/infrastructure/database/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type approvalPolicyRepository struct {
+	pool    *pgxpool.Pool
+	queries *sqlc.Queries
+}
+
+// NewApprovalPolicyRepository creates a new ApprovalPolicyRepository.
+func NewApprovalPolicyRepository(pool *pgxpool.Pool) repository.ApprovalPolicyRepository {
+	return &approvalPolicyRepository{
+		pool:    pool,
+		queries: sqlc.New(pool),
+	}
+}
+
+func (r *approvalPolicyRepository) GetByCompanyID(
+	ctx context.Context,
+	companyID int64,
+) (*entity.ApprovalPolicy, error) {
+	policy, err := queriesFor(ctx, r.queries).GetApprovalPolicyByCompanyID(ctx, companyID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return toApprovalPolicyEntity(&policy), nil
+}
+
+func (r *approvalPolicyRepository) Upsert(
+	ctx context.Context,
+	policy *entity.ApprovalPolicy,
+) (*entity.ApprovalPolicy, error) {
+	upserted, err := queriesFor(
+		ctx,
+		r.queries,
+	).UpsertApprovalPolicy(ctx, sqlc.UpsertApprovalPolicyParams{
+		CompanyID:            policy.CompanyID,
+		AmountThreshold:      toNullableInt64(policy.AmountThreshold),
+		RequireForNewVendors: policy.RequireForNewVendors,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toApprovalPolicyEntity(&upserted), nil
+}
+
+func toApprovalPolicyEntity(p *sqlc.ApprovalPolicy) *entity.ApprovalPolicy {
+	return &entity.ApprovalPolicy{
+		CompanyID:            p.CompanyID,
+		AmountThreshold:      fromNullableInt64(p.AmountThreshold),
+		RequireForNewVendors: p.RequireForNewVendors,
+		CreatedAt:            p.CreatedAt.Time,
+		UpdatedAt:            p.UpdatedAt.Time,
+	}
+}
@@ -0,0 +1,113 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+	"github.com/harusys/super-shiharai-kun/internal/domain/repository"
+	"github.com/harusys/super-shiharai-kun/internal/infrastructure/database/sqlc"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type companyAuditLogRepository struct {
+	pool    *pgxpool.Pool
+	queries *sqlc.Queries
+}
+
+// NewCompanyAuditLogRepository creates a new CompanyAuditLogRepository.
+func NewCompanyAuditLogRepository(pool *pgxpool.Pool) repository.CompanyAuditLogRepository {
+	return &companyAuditLogRepository{
+		pool:    pool,
+		queries: sqlc.New(pool),
+	}
+}
+
+// fieldChange is the JSON form of a change in company_audit_logs.changes.
+type fieldChange struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+func (r *companyAuditLogRepository) Create(
+	ctx context.Context,
+	log *entity.CompanyAuditLog,
+) (*entity.CompanyAuditLog, error) {
+	changes := make([]fieldChange, len(log.Changes))
+	for i, c := range log.Changes {
+		changes[i] = fieldChange{Field: c.Field, Before: c.Before, After: c.After}
+	}
+
+	data, err := json.Marshal(changes)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := queriesFor(ctx, r.queries).CreateCompanyAuditLog(
+		ctx,
+		sqlc.CreateCompanyAuditLogParams{
+			CompanyID: log.CompanyID,
+			UserID:    toNullableInt64(log.UserID),
+			Changes:   data,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return toCompanyAuditLogEntity(&created)
+}
+
+func (r *companyAuditLogRepository) GetByCompanyID(
+	ctx context.Context,
+	companyID int64,
+	limit int32,
+) ([]*entity.CompanyAuditLog, error) {
+	logs, err := queriesFor(ctx, r.queries).GetCompanyAuditLogsByCompanyID(
+		ctx,
+		sqlc.GetCompanyAuditLogsByCompanyIDParams{
+			CompanyID: companyID,
+			Limit:     limit,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*entity.CompanyAuditLog, len(logs))
+
+	for i, l := range logs {
+		result[i], err = toCompanyAuditLogEntity(&l)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func toCompanyAuditLogEntity(l *sqlc.CompanyAuditLog) (*entity.CompanyAuditLog, error) {
+	var changes []fieldChange
+	if err := json.Unmarshal(l.Changes, &changes); err != nil {
+		return nil, err
+	}
+
+	log := &entity.CompanyAuditLog{
+		ID:        l.ID,
+		CompanyID: l.CompanyID,
+		UserID:    fromNullableInt64(l.UserID),
+		Changes:   make([]*entity.CompanyFieldChange, len(changes)),
+		CreatedAt: l.CreatedAt.Time,
+	}
+
+	for i, c := range changes {
+		log.Changes[i] = &entity.CompanyFieldChange{
+			Field:  c.Field,
+			Before: c.Before,
+			After:  c.After,
+		}
+	}
+
+	return log, nil
+}
@@ -26,7 +26,7 @@ func NewCompanyRepository(pool *pgxpool.Pool) repository.CompanyRepository {
 }
 
 func (r *companyRepository) GetByID(ctx context.Context, id int64) (*entity.Company, error) {
-	company, err := r.queries.GetCompanyByID(ctx, id)
+	company, err := queriesFor(ctx, r.queries).GetCompanyByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, domain.ErrNotFound
@@ -42,12 +42,14 @@ func (r *companyRepository) Create(
 	ctx context.Context,
 	company *entity.Company,
 ) (*entity.Company, error) {
-	created, err := r.queries.CreateCompany(ctx, sqlc.CreateCompanyParams{
+	created, err := queriesFor(ctx, r.queries).CreateCompany(ctx, sqlc.CreateCompanyParams{
 		Name:               company.Name,
 		RepresentativeName: company.RepresentativeName,
 		PhoneNumber:        company.PhoneNumber,
 		ZipCode:            company.ZipCode,
 		Address:            company.Address,
+		NameKana:           toNullableString(company.NameKana),
+		MfaRequired:        company.MFARequired,
 	})
 	if err != nil {
 		return nil, err
@@ -60,13 +62,15 @@ func (r *companyRepository) Update(
 	ctx context.Context,
 	company *entity.Company,
 ) (*entity.Company, error) {
-	updated, err := r.queries.UpdateCompany(ctx, sqlc.UpdateCompanyParams{
+	updated, err := queriesFor(ctx, r.queries).UpdateCompany(ctx, sqlc.UpdateCompanyParams{
 		ID:                 company.ID,
 		Name:               company.Name,
 		RepresentativeName: company.RepresentativeName,
 		PhoneNumber:        company.PhoneNumber,
 		ZipCode:            company.ZipCode,
 		Address:            company.Address,
+		NameKana:           toNullableString(company.NameKana),
+		MfaRequired:        company.MFARequired,
 	})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -83,10 +87,12 @@ func toCompanyEntity(c *sqlc.Company) *entity.Company {
 	return &entity.Company{
 		ID:                 c.ID,
 		Name:               c.Name,
+		NameKana:           fromNullableString(c.NameKana),
 		RepresentativeName: c.RepresentativeName,
 		PhoneNumber:        c.PhoneNumber,
 		ZipCode:            c.ZipCode,
 		Address:            c.Address,
+		MFARequired:        c.MfaRequired,
 		CreatedAt:          c.CreatedAt.Time,
 		UpdatedAt:          c.UpdatedAt.Time,
 	}
@@ -0,0 +1,140 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+	"github.com/harusys/super-shiharai-kun/internal/domain/repository"
+	"github.com/harusys/super-shiharai-kun/internal/infrastructure/database/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type invitationRepository struct {
+	pool    *pgxpool.Pool
+	queries *sqlc.Queries
+}
+
+// NewInvitationRepository creates a new InvitationRepository.
+func NewInvitationRepository(pool *pgxpool.Pool) repository.InvitationRepository {
+	return &invitationRepository{
+		pool:    pool,
+		queries: sqlc.New(pool),
+	}
+}
+
+func (r *invitationRepository) GetByTokenHash(
+	ctx context.Context,
+	tokenHash string,
+) (*entity.Invitation, error) {
+	invitation, err := queriesFor(ctx, r.queries).GetInvitationByTokenHash(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return toInvitationEntity(&invitation), nil
+}
+
+func (r *invitationRepository) GetPendingByCompanyID(
+	ctx context.Context,
+	companyID int64,
+) ([]*entity.Invitation, error) {
+	invitations, err := queriesFor(ctx, r.queries).GetPendingInvitationsByCompanyID(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*entity.Invitation, len(invitations))
+	for i, inv := range invitations {
+		result[i] = toInvitationEntity(&inv)
+	}
+
+	return result, nil
+}
+
+func (r *invitationRepository) Create(
+	ctx context.Context,
+	invitation *entity.Invitation,
+) (*entity.Invitation, error) {
+	created, err := queriesFor(ctx, r.queries).CreateInvitation(ctx, sqlc.CreateInvitationParams{
+		CompanyID: invitation.CompanyID,
+		Email:     invitation.Email,
+		Role:      string(invitation.Role),
+		TokenHash: invitation.TokenHash,
+		InvitedBy: toNullableInt64(invitation.InvitedBy),
+		ExpiresAt: toNullableTimestamptz(&invitation.ExpiresAt),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toInvitationEntity(&created), nil
+}
+
+func (r *invitationRepository) MarkAccepted(
+	ctx context.Context,
+	id int64,
+	acceptedAt time.Time,
+) (*entity.Invitation, error) {
+	accepted, err := queriesFor(ctx, r.queries).AcceptInvitation(ctx, sqlc.AcceptInvitationParams{
+		ID:         id,
+		AcceptedAt: toNullableTimestamptz(&acceptedAt),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrInvalidState
+		}
+
+		return nil, err
+	}
+
+	return toInvitationEntity(&accepted), nil
+}
+
+func (r *invitationRepository) DeletePending(ctx context.Context, id, companyID int64) error {
+	rows, err := queriesFor(ctx, r.queries).DeletePendingInvitation(
+		ctx,
+		sqlc.DeletePendingInvitationParams{ID: id, CompanyID: companyID},
+	)
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *invitationRepository) DeletePendingByEmail(
+	ctx context.Context,
+	companyID int64,
+	email string,
+) error {
+	return queriesFor(ctx, r.queries).DeletePendingInvitationsByEmail(
+		ctx,
+		sqlc.DeletePendingInvitationsByEmailParams{CompanyID: companyID, Email: email},
+	)
+}
+
+func toInvitationEntity(i *sqlc.Invitation) *entity.Invitation {
+	return &entity.Invitation{
+		ID:         i.ID,
+		CompanyID:  i.CompanyID,
+		Email:      i.Email,
+		Role:       entity.UserRole(i.Role),
+		TokenHash:  i.TokenHash,
+		InvitedBy:  fromNullableInt64(i.InvitedBy),
+		ExpiresAt:  i.ExpiresAt.Time,
+		AcceptedAt: fromNullableTimestamptz(i.AcceptedAt),
+		CreatedAt:  i.CreatedAt.Time,
+	}
+}
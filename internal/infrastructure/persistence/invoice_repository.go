@@ -10,10 +10,17 @@ import (
 	"github.com/harusys/super-shiharai-kun/internal/domain/repository"
 	"github.com/harusys/super-shiharai-kun/internal/infrastructure/database/sqlc"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// PostgreSQL SQLSTATE codes for constraint violations.
+const (
+	uniqueViolationCode     = "23505"
+	foreignKeyViolationCode = "23503"
+)
+
 type invoiceRepository struct {
 	pool    *pgxpool.Pool
 	queries *sqlc.Queries
@@ -28,7 +35,7 @@ func NewInvoiceRepository(pool *pgxpool.Pool) repository.InvoiceRepository {
 }
 
 func (r *invoiceRepository) GetByID(ctx context.Context, id int64) (*entity.Invoice, error) {
-	invoice, err := r.queries.GetInvoiceByID(ctx, id)
+	invoice, err := queriesFor(ctx, r.queries).GetInvoiceByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, domain.ErrNotFound
@@ -44,7 +51,10 @@ func (r *invoiceRepository) GetByIDAndCompanyID(
 	ctx context.Context,
 	id, companyID int64,
 ) (*entity.Invoice, error) {
-	invoice, err := r.queries.GetInvoiceByIDAndCompanyID(ctx, sqlc.GetInvoiceByIDAndCompanyIDParams{
+	invoice, err := queriesFor(
+		ctx,
+		r.queries,
+	).GetInvoiceByIDAndCompanyID(ctx, sqlc.GetInvoiceByIDAndCompanyIDParams{
 		ID:        id,
 		CompanyID: companyID,
 	})
@@ -63,7 +73,7 @@ func (r *invoiceRepository) GetByCompanyID(
 	ctx context.Context,
 	companyID int64,
 ) ([]*entity.Invoice, error) {
-	invoices, err := r.queries.GetInvoicesByCompanyID(ctx, companyID)
+	invoices, err := queriesFor(ctx, r.queries).GetInvoicesByCompanyID(ctx, companyID)
 	if err != nil {
 		return nil, err
 	}
@@ -81,7 +91,7 @@ func (r *invoiceRepository) GetByCompanyIDAndDateRange(
 	companyID int64,
 	startDate, endDate time.Time,
 ) ([]*entity.Invoice, error) {
-	invoices, err := r.queries.GetInvoicesByCompanyIDAndDateRange(
+	invoices, err := queriesFor(ctx, r.queries).GetInvoicesByCompanyIDAndDateRange(
 		ctx,
 		sqlc.GetInvoicesByCompanyIDAndDateRangeParams{
 			CompanyID: companyID,
@@ -101,37 +111,208 @@ func (r *invoiceRepository) GetByCompanyIDAndDateRange(
 	return result, nil
 }
 
-func (r *invoiceRepository) Create(
+func (r *invoiceRepository) GetWithholdingSummariesByCompanyIDAndDateRange(
+	ctx context.Context,
+	companyID int64,
+	startDate, endDate time.Time,
+) ([]*entity.WithholdingSummary, error) {
+	rows, err := queriesFor(ctx, r.queries).GetWithholdingSummariesByCompanyIDAndDateRange(
+		ctx,
+		sqlc.GetWithholdingSummariesByCompanyIDAndDateRangeParams{
+			CompanyID: companyID,
+			DueDate:   toPgDate(startDate),
+			DueDate_2: toPgDate(endDate),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*entity.WithholdingSummary, len(rows))
+	for i, row := range rows {
+		result[i] = &entity.WithholdingSummary{
+			VendorID:       row.VendorID,
+			VendorName:     row.VendorName,
+			InvoiceCount:   row.InvoiceCount,
+			PaymentAmount:  row.PaymentAmount,
+			WithholdingTax: row.WithholdingTax,
+		}
+	}
+
+	return result, nil
+}
+
+func (r *invoiceRepository) GetVendorPaymentStats(
+	ctx context.Context,
+	vendorID, companyID int64,
+) (*entity.VendorPaymentStats, error) {
+	queries := queriesFor(ctx, r.queries)
+
+	summary, err := queries.GetVendorPaymentSummary(ctx, sqlc.GetVendorPaymentSummaryParams{
+		ID:        vendorID,
+		CompanyID: companyID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	months, err := queries.GetVendorMonthlyPayments(ctx, sqlc.GetVendorMonthlyPaymentsParams{
+		ID:        vendorID,
+		CompanyID: companyID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := queries.CountVendorInvoicesByStatus(ctx, sqlc.CountVendorInvoicesByStatusParams{
+		ID:        vendorID,
+		CompanyID: companyID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &entity.VendorPaymentStats{
+		PaidInvoiceCount:     summary.InvoiceCount,
+		PaymentAmount:        summary.PaymentAmount,
+		WithholdingTax:       summary.WithholdingTax,
+		TransferAmount:       summary.TransferAmount,
+		AveragePaymentAmount: summary.AveragePaymentAmount,
+		FirstPaymentDate:     fromNullablePgDate(summary.FirstPaymentDate),
+		LastPaymentDate:      fromNullablePgDate(summary.LastPaymentDate),
+		Monthly:              make([]*entity.MonthlyPayment, len(months)),
+		InvoiceCounts:        make(map[entity.InvoiceStatus]int64, len(counts)),
+	}
+
+	for i, m := range months {
+		stats.Monthly[i] = &entity.MonthlyPayment{
+			Month:          m.Month.Time,
+			InvoiceCount:   m.InvoiceCount,
+			PaymentAmount:  m.PaymentAmount,
+			TransferAmount: m.TransferAmount,
+		}
+	}
+
+	for _, c := range counts {
+		stats.InvoiceCounts[entity.InvoiceStatus(c.Status)] = c.InvoiceCount
+	}
+
+	return stats, nil
+}
+
+func (r *invoiceRepository) LockByVendorID(ctx context.Context, vendorID int64) error {
+	return queriesFor(ctx, r.queries).LockVendorInvoices(ctx, vendorID)
+}
+
+func (r *invoiceRepository) FindDuplicates(
 	ctx context.Context,
 	invoice *entity.Invoice,
-) (*entity.Invoice, error) {
-	created, err := r.queries.CreateInvoice(ctx, sqlc.CreateInvoiceParams{
+) ([]*entity.Invoice, error) {
+	invoices, err := queriesFor(
+		ctx,
+		r.queries,
+	).FindDuplicateInvoices(ctx, sqlc.FindDuplicateInvoicesParams{
 		CompanyID:           invoice.CompanyID,
 		VendorID:            invoice.VendorID,
-		VendorBankAccountID: invoice.VendorBankAccountID,
-		IssueDate:           toPgDate(invoice.IssueDate),
 		PaymentAmount:       invoice.PaymentAmount,
-		Fee:                 invoice.Fee,
-		FeeRate:             invoice.FeeRate,
-		Tax:                 invoice.Tax,
-		TaxRate:             invoice.TaxRate,
-		TotalAmount:         invoice.TotalAmount,
+		IssueDate:           toPgDate(invoice.IssueDate),
 		DueDate:             toPgDate(invoice.DueDate),
-		Status:              string(invoice.Status),
+		VendorInvoiceNumber: toNullableString(invoice.VendorInvoiceNumber),
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	result := make([]*entity.Invoice, len(invoices))
+	for i, inv := range invoices {
+		result[i] = toInvoiceEntity(&inv)
+	}
+
+	return result, nil
+}
+
+func (r *invoiceRepository) Create(
+	ctx context.Context,
+	invoice *entity.Invoice,
+) (*entity.Invoice, error) {
+	created, err := queriesFor(ctx, r.queries).CreateInvoice(ctx, sqlc.CreateInvoiceParams{
+		CompanyID:                  invoice.CompanyID,
+		VendorID:                   invoice.VendorID,
+		VendorBankAccountID:        invoice.VendorBankAccountID,
+		IssueDate:                  toPgDate(invoice.IssueDate),
+		PaymentAmount:              invoice.PaymentAmount,
+		Fee:                        invoice.Fee,
+		FeeRate:                    invoice.FeeRate,
+		Tax:                        invoice.Tax,
+		TaxRate:                    invoice.TaxRate,
+		TotalAmount:                invoice.TotalAmount,
+		WithholdingTax:             invoice.WithholdingTax,
+		TransferAmount:             invoice.TransferAmount,
+		DueDate:                    toPgDate(invoice.DueDate),
+		Status:                     string(invoice.Status),
+		VendorRegistrationNumber:   toNullableString(invoice.VendorRegistrationNumber),
+		RecurringInvoiceScheduleID: toNullableInt64(invoice.RecurringInvoiceScheduleID),
+		VendorInvoiceNumber:        toNullableString(invoice.VendorInvoiceNumber),
+		DuplicateOverride:          invoice.DuplicateOverride,
+		CreatedBy:                  toNullableInt64(invoice.CreatedBy),
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, domain.ErrAlreadyExists
+		}
+
+		return nil, err
+	}
+
 	return toInvoiceEntity(&created), nil
 }
 
+func (r *invoiceRepository) ExistsApprovedByVendorID(
+	ctx context.Context,
+	vendorID int64,
+) (bool, error) {
+	return queriesFor(ctx, r.queries).ExistsApprovedInvoiceByVendorID(ctx, vendorID)
+}
+
+func (r *invoiceRepository) ExistsUnpaidByBankAccountID(
+	ctx context.Context,
+	bankAccountID int64,
+) (bool, error) {
+	return queriesFor(ctx, r.queries).ExistsUnpaidInvoiceByVendorBankAccountID(ctx, bankAccountID)
+}
+
+func (r *invoiceRepository) Review(
+	ctx context.Context,
+	invoice *entity.Invoice,
+) (*entity.Invoice, error) {
+	reviewed, err := queriesFor(ctx, r.queries).ReviewInvoice(ctx, sqlc.ReviewInvoiceParams{
+		ID:              invoice.ID,
+		CompanyID:       invoice.CompanyID,
+		Status:          string(invoice.Status),
+		ReviewedBy:      toNullableInt64(invoice.ReviewedBy),
+		ReviewedAt:      toNullableTimestamptz(invoice.ReviewedAt),
+		RejectionReason: toNullableString(invoice.RejectionReason),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return toInvoiceEntity(&reviewed), nil
+}
+
 func (r *invoiceRepository) UpdateStatus(
 	ctx context.Context,
 	id int64,
 	status entity.InvoiceStatus,
 ) (*entity.Invoice, error) {
-	updated, err := r.queries.UpdateInvoiceStatus(ctx, sqlc.UpdateInvoiceStatusParams{
+	updated, err := queriesFor(
+		ctx,
+		r.queries,
+	).UpdateInvoiceStatus(ctx, sqlc.UpdateInvoiceStatusParams{
 		ID:     id,
 		Status: string(status),
 	})
@@ -148,21 +329,31 @@ func (r *invoiceRepository) UpdateStatus(
 
 func toInvoiceEntity(i *sqlc.Invoice) *entity.Invoice {
 	return &entity.Invoice{
-		ID:                  i.ID,
-		CompanyID:           i.CompanyID,
-		VendorID:            i.VendorID,
-		VendorBankAccountID: i.VendorBankAccountID,
-		IssueDate:           i.IssueDate.Time,
-		PaymentAmount:       i.PaymentAmount,
-		Fee:                 i.Fee,
-		FeeRate:             i.FeeRate,
-		Tax:                 i.Tax,
-		TaxRate:             i.TaxRate,
-		TotalAmount:         i.TotalAmount,
-		DueDate:             i.DueDate.Time,
-		Status:              entity.InvoiceStatus(i.Status),
-		CreatedAt:           i.CreatedAt.Time,
-		UpdatedAt:           i.UpdatedAt.Time,
+		ID:                         i.ID,
+		CompanyID:                  i.CompanyID,
+		VendorID:                   i.VendorID,
+		VendorBankAccountID:        i.VendorBankAccountID,
+		IssueDate:                  i.IssueDate.Time,
+		PaymentAmount:              i.PaymentAmount,
+		Fee:                        i.Fee,
+		FeeRate:                    i.FeeRate,
+		Tax:                        i.Tax,
+		TaxRate:                    i.TaxRate,
+		TotalAmount:                i.TotalAmount,
+		WithholdingTax:             i.WithholdingTax,
+		TransferAmount:             i.TransferAmount,
+		DueDate:                    i.DueDate.Time,
+		Status:                     entity.InvoiceStatus(i.Status),
+		VendorRegistrationNumber:   fromNullableString(i.VendorRegistrationNumber),
+		RecurringInvoiceScheduleID: fromNullableInt64(i.RecurringInvoiceScheduleID),
+		VendorInvoiceNumber:        fromNullableString(i.VendorInvoiceNumber),
+		DuplicateOverride:          i.DuplicateOverride,
+		CreatedBy:                  fromNullableInt64(i.CreatedBy),
+		ReviewedBy:                 fromNullableInt64(i.ReviewedBy),
+		ReviewedAt:                 fromNullableTimestamptz(i.ReviewedAt),
+		RejectionReason:            fromNullableString(i.RejectionReason),
+		CreatedAt:                  i.CreatedAt.Time,
+		UpdatedAt:                  i.UpdatedAt.Time,
 	}
 }
 
@@ -172,3 +363,71 @@ func toPgDate(t time.Time) pgtype.Date {
 		Valid: true,
 	}
 }
+
+// toNullableString maps an empty string to SQL NULL.
+func toNullableString(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	return &s
+}
+
+// fromNullableString maps SQL NULL to an empty string.
+func fromNullableString(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
+
+// toNullableInt64 maps a zero ID to SQL NULL.
+func toNullableInt64(n int64) *int64 {
+	if n == 0 {
+		return nil
+	}
+
+	return &n
+}
+
+// fromNullableInt64 maps SQL NULL to a zero ID.
+func fromNullableInt64(n *int64) int64 {
+	if n == nil {
+		return 0
+	}
+
+	return *n
+}
+
+// isUniqueViolation reports whether err is a PostgreSQL unique constraint violation.
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode
+}
+
+// isForeignKeyViolation reports whether err is a PostgreSQL foreign key constraint violation.
+func isForeignKeyViolation(err error) bool {
+	var pgErr *pgconn.PgError
+
+	return errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolationCode
+}
+
+// toNullableTimestamptz maps a nil time to SQL NULL.
+func toNullableTimestamptz(t *time.Time) pgtype.Timestamptz {
+	if t == nil {
+		return pgtype.Timestamptz{}
+	}
+
+	return pgtype.Timestamptz{Time: *t, Valid: true}
+}
+
+// fromNullableTimestamptz maps SQL NULL to a nil time.
+func fromNullableTimestamptz(t pgtype.Timestamptz) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+
+	return &t.Time
+}
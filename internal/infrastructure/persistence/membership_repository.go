@@ -0,0 +1,75 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+	"github.com/harusys/super-shiharai-kun/internal/domain/repository"
+	"github.com/harusys/super-shiharai-kun/internal/infrastructure/database/sqlc"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type membershipRepository struct {
+	pool    *pgxpool.Pool
+	queries *sqlc.Queries
+}
+
+// NewMembershipRepository creates a new MembershipRepository.
+func NewMembershipRepository(pool *pgxpool.Pool) repository.MembershipRepository {
+	return &membershipRepository{
+		pool:    pool,
+		queries: sqlc.New(pool),
+	}
+}
+
+func (r *membershipRepository) GetByUserID(
+	ctx context.Context,
+	userID int64,
+) ([]*entity.Membership, error) {
+	rows, err := queriesFor(ctx, r.queries).GetCompanyMembershipsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*entity.Membership, len(rows))
+	for i, row := range rows {
+		result[i] = &entity.Membership{
+			UserID:      row.UserID,
+			CompanyID:   row.CompanyID,
+			CompanyName: row.CompanyName,
+			Role:        entity.UserRole(row.Role),
+			CreatedAt:   row.CreatedAt.Time,
+		}
+	}
+
+	return result, nil
+}
+
+func (r *membershipRepository) Create(
+	ctx context.Context,
+	membership *entity.Membership,
+) (*entity.Membership, error) {
+	created, err := queriesFor(ctx, r.queries).CreateCompanyMembership(
+		ctx,
+		sqlc.CreateCompanyMembershipParams{
+			UserID:    membership.UserID,
+			CompanyID: membership.CompanyID,
+			Role:      string(membership.Role),
+		},
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, domain.ErrAlreadyExists
+		}
+
+		return nil, err
+	}
+
+	return &entity.Membership{
+		UserID:    created.UserID,
+		CompanyID: created.CompanyID,
+		Role:      entity.UserRole(created.Role),
+		CreatedAt: created.CreatedAt.Time,
+	}, nil
+}
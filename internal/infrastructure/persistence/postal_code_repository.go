@@ -0,0 +1,98 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+	"github.com/harusys/super-shiharai-kun/internal/domain/repository"
+	"github.com/harusys/super-shiharai-kun/internal/infrastructure/database/sqlc"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type postalCodeRepository struct {
+	pool    *pgxpool.Pool
+	queries *sqlc.Queries
+}
+
+// NewPostalCodeRepository creates a new PostalCodeRepository.
+func NewPostalCodeRepository(pool *pgxpool.Pool) repository.PostalCodeRepository {
+	return &postalCodeRepository{
+		pool:    pool,
+		queries: sqlc.New(pool),
+	}
+}
+
+func (r *postalCodeRepository) GetByCode(
+	ctx context.Context,
+	code string,
+) ([]*entity.PostalCode, error) {
+	codes, err := queriesFor(ctx, r.queries).GetPostalCodesByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*entity.PostalCode, len(codes))
+	for i, c := range codes {
+		result[i] = toPostalCodeEntity(&c)
+	}
+
+	return result, nil
+}
+
+func (r *postalCodeRepository) Exists(ctx context.Context, code string) (bool, error) {
+	return queriesFor(ctx, r.queries).ExistsPostalCode(ctx, code)
+}
+
+func (r *postalCodeRepository) Count(ctx context.Context) (int64, error) {
+	return queriesFor(ctx, r.queries).CountPostalCodes(ctx)
+}
+
+func (r *postalCodeRepository) ReplaceAll(
+	ctx context.Context,
+	codes []*entity.PostalCode,
+) (int64, error) {
+	// Lookups keep seeing the old dataset until the new one is committed.
+	tx, err := beginTx(ctx, r.pool)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // no-op after commit
+
+	qtx := r.queries.WithTx(tx)
+
+	if err := qtx.DeleteAllPostalCodes(ctx); err != nil {
+		return 0, err
+	}
+
+	params := make([]sqlc.CreatePostalCodesParams, len(codes))
+	for i, c := range codes {
+		params[i] = sqlc.CreatePostalCodesParams{
+			Code:           c.Code,
+			Prefecture:     c.Prefecture,
+			City:           c.City,
+			Town:           c.Town,
+			PrefectureKana: c.PrefectureKana,
+			CityKana:       c.CityKana,
+			TownKana:       c.TownKana,
+		}
+	}
+
+	count, err := qtx.CreatePostalCodes(ctx, params)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, tx.Commit(ctx)
+}
+
+func toPostalCodeEntity(c *sqlc.PostalCode) *entity.PostalCode {
+	return &entity.PostalCode{
+		Code:           c.Code,
+		Prefecture:     c.Prefecture,
+		City:           c.City,
+		Town:           c.Town,
+		PrefectureKana: c.PrefectureKana,
+		CityKana:       c.CityKana,
+		TownKana:       c.TownKana,
+	}
+}
@@ -0,0 +1,233 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+	"github.com/harusys/super-shiharai-kun/internal/domain/repository"
+	"github.com/harusys/super-shiharai-kun/internal/infrastructure/database/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type recurringInvoiceScheduleRepository struct {
+	pool    *pgxpool.Pool
+	queries *sqlc.Queries
+}
+
+// NewRecurringInvoiceScheduleRepository creates a new RecurringInvoiceScheduleRepository.
+func NewRecurringInvoiceScheduleRepository(
+	pool *pgxpool.Pool,
+) repository.RecurringInvoiceScheduleRepository {
+	return &recurringInvoiceScheduleRepository{
+		pool:    pool,
+		queries: sqlc.New(pool),
+	}
+}
+
+func (r *recurringInvoiceScheduleRepository) GetByIDAndCompanyID(
+	ctx context.Context,
+	id, companyID int64,
+) (*entity.RecurringInvoiceSchedule, error) {
+	schedule, err := queriesFor(ctx, r.queries).GetRecurringInvoiceScheduleByIDAndCompanyID(
+		ctx,
+		sqlc.GetRecurringInvoiceScheduleByIDAndCompanyIDParams{
+			ID:        id,
+			CompanyID: companyID,
+		},
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return toRecurringInvoiceScheduleEntity(&schedule), nil
+}
+
+func (r *recurringInvoiceScheduleRepository) GetByCompanyID(
+	ctx context.Context,
+	companyID int64,
+) ([]*entity.RecurringInvoiceSchedule, error) {
+	schedules, err := queriesFor(
+		ctx,
+		r.queries,
+	).GetRecurringInvoiceSchedulesByCompanyID(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*entity.RecurringInvoiceSchedule, len(schedules))
+	for i, s := range schedules {
+		result[i] = toRecurringInvoiceScheduleEntity(&s)
+	}
+
+	return result, nil
+}
+
+func (r *recurringInvoiceScheduleRepository) GetDue(
+	ctx context.Context,
+	until time.Time,
+) ([]*entity.RecurringInvoiceSchedule, error) {
+	schedules, err := queriesFor(
+		ctx,
+		r.queries,
+	).GetDueRecurringInvoiceSchedules(ctx, toPgDate(until))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*entity.RecurringInvoiceSchedule, len(schedules))
+	for i, s := range schedules {
+		result[i] = toRecurringInvoiceScheduleEntity(&s)
+	}
+
+	return result, nil
+}
+
+func (r *recurringInvoiceScheduleRepository) Create(
+	ctx context.Context,
+	schedule *entity.RecurringInvoiceSchedule,
+) (*entity.RecurringInvoiceSchedule, error) {
+	created, err := queriesFor(ctx, r.queries).CreateRecurringInvoiceSchedule(
+		ctx,
+		sqlc.CreateRecurringInvoiceScheduleParams{
+			CompanyID:           schedule.CompanyID,
+			VendorID:            schedule.VendorID,
+			VendorBankAccountID: schedule.VendorBankAccountID,
+			PaymentAmount:       schedule.PaymentAmount,
+			DayOfMonth:          toNullableDayOfMonth(schedule),
+			EndOfMonth:          schedule.EndOfMonth,
+			StartDate:           toPgDate(schedule.StartDate),
+			EndDate:             toNullablePgDate(schedule.EndDate),
+			NextDueDate:         toPgDate(schedule.NextDueDate),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return toRecurringInvoiceScheduleEntity(&created), nil
+}
+
+func (r *recurringInvoiceScheduleRepository) Update(
+	ctx context.Context,
+	schedule *entity.RecurringInvoiceSchedule,
+) (*entity.RecurringInvoiceSchedule, error) {
+	updated, err := queriesFor(ctx, r.queries).UpdateRecurringInvoiceSchedule(
+		ctx,
+		sqlc.UpdateRecurringInvoiceScheduleParams{
+			ID:                  schedule.ID,
+			CompanyID:           schedule.CompanyID,
+			VendorID:            schedule.VendorID,
+			VendorBankAccountID: schedule.VendorBankAccountID,
+			PaymentAmount:       schedule.PaymentAmount,
+			DayOfMonth:          toNullableDayOfMonth(schedule),
+			EndOfMonth:          schedule.EndOfMonth,
+			StartDate:           toPgDate(schedule.StartDate),
+			EndDate:             toNullablePgDate(schedule.EndDate),
+			NextDueDate:         toPgDate(schedule.NextDueDate),
+		},
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return toRecurringInvoiceScheduleEntity(&updated), nil
+}
+
+func (r *recurringInvoiceScheduleRepository) UpdateNextDueDate(
+	ctx context.Context,
+	id int64,
+	nextDueDate time.Time,
+) error {
+	return queriesFor(ctx, r.queries).UpdateRecurringInvoiceScheduleNextDueDate(
+		ctx,
+		sqlc.UpdateRecurringInvoiceScheduleNextDueDateParams{
+			ID:          id,
+			NextDueDate: toPgDate(nextDueDate),
+		},
+	)
+}
+
+func (r *recurringInvoiceScheduleRepository) Delete(
+	ctx context.Context,
+	id, companyID int64,
+) error {
+	rows, err := queriesFor(ctx, r.queries).DeleteRecurringInvoiceSchedule(
+		ctx,
+		sqlc.DeleteRecurringInvoiceScheduleParams{
+			ID:        id,
+			CompanyID: companyID,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func toRecurringInvoiceScheduleEntity(
+	s *sqlc.RecurringInvoiceSchedule,
+) *entity.RecurringInvoiceSchedule {
+	schedule := &entity.RecurringInvoiceSchedule{
+		ID:                  s.ID,
+		CompanyID:           s.CompanyID,
+		VendorID:            s.VendorID,
+		VendorBankAccountID: s.VendorBankAccountID,
+		PaymentAmount:       s.PaymentAmount,
+		EndOfMonth:          s.EndOfMonth,
+		StartDate:           s.StartDate.Time,
+		EndDate:             fromNullablePgDate(s.EndDate),
+		NextDueDate:         s.NextDueDate.Time,
+		CreatedAt:           s.CreatedAt.Time,
+		UpdatedAt:           s.UpdatedAt.Time,
+	}
+
+	if s.DayOfMonth != nil {
+		schedule.DayOfMonth = int(*s.DayOfMonth)
+	}
+
+	return schedule
+}
+
+func toNullableDayOfMonth(s *entity.RecurringInvoiceSchedule) *int16 {
+	if s.EndOfMonth {
+		return nil
+	}
+
+	day := int16(s.DayOfMonth) //nolint:gosec // day of month is validated to be within 1-31
+
+	return &day
+}
+
+func toNullablePgDate(t *time.Time) pgtype.Date {
+	if t == nil {
+		return pgtype.Date{}
+	}
+
+	return toPgDate(*t)
+}
+
+func fromNullablePgDate(d pgtype.Date) *time.Time {
+	if !d.Valid {
+		return nil
+	}
+
+	return &d.Time
+}
@@ -0,0 +1,139 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+	"github.com/harusys/super-shiharai-kun/internal/domain/repository"
+	"github.com/harusys/super-shiharai-kun/internal/infrastructure/database/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type refreshTokenRepository struct {
+	pool    *pgxpool.Pool
+	queries *sqlc.Queries
+}
+
+// NewRefreshTokenRepository creates a new RefreshTokenRepository.
+func NewRefreshTokenRepository(pool *pgxpool.Pool) repository.RefreshTokenRepository {
+	return &refreshTokenRepository{
+		pool:    pool,
+		queries: sqlc.New(pool),
+	}
+}
+
+func (r *refreshTokenRepository) GetByTokenHash(
+	ctx context.Context,
+	tokenHash string,
+) (*entity.RefreshToken, error) {
+	token, err := queriesFor(ctx, r.queries).GetRefreshTokenByTokenHash(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return toRefreshTokenEntity(&token), nil
+}
+
+func (r *refreshTokenRepository) Create(
+	ctx context.Context,
+	token *entity.RefreshToken,
+) (*entity.RefreshToken, error) {
+	created, err := queriesFor(
+		ctx,
+		r.queries,
+	).CreateRefreshToken(ctx, sqlc.CreateRefreshTokenParams{
+		UserID:    token.UserID,
+		CompanyID: token.CompanyID,
+		FamilyID:  token.FamilyID,
+		TokenHash: token.TokenHash,
+		ExpiresAt: toNullableTimestamptz(&token.ExpiresAt),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toRefreshTokenEntity(&created), nil
+}
+
+func (r *refreshTokenRepository) MarkRotated(
+	ctx context.Context,
+	id int64,
+	rotatedAt time.Time,
+) (*entity.RefreshToken, error) {
+	rotated, err := queriesFor(
+		ctx,
+		r.queries,
+	).RotateRefreshToken(ctx, sqlc.RotateRefreshTokenParams{
+		ID:        id,
+		RotatedAt: toNullableTimestamptz(&rotatedAt),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrInvalidState
+		}
+
+		return nil, err
+	}
+
+	return toRefreshTokenEntity(&rotated), nil
+}
+
+func (r *refreshTokenRepository) RevokeFamily(
+	ctx context.Context,
+	familyID string,
+	revokedAt time.Time,
+) error {
+	return queriesFor(ctx, r.queries).RevokeRefreshTokenFamily(
+		ctx,
+		sqlc.RevokeRefreshTokenFamilyParams{
+			FamilyID:  familyID,
+			RevokedAt: toNullableTimestamptz(&revokedAt),
+		},
+	)
+}
+
+func (r *refreshTokenRepository) RevokeByUserID(
+	ctx context.Context,
+	userID int64,
+	revokedAt time.Time,
+) error {
+	return queriesFor(ctx, r.queries).RevokeRefreshTokensByUserID(
+		ctx,
+		sqlc.RevokeRefreshTokensByUserIDParams{
+			UserID:    userID,
+			RevokedAt: toNullableTimestamptz(&revokedAt),
+		},
+	)
+}
+
+func (r *refreshTokenRepository) DeleteExpired(
+	ctx context.Context,
+	before time.Time,
+) (int64, error) {
+	return queriesFor(ctx, r.queries).DeleteExpiredRefreshTokens(
+		ctx,
+		toNullableTimestamptz(&before),
+	)
+}
+
+func toRefreshTokenEntity(t *sqlc.RefreshToken) *entity.RefreshToken {
+	return &entity.RefreshToken{
+		ID:        t.ID,
+		UserID:    t.UserID,
+		CompanyID: t.CompanyID,
+		FamilyID:  t.FamilyID,
+		TokenHash: t.TokenHash,
+		ExpiresAt: t.ExpiresAt.Time,
+		RotatedAt: fromNullableTimestamptz(t.RotatedAt),
+		RevokedAt: fromNullableTimestamptz(t.RevokedAt),
+		CreatedAt: t.CreatedAt.Time,
+	}
+}
@@ -0,0 +1,179 @@
+package persistence
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain/repository"
+	"github.com/harusys/super-shiharai-kun/internal/infrastructure"
+	"github.com/harusys/super-shiharai-kun/pkg/ctxutil"
+)
+
+// tokenRevocationCache keeps the answers of a TokenRevocationRepository in
+// memory, so that authenticating a request does not always query the database.
+//
+// A revoked token never becomes valid again, so that answer is kept for as long
+// as an access token lives. That a token is not revoked is kept only for ttl:
+// revocations made by another process take effect within ttl, while those made
+// through the cache take effect as soon as they are committed.
+type tokenRevocationCache struct {
+	next repository.TokenRevocationRepository
+	ttl  time.Duration
+
+	mu      sync.Mutex
+	entries map[string]revocationCacheEntry
+	sweptAt time.Time
+	// generation counts the committed revocations of users, so that an answer
+	// read from the store before one is not cached after it.
+	generation uint64
+}
+
+type revocationCacheEntry struct {
+	userID  int64
+	revoked bool
+	until   time.Time
+}
+
+// NewCachedTokenRevocationRepository wraps a TokenRevocationRepository with an
+// in-memory cache.
+func NewCachedTokenRevocationRepository(
+	next repository.TokenRevocationRepository,
+	ttl time.Duration,
+) repository.TokenRevocationRepository {
+	return &tokenRevocationCache{
+		next:    next,
+		ttl:     ttl,
+		entries: make(map[string]revocationCacheEntry),
+	}
+}
+
+func (c *tokenRevocationCache) Revoke(
+	ctx context.Context,
+	tokenID string,
+	userID int64,
+	expiresAt time.Time,
+) error {
+	if err := c.next.Revoke(ctx, tokenID, userID, expiresAt); err != nil {
+		return err
+	}
+
+	// Remember the revocation only once it is committed; a rolled back one
+	// would otherwise keep rejecting a valid token.
+	afterCommit(ctx, func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		c.store(ctx, tokenID, revocationCacheEntry{userID: userID, revoked: true, until: expiresAt})
+	})
+
+	return nil
+}
+
+func (c *tokenRevocationCache) RevokeUser(
+	ctx context.Context,
+	userID int64,
+	revokedBefore time.Time,
+) error {
+	if err := c.next.RevokeUser(ctx, userID, revokedBefore); err != nil {
+		return err
+	}
+
+	// Forget that the user's tokens were valid once the revocation is visible
+	// to the store; a check before that could otherwise cache the old answer.
+	afterCommit(ctx, func() { c.forgetValid(userID) })
+
+	return nil
+}
+
+// forgetValid drops the cached answers that the user's tokens are not revoked,
+// so that the next check asks the store.
+func (c *tokenRevocationCache) forgetValid(userID int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+
+	for tokenID, entry := range c.entries {
+		if entry.userID == userID && !entry.revoked {
+			delete(c.entries, tokenID)
+		}
+	}
+}
+
+func (c *tokenRevocationCache) IsRevoked(
+	ctx context.Context,
+	tokenID string,
+	userID int64,
+	issuedAt time.Time,
+) (bool, error) {
+	now := ctxutil.Now(ctx)
+
+	c.mu.Lock()
+	entry, ok := c.entries[tokenID]
+	generation := c.generation
+	c.mu.Unlock()
+
+	if ok && now.Before(entry.until) {
+		return entry.revoked, nil
+	}
+
+	revoked, err := c.next.IsRevoked(ctx, tokenID, userID, issuedAt)
+	if err != nil {
+		return false, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if revoked {
+		c.store(ctx, tokenID, revocationCacheEntry{
+			userID:  userID,
+			revoked: true,
+			until:   now.Add(infrastructure.AccessTokenExpiry),
+		})
+
+		return true, nil
+	}
+
+	// The token may have been revoked while the store was being asked
+	if cached, ok := c.entries[tokenID]; ok && cached.revoked {
+		return true, nil
+	}
+
+	if generation == c.generation {
+		c.store(ctx, tokenID, revocationCacheEntry{userID: userID, until: now.Add(c.ttl)})
+	}
+
+	return false, nil
+}
+
+func (c *tokenRevocationCache) DeleteExpired(
+	ctx context.Context,
+	before time.Time,
+) (int64, error) {
+	// Cached answers expire by themselves
+	return c.next.DeleteExpired(ctx, before)
+}
+
+// store caches an answer, dropping expired answers every ttl so that the cache
+// holds at most the tokens in use. c.mu must be held.
+func (c *tokenRevocationCache) store(
+	ctx context.Context,
+	tokenID string,
+	entry revocationCacheEntry,
+) {
+	now := ctxutil.Now(ctx)
+
+	if now.Sub(c.sweptAt) >= c.ttl {
+		for id, e := range c.entries {
+			if !now.Before(e.until) {
+				delete(c.entries, id)
+			}
+		}
+
+		c.sweptAt = now
+	}
+
+	c.entries[tokenID] = entry
+}
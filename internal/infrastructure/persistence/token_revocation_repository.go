@@ -0,0 +1,75 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain/repository"
+	"github.com/harusys/super-shiharai-kun/internal/infrastructure/database/sqlc"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type tokenRevocationRepository struct {
+	pool    *pgxpool.Pool
+	queries *sqlc.Queries
+}
+
+// NewTokenRevocationRepository creates a new TokenRevocationRepository.
+func NewTokenRevocationRepository(pool *pgxpool.Pool) repository.TokenRevocationRepository {
+	return &tokenRevocationRepository{
+		pool:    pool,
+		queries: sqlc.New(pool),
+	}
+}
+
+func (r *tokenRevocationRepository) Revoke(
+	ctx context.Context,
+	tokenID string,
+	userID int64,
+	expiresAt time.Time,
+) error {
+	return queriesFor(ctx, r.queries).RevokeToken(ctx, sqlc.RevokeTokenParams{
+		Jti:       tokenID,
+		UserID:    userID,
+		ExpiresAt: toNullableTimestamptz(&expiresAt),
+	})
+}
+
+func (r *tokenRevocationRepository) RevokeUser(
+	ctx context.Context,
+	userID int64,
+	revokedBefore time.Time,
+) error {
+	return queriesFor(ctx, r.queries).RevokeUserTokens(ctx, sqlc.RevokeUserTokensParams{
+		UserID:        userID,
+		RevokedBefore: toNullableTimestamptz(&revokedBefore),
+	})
+}
+
+func (r *tokenRevocationRepository) IsRevoked(
+	ctx context.Context,
+	tokenID string,
+	userID int64,
+	issuedAt time.Time,
+) (bool, error) {
+	revoked, err := queriesFor(ctx, r.queries).IsTokenRevoked(ctx, sqlc.IsTokenRevokedParams{
+		Jti:      tokenID,
+		UserID:   userID,
+		IssuedAt: toNullableTimestamptz(&issuedAt),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return revoked != nil && *revoked, nil
+}
+
+func (r *tokenRevocationRepository) DeleteExpired(
+	ctx context.Context,
+	before time.Time,
+) (int64, error) {
+	return queriesFor(ctx, r.queries).DeleteExpiredRevokedTokens(
+		ctx,
+		toNullableTimestamptz(&before),
+	)
+}
@@ -0,0 +1,93 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain/repository"
+	"github.com/harusys/super-shiharai-kun/internal/infrastructure/database/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// txKey is the context key of the transaction started by WithinTransaction.
+type txKey struct{}
+
+// afterCommitKey is the context key of the functions registered with
+// afterCommit in the transaction started by WithinTransaction.
+type afterCommitKey struct{}
+
+type transactor struct {
+	pool *pgxpool.Pool
+}
+
+// NewTransactor creates a new Transactor.
+func NewTransactor(pool *pgxpool.Pool) repository.Transactor {
+	return &transactor{pool: pool}
+}
+
+func (t *transactor) WithinTransaction(
+	ctx context.Context,
+	fn func(ctx context.Context) error,
+) error {
+	tx, err := beginTx(ctx, t.pool)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // no-op after commit
+
+	parent, nested := ctx.Value(afterCommitKey{}).(*[]func())
+	hooks := &[]func(){}
+
+	txCtx := context.WithValue(context.WithValue(ctx, txKey{}, tx), afterCommitKey{}, hooks)
+	if err := fn(txCtx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	// A savepoint is only committed with the enclosing transaction
+	if nested {
+		*parent = append(*parent, *hooks...)
+
+		return nil
+	}
+
+	for _, hook := range *hooks {
+		hook()
+	}
+
+	return nil
+}
+
+// afterCommit runs hook once the transaction carried by ctx has been committed,
+// or right away if ctx carries none. The hook is dropped if the transaction is
+// rolled back.
+func afterCommit(ctx context.Context, hook func()) {
+	if hooks, ok := ctx.Value(afterCommitKey{}).(*[]func()); ok {
+		*hooks = append(*hooks, hook)
+
+		return
+	}
+
+	hook()
+}
+
+// beginTx starts a transaction, or a savepoint if ctx already carries one.
+func beginTx(ctx context.Context, pool *pgxpool.Pool) (pgx.Tx, error) {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx.Begin(ctx)
+	}
+
+	return pool.Begin(ctx)
+}
+
+// queriesFor returns q bound to the transaction carried by ctx, if any.
+func queriesFor(ctx context.Context, q *sqlc.Queries) *sqlc.Queries {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return q.WithTx(tx)
+	}
+
+	return q
+}
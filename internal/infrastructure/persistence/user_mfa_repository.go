@@ -0,0 +1,192 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+	"github.com/harusys/super-shiharai-kun/internal/domain/repository"
+	"github.com/harusys/super-shiharai-kun/internal/infrastructure/database/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type userMFARepository struct {
+	pool    *pgxpool.Pool
+	queries *sqlc.Queries
+}
+
+// NewUserMFARepository creates a new UserMFARepository.
+func NewUserMFARepository(pool *pgxpool.Pool) repository.UserMFARepository {
+	return &userMFARepository{
+		pool:    pool,
+		queries: sqlc.New(pool),
+	}
+}
+
+func (r *userMFARepository) GetByUserID(
+	ctx context.Context,
+	userID int64,
+) (*entity.UserMFA, error) {
+	mfa, err := queriesFor(ctx, r.queries).GetUserMFAByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return toUserMFAEntity(&mfa), nil
+}
+
+func (r *userMFARepository) StartEnrollment(
+	ctx context.Context,
+	userID int64,
+	secret string,
+) (*entity.UserMFA, error) {
+	mfa, err := queriesFor(ctx, r.queries).StartUserMFAEnrollment(
+		ctx,
+		sqlc.StartUserMFAEnrollmentParams{
+			UserID: userID,
+			Secret: secret,
+		},
+	)
+	if err != nil {
+		// The enabled row was left as it is
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrAlreadyExists
+		}
+
+		return nil, err
+	}
+
+	return toUserMFAEntity(&mfa), nil
+}
+
+func (r *userMFARepository) Enable(
+	ctx context.Context,
+	userID, step int64,
+	enabledAt time.Time,
+) error {
+	_, err := queriesFor(ctx, r.queries).EnableUserMFA(ctx, sqlc.EnableUserMFAParams{
+		UserID:       userID,
+		EnabledAt:    toNullableTimestamptz(&enabledAt),
+		LastUsedStep: step,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrInvalidState
+	}
+
+	return err
+}
+
+func (r *userMFARepository) UseStep(ctx context.Context, userID, step int64) error {
+	_, err := queriesFor(ctx, r.queries).UseUserMFAStep(ctx, sqlc.UseUserMFAStepParams{
+		UserID:       userID,
+		LastUsedStep: step,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrInvalidState
+	}
+
+	return err
+}
+
+func (r *userMFARepository) ReserveAttempt(
+	ctx context.Context,
+	userID int64,
+	now time.Time,
+	maxAttempts int,
+	lockedUntil time.Time,
+) (*entity.UserMFA, error) {
+	mfa, err := queriesFor(ctx, r.queries).ReserveUserMFAAttempt(
+		ctx,
+		sqlc.ReserveUserMFAAttemptParams{
+			UserID:      userID,
+			MaxAttempts: int32(maxAttempts), //nolint:gosec // a small constant
+			LockedUntil: toNullableTimestamptz(&lockedUntil),
+			Now:         toNullableTimestamptz(&now),
+		},
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrInvalidState
+		}
+
+		return nil, err
+	}
+
+	return toUserMFAEntity(&mfa), nil
+}
+
+func (r *userMFARepository) ClearFailures(ctx context.Context, userID int64) error {
+	return queriesFor(ctx, r.queries).ClearUserMFAFailures(ctx, userID)
+}
+
+func (r *userMFARepository) Delete(ctx context.Context, userID int64) error {
+	queries := queriesFor(ctx, r.queries)
+
+	if err := queries.DeleteMFARecoveryCodesByUserID(ctx, userID); err != nil {
+		return err
+	}
+
+	return queries.DeleteUserMFA(ctx, userID)
+}
+
+func (r *userMFARepository) ReplaceRecoveryCodes(
+	ctx context.Context,
+	userID int64,
+	codeHashes []string,
+) error {
+	queries := queriesFor(ctx, r.queries)
+
+	if err := queries.DeleteMFARecoveryCodesByUserID(ctx, userID); err != nil {
+		return err
+	}
+
+	for _, codeHash := range codeHashes {
+		err := queries.CreateMFARecoveryCode(ctx, sqlc.CreateMFARecoveryCodeParams{
+			UserID:   userID,
+			CodeHash: codeHash,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *userMFARepository) UseRecoveryCode(
+	ctx context.Context,
+	userID int64,
+	codeHash string,
+	usedAt time.Time,
+) error {
+	_, err := queriesFor(ctx, r.queries).UseMFARecoveryCode(ctx, sqlc.UseMFARecoveryCodeParams{
+		UserID:   userID,
+		CodeHash: codeHash,
+		UsedAt:   toNullableTimestamptz(&usedAt),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrNotFound
+	}
+
+	return err
+}
+
+func toUserMFAEntity(m *sqlc.UserMfa) *entity.UserMFA {
+	return &entity.UserMFA{
+		UserID:         m.UserID,
+		Secret:         m.Secret,
+		EnabledAt:      fromNullableTimestamptz(m.EnabledAt),
+		LastUsedStep:   m.LastUsedStep,
+		FailedAttempts: int(m.FailedAttempts),
+		LockedUntil:    fromNullableTimestamptz(m.LockedUntil),
+		CreatedAt:      m.CreatedAt.Time,
+		UpdatedAt:      m.UpdatedAt.Time,
+	}
+}
@@ -25,8 +25,14 @@ func NewUserRepository(pool *pgxpool.Pool) repository.UserRepository {
 	}
 }
 
-func (r *userRepository) GetByID(ctx context.Context, id int64) (*entity.User, error) {
-	user, err := r.queries.GetUserByID(ctx, id)
+func (r *userRepository) GetByIDAndCompanyID(
+	ctx context.Context,
+	id, companyID int64,
+) (*entity.User, error) {
+	row, err := queriesFor(ctx, r.queries).GetUserByIDAndCompanyID(
+		ctx,
+		sqlc.GetUserByIDAndCompanyIDParams{ID: id, CompanyID: companyID},
+	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, domain.ErrNotFound
@@ -35,11 +41,11 @@ func (r *userRepository) GetByID(ctx context.Context, id int64) (*entity.User, e
 		return nil, err
 	}
 
-	return toUserEntity(&user), nil
+	return toMemberUserEntity(&row.User, row.CompanyID, row.Role), nil
 }
 
 func (r *userRepository) GetByEmail(ctx context.Context, email string) (*entity.User, error) {
-	user, err := r.queries.GetUserByEmail(ctx, email)
+	row, err := queriesFor(ctx, r.queries).GetUserByEmail(ctx, email)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, domain.ErrNotFound
@@ -48,42 +54,66 @@ func (r *userRepository) GetByEmail(ctx context.Context, email string) (*entity.
 		return nil, err
 	}
 
-	return toUserEntity(&user), nil
+	return toMemberUserEntity(&row.User, row.CompanyID, row.Role), nil
 }
 
 func (r *userRepository) GetByCompanyID(
 	ctx context.Context,
 	companyID int64,
 ) ([]*entity.User, error) {
-	users, err := r.queries.GetUsersByCompanyID(ctx, companyID)
+	rows, err := queriesFor(ctx, r.queries).GetUsersByCompanyID(ctx, companyID)
 	if err != nil {
 		return nil, err
 	}
 
-	result := make([]*entity.User, len(users))
-	for i, u := range users {
-		result[i] = toUserEntity(&u)
+	result := make([]*entity.User, len(rows))
+	for i, row := range rows {
+		result[i] = toMemberUserEntity(&row.User, row.CompanyID, row.Role)
 	}
 
 	return result, nil
 }
 
 func (r *userRepository) Create(ctx context.Context, user *entity.User) (*entity.User, error) {
-	created, err := r.queries.CreateUser(ctx, sqlc.CreateUserParams{
-		CompanyID:    user.CompanyID,
+	tx, err := beginTx(ctx, r.pool)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // no-op after commit
+
+	qtx := r.queries.WithTx(tx)
+
+	created, err := qtx.CreateUser(ctx, sqlc.CreateUserParams{
 		Name:         user.Name,
 		Email:        user.Email,
 		PasswordHash: user.PasswordHash,
 	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, domain.ErrAlreadyExists
+		}
+
+		return nil, err
+	}
+
+	membership, err := qtx.CreateCompanyMembership(ctx, sqlc.CreateCompanyMembershipParams{
+		UserID:    created.ID,
+		CompanyID: user.CompanyID,
+		Role:      string(user.Role),
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return toUserEntity(&created), nil
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return toMemberUserEntity(&created, membership.CompanyID, membership.Role), nil
 }
 
 func (r *userRepository) Update(ctx context.Context, user *entity.User) (*entity.User, error) {
-	updated, err := r.queries.UpdateUser(ctx, sqlc.UpdateUserParams{
+	updated, err := queriesFor(ctx, r.queries).UpdateUser(ctx, sqlc.UpdateUserParams{
 		ID:    user.ID,
 		Name:  user.Name,
 		Email: user.Email,
@@ -96,27 +126,32 @@ func (r *userRepository) Update(ctx context.Context, user *entity.User) (*entity
 		return nil, err
 	}
 
-	return toUserEntity(&updated), nil
+	return toMemberUserEntity(&updated, user.CompanyID, string(user.Role)), nil
 }
 
 func (r *userRepository) UpdatePassword(ctx context.Context, id int64, passwordHash string) error {
-	return r.queries.UpdateUserPassword(ctx, sqlc.UpdateUserPasswordParams{
+	return queriesFor(ctx, r.queries).UpdateUserPassword(ctx, sqlc.UpdateUserPasswordParams{
 		ID:           id,
 		PasswordHash: passwordHash,
 	})
 }
 
 func (r *userRepository) ExistsByEmail(ctx context.Context, email string) (bool, error) {
-	return r.queries.ExistsUserByEmail(ctx, email)
+	return queriesFor(ctx, r.queries).ExistsUserByEmail(ctx, email)
+}
+
+func (r *userRepository) LockByID(ctx context.Context, id int64) error {
+	return queriesFor(ctx, r.queries).LockUser(ctx, id)
 }
 
-func toUserEntity(u *sqlc.User) *entity.User {
+func toMemberUserEntity(u *sqlc.User, companyID int64, role string) *entity.User {
 	return &entity.User{
 		ID:           u.ID,
-		CompanyID:    u.CompanyID,
+		CompanyID:    companyID,
 		Name:         u.Name,
 		Email:        u.Email,
 		PasswordHash: u.PasswordHash,
+		Role:         entity.UserRole(role),
 		CreatedAt:    u.CreatedAt.Time,
 		UpdatedAt:    u.UpdatedAt.Time,
 	}
@@ -3,6 +3,8 @@ package persistence
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/harusys/super-shiharai-kun/internal/domain"
 	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
@@ -26,7 +28,7 @@ func NewVendorRepository(pool *pgxpool.Pool) repository.VendorRepository {
 }
 
 func (r *vendorRepository) GetByID(ctx context.Context, id int64) (*entity.Vendor, error) {
-	vendor, err := r.queries.GetVendorByID(ctx, id)
+	vendor, err := queriesFor(ctx, r.queries).GetVendorByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, domain.ErrNotFound
@@ -42,7 +44,10 @@ func (r *vendorRepository) GetByIDAndCompanyID(
 	ctx context.Context,
 	id, companyID int64,
 ) (*entity.Vendor, error) {
-	vendor, err := r.queries.GetVendorByIDAndCompanyID(ctx, sqlc.GetVendorByIDAndCompanyIDParams{
+	vendor, err := queriesFor(
+		ctx,
+		r.queries,
+	).GetVendorByIDAndCompanyID(ctx, sqlc.GetVendorByIDAndCompanyIDParams{
 		ID:        id,
 		CompanyID: companyID,
 	})
@@ -61,7 +66,7 @@ func (r *vendorRepository) GetByCompanyID(
 	ctx context.Context,
 	companyID int64,
 ) ([]*entity.Vendor, error) {
-	vendors, err := r.queries.GetVendorsByCompanyID(ctx, companyID)
+	vendors, err := queriesFor(ctx, r.queries).GetVendorsByCompanyID(ctx, companyID)
 	if err != nil {
 		return nil, err
 	}
@@ -74,19 +79,92 @@ func (r *vendorRepository) GetByCompanyID(
 	return result, nil
 }
 
+func (r *vendorRepository) GetByExternalCode(
+	ctx context.Context,
+	companyID int64,
+	externalCode string,
+) (*entity.Vendor, error) {
+	vendor, err := queriesFor(ctx, r.queries).GetVendorByCompanyIDAndExternalCode(
+		ctx,
+		sqlc.GetVendorByCompanyIDAndExternalCodeParams{
+			CompanyID:    companyID,
+			ExternalCode: &externalCode,
+		},
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return toVendorEntity(&vendor), nil
+}
+
+func (r *vendorRepository) Search(
+	ctx context.Context,
+	filter *repository.VendorFilter,
+) ([]*entity.Vendor, error) {
+	vendors, err := queriesFor(
+		ctx,
+		r.queries,
+	).SearchVendorsByCompanyID(ctx, sqlc.SearchVendorsByCompanyIDParams{
+		CompanyID:       filter.CompanyID,
+		Name:            toLikePattern(filter.Name),
+		NameKana:        toLikePattern(filter.NameKana),
+		IncludeArchived: filter.IncludeArchived,
+		RowLimit:        int32(filter.Limit),  //nolint:gosec // capped by the usecase
+		RowOffset:       int32(filter.Offset), //nolint:gosec // capped via MaxPage
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*entity.Vendor, len(vendors))
+	for i, v := range vendors {
+		result[i] = toVendorEntity(&v)
+	}
+
+	return result, nil
+}
+
+func (r *vendorRepository) Count(
+	ctx context.Context,
+	filter *repository.VendorFilter,
+) (int64, error) {
+	return queriesFor(
+		ctx,
+		r.queries,
+	).CountVendorsByCompanyID(ctx, sqlc.CountVendorsByCompanyIDParams{
+		CompanyID:       filter.CompanyID,
+		Name:            toLikePattern(filter.Name),
+		NameKana:        toLikePattern(filter.NameKana),
+		IncludeArchived: filter.IncludeArchived,
+	})
+}
+
 func (r *vendorRepository) Create(
 	ctx context.Context,
 	vendor *entity.Vendor,
 ) (*entity.Vendor, error) {
-	created, err := r.queries.CreateVendor(ctx, sqlc.CreateVendorParams{
-		CompanyID:          vendor.CompanyID,
-		Name:               vendor.Name,
-		RepresentativeName: vendor.RepresentativeName,
-		PhoneNumber:        vendor.PhoneNumber,
-		ZipCode:            vendor.ZipCode,
-		Address:            vendor.Address,
+	created, err := queriesFor(ctx, r.queries).CreateVendor(ctx, sqlc.CreateVendorParams{
+		CompanyID:                vendor.CompanyID,
+		Name:                     vendor.Name,
+		RepresentativeName:       vendor.RepresentativeName,
+		PhoneNumber:              vendor.PhoneNumber,
+		ZipCode:                  vendor.ZipCode,
+		Address:                  vendor.Address,
+		RegistrationNumber:       toNullableString(vendor.RegistrationNumber),
+		WithholdingTaxApplicable: vendor.WithholdingTaxApplicable,
+		ExternalCode:             toNullableString(vendor.ExternalCode),
+		NameKana:                 toNullableString(vendor.NameKana),
 	})
 	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, domain.ErrAlreadyExists
+		}
+
 		return nil, err
 	}
 
@@ -97,13 +175,44 @@ func (r *vendorRepository) Update(
 	ctx context.Context,
 	vendor *entity.Vendor,
 ) (*entity.Vendor, error) {
-	updated, err := r.queries.UpdateVendor(ctx, sqlc.UpdateVendorParams{
-		ID:                 vendor.ID,
-		Name:               vendor.Name,
-		RepresentativeName: vendor.RepresentativeName,
-		PhoneNumber:        vendor.PhoneNumber,
-		ZipCode:            vendor.ZipCode,
-		Address:            vendor.Address,
+	updated, err := queriesFor(ctx, r.queries).UpdateVendor(ctx, sqlc.UpdateVendorParams{
+		ID:                       vendor.ID,
+		Name:                     vendor.Name,
+		RepresentativeName:       vendor.RepresentativeName,
+		PhoneNumber:              vendor.PhoneNumber,
+		ZipCode:                  vendor.ZipCode,
+		Address:                  vendor.Address,
+		RegistrationNumber:       toNullableString(vendor.RegistrationNumber),
+		WithholdingTaxApplicable: vendor.WithholdingTaxApplicable,
+		ExternalCode:             toNullableString(vendor.ExternalCode),
+		NameKana:                 toNullableString(vendor.NameKana),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+
+		if isUniqueViolation(err) {
+			return nil, domain.ErrAlreadyExists
+		}
+
+		return nil, err
+	}
+
+	return toVendorEntity(&updated), nil
+}
+
+func (r *vendorRepository) UpdateArchivedAt(
+	ctx context.Context,
+	vendor *entity.Vendor,
+) (*entity.Vendor, error) {
+	updated, err := queriesFor(
+		ctx,
+		r.queries,
+	).UpdateVendorArchivedAt(ctx, sqlc.UpdateVendorArchivedAtParams{
+		ID:         vendor.ID,
+		CompanyID:  vendor.CompanyID,
+		ArchivedAt: toNullableTimestamptz(vendor.ArchivedAt),
 	})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -118,18 +227,35 @@ func (r *vendorRepository) Update(
 
 func toVendorEntity(v *sqlc.Vendor) *entity.Vendor {
 	return &entity.Vendor{
-		ID:                 v.ID,
-		CompanyID:          v.CompanyID,
-		Name:               v.Name,
-		RepresentativeName: v.RepresentativeName,
-		PhoneNumber:        v.PhoneNumber,
-		ZipCode:            v.ZipCode,
-		Address:            v.Address,
-		CreatedAt:          v.CreatedAt.Time,
-		UpdatedAt:          v.UpdatedAt.Time,
+		ID:                       v.ID,
+		CompanyID:                v.CompanyID,
+		ExternalCode:             fromNullableString(v.ExternalCode),
+		Name:                     v.Name,
+		NameKana:                 fromNullableString(v.NameKana),
+		RepresentativeName:       v.RepresentativeName,
+		PhoneNumber:              v.PhoneNumber,
+		ZipCode:                  v.ZipCode,
+		Address:                  v.Address,
+		RegistrationNumber:       fromNullableString(v.RegistrationNumber),
+		WithholdingTaxApplicable: v.WithholdingTaxApplicable,
+		ArchivedAt:               fromNullableTimestamptz(v.ArchivedAt),
+		CreatedAt:                v.CreatedAt.Time,
+		UpdatedAt:                v.UpdatedAt.Time,
 	}
 }
 
+// toLikePattern maps an empty search term to SQL NULL and escapes the LIKE
+// wildcards so that user input is matched literally.
+func toLikePattern(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+
+	return &escaped
+}
+
 type vendorBankAccountRepository struct {
 	pool    *pgxpool.Pool
 	queries *sqlc.Queries
@@ -147,7 +273,7 @@ func (r *vendorBankAccountRepository) GetByID(
 	ctx context.Context,
 	id int64,
 ) (*entity.VendorBankAccount, error) {
-	account, err := r.queries.GetVendorBankAccountByID(ctx, id)
+	account, err := queriesFor(ctx, r.queries).GetVendorBankAccountByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, domain.ErrNotFound
@@ -163,7 +289,7 @@ func (r *vendorBankAccountRepository) GetByIDAndVendorID(
 	ctx context.Context,
 	id, vendorID int64,
 ) (*entity.VendorBankAccount, error) {
-	account, err := r.queries.GetVendorBankAccountByIDAndVendorID(
+	account, err := queriesFor(ctx, r.queries).GetVendorBankAccountByIDAndVendorID(
 		ctx,
 		sqlc.GetVendorBankAccountByIDAndVendorIDParams{
 			ID:       id,
@@ -185,7 +311,7 @@ func (r *vendorBankAccountRepository) GetByVendorID(
 	ctx context.Context,
 	vendorID int64,
 ) ([]*entity.VendorBankAccount, error) {
-	accounts, err := r.queries.GetVendorBankAccountsByVendorID(ctx, vendorID)
+	accounts, err := queriesFor(ctx, r.queries).GetVendorBankAccountsByVendorID(ctx, vendorID)
 	if err != nil {
 		return nil, err
 	}
@@ -198,18 +324,47 @@ func (r *vendorBankAccountRepository) GetByVendorID(
 	return result, nil
 }
 
+func (r *vendorBankAccountRepository) GetDefaultByVendorID(
+	ctx context.Context,
+	vendorID int64,
+) (*entity.VendorBankAccount, error) {
+	account, err := queriesFor(ctx, r.queries).GetDefaultVendorBankAccountByVendorID(ctx, vendorID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return toVendorBankAccountEntity(&account), nil
+}
+
 func (r *vendorBankAccountRepository) Create(
 	ctx context.Context,
 	account *entity.VendorBankAccount,
 ) (*entity.VendorBankAccount, error) {
-	created, err := r.queries.CreateVendorBankAccount(ctx, sqlc.CreateVendorBankAccountParams{
+	created, err := queriesFor(
+		ctx,
+		r.queries,
+	).CreateVendorBankAccount(ctx, sqlc.CreateVendorBankAccountParams{
 		VendorID:          account.VendorID,
 		BankName:          account.BankName,
 		BranchName:        account.BranchName,
 		AccountNumber:     account.AccountNumber,
 		AccountHolderName: account.AccountHolderName,
+		Status:            string(account.Status),
+		ChangedBy:         toNullableInt64(account.ChangedBy),
+		CoolingOffUntil:   toNullableTimestamptz(account.CoolingOffUntil),
+		IsDefault:         account.IsDefault,
+		BankCode:          toNullableString(account.BankCode),
+		BranchCode:        toNullableString(account.BranchCode),
 	})
 	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, domain.ErrAlreadyExists
+		}
+
 		return nil, err
 	}
 
@@ -220,12 +375,20 @@ func (r *vendorBankAccountRepository) Update(
 	ctx context.Context,
 	account *entity.VendorBankAccount,
 ) (*entity.VendorBankAccount, error) {
-	updated, err := r.queries.UpdateVendorBankAccount(ctx, sqlc.UpdateVendorBankAccountParams{
+	updated, err := queriesFor(
+		ctx,
+		r.queries,
+	).UpdateVendorBankAccount(ctx, sqlc.UpdateVendorBankAccountParams{
 		ID:                account.ID,
 		BankName:          account.BankName,
 		BranchName:        account.BranchName,
 		AccountNumber:     account.AccountNumber,
 		AccountHolderName: account.AccountHolderName,
+		Status:            string(account.Status),
+		ChangedBy:         toNullableInt64(account.ChangedBy),
+		CoolingOffUntil:   toNullableTimestamptz(account.CoolingOffUntil),
+		BankCode:          toNullableString(account.BankCode),
+		BranchCode:        toNullableString(account.BranchCode),
 	})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -238,14 +401,130 @@ func (r *vendorBankAccountRepository) Update(
 	return toVendorBankAccountEntity(&updated), nil
 }
 
+func (r *vendorBankAccountRepository) Verify(
+	ctx context.Context,
+	account *entity.VendorBankAccount,
+) (*entity.VendorBankAccount, error) {
+	verified, err := queriesFor(
+		ctx,
+		r.queries,
+	).VerifyVendorBankAccount(ctx, sqlc.VerifyVendorBankAccountParams{
+		ID:         account.ID,
+		VerifiedBy: toNullableInt64(account.VerifiedBy),
+		VerifiedAt: toNullableTimestamptz(account.VerifiedAt),
+		ChangedBy:  toNullableInt64(account.ChangedBy),
+		UpdatedAt:  toNullableTimestamptz(&account.UpdatedAt),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf(
+				"%w: bank account was changed or verified concurrently",
+				domain.ErrInvalidState,
+			)
+		}
+
+		return nil, err
+	}
+
+	return toVendorBankAccountEntity(&verified), nil
+}
+
+func (r *vendorBankAccountRepository) UpdateArchivedAt(
+	ctx context.Context,
+	account *entity.VendorBankAccount,
+) (*entity.VendorBankAccount, error) {
+	updated, err := queriesFor(ctx, r.queries).UpdateVendorBankAccountArchivedAt(
+		ctx,
+		sqlc.UpdateVendorBankAccountArchivedAtParams{
+			ID:         account.ID,
+			VendorID:   account.VendorID,
+			ArchivedAt: toNullableTimestamptz(account.ArchivedAt),
+			IsDefault:  account.IsDefault,
+		},
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return toVendorBankAccountEntity(&updated), nil
+}
+
+func (r *vendorBankAccountRepository) SetDefault(ctx context.Context, id, vendorID int64) error {
+	// Clear the current default first; the partial unique index allows only one
+	// default account per vendor.
+	tx, err := beginTx(ctx, r.pool)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // no-op after commit
+
+	qtx := r.queries.WithTx(tx)
+
+	if err := qtx.ClearDefaultVendorBankAccount(ctx, vendorID); err != nil {
+		return err
+	}
+
+	rows, err := qtx.SetDefaultVendorBankAccount(ctx, sqlc.SetDefaultVendorBankAccountParams{
+		ID:       id,
+		VendorID: vendorID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *vendorBankAccountRepository) Delete(ctx context.Context, id, vendorID int64) error {
+	rows, err := queriesFor(
+		ctx,
+		r.queries,
+	).DeleteVendorBankAccount(ctx, sqlc.DeleteVendorBankAccountParams{
+		ID:       id,
+		VendorID: vendorID,
+	})
+	if err != nil {
+		// invoices.vendor_bank_account_id and
+		// recurring_invoice_schedules.vendor_bank_account_id are ON DELETE RESTRICT
+		if isForeignKeyViolation(err) {
+			return domain.ErrInUse
+		}
+
+		return err
+	}
+
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
 func toVendorBankAccountEntity(a *sqlc.VendorBankAccount) *entity.VendorBankAccount {
 	return &entity.VendorBankAccount{
 		ID:                a.ID,
 		VendorID:          a.VendorID,
+		BankCode:          fromNullableString(a.BankCode),
 		BankName:          a.BankName,
+		BranchCode:        fromNullableString(a.BranchCode),
 		BranchName:        a.BranchName,
 		AccountNumber:     a.AccountNumber,
 		AccountHolderName: a.AccountHolderName,
+		IsDefault:         a.IsDefault,
+		Status:            entity.BankAccountStatus(a.Status),
+		ChangedBy:         fromNullableInt64(a.ChangedBy),
+		CoolingOffUntil:   fromNullableTimestamptz(a.CoolingOffUntil),
+		VerifiedBy:        fromNullableInt64(a.VerifiedBy),
+		VerifiedAt:        fromNullableTimestamptz(a.VerifiedAt),
+		ArchivedAt:        fromNullableTimestamptz(a.ArchivedAt),
 		CreatedAt:         a.CreatedAt.Time,
 		UpdatedAt:         a.UpdatedAt.Time,
 	}
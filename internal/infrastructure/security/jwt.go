@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
 	"github.com/harusys/super-shiharai-kun/internal/infrastructure"
 	"github.com/harusys/super-shiharai-kun/pkg/ctxutil"
 )
@@ -16,65 +17,126 @@ var ErrInvalidToken = errors.New("invalid token")
 // ErrExpiredToken is returned when the token is expired.
 var ErrExpiredToken = errors.New("expired token")
 
-// Claims represents JWT claims.
+// TokenType tells access tokens, refresh tokens and MFA tokens apart, so that
+// none can be used in place of another.
+type TokenType string
+
+const (
+	TokenTypeAccess  TokenType = "access"
+	TokenTypeRefresh TokenType = "refresh"
+	// TokenTypeMFA is the type of tokens that stand for a login waiting for
+	// its second factor.
+	TokenTypeMFA TokenType = "mfa"
+)
+
+// Claims represents JWT claims. Every token has a random ID (jti) by which it
+// can be revoked.
 type Claims struct {
-	UserID    int64 `json:"user_id"`
-	CompanyID int64 `json:"company_id"`
+	UserID    int64           `json:"user_id"`
+	CompanyID int64           `json:"company_id"`
+	Role      entity.UserRole `json:"role"`
+	TokenType TokenType       `json:"typ"`
+	// SessionID identifies the login the token was issued for. Refreshed
+	// tokens keep the session of the refresh token.
+	SessionID string `json:"sid"`
 	jwt.RegisteredClaims
 }
 
-// JWTService provides JWT token generation and validation.
+// JWTService provides JWT token generation and validation. Tokens are signed
+// with the private key of a KeySet, so services that only verify tokens need
+// nothing but its public keys.
 type JWTService struct {
-	secretKey     []byte
+	keys          *KeySet
 	accessExpiry  time.Duration
 	refreshExpiry time.Duration
+	mfaExpiry     time.Duration
 }
 
 // NewJWTService creates a new JWTService.
-func NewJWTService(secretKey string) *JWTService {
+func NewJWTService(keys *KeySet) *JWTService {
 	return &JWTService{
-		secretKey:     []byte(secretKey),
+		keys:          keys,
 		accessExpiry:  infrastructure.AccessTokenExpiry,
 		refreshExpiry: infrastructure.RefreshTokenExpiry,
+		mfaExpiry:     infrastructure.MFATokenExpiry,
 	}
 }
 
+// JWKS returns the public keys tokens are verified with.
+func (s *JWTService) JWKS() *JWKS {
+	return s.keys.JWKS()
+}
+
 // GenerateAccessToken generates an access token and returns the token with its expiration time.
 func (s *JWTService) GenerateAccessToken(
 	ctx context.Context,
 	userID, companyID int64,
+	role entity.UserRole,
+	sessionID string,
 ) (string, time.Time, error) {
-	return s.generateToken(ctx, userID, companyID, s.accessExpiry)
+	return s.generateToken(ctx, &Claims{
+		UserID:    userID,
+		CompanyID: companyID,
+		Role:      role,
+		TokenType: TokenTypeAccess,
+		SessionID: sessionID,
+	}, s.accessExpiry)
 }
 
 // GenerateRefreshToken generates a refresh token and returns the token with its expiration time.
 func (s *JWTService) GenerateRefreshToken(
 	ctx context.Context,
 	userID, companyID int64,
+	role entity.UserRole,
+	sessionID string,
 ) (string, time.Time, error) {
-	return s.generateToken(ctx, userID, companyID, s.refreshExpiry)
+	return s.generateToken(ctx, &Claims{
+		UserID:    userID,
+		CompanyID: companyID,
+		Role:      role,
+		TokenType: TokenTypeRefresh,
+		SessionID: sessionID,
+	}, s.refreshExpiry)
 }
 
-func (s *JWTService) generateToken(
+// GenerateMFAToken generates a token for a login whose password has been
+// verified and that has yet to give its second factor, and returns the token
+// with its expiration time.
+func (s *JWTService) GenerateMFAToken(
 	ctx context.Context,
 	userID, companyID int64,
-	expiry time.Duration,
 ) (string, time.Time, error) {
-	now := ctxutil.Now(ctx)
-	expiresAt := now.Add(expiry)
-	claims := &Claims{
+	return s.generateToken(ctx, &Claims{
 		UserID:    userID,
 		CompanyID: companyID,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(expiresAt),
-			IssuedAt:  jwt.NewNumericDate(now),
-			NotBefore: jwt.NewNumericDate(now),
-		},
+		TokenType: TokenTypeMFA,
+	}, s.mfaExpiry)
+}
+
+// generateToken signs claims with a new token ID, so that no two tokens are the
+// same even when issued to the same user at the same second.
+func (s *JWTService) generateToken(
+	ctx context.Context,
+	claims *Claims,
+	expiry time.Duration,
+) (string, time.Time, error) {
+	id, err := GenerateToken()
+	if err != nil {
+		return "", time.Time{}, err
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	now := ctxutil.Now(ctx)
+	expiresAt := now.Add(expiry)
+	claims.ID = id
+	claims.ExpiresAt = jwt.NewNumericDate(expiresAt)
+	claims.IssuedAt = jwt.NewNumericDate(now)
+	claims.NotBefore = jwt.NewNumericDate(now)
+
+	signing := s.keys.signing()
+	token := jwt.NewWithClaims(signing.method, claims)
+	token.Header["kid"] = signing.jwk.KeyID
 
-	tokenString, err := token.SignedString(s.secretKey)
+	tokenString, err := token.SignedString(s.keys.signingKey)
 	if err != nil {
 		return "", time.Time{}, err
 	}
@@ -82,18 +144,41 @@ func (s *JWTService) generateToken(
 	return tokenString, expiresAt, nil
 }
 
-// ValidateToken validates a token and returns claims.
-func (s *JWTService) ValidateToken(tokenString string) (*Claims, error) {
+// ValidateAccessToken validates an access token and returns claims.
+func (s *JWTService) ValidateAccessToken(tokenString string) (*Claims, error) {
+	return s.validateToken(tokenString, TokenTypeAccess)
+}
+
+// ValidateRefreshToken validates a refresh token and returns claims.
+func (s *JWTService) ValidateRefreshToken(tokenString string) (*Claims, error) {
+	return s.validateToken(tokenString, TokenTypeRefresh)
+}
+
+// ValidateMFAToken validates an MFA token and returns claims.
+func (s *JWTService) ValidateMFAToken(tokenString string) (*Claims, error) {
+	return s.validateToken(tokenString, TokenTypeMFA)
+}
+
+// validateToken verifies a token with the key its kid header names. The key
+// decides the algorithm, so a token cannot pick a weaker one.
+func (s *JWTService) validateToken(tokenString string, tokenType TokenType) (*Claims, error) {
 	token, err := jwt.ParseWithClaims(
 		tokenString,
 		&Claims{},
-		func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		func(token *jwt.Token) (any, error) {
+			keyID, _ := token.Header["kid"].(string)
+
+			key, ok := s.keys.verificationKey(keyID)
+			if !ok || token.Method.Alg() != key.method.Alg() {
 				return nil, ErrInvalidToken
 			}
 
-			return s.secretKey, nil
+			return key.public, nil
 		},
+		jwt.WithValidMethods([]string{
+			jwt.SigningMethodRS256.Alg(),
+			jwt.SigningMethodEdDSA.Alg(),
+		}),
 	)
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
@@ -104,7 +189,8 @@ func (s *JWTService) ValidateToken(tokenString string) (*Claims, error) {
 	}
 
 	claims, ok := token.Claims.(*Claims)
-	if !ok || !token.Valid {
+	if !ok || !token.Valid || claims.TokenType != tokenType || claims.ID == "" ||
+		claims.IssuedAt == nil {
 		return nil, ErrInvalidToken
 	}
 
@@ -0,0 +1,267 @@
+package security
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrInvalidKey is returned when a key cannot be used to sign or verify tokens.
+var ErrInvalidKey = errors.New("invalid key")
+
+// minRSAKeyBits is the smallest RSA modulus accepted for RS256.
+const minRSAKeyBits = 2048
+
+// JWK is a public key in JSON Web Key format (RFC 7517).
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+}
+
+// JWKS is a set of public keys in JSON Web Key Set format.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// verificationKey is a public key tokens are verified with.
+type verificationKey struct {
+	method jwt.SigningMethod
+	public crypto.PublicKey
+	jwk    JWK
+}
+
+// KeySet holds the private key tokens are signed with and the public keys they
+// are verified with. Keys are identified by their JWK thumbprint (RFC 7638),
+// which is put in the kid header of every token.
+//
+// During a key rotation the set holds the new signing key together with the
+// public keys of earlier signing keys, so that tokens signed before the
+// rotation keep verifying until they expire.
+type KeySet struct {
+	signingKey   crypto.Signer
+	signingKeyID string
+	keys         map[string]verificationKey
+	// keyIDs keeps the order keys were added in, signing key first.
+	keyIDs []string
+}
+
+// NewKeySet creates a KeySet that signs with signingKey, an RSA or Ed25519
+// private key, and verifies with its public key and verificationKeys.
+func NewKeySet(signingKey crypto.Signer, verificationKeys ...crypto.PublicKey) (*KeySet, error) {
+	signing, err := newVerificationKey(signingKey.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	set := &KeySet{
+		signingKey:   signingKey,
+		signingKeyID: signing.jwk.KeyID,
+		keys:         make(map[string]verificationKey),
+	}
+	set.add(signing)
+
+	for _, public := range verificationKeys {
+		key, err := newVerificationKey(public)
+		if err != nil {
+			return nil, err
+		}
+
+		set.add(key)
+	}
+
+	return set, nil
+}
+
+// LoadKeySet creates a KeySet from PEM files: a private key to sign with and
+// public keys to verify with besides it.
+func LoadKeySet(signingKeyFile string, verificationKeyFiles []string) (*KeySet, error) {
+	block, err := readPEM(signingKeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	signingKey, err := parsePrivateKey(block)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", signingKeyFile, err)
+	}
+
+	verificationKeys := make([]crypto.PublicKey, 0, len(verificationKeyFiles))
+
+	for _, file := range verificationKeyFiles {
+		block, err := readPEM(file)
+		if err != nil {
+			return nil, err
+		}
+
+		public, err := parsePublicKey(block)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+
+		verificationKeys = append(verificationKeys, public)
+	}
+
+	return NewKeySet(signingKey, verificationKeys...)
+}
+
+// JWKS returns the public keys tokens are verified with.
+func (s *KeySet) JWKS() *JWKS {
+	jwks := &JWKS{Keys: make([]JWK, 0, len(s.keyIDs))}
+	for _, id := range s.keyIDs {
+		jwks.Keys = append(jwks.Keys, s.keys[id].jwk)
+	}
+
+	return jwks
+}
+
+func (s *KeySet) add(key verificationKey) {
+	if _, ok := s.keys[key.jwk.KeyID]; ok {
+		return
+	}
+
+	s.keys[key.jwk.KeyID] = key
+	s.keyIDs = append(s.keyIDs, key.jwk.KeyID)
+}
+
+func (s *KeySet) signing() verificationKey {
+	return s.keys[s.signingKeyID]
+}
+
+func (s *KeySet) verificationKey(keyID string) (verificationKey, bool) {
+	key, ok := s.keys[keyID]
+
+	return key, ok
+}
+
+// newVerificationKey picks the signing method for a public key and describes it
+// as a JWK: RS256 for RSA keys, EdDSA for Ed25519 keys.
+func newVerificationKey(public crypto.PublicKey) (verificationKey, error) {
+	encode := base64.RawURLEncoding.EncodeToString
+
+	switch key := public.(type) {
+	case *rsa.PublicKey:
+		if key.N.BitLen() < minRSAKeyBits {
+			return verificationKey{}, fmt.Errorf(
+				"%w: RSA keys must be at least %d bits",
+				ErrInvalidKey,
+				minRSAKeyBits,
+			)
+		}
+
+		jwk := JWK{
+			KeyType:   "RSA",
+			Use:       "sig",
+			Algorithm: jwt.SigningMethodRS256.Alg(),
+			N:         encode(key.N.Bytes()),
+			E:         encode(big.NewInt(int64(key.E)).Bytes()),
+		}
+		jwk.KeyID = thumbprint(fmt.Sprintf(`{"e":%q,"kty":"RSA","n":%q}`, jwk.E, jwk.N))
+
+		return verificationKey{method: jwt.SigningMethodRS256, public: key, jwk: jwk}, nil
+	case ed25519.PublicKey:
+		jwk := JWK{
+			KeyType:   "OKP",
+			Use:       "sig",
+			Algorithm: jwt.SigningMethodEdDSA.Alg(),
+			Curve:     "Ed25519",
+			X:         encode(key),
+		}
+		jwk.KeyID = thumbprint(fmt.Sprintf(`{"crv":"Ed25519","kty":"OKP","x":%q}`, jwk.X))
+
+		return verificationKey{method: jwt.SigningMethodEdDSA, public: key, jwk: jwk}, nil
+	default:
+		return verificationKey{}, fmt.Errorf(
+			"%w: only RSA and Ed25519 keys are supported",
+			ErrInvalidKey,
+		)
+	}
+}
+
+// thumbprint returns the JWK thumbprint of the canonical JSON of a key's
+// required members.
+func thumbprint(canonical string) string {
+	sum := sha256.Sum256([]byte(canonical))
+
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func readPEM(file string) (*pem.Block, error) {
+	data, err := os.ReadFile(file) //nolint:gosec // the path comes from configuration
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key: %w", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%w: %s is not PEM encoded", ErrInvalidKey, file)
+	}
+
+	return block, nil
+}
+
+func parsePrivateKey(block *pem.Block) (crypto.Signer, error) {
+	var (
+		key any
+		err error
+	)
+
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("%w: unexpected PEM block %q", ErrInvalidKey, block.Type)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidKey, err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("%w: not a signing key", ErrInvalidKey)
+	}
+
+	return signer, nil
+}
+
+// parsePublicKey parses a public key. Private keys are refused, so that the
+// services verifying tokens are never handed a key that can sign them.
+func parsePublicKey(block *pem.Block) (crypto.PublicKey, error) {
+	var (
+		key any
+		err error
+	)
+
+	switch block.Type {
+	case "PUBLIC KEY":
+		key, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		key, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("%w: unexpected PEM block %q", ErrInvalidKey, block.Type)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidKey, err)
+	}
+
+	return key, nil
+}
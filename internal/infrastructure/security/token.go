@@ -0,0 +1,30 @@
+package security
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// tokenBytes is the number of random bytes in a token.
+const tokenBytes = 32
+
+// GenerateToken returns a random URL-safe token for single-use links such as
+// invitations.
+func GenerateToken() (string, error) {
+	b := make([]byte, tokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashToken returns the hex-encoded SHA-256 of a token. Tokens are stored only
+// as hashes so that a database leak does not expose usable links.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
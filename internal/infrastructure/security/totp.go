@@ -0,0 +1,117 @@
+package security
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base32"
+	"strings"
+	"time"
+
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+)
+
+// TOTP parameters. Authenticator apps assume these RFC 6238 defaults and some
+// ignore other values in the provisioning URI.
+const (
+	totpPeriod     = 30
+	totpDigits     = otp.DigitsSix
+	totpSecretSize = 20
+	// totpSkew is the number of time steps accepted either side of the
+	// current one, for clocks that are slightly off.
+	totpSkew = 1
+)
+
+// recoveryCodeBytes is the number of random bytes in a recovery code, which
+// encode to 10 Base32 characters.
+const recoveryCodeBytes = 6
+
+// TOTPKey is a new TOTP secret and the otpauth:// URI that shares it with an
+// authenticator app, usually as a QR code.
+type TOTPKey struct {
+	Secret          string
+	ProvisioningURI string
+}
+
+// GenerateTOTPKey returns a new random TOTP secret for the account, labelled
+// with the issuer in authenticator apps.
+func GenerateTOTPKey(issuer, accountName string) (*TOTPKey, error) {
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      issuer,
+		AccountName: accountName,
+		Period:      totpPeriod,
+		SecretSize:  totpSecretSize,
+		Digits:      totpDigits,
+		Algorithm:   otp.AlgorithmSHA1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &TOTPKey{Secret: key.Secret(), ProvisioningURI: key.URL()}, nil
+}
+
+// IsTOTPCode reports whether code has the form of a TOTP code rather than a
+// recovery code.
+func IsTOTPCode(code string) bool {
+	if len(code) != totpDigits.Length() {
+		return false
+	}
+
+	for _, c := range code {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// MatchTOTP reports whether code is the TOTP code of the secret at now, within
+// the allowed skew, and returns the time step it belongs to. Callers refuse
+// steps that have already been used, so that a code works only once.
+func MatchTOTP(secret, code string, now time.Time) (int64, bool) {
+	for skew := -totpSkew; skew <= totpSkew; skew++ {
+		at := now.Add(time.Duration(skew*totpPeriod) * time.Second)
+
+		expected, err := totp.GenerateCodeCustom(secret, at, totp.ValidateOpts{
+			Period:    totpPeriod,
+			Digits:    totpDigits,
+			Algorithm: otp.AlgorithmSHA1,
+		})
+		if err != nil {
+			return 0, false
+		}
+
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return at.Unix() / totpPeriod, true
+		}
+	}
+
+	return 0, false
+}
+
+// GenerateRecoveryCode returns a random single-use code, such as "k7d2m-q4xpa",
+// that stands in for a TOTP code when the authenticator is lost.
+func GenerateRecoveryCode() (string, error) {
+	b := make([]byte, recoveryCodeBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	code := strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b))
+
+	return code[:5] + "-" + code[5:], nil
+}
+
+// NormalizeRecoveryCode returns the form of a recovery code that is hashed, so
+// that the code may be typed in any case, with or without separators.
+func NormalizeRecoveryCode(code string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+
+		return r
+	}, strings.ToLower(strings.TrimSpace(code)))
+}
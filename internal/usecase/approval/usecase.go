@@ -0,0 +1,27 @@
+//go:generate mockgen -source=$GOFILE -destination=mock/mock_usecase.go -package=mock
+
+package approval
+
+import (
+	"context"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+)
+
+// UpdatePolicyInput is the input for updating a company's approval policy.
+type UpdatePolicyInput struct {
+	CompanyID int64
+	// UserID is the user making the change, who must be an admin.
+	UserID               int64
+	AmountThreshold      int64
+	RequireForNewVendors bool
+}
+
+// Usecase defines approval policy operations.
+type Usecase interface {
+	// GetPolicy returns the approval policy of a company. Companies without a
+	// policy get one that never requires approval.
+	GetPolicy(ctx context.Context, companyID int64) (*entity.ApprovalPolicy, error)
+	// UpdatePolicy replaces the approval policy of a company.
+	UpdatePolicy(ctx context.Context, input *UpdatePolicyInput) (*entity.ApprovalPolicy, error)
+}
@@ -0,0 +1,63 @@
+package approval
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+	"github.com/harusys/super-shiharai-kun/internal/domain/repository"
+)
+
+type usecaseImpl struct {
+	policyRepo repository.ApprovalPolicyRepository
+	userRepo   repository.UserRepository
+}
+
+// NewUsecase creates a new approval policy Usecase.
+func NewUsecase(
+	policyRepo repository.ApprovalPolicyRepository,
+	userRepo repository.UserRepository,
+) Usecase {
+	return &usecaseImpl{
+		policyRepo: policyRepo,
+		userRepo:   userRepo,
+	}
+}
+
+func (u *usecaseImpl) GetPolicy(
+	ctx context.Context,
+	companyID int64,
+) (*entity.ApprovalPolicy, error) {
+	policy, err := u.policyRepo.GetByCompanyID(ctx, companyID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return &entity.ApprovalPolicy{CompanyID: companyID}, nil
+		}
+
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+func (u *usecaseImpl) UpdatePolicy(
+	ctx context.Context,
+	input *UpdatePolicyInput,
+) (*entity.ApprovalPolicy, error) {
+	user, err := u.userRepo.GetByIDAndCompanyID(ctx, input.UserID, input.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.CompanyID != input.CompanyID || !user.IsAdmin() {
+		return nil, fmt.Errorf("%w: admin role is required", domain.ErrForbidden)
+	}
+
+	return u.policyRepo.Upsert(ctx, &entity.ApprovalPolicy{
+		CompanyID:            input.CompanyID,
+		AmountThreshold:      input.AmountThreshold,
+		RequireForNewVendors: input.RequireForNewVendors,
+	})
+}
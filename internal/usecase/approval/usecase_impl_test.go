@@ -0,0 +1,123 @@
+package approval_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+	"github.com/harusys/super-shiharai-kun/internal/domain/repository/mock"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/approval"
+	"github.com/harusys/super-shiharai-kun/pkg/ctxutil/ctxutiltest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUsecaseImpl_GetPolicy(t *testing.T) {
+	t.Parallel()
+
+	ctx, uc, c := newUsecase(t)
+	defer c.ctrl.Finish()
+
+	c.policyRepo.EXPECT().
+		GetByCompanyID(ctx, int64(1)).
+		Return(nil, domain.ErrNotFound)
+
+	got, err := uc.GetPolicy(ctx, 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, &entity.ApprovalPolicy{CompanyID: 1}, got)
+}
+
+func TestUsecaseImpl_UpdatePolicy(t *testing.T) {
+	t.Parallel()
+
+	input := &approval.UpdatePolicyInput{
+		CompanyID:            1,
+		UserID:               10,
+		AmountThreshold:      1000000,
+		RequireForNewVendors: true,
+	}
+
+	tests := []struct {
+		name    string
+		prepare func(ctx context.Context, c *controllers)
+		want    *entity.ApprovalPolicy
+		wantErr error
+	}{
+		{
+			name: "success",
+			prepare: func(ctx context.Context, c *controllers) {
+				c.userRepo.EXPECT().
+					GetByIDAndCompanyID(ctx, int64(10), int64(1)).
+					Return(&entity.User{ID: 10, CompanyID: 1, Role: entity.UserRoleAdmin}, nil)
+				c.policyRepo.EXPECT().
+					Upsert(ctx, &entity.ApprovalPolicy{
+						CompanyID:            1,
+						AmountThreshold:      1000000,
+						RequireForNewVendors: true,
+					}).
+					Return(&entity.ApprovalPolicy{CompanyID: 1, AmountThreshold: 1000000}, nil)
+			},
+			want: &entity.ApprovalPolicy{CompanyID: 1, AmountThreshold: 1000000},
+		},
+		{
+			name: "not an admin",
+			prepare: func(ctx context.Context, c *controllers) {
+				c.userRepo.EXPECT().
+					GetByIDAndCompanyID(ctx, int64(10), int64(1)).
+					Return(&entity.User{ID: 10, CompanyID: 1, Role: entity.UserRoleApprover}, nil)
+			},
+			wantErr: domain.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, uc, c := newUsecase(t)
+			defer c.ctrl.Finish()
+
+			tt.prepare(ctx, c)
+
+			got, err := uc.UpdatePolicy(ctx, input)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+type controllers struct {
+	ctrl       *gomock.Controller
+	policyRepo *mock.MockApprovalPolicyRepository
+	userRepo   *mock.MockUserRepository
+}
+
+func newUsecase(t *testing.T) (context.Context, approval.Usecase, *controllers) {
+	t.Helper()
+
+	ctxProvider := ctxutiltest.TestContextProvider{}
+	ctx := ctxutiltest.TestContext(&ctxProvider)
+
+	ctrl := gomock.NewController(t)
+	policyRepo := mock.NewMockApprovalPolicyRepository(ctrl)
+	userRepo := mock.NewMockUserRepository(ctrl)
+
+	uc := approval.NewUsecase(policyRepo, userRepo)
+
+	return ctx, uc, &controllers{
+		ctrl:       ctrl,
+		policyRepo: policyRepo,
+		userRepo:   userRepo,
+	}
+}
@@ -5,6 +5,12 @@ package auth
 import (
 	"context"
 	"time"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+	"github.com/harusys/super-shiharai-kun/internal/infrastructure/security"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/company"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/invitation"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/mfa"
 )
 
 // Input is the input for authentication operations.
@@ -13,12 +19,20 @@ type Input struct {
 	Password string
 }
 
-// RegisterInput is the input for user registration.
+// RegisterInput is the input for signing up a new company and its first admin.
 type RegisterInput struct {
-	CompanyID int64
-	Name      string
-	Email     string
-	Password  string
+	Company  company.CreateInput
+	Name     string
+	Email    string
+	Password string
+}
+
+// ChangePasswordInput is the input for changing a user's password.
+type ChangePasswordInput struct {
+	UserID          int64
+	CompanyID       int64
+	CurrentPassword string
+	NewPassword     string
 }
 
 // TokenPair holds access and refresh tokens with their expiration times.
@@ -29,12 +43,62 @@ type TokenPair struct {
 	RefreshTokenExpiresAt time.Time
 }
 
+// LoginResult is the result of a login. A user who logs in with two-factor
+// authentication gets an MFA token instead of tokens, to give the second
+// factor with.
+type LoginResult struct {
+	// Tokens is nil when the login is waiting for the second factor.
+	Tokens            *TokenPair
+	MFAToken          string
+	MFATokenExpiresAt time.Time
+	// MFAEnrollmentRequired tells that the company requires two-factor
+	// authentication and the user has to enrol before giving a code.
+	MFAEnrollmentRequired bool
+	// RecoveryCodes are returned once, when the login completed enrolment.
+	RecoveryCodes []string
+}
+
 // Usecase defines authentication operations.
 type Usecase interface {
-	// Register creates a new user account.
+	// Register creates a new company and its first user, an admin, in a single
+	// transaction. Users join an existing company only through an invitation.
 	Register(ctx context.Context, input *RegisterInput) (*TokenPair, error)
-	// Login authenticates a user and returns tokens.
-	Login(ctx context.Context, input *Input) (*TokenPair, error)
-	// RefreshToken generates new tokens using a refresh token.
+	// AcceptInvitation creates the invited user and logs them in like Login.
+	AcceptInvitation(ctx context.Context, input *invitation.AcceptInput) (*LoginResult, error)
+	// Login authenticates a user with their password. The result holds tokens,
+	// or an MFA token if the user has two-factor authentication or the company
+	// requires it.
+	Login(ctx context.Context, input *Input) (*LoginResult, error)
+	// EnrollMFA starts the enrolment of a user logging in to a company that
+	// requires two-factor authentication, authenticated by the MFA token.
+	EnrollMFA(ctx context.Context, mfaToken string) (*mfa.Enrollment, error)
+	// VerifyMFA completes a login with a TOTP code or a recovery code and
+	// returns tokens. For a user who is enrolling, the code confirms the
+	// enrolment and the recovery codes are returned too. An MFA token
+	// completes one login only.
+	VerifyMFA(ctx context.Context, mfaToken, code string) (*LoginResult, error)
+	// RefreshToken generates new tokens using a refresh token. The tokens keep
+	// the company of the refresh token. If the company has come to require
+	// two-factor authentication the user has not enrolled in, ErrMFARequired
+	// is returned and the user has to log in again.
 	RefreshToken(ctx context.Context, refreshToken string) (*TokenPair, error)
+	// SwitchCompany returns tokens whose active company is companyID, with the
+	// user's role there. The user must be a member of the company, and have
+	// enrolled in two-factor authentication if the company requires it.
+	SwitchCompany(ctx context.Context, userID, companyID int64) (*TokenPair, error)
+	// ListCompanies returns the companies the user belongs to.
+	ListCompanies(ctx context.Context, userID int64) ([]*entity.Membership, error)
+	// Authenticate validates an access token and returns its claims. Tokens
+	// that have been logged out are rejected with ErrTokenRevoked.
+	Authenticate(ctx context.Context, accessToken string) (*security.Claims, error)
+	// Logout revokes the access token and the refresh tokens of its session.
+	Logout(ctx context.Context, claims *security.Claims) error
+	// LogoutAll revokes every token issued to the user, on every device.
+	LogoutAll(ctx context.Context, userID int64) error
+	// ChangePassword changes the user's password and revokes every token issued
+	// to the user. It returns tokens for a new session.
+	ChangePassword(ctx context.Context, input *ChangePasswordInput) (*TokenPair, error)
+	// JWKS returns the public keys access tokens are verified with, for other
+	// services to verify them without being able to issue them.
+	JWKS() *security.JWKS
 }
@@ -3,33 +3,77 @@ package auth
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/harusys/super-shiharai-kun/internal/domain"
 	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
 	"github.com/harusys/super-shiharai-kun/internal/domain/repository"
 	"github.com/harusys/super-shiharai-kun/internal/infrastructure"
+	"github.com/harusys/super-shiharai-kun/internal/infrastructure/database"
 	"github.com/harusys/super-shiharai-kun/internal/infrastructure/security"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/company"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/invitation"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/mfa"
 	"github.com/harusys/super-shiharai-kun/pkg/ctxutil"
 )
 
 var (
 	ErrInvalidCredentials = errors.New("invalid credentials")
 	ErrEmailAlreadyExists = errors.New("email already exists")
+	// ErrNotMember is returned when switching to a company the user does not
+	// belong to.
+	ErrNotMember = fmt.Errorf("%w: not a member of the company", domain.ErrForbidden)
+	// ErrRefreshTokenReused is returned when a refresh token that has already
+	// been exchanged is presented again. All tokens of its family are revoked.
+	ErrRefreshTokenReused = errors.New("refresh token reused")
+	// ErrTokenRevoked is returned when an access token has been logged out.
+	ErrTokenRevoked = errors.New("token revoked")
+	// ErrWrongPassword is returned when the current password given to change
+	// the password is not the user's.
+	ErrWrongPassword = fmt.Errorf("%w: current password is incorrect", domain.ErrInvalidInput)
+	// ErrMFARequired is returned when using a company that requires two-factor
+	// authentication the user has not enrolled in.
+	ErrMFARequired = fmt.Errorf(
+		"%w: the company requires two-factor authentication",
+		domain.ErrForbidden,
+	)
 )
 
 type usecaseImpl struct {
-	userRepo   repository.UserRepository
-	jwtService *security.JWTService
+	transactor     repository.Transactor
+	userRepo       repository.UserRepository
+	membershipRepo repository.MembershipRepository
+	tokenRepo      repository.RefreshTokenRepository
+	revocations    repository.TokenRevocationRepository
+	companies      company.Usecase
+	invitations    invitation.Usecase
+	mfa            mfa.Usecase
+	jwtService     *security.JWTService
 }
 
 // NewUsecase creates a new auth Usecase.
 func NewUsecase(
+	transactor repository.Transactor,
 	userRepo repository.UserRepository,
+	membershipRepo repository.MembershipRepository,
+	tokenRepo repository.RefreshTokenRepository,
+	revocations repository.TokenRevocationRepository,
+	companies company.Usecase,
+	invitations invitation.Usecase,
+	mfaUsecase mfa.Usecase,
 	jwtService *security.JWTService,
 ) Usecase {
 	return &usecaseImpl{
-		userRepo:   userRepo,
-		jwtService: jwtService,
+		transactor:     transactor,
+		userRepo:       userRepo,
+		membershipRepo: membershipRepo,
+		tokenRepo:      tokenRepo,
+		revocations:    revocations,
+		companies:      companies,
+		invitations:    invitations,
+		mfa:            mfaUsecase,
+		jwtService:     jwtService,
 	}
 }
 
@@ -50,26 +94,55 @@ func (u *usecaseImpl) Register(ctx context.Context, input *RegisterInput) (*Toke
 		return nil, err
 	}
 
-	// Create user
-	user := &entity.User{
-		CompanyID:    input.CompanyID,
-		Name:         input.Name,
-		Email:        input.Email,
-		PasswordHash: hashedPassword,
-	}
+	// Create the company and its first admin together, so that a failed signup
+	// leaves no company without users behind. There is no company to scope the
+	// queries to until it has been created.
+	signupCtx := database.WithAllCompanies(ctx)
+
+	var created *entity.User
+
+	err = u.transactor.WithinTransaction(signupCtx, func(ctx context.Context) error {
+		company, err := u.companies.Create(ctx, &input.Company)
+		if err != nil {
+			return err
+		}
 
-	created, err := u.userRepo.Create(ctx, user)
+		created, err = u.userRepo.Create(ctx, &entity.User{
+			CompanyID:    company.ID,
+			Name:         input.Name,
+			Email:        input.Email,
+			PasswordHash: hashedPassword,
+			Role:         entity.UserRoleAdmin,
+		})
+		if errors.Is(err, domain.ErrAlreadyExists) {
+			return ErrEmailAlreadyExists
+		}
+
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	// Generate tokens
-	return u.generateTokenPair(ctx, created.ID, created.CompanyID)
+	return u.generateTokenPair(ctx, created)
+}
+
+func (u *usecaseImpl) AcceptInvitation(
+	ctx context.Context,
+	input *invitation.AcceptInput,
+) (*LoginResult, error) {
+	user, err := u.invitations.Accept(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.login(ctx, user)
 }
 
-func (u *usecaseImpl) Login(ctx context.Context, input *Input) (*TokenPair, error) {
-	// Get user by email
-	user, err := u.userRepo.GetByEmail(ctx, input.Email)
+func (u *usecaseImpl) Login(ctx context.Context, input *Input) (*LoginResult, error) {
+	// Get user by email; the tokens start in the company they joined first
+	user, err := u.userRepo.GetByEmail(database.WithAllCompanies(ctx), input.Email)
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			return nil, ErrInvalidCredentials
@@ -83,19 +156,84 @@ func (u *usecaseImpl) Login(ctx context.Context, input *Input) (*TokenPair, erro
 		return nil, ErrInvalidCredentials
 	}
 
-	// Generate tokens
-	return u.generateTokenPair(ctx, user.ID, user.CompanyID)
+	return u.login(ctx, user)
+}
+
+func (u *usecaseImpl) EnrollMFA(ctx context.Context, mfaToken string) (*mfa.Enrollment, error) {
+	claims, err := u.validateMFAToken(ctx, mfaToken)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = database.WithUserID(ctx, claims.UserID)
+
+	return u.mfa.Enroll(ctx, claims.UserID, claims.CompanyID)
+}
+
+func (u *usecaseImpl) VerifyMFA(ctx context.Context, mfaToken, code string) (*LoginResult, error) {
+	claims, err := u.validateMFAToken(ctx, mfaToken)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = database.WithUserID(ctx, claims.UserID)
+
+	user, err := u.userRepo.GetByIDAndCompanyID(ctx, claims.UserID, claims.CompanyID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrInvalidCredentials
+		}
+
+		return nil, err
+	}
+
+	status, err := u.mfa.Status(ctx, user.ID, user.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+
+	// A user who is enrolling confirms the enrolment with their first code
+	var recoveryCodes []string
+
+	if status.Enabled {
+		err = u.mfa.Verify(ctx, user.ID, code)
+	} else {
+		recoveryCodes, err = u.mfa.Activate(ctx, user.ID, code)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.revocations.Revoke(ctx, claims.ID, claims.UserID, claims.ExpiresAt.Time)
+	if err != nil {
+		return nil, err
+	}
+
+	pair, err := u.generateTokenPair(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LoginResult{Tokens: pair, RecoveryCodes: recoveryCodes}, nil
 }
 
 func (u *usecaseImpl) RefreshToken(ctx context.Context, refreshToken string) (*TokenPair, error) {
 	// Validate refresh token
-	claims, err := u.jwtService.ValidateToken(refreshToken)
+	claims, err := u.jwtService.ValidateRefreshToken(refreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = database.WithUserID(ctx, claims.UserID)
+
+	stored, err := u.usableRefreshToken(ctx, refreshToken)
 	if err != nil {
 		return nil, err
 	}
 
-	// Verify user still exists
-	_, err = u.userRepo.GetByID(ctx, claims.UserID)
+	// Verify user still belongs to the active company
+	user, err := u.userRepo.GetByIDAndCompanyID(ctx, claims.UserID, claims.CompanyID)
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			return nil, ErrInvalidCredentials
@@ -104,20 +242,277 @@ func (u *usecaseImpl) RefreshToken(ctx context.Context, refreshToken string) (*T
 		return nil, err
 	}
 
-	// Generate new tokens
-	return u.generateTokenPair(ctx, claims.UserID, claims.CompanyID)
+	if err := u.requireMFA(ctx, user); err != nil {
+		return nil, err
+	}
+
+	// Exchange the token for a new one in the same family. The new tokens carry
+	// the user's current role, so a role change takes effect at the next refresh.
+	var pair *TokenPair
+
+	err = u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := u.tokenRepo.MarkRotated(ctx, stored.ID, ctxutil.Now(ctx)); err != nil {
+			if errors.Is(err, domain.ErrInvalidState) {
+				return ErrRefreshTokenReused
+			}
+
+			return err
+		}
+
+		pair, err = u.issueTokenPair(ctx, user, stored.FamilyID)
+
+		return err
+	})
+	if errors.Is(err, ErrRefreshTokenReused) {
+		// Another request exchanged the token first
+		return nil, u.revokeFamily(ctx, stored)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return pair, nil
 }
 
-func (u *usecaseImpl) generateTokenPair(
+func (u *usecaseImpl) SwitchCompany(
 	ctx context.Context,
 	userID, companyID int64,
 ) (*TokenPair, error) {
-	accessToken, accessExpiresAt, err := u.jwtService.GenerateAccessToken(ctx, userID, companyID)
+	user, err := u.userRepo.GetByIDAndCompanyID(ctx, userID, companyID)
 	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrNotMember
+		}
+
 		return nil, err
 	}
 
-	refreshToken, refreshExpiresAt, err := u.jwtService.GenerateRefreshToken(ctx, userID, companyID)
+	if err := u.requireMFA(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return u.generateTokenPair(ctx, user)
+}
+
+func (u *usecaseImpl) ListCompanies(
+	ctx context.Context,
+	userID int64,
+) ([]*entity.Membership, error) {
+	return u.membershipRepo.GetByUserID(ctx, userID)
+}
+
+func (u *usecaseImpl) Authenticate(
+	ctx context.Context,
+	accessToken string,
+) (*security.Claims, error) {
+	claims, err := u.jwtService.ValidateAccessToken(accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.checkRevoked(ctx, claims); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+func (u *usecaseImpl) Logout(ctx context.Context, claims *security.Claims) error {
+	return u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := u.revocations.Revoke(ctx, claims.ID, claims.UserID, claims.ExpiresAt.Time)
+		if err != nil {
+			return err
+		}
+
+		return u.tokenRepo.RevokeFamily(ctx, claims.SessionID, ctxutil.Now(ctx))
+	})
+}
+
+func (u *usecaseImpl) LogoutAll(ctx context.Context, userID int64) error {
+	return u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		return u.revokeUser(ctx, userID)
+	})
+}
+
+func (u *usecaseImpl) ChangePassword(
+	ctx context.Context,
+	input *ChangePasswordInput,
+) (*TokenPair, error) {
+	user, err := u.userRepo.GetByIDAndCompanyID(ctx, input.UserID, input.CompanyID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrInvalidCredentials
+		}
+
+		return nil, err
+	}
+
+	if !security.CheckPassword(input.CurrentPassword, user.PasswordHash) {
+		return nil, ErrWrongPassword
+	}
+
+	hashedPassword, err := ctxutil.HashPassword(ctx, input.NewPassword, infrastructure.BcryptCost)
+	if err != nil {
+		return nil, err
+	}
+
+	// Whoever may have learned the old password loses their sessions
+	err = u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := u.userRepo.UpdatePassword(ctx, user.ID, hashedPassword); err != nil {
+			return err
+		}
+
+		return u.revokeUser(ctx, user.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return u.generateTokenPair(ctx, user)
+}
+
+func (u *usecaseImpl) JWKS() *security.JWKS {
+	return u.jwtService.JWKS()
+}
+
+// login completes a login whose password has been verified. A user with
+// two-factor authentication, or who has to enrol in it, gets an MFA token
+// instead of tokens.
+func (u *usecaseImpl) login(ctx context.Context, user *entity.User) (*LoginResult, error) {
+	status, err := u.mfa.Status(ctx, user.ID, user.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !status.Enabled && !status.Required {
+		pair, err := u.generateTokenPair(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+
+		return &LoginResult{Tokens: pair}, nil
+	}
+
+	token, expiresAt, err := u.jwtService.GenerateMFAToken(ctx, user.ID, user.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LoginResult{
+		MFAToken:              token,
+		MFATokenExpiresAt:     expiresAt,
+		MFAEnrollmentRequired: status.MustEnroll(),
+	}, nil
+}
+
+// requireMFA returns ErrMFARequired if the user's company requires two-factor
+// authentication the user has not enrolled in.
+func (u *usecaseImpl) requireMFA(ctx context.Context, user *entity.User) error {
+	status, err := u.mfa.Status(ctx, user.ID, user.CompanyID)
+	if err != nil {
+		return err
+	}
+
+	if status.MustEnroll() {
+		return ErrMFARequired
+	}
+
+	return nil
+}
+
+func (u *usecaseImpl) validateMFAToken(
+	ctx context.Context,
+	mfaToken string,
+) (*security.Claims, error) {
+	claims, err := u.jwtService.ValidateMFAToken(mfaToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.checkRevoked(ctx, claims); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+// checkRevoked returns ErrTokenRevoked if the token has been revoked.
+func (u *usecaseImpl) checkRevoked(ctx context.Context, claims *security.Claims) error {
+	revoked, err := u.revocations.IsRevoked(ctx, claims.ID, claims.UserID, claims.IssuedAt.Time)
+	if err != nil {
+		return err
+	}
+
+	if revoked {
+		return ErrTokenRevoked
+	}
+
+	return nil
+}
+
+// revokeUser revokes the access and refresh tokens issued to the user so far.
+// Token issue times are in whole seconds, so the revocation starts at the
+// current second; tokens issued right after it stay valid.
+func (u *usecaseImpl) revokeUser(ctx context.Context, userID int64) error {
+	now := ctxutil.Now(ctx).Truncate(time.Second)
+
+	if err := u.revocations.RevokeUser(ctx, userID, now); err != nil {
+		return err
+	}
+
+	return u.tokenRepo.RevokeByUserID(ctx, userID, now)
+}
+
+// generateTokenPair issues tokens that start a new refresh token family.
+func (u *usecaseImpl) generateTokenPair(
+	ctx context.Context,
+	user *entity.User,
+) (*TokenPair, error) {
+	familyID, err := security.GenerateToken()
+	if err != nil {
+		return nil, err
+	}
+
+	return u.issueTokenPair(ctx, user, familyID)
+}
+
+// issueTokenPair issues tokens and stores the hash of the refresh token in the
+// family. The family is also the session of the tokens, which logout ends.
+func (u *usecaseImpl) issueTokenPair(
+	ctx context.Context,
+	user *entity.User,
+	familyID string,
+) (*TokenPair, error) {
+	accessToken, accessExpiresAt, err := u.jwtService.GenerateAccessToken(
+		ctx,
+		user.ID,
+		user.CompanyID,
+		user.Role,
+		familyID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, refreshExpiresAt, err := u.jwtService.GenerateRefreshToken(
+		ctx,
+		user.ID,
+		user.CompanyID,
+		user.Role,
+		familyID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = u.tokenRepo.Create(ctx, &entity.RefreshToken{
+		UserID:    user.ID,
+		CompanyID: user.CompanyID,
+		FamilyID:  familyID,
+		TokenHash: security.HashToken(refreshToken),
+		ExpiresAt: refreshExpiresAt,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -129,3 +524,38 @@ func (u *usecaseImpl) generateTokenPair(
 		RefreshTokenExpiresAt: refreshExpiresAt,
 	}, nil
 }
+
+// usableRefreshToken returns the stored refresh token if it can be exchanged.
+func (u *usecaseImpl) usableRefreshToken(
+	ctx context.Context,
+	refreshToken string,
+) (*entity.RefreshToken, error) {
+	stored, err := u.tokenRepo.GetByTokenHash(ctx, security.HashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrInvalidCredentials
+		}
+
+		return nil, err
+	}
+
+	if stored.IsRevoked() {
+		return nil, ErrInvalidCredentials
+	}
+
+	if stored.IsRotated() {
+		return nil, u.revokeFamily(ctx, stored)
+	}
+
+	return stored, nil
+}
+
+// revokeFamily revokes every token descending from the same login as a reused
+// token, since either the legitimate user or an attacker holds a stolen copy.
+func (u *usecaseImpl) revokeFamily(ctx context.Context, reused *entity.RefreshToken) error {
+	if err := u.tokenRepo.RevokeFamily(ctx, reused.FamilyID, ctxutil.Now(ctx)); err != nil {
+		return err
+	}
+
+	return ErrRefreshTokenReused
+}
@@ -1,16 +1,27 @@
 package auth_test
 
 import (
+	"bytes"
 	"context"
+	"crypto/ed25519"
 	"errors"
 	"strings"
 	"testing"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/harusys/super-shiharai-kun/internal/domain"
 	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
 	"github.com/harusys/super-shiharai-kun/internal/domain/repository/mock"
+	"github.com/harusys/super-shiharai-kun/internal/domain/valueobject"
+	"github.com/harusys/super-shiharai-kun/internal/infrastructure/database"
 	"github.com/harusys/super-shiharai-kun/internal/infrastructure/security"
 	"github.com/harusys/super-shiharai-kun/internal/usecase/auth"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/company"
+	companymock "github.com/harusys/super-shiharai-kun/internal/usecase/company/mock"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/invitation"
+	invitationmock "github.com/harusys/super-shiharai-kun/internal/usecase/invitation/mock"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/mfa"
+	mfamock "github.com/harusys/super-shiharai-kun/internal/usecase/mfa/mock"
 	"github.com/harusys/super-shiharai-kun/pkg/ctxutil/ctxutiltest"
 	"github.com/harusys/super-shiharai-kun/pkg/timeutil"
 	"github.com/stretchr/testify/assert"
@@ -29,13 +40,8 @@ func TestUsecaseImpl_Register(t *testing.T) {
 		wantErr error
 	}{
 		{
-			name: "success",
-			input: &auth.RegisterInput{
-				CompanyID: 1,
-				Name:      "Test User",
-				Email:     "test@example.com",
-				Password:  "password123",
-			},
+			name:  "success",
+			input: newRegisterInput("test@example.com"),
 			prepare: func(ctx context.Context, c *controllers) {
 				c.ctxProvider.SetAsiaTokyo(t, "2024-01-01 01:23:45")
 
@@ -45,12 +51,17 @@ func TestUsecaseImpl_Register(t *testing.T) {
 				c.userRepo.EXPECT().
 					ExistsByEmail(ctx, "test@example.com").
 					Return(false, nil)
+				expectTransaction(c)
+				c.companies.EXPECT().
+					Create(database.WithAllCompanies(ctx), &newRegisterInput("test@example.com").Company).
+					Return(&entity.Company{ID: 1, Name: "テスト株式会社"}, nil)
 				c.userRepo.EXPECT().
-					Create(ctx, &entity.User{
+					Create(database.WithAllCompanies(ctx), &entity.User{
 						CompanyID:    1,
 						Name:         "Test User",
 						Email:        "test@example.com",
 						PasswordHash: fixedHash,
+						Role:         entity.UserRoleAdmin,
 					}).
 					Return(&entity.User{
 						ID:           1,
@@ -58,9 +69,11 @@ func TestUsecaseImpl_Register(t *testing.T) {
 						Name:         "Test User",
 						Email:        "test@example.com",
 						PasswordHash: fixedHash,
+						Role:         entity.UserRoleAdmin,
 						CreatedAt:    timeutil.AsiaTokyo(t, "2024-01-01 01:23:45"),
 						UpdatedAt:    timeutil.AsiaTokyo(t, "2024-01-01 01:23:45"),
 					}, nil)
+				expectRefreshTokenStored(ctx, c)
 			},
 			want: &auth.TokenPair{
 				AccessTokenExpiresAt:  timeutil.AsiaTokyo(t, "2024-01-01 01:38:45"),
@@ -69,13 +82,8 @@ func TestUsecaseImpl_Register(t *testing.T) {
 			wantErr: nil,
 		},
 		{
-			name: "email already exists",
-			input: &auth.RegisterInput{
-				CompanyID: 1,
-				Name:      "Test User",
-				Email:     "existing@example.com",
-				Password:  "password123",
-			},
+			name:  "email already exists",
+			input: newRegisterInput("existing@example.com"),
 			prepare: func(ctx context.Context, c *controllers) {
 				c.userRepo.EXPECT().
 					ExistsByEmail(ctx, "existing@example.com").
@@ -85,13 +93,39 @@ func TestUsecaseImpl_Register(t *testing.T) {
 			wantErr: auth.ErrEmailAlreadyExists,
 		},
 		{
-			name: "repository error on exists check",
-			input: &auth.RegisterInput{
-				CompanyID: 1,
-				Name:      "Test User",
-				Email:     "test@example.com",
-				Password:  "password123",
+			name:  "email registered concurrently",
+			input: newRegisterInput("test@example.com"),
+			prepare: func(ctx context.Context, c *controllers) {
+				c.userRepo.EXPECT().
+					ExistsByEmail(ctx, "test@example.com").
+					Return(false, nil)
+				expectTransaction(c)
+				c.companies.EXPECT().
+					Create(database.WithAllCompanies(ctx), gomock.Any()).
+					Return(&entity.Company{ID: 1}, nil)
+				c.userRepo.EXPECT().
+					Create(database.WithAllCompanies(ctx), gomock.Any()).
+					Return(nil, domain.ErrAlreadyExists)
 			},
+			wantErr: auth.ErrEmailAlreadyExists,
+		},
+		{
+			name:  "invalid company profile",
+			input: newRegisterInput("test@example.com"),
+			prepare: func(ctx context.Context, c *controllers) {
+				c.userRepo.EXPECT().
+					ExistsByEmail(ctx, "test@example.com").
+					Return(false, nil)
+				expectTransaction(c)
+				c.companies.EXPECT().
+					Create(database.WithAllCompanies(ctx), gomock.Any()).
+					Return(nil, valueobject.ErrInvalidPhoneNumber)
+			},
+			wantErr: valueobject.ErrInvalidPhoneNumber,
+		},
+		{
+			name:  "repository error on exists check",
+			input: newRegisterInput("test@example.com"),
 			prepare: func(ctx context.Context, c *controllers) {
 				c.userRepo.EXPECT().
 					ExistsByEmail(ctx, "test@example.com").
@@ -133,6 +167,76 @@ func TestUsecaseImpl_Register(t *testing.T) {
 	}
 }
 
+func TestUsecaseImpl_AcceptInvitation(t *testing.T) {
+	t.Parallel()
+
+	input := &invitation.AcceptInput{
+		Token:    "invitation-token",
+		Name:     "New User",
+		Password: "password123",
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, uc, c := newUsecase(t)
+		defer c.ctrl.Finish()
+
+		c.ctxProvider.SetAsiaTokyo(t, "2024-01-01 10:00:00")
+		c.invitations.EXPECT().
+			Accept(ctx, input).
+			Return(&entity.User{ID: 20, CompanyID: 1, Role: entity.UserRoleViewer}, nil)
+		c.mfa.EXPECT().Status(ctx, int64(20), int64(1)).Return(&mfa.Status{}, nil)
+		expectRefreshTokenStored(ctx, c)
+
+		got, err := uc.AcceptInvitation(ctx, input)
+
+		require.NoError(t, err)
+		require.NotNil(t, got.Tokens)
+		assert.Len(t, strings.Split(got.Tokens.AccessToken, "."), 3)
+		assert.Equal(
+			t,
+			timeutil.AsiaTokyo(t, "2024-01-01 10:15:00"),
+			got.Tokens.AccessTokenExpiresAt,
+		)
+		assert.Equal(t, entity.UserRoleViewer, tokenClaims(t, got.Tokens.AccessToken).Role)
+	})
+
+	t.Run("company requires two-factor authentication", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, uc, c := newUsecase(t)
+		defer c.ctrl.Finish()
+
+		c.ctxProvider.SetAsiaTokyo(t, "2024-01-01 10:00:00")
+		c.invitations.EXPECT().
+			Accept(ctx, input).
+			Return(&entity.User{ID: 20, CompanyID: 1, Role: entity.UserRoleViewer}, nil)
+		c.mfa.EXPECT().Status(ctx, int64(20), int64(1)).Return(&mfa.Status{Required: true}, nil)
+
+		got, err := uc.AcceptInvitation(ctx, input)
+
+		require.NoError(t, err)
+		assert.Nil(t, got.Tokens)
+		assert.True(t, got.MFAEnrollmentRequired)
+		assert.Equal(t, security.TokenTypeMFA, tokenClaims(t, got.MFAToken).TokenType)
+	})
+
+	t.Run("invalid invitation", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, uc, c := newUsecase(t)
+		defer c.ctrl.Finish()
+
+		c.invitations.EXPECT().Accept(ctx, input).Return(nil, invitation.ErrInvalidInvitation)
+
+		got, err := uc.AcceptInvitation(ctx, input)
+
+		require.ErrorIs(t, err, invitation.ErrInvalidInvitation)
+		assert.Nil(t, got)
+	})
+}
+
 func TestUsecaseImpl_Login(t *testing.T) {
 	t.Parallel()
 
@@ -155,13 +259,15 @@ func TestUsecaseImpl_Login(t *testing.T) {
 				c.ctxProvider.SetAsiaTokyo(t, "2024-01-01 10:00:00")
 
 				c.userRepo.EXPECT().
-					GetByEmail(ctx, "test@example.com").
+					GetByEmail(database.WithAllCompanies(ctx), "test@example.com").
 					Return(&entity.User{
 						ID:           1,
 						CompanyID:    1,
 						Email:        "test@example.com",
 						PasswordHash: hashedPassword,
 					}, nil)
+				c.mfa.EXPECT().Status(ctx, int64(1), int64(1)).Return(&mfa.Status{}, nil)
+				expectRefreshTokenStored(ctx, c)
 			},
 			want: &auth.TokenPair{
 				AccessTokenExpiresAt:  timeutil.AsiaTokyo(t, "2024-01-01 10:15:00"),
@@ -177,7 +283,7 @@ func TestUsecaseImpl_Login(t *testing.T) {
 			},
 			prepare: func(ctx context.Context, c *controllers) {
 				c.userRepo.EXPECT().
-					GetByEmail(ctx, "notfound@example.com").
+					GetByEmail(database.WithAllCompanies(ctx), "notfound@example.com").
 					Return(nil, domain.ErrNotFound)
 			},
 			want:    nil,
@@ -191,7 +297,7 @@ func TestUsecaseImpl_Login(t *testing.T) {
 			},
 			prepare: func(ctx context.Context, c *controllers) {
 				c.userRepo.EXPECT().
-					GetByEmail(ctx, "test@example.com").
+					GetByEmail(database.WithAllCompanies(ctx), "test@example.com").
 					Return(&entity.User{
 						ID:           1,
 						CompanyID:    1,
@@ -225,13 +331,265 @@ func TestUsecaseImpl_Login(t *testing.T) {
 			}
 
 			require.NoError(t, err)
-			require.NotNil(t, got)
+			require.NotNil(t, got.Tokens)
 			// トークンはJWT形式であることを検証（3つのドットで区切られた形式）
-			assert.Len(t, strings.Split(got.AccessToken, "."), 3)
-			assert.Len(t, strings.Split(got.RefreshToken, "."), 3)
+			assert.Len(t, strings.Split(got.Tokens.AccessToken, "."), 3)
+			assert.Len(t, strings.Split(got.Tokens.RefreshToken, "."), 3)
 			// 有効期限はモック時間から進んでいることを検証
-			assert.Equal(t, tt.want.AccessTokenExpiresAt, got.AccessTokenExpiresAt)
-			assert.Equal(t, tt.want.RefreshTokenExpiresAt, got.RefreshTokenExpiresAt)
+			assert.Equal(t, tt.want.AccessTokenExpiresAt, got.Tokens.AccessTokenExpiresAt)
+			assert.Equal(t, tt.want.RefreshTokenExpiresAt, got.Tokens.RefreshTokenExpiresAt)
+		})
+	}
+}
+
+func TestUsecaseImpl_Login_MFA(t *testing.T) {
+	t.Parallel()
+
+	hashedPassword, _ := security.HashPassword("password123")
+	input := &auth.Input{Email: "test@example.com", Password: "password123"}
+	user := func() *entity.User {
+		return &entity.User{ID: 1, CompanyID: 1, PasswordHash: hashedPassword}
+	}
+
+	tests := []struct {
+		name               string
+		status             *mfa.Status
+		wantEnrollRequired bool
+	}{
+		{
+			name:               "enabled",
+			status:             &mfa.Status{Enabled: true},
+			wantEnrollRequired: false,
+		},
+		{
+			name:               "required by the company but not enrolled",
+			status:             &mfa.Status{Required: true},
+			wantEnrollRequired: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, uc, c := newUsecase(t)
+			defer c.ctrl.Finish()
+
+			c.ctxProvider.SetAsiaTokyo(t, "2024-01-01 10:00:00")
+			c.userRepo.EXPECT().
+				GetByEmail(database.WithAllCompanies(ctx), "test@example.com").
+				Return(user(), nil)
+			c.mfa.EXPECT().Status(ctx, int64(1), int64(1)).Return(tt.status, nil)
+
+			got, err := uc.Login(ctx, input)
+
+			require.NoError(t, err)
+			// パスワードだけではトークンを発行しないことを検証
+			assert.Nil(t, got.Tokens)
+			assert.Equal(t, tt.wantEnrollRequired, got.MFAEnrollmentRequired)
+			assert.Equal(
+				t,
+				timeutil.AsiaTokyo(t, "2024-01-01 10:05:00"),
+				got.MFATokenExpiresAt,
+			)
+
+			claims := tokenClaims(t, got.MFAToken)
+			assert.Equal(t, security.TokenTypeMFA, claims.TokenType)
+			assert.Equal(t, int64(1), claims.UserID)
+			assert.Equal(t, int64(1), claims.CompanyID)
+		})
+	}
+}
+
+func TestUsecaseImpl_EnrollMFA(t *testing.T) {
+	t.Parallel()
+
+	jwtService := newJWTService(t)
+	mfaToken, _, _ := jwtService.GenerateMFAToken(context.Background(), 1, 1)
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, uc, c := newUsecase(t)
+		defer c.ctrl.Finish()
+
+		enrollment := &mfa.Enrollment{Secret: "SECRET", ProvisioningURI: "otpauth://totp/x"}
+
+		c.revocations.EXPECT().
+			IsRevoked(ctx, gomock.Any(), int64(1), gomock.Any()).
+			Return(false, nil)
+		c.mfa.EXPECT().
+			Enroll(database.WithUserID(ctx, 1), int64(1), int64(1)).
+			Return(enrollment, nil)
+
+		got, err := uc.EnrollMFA(ctx, mfaToken)
+
+		require.NoError(t, err)
+		assert.Equal(t, enrollment, got)
+	})
+
+	t.Run("not an MFA token", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, uc, c := newUsecase(t)
+		defer c.ctrl.Finish()
+
+		accessToken, _, _ := jwtService.GenerateAccessToken(
+			ctx,
+			1,
+			1,
+			entity.UserRoleViewer,
+			"family-1",
+		)
+
+		got, err := uc.EnrollMFA(ctx, accessToken)
+
+		require.ErrorIs(t, err, security.ErrInvalidToken)
+		assert.Nil(t, got)
+	})
+}
+
+func TestUsecaseImpl_VerifyMFA(t *testing.T) {
+	t.Parallel()
+
+	jwtService := newJWTService(t)
+	mfaToken, _, _ := jwtService.GenerateMFAToken(context.Background(), 1, 1)
+	user := func() *entity.User {
+		return &entity.User{ID: 1, CompanyID: 1, Role: entity.UserRoleAdmin}
+	}
+
+	tests := []struct {
+		name              string
+		mfaToken          string
+		prepare           func(ctx context.Context, c *controllers)
+		wantRecoveryCodes []string
+		wantErr           error
+	}{
+		{
+			name:     "enabled",
+			mfaToken: mfaToken,
+			prepare: func(ctx context.Context, c *controllers) {
+				c.ctxProvider.SetAsiaTokyo(t, "2024-01-01 10:00:00")
+
+				c.revocations.EXPECT().
+					IsRevoked(ctx, gomock.Any(), int64(1), gomock.Any()).
+					Return(false, nil)
+				c.userRepo.EXPECT().
+					GetByIDAndCompanyID(database.WithUserID(ctx, 1), int64(1), int64(1)).
+					Return(user(), nil)
+				c.mfa.EXPECT().
+					Status(database.WithUserID(ctx, 1), int64(1), int64(1)).
+					Return(&mfa.Status{Enabled: true}, nil)
+				c.mfa.EXPECT().Verify(database.WithUserID(ctx, 1), int64(1), "123456").Return(nil)
+				// MFAトークンは1回のログインにしか使えないことを検証
+				c.revocations.EXPECT().
+					Revoke(database.WithUserID(ctx, 1), gomock.Any(), int64(1), gomock.Any()).
+					Return(nil)
+				expectRefreshTokenStored(database.WithUserID(ctx, 1), c)
+			},
+		},
+		{
+			name:     "completes enrolment",
+			mfaToken: mfaToken,
+			prepare: func(ctx context.Context, c *controllers) {
+				c.ctxProvider.SetAsiaTokyo(t, "2024-01-01 10:00:00")
+
+				c.revocations.EXPECT().
+					IsRevoked(ctx, gomock.Any(), int64(1), gomock.Any()).
+					Return(false, nil)
+				c.userRepo.EXPECT().
+					GetByIDAndCompanyID(database.WithUserID(ctx, 1), int64(1), int64(1)).
+					Return(user(), nil)
+				c.mfa.EXPECT().
+					Status(database.WithUserID(ctx, 1), int64(1), int64(1)).
+					Return(&mfa.Status{Required: true}, nil)
+				c.mfa.EXPECT().
+					Activate(database.WithUserID(ctx, 1), int64(1), "123456").
+					Return([]string{"aaaaa-bbbbb"}, nil)
+				c.revocations.EXPECT().
+					Revoke(database.WithUserID(ctx, 1), gomock.Any(), int64(1), gomock.Any()).
+					Return(nil)
+				expectRefreshTokenStored(database.WithUserID(ctx, 1), c)
+			},
+			wantRecoveryCodes: []string{"aaaaa-bbbbb"},
+		},
+		{
+			name:     "invalid code",
+			mfaToken: mfaToken,
+			prepare: func(ctx context.Context, c *controllers) {
+				c.revocations.EXPECT().
+					IsRevoked(ctx, gomock.Any(), int64(1), gomock.Any()).
+					Return(false, nil)
+				c.userRepo.EXPECT().
+					GetByIDAndCompanyID(database.WithUserID(ctx, 1), int64(1), int64(1)).
+					Return(user(), nil)
+				c.mfa.EXPECT().
+					Status(database.WithUserID(ctx, 1), int64(1), int64(1)).
+					Return(&mfa.Status{Enabled: true}, nil)
+				c.mfa.EXPECT().
+					Verify(database.WithUserID(ctx, 1), int64(1), "123456").
+					Return(mfa.ErrInvalidCode)
+			},
+			wantErr: mfa.ErrInvalidCode,
+		},
+		{
+			name:     "used MFA token",
+			mfaToken: mfaToken,
+			prepare: func(ctx context.Context, c *controllers) {
+				c.revocations.EXPECT().
+					IsRevoked(ctx, gomock.Any(), int64(1), gomock.Any()).
+					Return(true, nil)
+			},
+			wantErr: auth.ErrTokenRevoked,
+		},
+		{
+			name:     "invalid MFA token",
+			mfaToken: "invalid-token",
+			prepare:  func(_ context.Context, _ *controllers) {},
+			wantErr:  security.ErrInvalidToken,
+		},
+		{
+			name:     "user left the company",
+			mfaToken: mfaToken,
+			prepare: func(ctx context.Context, c *controllers) {
+				c.revocations.EXPECT().
+					IsRevoked(ctx, gomock.Any(), int64(1), gomock.Any()).
+					Return(false, nil)
+				c.userRepo.EXPECT().
+					GetByIDAndCompanyID(database.WithUserID(ctx, 1), int64(1), int64(1)).
+					Return(nil, domain.ErrNotFound)
+			},
+			wantErr: auth.ErrInvalidCredentials,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, uc, c := newUsecase(t)
+			defer c.ctrl.Finish()
+
+			tt.prepare(ctx, c)
+
+			got, err := uc.VerifyMFA(ctx, tt.mfaToken, "123456")
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, got.Tokens)
+			assert.Equal(t, tt.wantRecoveryCodes, got.RecoveryCodes)
+			assert.Equal(
+				t,
+				timeutil.AsiaTokyo(t, "2024-01-01 10:15:00"),
+				got.Tokens.AccessTokenExpiresAt,
+			)
+			assert.Equal(t, entity.UserRoleAdmin, tokenClaims(t, got.Tokens.AccessToken).Role)
 		})
 	}
 }
@@ -239,16 +597,39 @@ func TestUsecaseImpl_Login(t *testing.T) {
 func TestUsecaseImpl_RefreshToken(t *testing.T) {
 	t.Parallel()
 
-	jwtService := security.NewJWTService("test-secret-key")
-	validToken, _, _ := jwtService.GenerateRefreshToken(context.Background(), 1, 1)
-	invalidUserToken, _, _ := jwtService.GenerateRefreshToken(context.Background(), 999, 1)
+	jwtService := newJWTService(t)
+	validToken, _, _ := jwtService.GenerateRefreshToken(
+		context.Background(),
+		1,
+		1,
+		entity.UserRoleViewer,
+		"family-1",
+	)
+	accessToken, _, _ := jwtService.GenerateAccessToken(
+		context.Background(),
+		1,
+		1,
+		entity.UserRoleViewer,
+		"family-1",
+	)
+	invalidUserToken, _, _ := jwtService.GenerateRefreshToken(
+		context.Background(),
+		999,
+		1,
+		entity.UserRoleViewer,
+		"family-1",
+	)
+
+	stored := func() *entity.RefreshToken {
+		return &entity.RefreshToken{ID: 7, UserID: 1, CompanyID: 1, FamilyID: "family-1"}
+	}
 
 	tests := []struct {
 		name         string
 		refreshToken string
 		prepare      func(ctx context.Context, c *controllers)
 		want         *auth.TokenPair
-		wantErr      bool
+		wantErr      error
 	}{
 		{
 			name:         "success",
@@ -256,33 +637,146 @@ func TestUsecaseImpl_RefreshToken(t *testing.T) {
 			prepare: func(ctx context.Context, c *controllers) {
 				c.ctxProvider.SetAsiaTokyo(t, "2024-01-01 10:00:00")
 
+				c.tokenRepo.EXPECT().
+					GetByTokenHash(database.WithUserID(ctx, 1), security.HashToken(validToken)).
+					Return(stored(), nil)
 				c.userRepo.EXPECT().
-					GetByID(ctx, int64(1)).
-					Return(&entity.User{ID: 1, CompanyID: 1}, nil)
+					GetByIDAndCompanyID(database.WithUserID(ctx, 1), int64(1), int64(1)).
+					Return(&entity.User{ID: 1, CompanyID: 1, Role: entity.UserRoleAccountant}, nil)
+				c.mfa.EXPECT().
+					Status(database.WithUserID(ctx, 1), int64(1), int64(1)).
+					Return(&mfa.Status{}, nil)
+				expectTransaction(c)
+				c.tokenRepo.EXPECT().
+					MarkRotated(database.WithUserID(ctx, 1), int64(7), timeutil.AsiaTokyo(t, "2024-01-01 10:00:00")).
+					Return(stored(), nil)
+				c.tokenRepo.EXPECT().
+					Create(database.WithUserID(ctx, 1), gomock.Any()).
+					DoAndReturn(func(_ context.Context, token *entity.RefreshToken) (*entity.RefreshToken, error) {
+						// 新しいトークンは同じ系列に属することを検証
+						assert.Equal(t, "family-1", token.FamilyID)
+						assert.Equal(t, int64(1), token.UserID)
+						assert.Equal(t, int64(1), token.CompanyID)
+						assert.Equal(
+							t,
+							timeutil.AsiaTokyo(t, "2024-01-08 10:00:00"),
+							token.ExpiresAt,
+						)
+
+						return token, nil
+					})
 			},
 			want: &auth.TokenPair{
 				AccessTokenExpiresAt:  timeutil.AsiaTokyo(t, "2024-01-01 10:15:00"),
 				RefreshTokenExpiresAt: timeutil.AsiaTokyo(t, "2024-01-08 10:00:00"),
 			},
-			wantErr: false,
 		},
 		{
 			name:         "invalid token",
 			refreshToken: "invalid-token",
 			prepare:      func(_ context.Context, _ *controllers) {},
-			want:         nil,
-			wantErr:      true,
+			wantErr:      security.ErrInvalidToken,
+		},
+		{
+			name:         "access token",
+			refreshToken: accessToken,
+			prepare:      func(_ context.Context, _ *controllers) {},
+			wantErr:      security.ErrInvalidToken,
+		},
+		{
+			name:         "token not issued by the server",
+			refreshToken: validToken,
+			prepare: func(ctx context.Context, c *controllers) {
+				c.tokenRepo.EXPECT().
+					GetByTokenHash(database.WithUserID(ctx, 1), security.HashToken(validToken)).
+					Return(nil, domain.ErrNotFound)
+			},
+			wantErr: auth.ErrInvalidCredentials,
+		},
+		{
+			name:         "revoked token",
+			refreshToken: validToken,
+			prepare: func(ctx context.Context, c *controllers) {
+				revokedAt := timeutil.AsiaTokyo(t, "2024-01-01 09:00:00")
+				revoked := stored()
+				revoked.RevokedAt = &revokedAt
+
+				c.tokenRepo.EXPECT().
+					GetByTokenHash(database.WithUserID(ctx, 1), security.HashToken(validToken)).
+					Return(revoked, nil)
+			},
+			wantErr: auth.ErrInvalidCredentials,
+		},
+		{
+			name:         "reused token revokes the family",
+			refreshToken: validToken,
+			prepare: func(ctx context.Context, c *controllers) {
+				c.ctxProvider.SetAsiaTokyo(t, "2024-01-01 10:00:00")
+
+				rotatedAt := timeutil.AsiaTokyo(t, "2024-01-01 09:00:00")
+				rotated := stored()
+				rotated.RotatedAt = &rotatedAt
+
+				c.tokenRepo.EXPECT().
+					GetByTokenHash(database.WithUserID(ctx, 1), security.HashToken(validToken)).
+					Return(rotated, nil)
+				c.tokenRepo.EXPECT().
+					RevokeFamily(database.WithUserID(ctx, 1), "family-1", timeutil.AsiaTokyo(t, "2024-01-01 10:00:00")).
+					Return(nil)
+			},
+			wantErr: auth.ErrRefreshTokenReused,
+		},
+		{
+			name:         "rotated concurrently",
+			refreshToken: validToken,
+			prepare: func(ctx context.Context, c *controllers) {
+				c.tokenRepo.EXPECT().
+					GetByTokenHash(database.WithUserID(ctx, 1), security.HashToken(validToken)).
+					Return(stored(), nil)
+				c.userRepo.EXPECT().
+					GetByIDAndCompanyID(database.WithUserID(ctx, 1), int64(1), int64(1)).
+					Return(&entity.User{ID: 1, CompanyID: 1}, nil)
+				c.mfa.EXPECT().
+					Status(database.WithUserID(ctx, 1), int64(1), int64(1)).
+					Return(&mfa.Status{}, nil)
+				expectTransaction(c)
+				c.tokenRepo.EXPECT().
+					MarkRotated(database.WithUserID(ctx, 1), int64(7), gomock.Any()).
+					Return(nil, domain.ErrInvalidState)
+				c.tokenRepo.EXPECT().
+					RevokeFamily(database.WithUserID(ctx, 1), "family-1", gomock.Any()).
+					Return(nil)
+			},
+			wantErr: auth.ErrRefreshTokenReused,
+		},
+		{
+			name:         "company came to require two-factor authentication",
+			refreshToken: validToken,
+			prepare: func(ctx context.Context, c *controllers) {
+				c.tokenRepo.EXPECT().
+					GetByTokenHash(database.WithUserID(ctx, 1), security.HashToken(validToken)).
+					Return(stored(), nil)
+				c.userRepo.EXPECT().
+					GetByIDAndCompanyID(database.WithUserID(ctx, 1), int64(1), int64(1)).
+					Return(&entity.User{ID: 1, CompanyID: 1}, nil)
+				c.mfa.EXPECT().
+					Status(database.WithUserID(ctx, 1), int64(1), int64(1)).
+					Return(&mfa.Status{Required: true}, nil)
+			},
+			wantErr: auth.ErrMFARequired,
 		},
 		{
 			name:         "user not found",
 			refreshToken: invalidUserToken,
 			prepare: func(ctx context.Context, c *controllers) {
+				c.tokenRepo.EXPECT().
+					GetByTokenHash(database.WithUserID(ctx, 999), security.HashToken(invalidUserToken)).
+					Return(stored(), nil)
 				c.userRepo.EXPECT().
-					GetByID(ctx, int64(999)).
+					GetByIDAndCompanyID(database.WithUserID(ctx, 999), int64(999), int64(1)).
 					Return(nil, domain.ErrNotFound)
 			},
-			want:    nil,
-			wantErr: true,
+			wantErr: auth.ErrInvalidCredentials,
 		},
 	}
 
@@ -299,8 +793,8 @@ func TestUsecaseImpl_RefreshToken(t *testing.T) {
 
 			got, err := uc.RefreshToken(ctx, tt.refreshToken)
 
-			if tt.wantErr {
-				require.Error(t, err)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
 				assert.Nil(t, got)
 
 				return
@@ -311,17 +805,393 @@ func TestUsecaseImpl_RefreshToken(t *testing.T) {
 			// トークンはJWT形式であることを検証（3つのドットで区切られた形式）
 			assert.Len(t, strings.Split(got.AccessToken, "."), 3)
 			assert.Len(t, strings.Split(got.RefreshToken, "."), 3)
+			// 使用したトークンとは別のトークンが発行されることを検証
+			assert.NotEqual(t, tt.refreshToken, got.RefreshToken)
 			// 有効期限はモック時間から進んでいることを検証
 			assert.Equal(t, tt.want.AccessTokenExpiresAt, got.AccessTokenExpiresAt)
 			assert.Equal(t, tt.want.RefreshTokenExpiresAt, got.RefreshTokenExpiresAt)
+			// ロールはトークンではなく最新のユーザー情報から引き継ぐことを検証
+			assert.Equal(t, entity.UserRoleAccountant, tokenClaims(t, got.AccessToken).Role)
+			assert.Equal(t, security.TokenTypeAccess, tokenClaims(t, got.AccessToken).TokenType)
+			assert.Equal(t, security.TokenTypeRefresh, tokenClaims(t, got.RefreshToken).TokenType)
+			// セッションは使用したリフレッシュトークンから引き継ぐことを検証
+			assert.Equal(t, "family-1", tokenClaims(t, got.AccessToken).SessionID)
+		})
+	}
+}
+
+func TestUsecaseImpl_SwitchCompany(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, uc, c := newUsecase(t)
+		defer c.ctrl.Finish()
+
+		c.ctxProvider.SetAsiaTokyo(t, "2024-01-01 10:00:00")
+		c.userRepo.EXPECT().
+			GetByIDAndCompanyID(ctx, int64(1), int64(2)).
+			Return(&entity.User{ID: 1, CompanyID: 2, Role: entity.UserRoleViewer}, nil)
+		c.mfa.EXPECT().Status(ctx, int64(1), int64(2)).Return(&mfa.Status{}, nil)
+		expectRefreshTokenStored(ctx, c)
+
+		got, err := uc.SwitchCompany(ctx, 1, 2)
+
+		require.NoError(t, err)
+		assert.Equal(t, timeutil.AsiaTokyo(t, "2024-01-01 10:15:00"), got.AccessTokenExpiresAt)
+
+		// 切り替え先の企業とその企業での権限がトークンに入ることを検証
+		claims := tokenClaims(t, got.AccessToken)
+		assert.Equal(t, int64(2), claims.CompanyID)
+		assert.Equal(t, entity.UserRoleViewer, claims.Role)
+	})
+
+	t.Run("not a member", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, uc, c := newUsecase(t)
+		defer c.ctrl.Finish()
+
+		c.userRepo.EXPECT().
+			GetByIDAndCompanyID(ctx, int64(1), int64(3)).
+			Return(nil, domain.ErrNotFound)
+
+		got, err := uc.SwitchCompany(ctx, 1, 3)
+
+		require.ErrorIs(t, err, auth.ErrNotMember)
+		require.ErrorIs(t, err, domain.ErrForbidden)
+		assert.Nil(t, got)
+	})
+
+	t.Run("company requires two-factor authentication", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, uc, c := newUsecase(t)
+		defer c.ctrl.Finish()
+
+		c.userRepo.EXPECT().
+			GetByIDAndCompanyID(ctx, int64(1), int64(2)).
+			Return(&entity.User{ID: 1, CompanyID: 2}, nil)
+		c.mfa.EXPECT().Status(ctx, int64(1), int64(2)).Return(&mfa.Status{Required: true}, nil)
+
+		got, err := uc.SwitchCompany(ctx, 1, 2)
+
+		require.ErrorIs(t, err, auth.ErrMFARequired)
+		require.ErrorIs(t, err, domain.ErrForbidden)
+		assert.Nil(t, got)
+	})
+}
+
+func TestUsecaseImpl_Authenticate(t *testing.T) {
+	t.Parallel()
+
+	jwtService := newJWTService(t)
+	accessToken, _, _ := jwtService.GenerateAccessToken(
+		context.Background(),
+		1,
+		2,
+		entity.UserRoleViewer,
+		"family-1",
+	)
+	refreshToken, _, _ := jwtService.GenerateRefreshToken(
+		context.Background(),
+		1,
+		2,
+		entity.UserRoleViewer,
+		"family-1",
+	)
+	accessClaims, err := jwtService.ValidateAccessToken(accessToken)
+	require.NoError(t, err)
+
+	// Tokens signed before the signing key was rotated
+	previousKeyToken, _, _ := newJWTServiceWithKey(t, testKey(previousKeySeed)).
+		GenerateAccessToken(context.Background(), 1, 2, entity.UserRoleViewer, "family-1")
+	unknownKeyToken, _, _ := newJWTServiceWithKey(t, testKey(unknownKeySeed)).
+		GenerateAccessToken(context.Background(), 1, 2, entity.UserRoleViewer, "family-1")
+
+	// A token signed with HMAC, using the public key as the secret, that names
+	// the signing key
+	hmacToken := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaims)
+	hmacToken.Header["kid"] = jwtService.JWKS().Keys[0].KeyID
+	hmacTokenString, err := hmacToken.SignedString(
+		[]byte(testKey(currentKeySeed).Public().(ed25519.PublicKey)),
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		accessToken string
+		prepare     func(ctx context.Context, c *controllers)
+		wantErr     error
+	}{
+		{
+			name:        "success",
+			accessToken: accessToken,
+			prepare: func(ctx context.Context, c *controllers) {
+				c.revocations.EXPECT().
+					IsRevoked(ctx, accessClaims.ID, int64(1), accessClaims.IssuedAt.Time).
+					Return(false, nil)
+			},
+		},
+		{
+			name:        "revoked token",
+			accessToken: accessToken,
+			prepare: func(ctx context.Context, c *controllers) {
+				c.revocations.EXPECT().
+					IsRevoked(ctx, accessClaims.ID, int64(1), gomock.Any()).
+					Return(true, nil)
+			},
+			wantErr: auth.ErrTokenRevoked,
+		},
+		{
+			name:        "signed with the previous key",
+			accessToken: previousKeyToken,
+			prepare: func(ctx context.Context, c *controllers) {
+				c.revocations.EXPECT().
+					IsRevoked(ctx, gomock.Any(), int64(1), gomock.Any()).
+					Return(false, nil)
+			},
+		},
+		{
+			name:        "signed with an unknown key",
+			accessToken: unknownKeyToken,
+			prepare:     func(_ context.Context, _ *controllers) {},
+			wantErr:     security.ErrInvalidToken,
+		},
+		{
+			name:        "signed with HMAC",
+			accessToken: hmacTokenString,
+			prepare:     func(_ context.Context, _ *controllers) {},
+			wantErr:     security.ErrInvalidToken,
+		},
+		{
+			name:        "refresh token",
+			accessToken: refreshToken,
+			prepare:     func(_ context.Context, _ *controllers) {},
+			wantErr:     security.ErrInvalidToken,
+		},
+		{
+			name:        "invalid token",
+			accessToken: "invalid-token",
+			prepare:     func(_ context.Context, _ *controllers) {},
+			wantErr:     security.ErrInvalidToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, uc, c := newUsecase(t)
+			defer c.ctrl.Finish()
+
+			tt.prepare(ctx, c)
+
+			got, err := uc.Authenticate(ctx, tt.accessToken)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), got.UserID)
+			assert.Equal(t, int64(2), got.CompanyID)
+			assert.Equal(t, "family-1", got.SessionID)
 		})
 	}
 }
 
+func TestUsecaseImpl_Logout(t *testing.T) {
+	t.Parallel()
+
+	ctx, uc, c := newUsecase(t)
+	defer c.ctrl.Finish()
+
+	c.ctxProvider.SetAsiaTokyo(t, "2024-01-01 10:00:00")
+
+	expiresAt := timeutil.AsiaTokyo(t, "2024-01-01 10:15:00")
+	claims := &security.Claims{
+		UserID:    1,
+		SessionID: "family-1",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        "token-1",
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	expectTransaction(c)
+	c.revocations.EXPECT().Revoke(ctx, "token-1", int64(1), expiresAt).Return(nil)
+	c.tokenRepo.EXPECT().
+		RevokeFamily(ctx, "family-1", timeutil.AsiaTokyo(t, "2024-01-01 10:00:00")).
+		Return(nil)
+
+	require.NoError(t, uc.Logout(ctx, claims))
+}
+
+func TestUsecaseImpl_LogoutAll(t *testing.T) {
+	t.Parallel()
+
+	ctx, uc, c := newUsecase(t)
+	defer c.ctrl.Finish()
+
+	// 発行日時は秒単位のため、失効は現在の秒の始めから
+	c.ctxProvider.SetAsiaTokyo(t, "2024-01-01 10:00:00.500")
+
+	expectTransaction(c)
+	c.revocations.EXPECT().
+		RevokeUser(ctx, int64(1), timeutil.AsiaTokyo(t, "2024-01-01 10:00:00")).
+		Return(nil)
+	c.tokenRepo.EXPECT().
+		RevokeByUserID(ctx, int64(1), timeutil.AsiaTokyo(t, "2024-01-01 10:00:00")).
+		Return(nil)
+
+	require.NoError(t, uc.LogoutAll(ctx, 1))
+}
+
+func TestUsecaseImpl_ChangePassword(t *testing.T) {
+	t.Parallel()
+
+	hashedPassword, _ := security.HashPassword("password123")
+	user := &entity.User{ID: 1, CompanyID: 2, PasswordHash: hashedPassword}
+
+	tests := []struct {
+		name            string
+		currentPassword string
+		prepare         func(ctx context.Context, c *controllers)
+		wantErr         error
+	}{
+		{
+			name:            "success",
+			currentPassword: "password123",
+			prepare: func(ctx context.Context, c *controllers) {
+				c.ctxProvider.SetAsiaTokyo(t, "2024-01-01 10:00:00")
+
+				fixedHash := "hashed_new_password"
+				c.ctxProvider.PasswordHash = &fixedHash
+
+				c.userRepo.EXPECT().GetByIDAndCompanyID(ctx, int64(1), int64(2)).Return(user, nil)
+				expectTransaction(c)
+				c.userRepo.EXPECT().UpdatePassword(ctx, int64(1), fixedHash).Return(nil)
+				c.revocations.EXPECT().
+					RevokeUser(ctx, int64(1), timeutil.AsiaTokyo(t, "2024-01-01 10:00:00")).
+					Return(nil)
+				c.tokenRepo.EXPECT().
+					RevokeByUserID(ctx, int64(1), timeutil.AsiaTokyo(t, "2024-01-01 10:00:00")).
+					Return(nil)
+				expectRefreshTokenStored(ctx, c)
+			},
+		},
+		{
+			name:            "wrong current password",
+			currentPassword: "wrong-password",
+			prepare: func(ctx context.Context, c *controllers) {
+				c.userRepo.EXPECT().GetByIDAndCompanyID(ctx, int64(1), int64(2)).Return(user, nil)
+			},
+			wantErr: auth.ErrWrongPassword,
+		},
+		{
+			name:            "no longer a member",
+			currentPassword: "password123",
+			prepare: func(ctx context.Context, c *controllers) {
+				c.userRepo.EXPECT().
+					GetByIDAndCompanyID(ctx, int64(1), int64(2)).
+					Return(nil, domain.ErrNotFound)
+			},
+			wantErr: auth.ErrInvalidCredentials,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, uc, c := newUsecase(t)
+			defer c.ctrl.Finish()
+
+			tt.prepare(ctx, c)
+
+			got, err := uc.ChangePassword(ctx, &auth.ChangePasswordInput{
+				UserID:          1,
+				CompanyID:       2,
+				CurrentPassword: tt.currentPassword,
+				NewPassword:     "new-password123",
+			})
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, timeutil.AsiaTokyo(t, "2024-01-01 10:15:00"), got.AccessTokenExpiresAt)
+		})
+	}
+}
+
+func newRegisterInput(email string) *auth.RegisterInput {
+	return &auth.RegisterInput{
+		Company: company.CreateInput{
+			Name:               "テスト株式会社",
+			RepresentativeName: "山田太郎",
+			PhoneNumber:        "03-1234-5678",
+			ZipCode:            "100-0001",
+			Address:            "東京都千代田区千代田1-1",
+		},
+		Name:     "Test User",
+		Email:    email,
+		Password: "password123",
+	}
+}
+
+// tokenClaims returns the claims of token without validating it, since the
+// tokens are issued at the mocked time and have already expired.
+func tokenClaims(t *testing.T, token string) *security.Claims {
+	t.Helper()
+
+	claims := &security.Claims{}
+	_, _, err := jwt.NewParser().ParseUnverified(token, claims)
+	require.NoError(t, err)
+
+	return claims
+}
+
+// expectRefreshTokenStored expects the refresh token of a new token pair to be
+// stored.
+func expectRefreshTokenStored(ctx context.Context, c *controllers) {
+	c.tokenRepo.EXPECT().
+		Create(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, token *entity.RefreshToken) (*entity.RefreshToken, error) {
+			return token, nil
+		})
+}
+
+// expectTransaction runs the unit of work passed to the transactor and returns
+// its error, as the real transactor would after rolling back or committing.
+func expectTransaction(c *controllers) {
+	c.transactor.EXPECT().
+		WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+}
+
 type controllers struct {
-	ctrl        *gomock.Controller
-	ctxProvider *ctxutiltest.TestContextProvider
-	userRepo    *mock.MockUserRepository
+	ctrl           *gomock.Controller
+	ctxProvider    *ctxutiltest.TestContextProvider
+	transactor     *mock.MockTransactor
+	userRepo       *mock.MockUserRepository
+	membershipRepo *mock.MockMembershipRepository
+	tokenRepo      *mock.MockRefreshTokenRepository
+	revocations    *mock.MockTokenRevocationRepository
+	companies      *companymock.MockUsecase
+	invitations    *invitationmock.MockUsecase
+	mfa            *mfamock.MockUsecase
 }
 
 func newUsecase(t *testing.T) (context.Context, auth.Usecase, *controllers) {
@@ -331,13 +1201,69 @@ func newUsecase(t *testing.T) (context.Context, auth.Usecase, *controllers) {
 	ctx := ctxutiltest.TestContext(&ctxProvider)
 
 	ctrl := gomock.NewController(t)
+	transactor := mock.NewMockTransactor(ctrl)
 	userRepo := mock.NewMockUserRepository(ctrl)
-	jwtService := security.NewJWTService("test-secret-key")
-	uc := auth.NewUsecase(userRepo, jwtService)
+	membershipRepo := mock.NewMockMembershipRepository(ctrl)
+	tokenRepo := mock.NewMockRefreshTokenRepository(ctrl)
+	revocations := mock.NewMockTokenRevocationRepository(ctrl)
+	companies := companymock.NewMockUsecase(ctrl)
+	invitations := invitationmock.NewMockUsecase(ctrl)
+	mfaUsecase := mfamock.NewMockUsecase(ctrl)
+	jwtService := newJWTService(t)
+	uc := auth.NewUsecase(
+		transactor,
+		userRepo,
+		membershipRepo,
+		tokenRepo,
+		revocations,
+		companies,
+		invitations,
+		mfaUsecase,
+		jwtService,
+	)
 
 	return ctx, uc, &controllers{
 		ctrl,
 		&ctxProvider,
+		transactor,
 		userRepo,
+		membershipRepo,
+		tokenRepo,
+		revocations,
+		companies,
+		invitations,
+		mfaUsecase,
 	}
 }
+
+// Seeds of the Ed25519 keys tokens are signed with in the tests.
+const (
+	currentKeySeed byte = iota + 1
+	previousKeySeed
+	unknownKeySeed
+)
+
+func testKey(seed byte) ed25519.PrivateKey {
+	return ed25519.NewKeyFromSeed(bytes.Repeat([]byte{seed}, ed25519.SeedSize))
+}
+
+// newJWTService returns the JWTService of the usecase under test. It signs with
+// the current key and, as after a key rotation, still verifies tokens signed
+// with the previous key.
+func newJWTService(t *testing.T) *security.JWTService {
+	t.Helper()
+
+	keys, err := security.NewKeySet(testKey(currentKeySeed), testKey(previousKeySeed).Public())
+	require.NoError(t, err)
+
+	return security.NewJWTService(keys)
+}
+
+func newJWTServiceWithKey(t *testing.T, key ed25519.PrivateKey) *security.JWTService {
+	t.Helper()
+
+	keys, err := security.NewKeySet(key)
+	require.NoError(t, err)
+
+	return security.NewJWTService(keys)
+}
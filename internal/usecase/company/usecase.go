@@ -0,0 +1,55 @@
+//go:generate mockgen -source=$GOFILE -destination=mock/mock_usecase.go -package=mock
+
+package company
+
+import (
+	"context"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+)
+
+// MaxAuditLogs is the number of most recent audit logs ListAuditLogs returns.
+const MaxAuditLogs = 100
+
+// CreateInput is the input for creating a company.
+type CreateInput struct {
+	Name               string
+	NameKana           string // 空文字=未登録
+	RepresentativeName string
+	PhoneNumber        string
+	ZipCode            string
+	Address            string
+}
+
+// UpdateInput is the input for updating a company profile. Nil fields are left
+// unchanged.
+type UpdateInput struct {
+	CompanyID int64
+	// UserID is the user making the change, who must be an admin.
+	UserID             int64
+	Name               *string
+	NameKana           *string // 空文字で削除
+	RepresentativeName *string
+	PhoneNumber        *string
+	ZipCode            *string
+	Address            *string
+	// MFARequired requires every member to log in with two-factor
+	// authentication.
+	MFARequired *bool
+}
+
+// Usecase defines company profile operations. All of them except Create require
+// the user to be an admin of the company.
+type Usecase interface {
+	// Create creates a company with a validated and normalized profile. It is
+	// used by signup, so the caller is responsible for authorization.
+	Create(ctx context.Context, input *CreateInput) (*entity.Company, error)
+	// Get returns the profile of a company.
+	Get(ctx context.Context, companyID, userID int64) (*entity.Company, error)
+	// Update changes the profile of a company and records the changed fields in
+	// the audit log.
+	Update(ctx context.Context, input *UpdateInput) (*entity.Company, error)
+	// ListAuditLogs returns the most recent profile changes of a company, newest
+	// first.
+	ListAuditLogs(ctx context.Context, companyID, userID int64) ([]*entity.CompanyAuditLog, error)
+}
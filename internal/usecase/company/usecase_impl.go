@@ -0,0 +1,214 @@
+package company
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+	"github.com/harusys/super-shiharai-kun/internal/domain/repository"
+	"github.com/harusys/super-shiharai-kun/internal/domain/valueobject"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/postalcode"
+	"github.com/harusys/super-shiharai-kun/pkg/textutil"
+)
+
+type usecaseImpl struct {
+	transactor   repository.Transactor
+	companyRepo  repository.CompanyRepository
+	auditLogRepo repository.CompanyAuditLogRepository
+	userRepo     repository.UserRepository
+	postalCodes  postalcode.Usecase
+}
+
+// NewUsecase creates a new company profile Usecase.
+func NewUsecase(
+	transactor repository.Transactor,
+	companyRepo repository.CompanyRepository,
+	auditLogRepo repository.CompanyAuditLogRepository,
+	userRepo repository.UserRepository,
+	postalCodes postalcode.Usecase,
+) Usecase {
+	return &usecaseImpl{
+		transactor:   transactor,
+		companyRepo:  companyRepo,
+		auditLogRepo: auditLogRepo,
+		userRepo:     userRepo,
+		postalCodes:  postalCodes,
+	}
+}
+
+func (u *usecaseImpl) Create(ctx context.Context, input *CreateInput) (*entity.Company, error) {
+	company, err := u.apply(ctx, &entity.Company{}, &UpdateInput{
+		Name:               &input.Name,
+		NameKana:           &input.NameKana,
+		RepresentativeName: &input.RepresentativeName,
+		PhoneNumber:        &input.PhoneNumber,
+		ZipCode:            &input.ZipCode,
+		Address:            &input.Address,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return u.companyRepo.Create(ctx, company)
+}
+
+func (u *usecaseImpl) Get(ctx context.Context, companyID, userID int64) (*entity.Company, error) {
+	if err := u.requireAdmin(ctx, companyID, userID); err != nil {
+		return nil, err
+	}
+
+	return u.companyRepo.GetByID(ctx, companyID)
+}
+
+func (u *usecaseImpl) Update(ctx context.Context, input *UpdateInput) (*entity.Company, error) {
+	if err := u.requireAdmin(ctx, input.CompanyID, input.UserID); err != nil {
+		return nil, err
+	}
+
+	var updated *entity.Company
+
+	err := u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		existing, err := u.companyRepo.GetByID(ctx, input.CompanyID)
+		if err != nil {
+			return err
+		}
+
+		company, err := u.apply(ctx, existing, input)
+		if err != nil {
+			return err
+		}
+
+		changes := existing.Diff(company)
+		if len(changes) == 0 {
+			updated = existing
+
+			return nil
+		}
+
+		updated, err = u.companyRepo.Update(ctx, company)
+		if err != nil {
+			return err
+		}
+
+		_, err = u.auditLogRepo.Create(ctx, &entity.CompanyAuditLog{
+			CompanyID: input.CompanyID,
+			UserID:    input.UserID,
+			Changes:   changes,
+		})
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func (u *usecaseImpl) ListAuditLogs(
+	ctx context.Context,
+	companyID, userID int64,
+) ([]*entity.CompanyAuditLog, error) {
+	if err := u.requireAdmin(ctx, companyID, userID); err != nil {
+		return nil, err
+	}
+
+	return u.auditLogRepo.GetByCompanyID(ctx, companyID, MaxAuditLogs)
+}
+
+func (u *usecaseImpl) requireAdmin(ctx context.Context, companyID, userID int64) error {
+	user, err := u.userRepo.GetByIDAndCompanyID(ctx, userID, companyID)
+	if err != nil {
+		return err
+	}
+
+	if user.CompanyID != companyID || !user.IsAdmin() {
+		return fmt.Errorf("%w: admin role is required", domain.ErrForbidden)
+	}
+
+	return nil
+}
+
+// apply returns a copy of company with the fields of input applied, normalized
+// like vendor profiles.
+func (u *usecaseImpl) apply(
+	ctx context.Context,
+	company *entity.Company,
+	input *UpdateInput,
+) (*entity.Company, error) {
+	updated := *company
+
+	if err := applyNames(&updated, input); err != nil {
+		return nil, err
+	}
+
+	if input.PhoneNumber != nil {
+		phoneNumber, err := valueobject.NewPhoneNumber(*input.PhoneNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		updated.PhoneNumber = phoneNumber.String()
+	}
+
+	if input.ZipCode != nil {
+		zipCode, err := u.postalCodes.Validate(ctx, *input.ZipCode)
+		if err != nil {
+			return nil, err
+		}
+
+		updated.ZipCode = zipCode.String()
+	}
+
+	if input.Address != nil {
+		address := strings.TrimSpace(*input.Address)
+		if address == "" {
+			return nil, fmt.Errorf("%w: address is required", domain.ErrInvalidInput)
+		}
+
+		updated.Address = address
+	}
+
+	if input.MFARequired != nil {
+		updated.MFARequired = *input.MFARequired
+	}
+
+	return &updated, nil
+}
+
+func applyNames(company *entity.Company, input *UpdateInput) error {
+	if input.Name != nil {
+		name := textutil.NormalizeName(*input.Name)
+		if name == "" {
+			return fmt.Errorf("%w: name is required", domain.ErrInvalidInput)
+		}
+
+		company.Name = name
+	}
+
+	if input.NameKana != nil {
+		company.NameKana = ""
+
+		if *input.NameKana != "" {
+			kana, err := valueobject.NewKana(*input.NameKana)
+			if err != nil {
+				return err
+			}
+
+			company.NameKana = kana.String()
+		}
+	}
+
+	if input.RepresentativeName != nil {
+		name := textutil.NormalizeName(*input.RepresentativeName)
+		if name == "" {
+			return fmt.Errorf("%w: representative_name is required", domain.ErrInvalidInput)
+		}
+
+		company.RepresentativeName = name
+	}
+
+	return nil
+}
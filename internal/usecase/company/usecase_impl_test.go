@@ -0,0 +1,381 @@
+package company_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+	"github.com/harusys/super-shiharai-kun/internal/domain/repository/mock"
+	"github.com/harusys/super-shiharai-kun/internal/domain/valueobject"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/company"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/postalcode"
+	postalcodemock "github.com/harusys/super-shiharai-kun/internal/usecase/postalcode/mock"
+	"github.com/harusys/super-shiharai-kun/pkg/ctxutil/ctxutiltest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newCompany() *entity.Company {
+	return &entity.Company{
+		ID:                 1,
+		Name:               "テスト株式会社",
+		RepresentativeName: "山田太郎",
+		PhoneNumber:        "+81312345678",
+		ZipCode:            "100-0001",
+		Address:            "東京都千代田区千代田1-1",
+	}
+}
+
+func TestUsecaseImpl_Create(t *testing.T) {
+	t.Parallel()
+
+	input := &company.CreateInput{
+		Name:               " テスト株式会社 ",
+		NameKana:           "てすとかぶしきがいしゃ",
+		RepresentativeName: "山田　太郎",
+		PhoneNumber:        "03-1234-5678",
+		ZipCode:            "1000001",
+		Address:            "東京都千代田区千代田1-1",
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, uc, c := newUsecase(t)
+		defer c.ctrl.Finish()
+
+		want := &entity.Company{
+			Name:               "テスト株式会社",
+			NameKana:           "テストカブシキガイシャ",
+			RepresentativeName: "山田 太郎",
+			PhoneNumber:        "+81312345678",
+			ZipCode:            "100-0001",
+			Address:            "東京都千代田区千代田1-1",
+		}
+
+		expectZipCodes(c)
+		c.companyRepo.EXPECT().Create(ctx, want).Return(want, nil)
+
+		got, err := uc.Create(ctx, input)
+
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("missing address", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, uc, c := newUsecase(t)
+		defer c.ctrl.Finish()
+
+		expectZipCodes(c)
+
+		invalid := *input
+		invalid.Address = " "
+
+		got, err := uc.Create(ctx, &invalid)
+
+		require.ErrorIs(t, err, domain.ErrInvalidInput)
+		assert.Nil(t, got)
+	})
+}
+
+func TestUsecaseImpl_Get(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, uc, c := newUsecase(t)
+		defer c.ctrl.Finish()
+
+		expectUser(ctx, c, entity.UserRoleAdmin)
+		c.companyRepo.EXPECT().GetByID(ctx, int64(1)).Return(newCompany(), nil)
+
+		got, err := uc.Get(ctx, 1, 10)
+
+		require.NoError(t, err)
+		assert.Equal(t, newCompany(), got)
+	})
+
+	t.Run("not an admin", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, uc, c := newUsecase(t)
+		defer c.ctrl.Finish()
+
+		expectUser(ctx, c, entity.UserRoleApprover)
+
+		got, err := uc.Get(ctx, 1, 10)
+
+		require.ErrorIs(t, err, domain.ErrForbidden)
+		assert.Nil(t, got)
+	})
+
+	t.Run("admin of another company", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, uc, c := newUsecase(t)
+		defer c.ctrl.Finish()
+
+		c.userRepo.EXPECT().
+			GetByIDAndCompanyID(ctx, int64(10), int64(1)).
+			Return(&entity.User{ID: 10, CompanyID: 2, Role: entity.UserRoleAdmin}, nil)
+
+		got, err := uc.Get(ctx, 1, 10)
+
+		require.ErrorIs(t, err, domain.ErrForbidden)
+		assert.Nil(t, got)
+	})
+}
+
+func TestUsecaseImpl_Update(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   *company.UpdateInput
+		prepare func(ctx context.Context, c *controllers)
+		want    *entity.Company
+		wantErr error
+	}{
+		{
+			name: "success",
+			input: &company.UpdateInput{
+				NameKana:    ptr("てすとかぶしきがいしゃ"),
+				PhoneNumber: ptr("０３-９８７６-５４３２"),
+				ZipCode:     ptr("1500001"),
+			},
+			prepare: func(ctx context.Context, c *controllers) {
+				updated := newCompany()
+				updated.NameKana = "テストカブシキガイシャ"
+				updated.PhoneNumber = "+81398765432"
+				updated.ZipCode = "150-0001"
+
+				expectZipCodes(c)
+				c.companyRepo.EXPECT().GetByID(ctx, int64(1)).Return(newCompany(), nil)
+				c.companyRepo.EXPECT().Update(ctx, updated).Return(updated, nil)
+				c.auditLogRepo.EXPECT().
+					Create(ctx, &entity.CompanyAuditLog{
+						CompanyID: 1,
+						UserID:    10,
+						Changes: []*entity.CompanyFieldChange{
+							{Field: "name_kana", Before: "", After: "テストカブシキガイシャ"},
+							{Field: "phone_number", Before: "+81312345678", After: "+81398765432"},
+							{Field: "zip_code", Before: "100-0001", After: "150-0001"},
+						},
+					}).
+					Return(&entity.CompanyAuditLog{ID: 1}, nil)
+			},
+			want: &entity.Company{
+				ID:                 1,
+				Name:               "テスト株式会社",
+				NameKana:           "テストカブシキガイシャ",
+				RepresentativeName: "山田太郎",
+				PhoneNumber:        "+81398765432",
+				ZipCode:            "150-0001",
+				Address:            "東京都千代田区千代田1-1",
+			},
+		},
+		{
+			name:  "require two-factor authentication",
+			input: &company.UpdateInput{MFARequired: ptr(true)},
+			prepare: func(ctx context.Context, c *controllers) {
+				updated := newCompany()
+				updated.MFARequired = true
+
+				c.companyRepo.EXPECT().GetByID(ctx, int64(1)).Return(newCompany(), nil)
+				c.companyRepo.EXPECT().Update(ctx, updated).Return(updated, nil)
+				c.auditLogRepo.EXPECT().
+					Create(ctx, &entity.CompanyAuditLog{
+						CompanyID: 1,
+						UserID:    10,
+						Changes: []*entity.CompanyFieldChange{
+							{Field: "mfa_required", Before: "false", After: "true"},
+						},
+					}).
+					Return(&entity.CompanyAuditLog{ID: 1}, nil)
+			},
+			want: &entity.Company{
+				ID:                 1,
+				Name:               "テスト株式会社",
+				RepresentativeName: "山田太郎",
+				PhoneNumber:        "+81312345678",
+				ZipCode:            "100-0001",
+				Address:            "東京都千代田区千代田1-1",
+				MFARequired:        true,
+			},
+		},
+		{
+			name: "unchanged",
+			input: &company.UpdateInput{
+				Name:        ptr(" テスト株式会社　"),
+				PhoneNumber: ptr("03-1234-5678"),
+			},
+			prepare: func(ctx context.Context, c *controllers) {
+				c.companyRepo.EXPECT().GetByID(ctx, int64(1)).Return(newCompany(), nil)
+			},
+			want: newCompany(),
+		},
+		{
+			name:  "invalid phone number",
+			input: &company.UpdateInput{PhoneNumber: ptr("03-1234")},
+			prepare: func(ctx context.Context, c *controllers) {
+				c.companyRepo.EXPECT().GetByID(ctx, int64(1)).Return(newCompany(), nil)
+			},
+			wantErr: valueobject.ErrInvalidPhoneNumber,
+		},
+		{
+			name:  "unknown zip code",
+			input: &company.UpdateInput{ZipCode: ptr("999-9999")},
+			prepare: func(ctx context.Context, c *controllers) {
+				c.companyRepo.EXPECT().GetByID(ctx, int64(1)).Return(newCompany(), nil)
+				c.postalCodes.EXPECT().
+					Validate(ctx, "999-9999").
+					Return(valueobject.ZipCode(""), postalcode.ErrUnknownZipCode)
+			},
+			wantErr: domain.ErrInvalidInput,
+		},
+		{
+			name:  "empty name",
+			input: &company.UpdateInput{Name: ptr("　")},
+			prepare: func(ctx context.Context, c *controllers) {
+				c.companyRepo.EXPECT().GetByID(ctx, int64(1)).Return(newCompany(), nil)
+			},
+			wantErr: domain.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, uc, c := newUsecase(t)
+			defer c.ctrl.Finish()
+
+			expectUser(ctx, c, entity.UserRoleAdmin)
+			expectTransaction(c)
+			tt.prepare(ctx, c)
+
+			tt.input.CompanyID = 1
+			tt.input.UserID = 10
+
+			got, err := uc.Update(ctx, tt.input)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUsecaseImpl_Update_NotAnAdmin(t *testing.T) {
+	t.Parallel()
+
+	ctx, uc, c := newUsecase(t)
+	defer c.ctrl.Finish()
+
+	expectUser(ctx, c, entity.UserRoleApprover)
+
+	got, err := uc.Update(ctx, &company.UpdateInput{
+		CompanyID: 1,
+		UserID:    10,
+		Address:   ptr("東京都千代田区千代田1-2"),
+	})
+
+	require.ErrorIs(t, err, domain.ErrForbidden)
+	assert.Nil(t, got)
+}
+
+func TestUsecaseImpl_ListAuditLogs(t *testing.T) {
+	t.Parallel()
+
+	ctx, uc, c := newUsecase(t)
+	defer c.ctrl.Finish()
+
+	logs := []*entity.CompanyAuditLog{{ID: 2, CompanyID: 1}, {ID: 1, CompanyID: 1}}
+
+	expectUser(ctx, c, entity.UserRoleAdmin)
+	c.auditLogRepo.EXPECT().
+		GetByCompanyID(ctx, int64(1), int32(company.MaxAuditLogs)).
+		Return(logs, nil)
+
+	got, err := uc.ListAuditLogs(ctx, 1, 10)
+
+	require.NoError(t, err)
+	assert.Equal(t, logs, got)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func expectUser(ctx context.Context, c *controllers, role entity.UserRole) {
+	c.userRepo.EXPECT().
+		GetByIDAndCompanyID(ctx, int64(10), int64(1)).
+		Return(&entity.User{ID: 10, CompanyID: 1, Role: role}, nil)
+}
+
+// expectTransaction runs the unit of work passed to the transactor and returns
+// its error, as the real transactor would after rolling back or committing.
+func expectTransaction(c *controllers) {
+	c.transactor.EXPECT().
+		WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+}
+
+// expectZipCodes accepts every well-formed postal code, as the postal code
+// usecase does before the dataset has been imported.
+func expectZipCodes(c *controllers) {
+	c.postalCodes.EXPECT().
+		Validate(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, code string) (valueobject.ZipCode, error) {
+			return valueobject.NewZipCode(code)
+		}).
+		AnyTimes()
+}
+
+type controllers struct {
+	ctrl         *gomock.Controller
+	transactor   *mock.MockTransactor
+	companyRepo  *mock.MockCompanyRepository
+	auditLogRepo *mock.MockCompanyAuditLogRepository
+	userRepo     *mock.MockUserRepository
+	postalCodes  *postalcodemock.MockUsecase
+}
+
+func newUsecase(t *testing.T) (context.Context, company.Usecase, *controllers) {
+	t.Helper()
+
+	ctxProvider := ctxutiltest.TestContextProvider{}
+	ctxProvider.SetAsiaTokyo(t, "2024-03-01 10:00:00")
+	ctx := ctxutiltest.TestContext(&ctxProvider)
+
+	ctrl := gomock.NewController(t)
+	transactor := mock.NewMockTransactor(ctrl)
+	companyRepo := mock.NewMockCompanyRepository(ctrl)
+	auditLogRepo := mock.NewMockCompanyAuditLogRepository(ctrl)
+	userRepo := mock.NewMockUserRepository(ctrl)
+	postalCodes := postalcodemock.NewMockUsecase(ctrl)
+
+	uc := company.NewUsecase(transactor, companyRepo, auditLogRepo, userRepo, postalCodes)
+
+	return ctx, uc, &controllers{
+		ctrl:         ctrl,
+		transactor:   transactor,
+		companyRepo:  companyRepo,
+		auditLogRepo: auditLogRepo,
+		userRepo:     userRepo,
+		postalCodes:  postalCodes,
+	}
+}
@@ -0,0 +1,68 @@
+//go:generate mockgen -source=$GOFILE -destination=mock/mock_usecase.go -package=mock
+
+package invitation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+)
+
+var (
+	// ErrInvalidInvitation is returned for an unknown, expired or already
+	// accepted invitation token.
+	ErrInvalidInvitation = fmt.Errorf(
+		"%w: invitation is invalid or has expired",
+		domain.ErrInvalidInput,
+	)
+	// ErrAlreadyMember is returned when the invited email belongs to a user who
+	// is already a member of the company.
+	ErrAlreadyMember = fmt.Errorf(
+		"%w: the user is already a member of the company",
+		domain.ErrAlreadyExists,
+	)
+	// ErrPasswordMismatch is returned when an existing user accepts an
+	// invitation with a password other than their own.
+	ErrPasswordMismatch = fmt.Errorf(
+		"%w: password does not match the existing account",
+		domain.ErrInvalidInput,
+	)
+)
+
+// CreateInput is the input for inviting a person to a company.
+type CreateInput struct {
+	CompanyID int64
+	// UserID is the user sending the invitation, who must be an admin.
+	UserID int64
+	Email  string
+	Role   entity.UserRole
+}
+
+// AcceptInput is the input for accepting an invitation. Name is required only
+// when the invited email has no account yet; an existing user instead proves
+// the account is theirs with its password.
+type AcceptInput struct {
+	Token    string
+	Name     string
+	Password string
+}
+
+// Usecase defines invitation operations.
+type Usecase interface {
+	// Create invites a person to the company and emails them a single-use
+	// token. A new invitation to the same email replaces the pending one. The
+	// user must be an admin.
+	Create(ctx context.Context, input *CreateInput) (*entity.Invitation, error)
+	// List returns the company's invitations that have not been accepted,
+	// newest first. The user must be an admin.
+	List(ctx context.Context, companyID, userID int64) ([]*entity.Invitation, error)
+	// Revoke deletes a pending invitation so that its token can no longer be
+	// used. The user must be an admin.
+	Revoke(ctx context.Context, companyID, userID, invitationID int64) error
+	// Accept adds the invited person to the inviting company with the role of
+	// the invitation, creating their user if the email has no account yet, and
+	// uses up the token. The user is returned as a member of that company.
+	Accept(ctx context.Context, input *AcceptInput) (*entity.User, error)
+}
@@ -0,0 +1,319 @@
+package invitation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+	"github.com/harusys/super-shiharai-kun/internal/domain/repository"
+	"github.com/harusys/super-shiharai-kun/internal/infrastructure"
+	"github.com/harusys/super-shiharai-kun/internal/infrastructure/database"
+	"github.com/harusys/super-shiharai-kun/internal/infrastructure/mail"
+	"github.com/harusys/super-shiharai-kun/internal/infrastructure/security"
+	"github.com/harusys/super-shiharai-kun/pkg/ctxutil"
+)
+
+type usecaseImpl struct {
+	transactor     repository.Transactor
+	invitationRepo repository.InvitationRepository
+	userRepo       repository.UserRepository
+	membershipRepo repository.MembershipRepository
+	companyRepo    repository.CompanyRepository
+	mailer         mail.Mailer
+	appBaseURL     string
+	ttl            time.Duration
+}
+
+// NewUsecase creates a new invitation Usecase. Invitations expire after ttl,
+// and the emailed link points to the accept page of the app at appBaseURL.
+func NewUsecase(
+	transactor repository.Transactor,
+	invitationRepo repository.InvitationRepository,
+	userRepo repository.UserRepository,
+	membershipRepo repository.MembershipRepository,
+	companyRepo repository.CompanyRepository,
+	mailer mail.Mailer,
+	appBaseURL string,
+	ttl time.Duration,
+) Usecase {
+	return &usecaseImpl{
+		transactor:     transactor,
+		invitationRepo: invitationRepo,
+		userRepo:       userRepo,
+		membershipRepo: membershipRepo,
+		companyRepo:    companyRepo,
+		mailer:         mailer,
+		appBaseURL:     strings.TrimRight(appBaseURL, "/"),
+		ttl:            ttl,
+	}
+}
+
+func (u *usecaseImpl) Create(ctx context.Context, input *CreateInput) (*entity.Invitation, error) {
+	inviter, err := u.requireAdmin(ctx, input.CompanyID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !input.Role.IsValid() {
+		return nil, fmt.Errorf("%w: unknown role %q", domain.ErrInvalidInput, input.Role)
+	}
+
+	email := strings.TrimSpace(input.Email)
+
+	member, err := u.isMember(ctx, input.CompanyID, email)
+	if err != nil {
+		return nil, err
+	}
+
+	if member {
+		return nil, ErrAlreadyMember
+	}
+
+	company, err := u.companyRepo.GetByID(ctx, input.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := security.GenerateToken()
+	if err != nil {
+		return nil, err
+	}
+
+	var created *entity.Invitation
+
+	// The invitation is only kept if the email could be sent, since nobody can
+	// accept it otherwise.
+	err = u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := u.invitationRepo.DeletePendingByEmail(ctx, input.CompanyID, email); err != nil {
+			return err
+		}
+
+		created, err = u.invitationRepo.Create(ctx, &entity.Invitation{
+			CompanyID: input.CompanyID,
+			Email:     email,
+			Role:      input.Role,
+			TokenHash: security.HashToken(token),
+			InvitedBy: inviter.ID,
+			ExpiresAt: ctxutil.Now(ctx).Add(u.ttl),
+		})
+		if err != nil {
+			return err
+		}
+
+		return u.mailer.Send(ctx, u.invitationMessage(created, token, company, inviter))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (u *usecaseImpl) List(
+	ctx context.Context,
+	companyID, userID int64,
+) ([]*entity.Invitation, error) {
+	if _, err := u.requireAdmin(ctx, companyID, userID); err != nil {
+		return nil, err
+	}
+
+	return u.invitationRepo.GetPendingByCompanyID(ctx, companyID)
+}
+
+func (u *usecaseImpl) Revoke(ctx context.Context, companyID, userID, invitationID int64) error {
+	if _, err := u.requireAdmin(ctx, companyID, userID); err != nil {
+		return err
+	}
+
+	return u.invitationRepo.DeletePending(ctx, invitationID, companyID)
+}
+
+func (u *usecaseImpl) Accept(ctx context.Context, input *AcceptInput) (*entity.User, error) {
+	// The token is the only proof of which company the invitation is for, so
+	// the lookup searches all companies and the rest runs in the invited one.
+	invitation, err := u.invitationRepo.GetByTokenHash(
+		database.WithAllCompanies(ctx),
+		security.HashToken(input.Token),
+	)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrInvalidInvitation
+		}
+
+		return nil, err
+	}
+
+	ctx = database.WithCompanyID(ctx, invitation.CompanyID)
+
+	now := ctxutil.Now(ctx)
+	if !invitation.CanAccept(now) {
+		return nil, ErrInvalidInvitation
+	}
+
+	existing, newUser, err := u.acceptingUser(ctx, invitation, input)
+	if err != nil {
+		return nil, err
+	}
+
+	var accepted *entity.User
+
+	err = u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Marking the invitation first makes a concurrent accept of the same
+		// token fail instead of adding the user twice.
+		if _, err := u.invitationRepo.MarkAccepted(ctx, invitation.ID, now); err != nil {
+			if errors.Is(err, domain.ErrInvalidState) {
+				return ErrInvalidInvitation
+			}
+
+			return err
+		}
+
+		if existing != nil {
+			accepted, err = u.addMembership(ctx, existing, invitation)
+		} else {
+			accepted, err = u.userRepo.Create(ctx, newUser)
+		}
+
+		if errors.Is(err, domain.ErrAlreadyExists) {
+			return ErrAlreadyMember
+		}
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return accepted, nil
+}
+
+// acceptingUser returns the existing user the invited email belongs to, once
+// they have proven the account is theirs, or else the user to create.
+func (u *usecaseImpl) acceptingUser(
+	ctx context.Context,
+	invitation *entity.Invitation,
+	input *AcceptInput,
+) (existing, newUser *entity.User, err error) {
+	// The user may so far belong only to other companies
+	existing, err = u.userRepo.GetByEmail(database.WithAllCompanies(ctx), invitation.Email)
+	if err == nil {
+		if !security.CheckPassword(input.Password, existing.PasswordHash) {
+			return nil, nil, ErrPasswordMismatch
+		}
+
+		return existing, nil, nil
+	}
+
+	if !errors.Is(err, domain.ErrNotFound) {
+		return nil, nil, err
+	}
+
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, nil, fmt.Errorf("%w: name is required", domain.ErrInvalidInput)
+	}
+
+	hashedPassword, err := ctxutil.HashPassword(ctx, input.Password, infrastructure.BcryptCost)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return nil, &entity.User{
+		CompanyID:    invitation.CompanyID,
+		Name:         name,
+		Email:        invitation.Email,
+		PasswordHash: hashedPassword,
+		Role:         invitation.Role,
+	}, nil
+}
+
+// addMembership adds an existing user to the inviting company and returns them
+// as a member of it.
+func (u *usecaseImpl) addMembership(
+	ctx context.Context,
+	user *entity.User,
+	invitation *entity.Invitation,
+) (*entity.User, error) {
+	_, err := u.membershipRepo.Create(ctx, &entity.Membership{
+		UserID:    user.ID,
+		CompanyID: invitation.CompanyID,
+		Role:      invitation.Role,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	member := *user
+	member.CompanyID = invitation.CompanyID
+	member.Role = invitation.Role
+
+	return &member, nil
+}
+
+// isMember reports whether the email belongs to a member of the company.
+func (u *usecaseImpl) isMember(ctx context.Context, companyID int64, email string) (bool, error) {
+	user, err := u.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	_, err = u.userRepo.GetByIDAndCompanyID(ctx, user.ID, companyID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (u *usecaseImpl) requireAdmin(
+	ctx context.Context,
+	companyID, userID int64,
+) (*entity.User, error) {
+	user, err := u.userRepo.GetByIDAndCompanyID(ctx, userID, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.CompanyID != companyID || !user.IsAdmin() {
+		return nil, fmt.Errorf("%w: admin role is required", domain.ErrForbidden)
+	}
+
+	return user, nil
+}
+
+func (u *usecaseImpl) invitationMessage(
+	invitation *entity.Invitation,
+	token string,
+	company *entity.Company,
+	inviter *entity.User,
+) *mail.Message {
+	link := u.appBaseURL + "/accept-invitation?token=" + url.QueryEscape(token)
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%sさんから、%sのユーザーとして招待されました。\n\n", inviter.Name, company.Name)
+	b.WriteString("以下のリンクから氏名とパスワードを設定して登録してください。\n")
+	b.WriteString(link + "\n\n")
+	fmt.Fprintf(&b, "このリンクは%sまで有効で、1回のみ使用できます。\n",
+		invitation.ExpiresAt.Format("2006-01-02 15:04"))
+	b.WriteString("心当たりのない場合は、このメールを破棄してください。\n")
+
+	return &mail.Message{
+		To:      []string{invitation.Email},
+		Subject: "【スーパー支払い君.com】" + company.Name + "への招待",
+		Body:    b.String(),
+	}
+}
@@ -0,0 +1,542 @@
+package invitation_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+	"github.com/harusys/super-shiharai-kun/internal/domain/repository/mock"
+	"github.com/harusys/super-shiharai-kun/internal/infrastructure/database"
+	"github.com/harusys/super-shiharai-kun/internal/infrastructure/mail"
+	mailmock "github.com/harusys/super-shiharai-kun/internal/infrastructure/mail/mock"
+	"github.com/harusys/super-shiharai-kun/internal/infrastructure/security"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/invitation"
+	"github.com/harusys/super-shiharai-kun/pkg/ctxutil/ctxutiltest"
+	"github.com/harusys/super-shiharai-kun/pkg/timeutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUsecaseImpl_Create(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, uc, c := newUsecase(t)
+		defer c.ctrl.Finish()
+
+		var sent *mail.Message
+
+		expectAdmin(ctx, c)
+		c.userRepo.EXPECT().GetByEmail(ctx, "new@example.com").Return(nil, domain.ErrNotFound)
+		c.companyRepo.EXPECT().
+			GetByID(ctx, int64(1)).
+			Return(&entity.Company{ID: 1, Name: "テスト株式会社"}, nil)
+		expectTransaction(c)
+		c.invitationRepo.EXPECT().DeletePendingByEmail(ctx, int64(1), "new@example.com")
+		c.invitationRepo.EXPECT().
+			Create(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, inv *entity.Invitation) (*entity.Invitation, error) {
+				assert.Equal(t, int64(1), inv.CompanyID)
+				assert.Equal(t, entity.UserRoleApprover, inv.Role)
+				assert.Equal(t, int64(10), inv.InvitedBy)
+				assert.Equal(t, timeutil.AsiaTokyo(t, "2024-03-08 10:00:00"), inv.ExpiresAt)
+
+				created := *inv
+				created.ID = 5
+
+				return &created, nil
+			})
+		c.mailer.EXPECT().
+			Send(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, msg *mail.Message) error {
+				sent = msg
+
+				return nil
+			})
+
+		got, err := uc.Create(ctx, &invitation.CreateInput{
+			CompanyID: 1,
+			UserID:    10,
+			Email:     " new@example.com ",
+			Role:      entity.UserRoleApprover,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(5), got.ID)
+
+		// The emailed token is stored only as a hash.
+		require.NotNil(t, sent)
+		assert.Equal(t, []string{"new@example.com"}, sent.To)
+		assert.Contains(t, sent.Subject, "テスト株式会社")
+
+		token := emailedToken(t, sent.Body)
+		assert.Equal(t, security.HashToken(token), got.TokenHash)
+		assert.NotContains(t, sent.Body, got.TokenHash)
+	})
+
+	t.Run("mail failure discards the invitation", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, uc, c := newUsecase(t)
+		defer c.ctrl.Finish()
+
+		expectAdmin(ctx, c)
+		c.userRepo.EXPECT().GetByEmail(ctx, "new@example.com").Return(nil, domain.ErrNotFound)
+		c.companyRepo.EXPECT().GetByID(ctx, int64(1)).Return(&entity.Company{ID: 1}, nil)
+		expectTransaction(c)
+		c.invitationRepo.EXPECT().DeletePendingByEmail(ctx, int64(1), "new@example.com")
+		c.invitationRepo.EXPECT().Create(ctx, gomock.Any()).Return(&entity.Invitation{ID: 5}, nil)
+		c.mailer.EXPECT().Send(ctx, gomock.Any()).Return(errors.New("smtp error"))
+
+		got, err := uc.Create(ctx, &invitation.CreateInput{
+			CompanyID: 1,
+			UserID:    10,
+			Email:     "new@example.com",
+			Role:      entity.UserRoleViewer,
+		})
+
+		require.EqualError(t, err, "smtp error")
+		assert.Nil(t, got)
+	})
+
+	t.Run("user of another company", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, uc, c := newUsecase(t)
+		defer c.ctrl.Finish()
+
+		expectAdmin(ctx, c)
+		c.userRepo.EXPECT().
+			GetByEmail(ctx, "user@example.com").
+			Return(&entity.User{ID: 30, CompanyID: 2}, nil)
+		c.userRepo.EXPECT().
+			GetByIDAndCompanyID(ctx, int64(30), int64(1)).
+			Return(nil, domain.ErrNotFound)
+		c.companyRepo.EXPECT().GetByID(ctx, int64(1)).Return(&entity.Company{ID: 1}, nil)
+		expectTransaction(c)
+		c.invitationRepo.EXPECT().DeletePendingByEmail(ctx, int64(1), "user@example.com")
+		c.invitationRepo.EXPECT().
+			Create(ctx, gomock.Any()).
+			Return(&entity.Invitation{ID: 5, Email: "user@example.com"}, nil)
+		c.mailer.EXPECT().Send(ctx, gomock.Any())
+
+		got, err := uc.Create(ctx, &invitation.CreateInput{
+			CompanyID: 1,
+			UserID:    10,
+			Email:     "user@example.com",
+			Role:      entity.UserRoleViewer,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(5), got.ID)
+	})
+
+	t.Run("already a member", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, uc, c := newUsecase(t)
+		defer c.ctrl.Finish()
+
+		expectAdmin(ctx, c)
+		c.userRepo.EXPECT().
+			GetByEmail(ctx, "user@example.com").
+			Return(&entity.User{ID: 30, CompanyID: 2}, nil)
+		c.userRepo.EXPECT().
+			GetByIDAndCompanyID(ctx, int64(30), int64(1)).
+			Return(&entity.User{ID: 30, CompanyID: 1}, nil)
+
+		got, err := uc.Create(ctx, &invitation.CreateInput{
+			CompanyID: 1,
+			UserID:    10,
+			Email:     "user@example.com",
+			Role:      entity.UserRoleViewer,
+		})
+
+		require.ErrorIs(t, err, invitation.ErrAlreadyMember)
+		assert.Nil(t, got)
+	})
+
+	t.Run("unknown role", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, uc, c := newUsecase(t)
+		defer c.ctrl.Finish()
+
+		expectAdmin(ctx, c)
+
+		got, err := uc.Create(ctx, &invitation.CreateInput{
+			CompanyID: 1,
+			UserID:    10,
+			Email:     "new@example.com",
+			Role:      "owner",
+		})
+
+		require.ErrorIs(t, err, domain.ErrInvalidInput)
+		assert.Nil(t, got)
+	})
+
+	t.Run("not an admin", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, uc, c := newUsecase(t)
+		defer c.ctrl.Finish()
+
+		c.userRepo.EXPECT().
+			GetByIDAndCompanyID(ctx, int64(10), int64(1)).
+			Return(&entity.User{ID: 10, CompanyID: 1, Role: entity.UserRoleAccountant}, nil)
+
+		got, err := uc.Create(ctx, &invitation.CreateInput{
+			CompanyID: 1,
+			UserID:    10,
+			Email:     "new@example.com",
+			Role:      entity.UserRoleViewer,
+		})
+
+		require.ErrorIs(t, err, domain.ErrForbidden)
+		assert.Nil(t, got)
+	})
+}
+
+func TestUsecaseImpl_Revoke(t *testing.T) {
+	t.Parallel()
+
+	ctx, uc, c := newUsecase(t)
+	defer c.ctrl.Finish()
+
+	expectAdmin(ctx, c)
+	c.invitationRepo.EXPECT().DeletePending(ctx, int64(5), int64(1)).Return(domain.ErrNotFound)
+
+	err := uc.Revoke(ctx, 1, 10, 5)
+
+	require.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestUsecaseImpl_Accept(t *testing.T) {
+	t.Parallel()
+
+	const token = "invitation-token"
+
+	pending := func(t *testing.T) *entity.Invitation {
+		t.Helper()
+
+		return &entity.Invitation{
+			ID:        5,
+			CompanyID: 1,
+			Email:     "new@example.com",
+			Role:      entity.UserRoleApprover,
+			TokenHash: security.HashToken(token),
+			ExpiresAt: timeutil.AsiaTokyo(t, "2024-03-08 10:00:00"),
+		}
+	}
+
+	// existing is a user of another company whose password is "password123".
+	existingHash, err := security.HashPassword("password123")
+	require.NoError(t, err)
+
+	existing := &entity.User{
+		ID:           30,
+		CompanyID:    2,
+		Name:         "Existing User",
+		Email:        "new@example.com",
+		PasswordHash: existingHash,
+		Role:         entity.UserRoleAdmin,
+	}
+
+	tests := []struct {
+		name     string
+		password string
+		userName string
+		prepare  func(ctx context.Context, c *controllers)
+		want     *entity.User
+		wantErr  error
+	}{
+		{
+			name: "success",
+			prepare: func(ctx context.Context, c *controllers) {
+				fixedHash := "hashed_password123"
+				c.ctxProvider.PasswordHash = &fixedHash
+
+				c.invitationRepo.EXPECT().
+					GetByTokenHash(database.WithAllCompanies(ctx), security.HashToken(token)).
+					Return(pending(t), nil)
+				c.userRepo.EXPECT().
+					GetByEmail(database.WithAllCompanies(database.WithCompanyID(ctx, 1)), "new@example.com").
+					Return(nil, domain.ErrNotFound)
+				expectTransaction(c)
+				c.invitationRepo.EXPECT().
+					MarkAccepted(database.WithCompanyID(ctx, 1), int64(5), timeutil.AsiaTokyo(t, "2024-03-01 10:00:00")).
+					Return(pending(t), nil)
+				c.userRepo.EXPECT().
+					Create(database.WithCompanyID(ctx, 1), &entity.User{
+						CompanyID:    1,
+						Name:         "New User",
+						Email:        "new@example.com",
+						PasswordHash: fixedHash,
+						Role:         entity.UserRoleApprover,
+					}).
+					Return(&entity.User{ID: 20, CompanyID: 1, Role: entity.UserRoleApprover}, nil)
+			},
+			want: &entity.User{ID: 20, CompanyID: 1, Role: entity.UserRoleApprover},
+		},
+		{
+			name: "existing user joins the company",
+			prepare: func(ctx context.Context, c *controllers) {
+				c.invitationRepo.EXPECT().
+					GetByTokenHash(database.WithAllCompanies(ctx), security.HashToken(token)).
+					Return(pending(t), nil)
+				c.userRepo.EXPECT().
+					GetByEmail(database.WithAllCompanies(database.WithCompanyID(ctx, 1)), "new@example.com").
+					Return(existing, nil)
+				expectTransaction(c)
+				c.invitationRepo.EXPECT().
+					MarkAccepted(database.WithCompanyID(ctx, 1), int64(5), gomock.Any()).
+					Return(pending(t), nil)
+				c.membershipRepo.EXPECT().
+					Create(database.WithCompanyID(ctx, 1), &entity.Membership{
+						UserID:    30,
+						CompanyID: 1,
+						Role:      entity.UserRoleApprover,
+					}).
+					Return(&entity.Membership{UserID: 30, CompanyID: 1}, nil)
+			},
+			want: &entity.User{
+				ID:           30,
+				CompanyID:    1,
+				Name:         "Existing User",
+				Email:        "new@example.com",
+				PasswordHash: existingHash,
+				Role:         entity.UserRoleApprover,
+			},
+		},
+		{
+			name:     "existing user with another password",
+			password: "wrong-password",
+			prepare: func(ctx context.Context, c *controllers) {
+				c.invitationRepo.EXPECT().
+					GetByTokenHash(database.WithAllCompanies(ctx), security.HashToken(token)).
+					Return(pending(t), nil)
+				c.userRepo.EXPECT().
+					GetByEmail(database.WithAllCompanies(database.WithCompanyID(ctx, 1)), "new@example.com").
+					Return(existing, nil)
+			},
+			wantErr: invitation.ErrPasswordMismatch,
+		},
+		{
+			name: "existing user already a member",
+			prepare: func(ctx context.Context, c *controllers) {
+				c.invitationRepo.EXPECT().
+					GetByTokenHash(database.WithAllCompanies(ctx), security.HashToken(token)).
+					Return(pending(t), nil)
+				c.userRepo.EXPECT().
+					GetByEmail(database.WithAllCompanies(database.WithCompanyID(ctx, 1)), "new@example.com").
+					Return(existing, nil)
+				expectTransaction(c)
+				c.invitationRepo.EXPECT().
+					MarkAccepted(database.WithCompanyID(ctx, 1), int64(5), gomock.Any()).
+					Return(pending(t), nil)
+				c.membershipRepo.EXPECT().
+					Create(database.WithCompanyID(ctx, 1), gomock.Any()).
+					Return(nil, domain.ErrAlreadyExists)
+			},
+			wantErr: invitation.ErrAlreadyMember,
+		},
+		{
+			name:     "new user without a name",
+			userName: " ",
+			prepare: func(ctx context.Context, c *controllers) {
+				c.invitationRepo.EXPECT().
+					GetByTokenHash(database.WithAllCompanies(ctx), security.HashToken(token)).
+					Return(pending(t), nil)
+				c.userRepo.EXPECT().
+					GetByEmail(database.WithAllCompanies(database.WithCompanyID(ctx, 1)), "new@example.com").
+					Return(nil, domain.ErrNotFound)
+			},
+			wantErr: domain.ErrInvalidInput,
+		},
+		{
+			name: "unknown token",
+			prepare: func(ctx context.Context, c *controllers) {
+				c.invitationRepo.EXPECT().
+					GetByTokenHash(database.WithAllCompanies(ctx), security.HashToken(token)).
+					Return(nil, domain.ErrNotFound)
+			},
+			wantErr: invitation.ErrInvalidInvitation,
+		},
+		{
+			name: "expired",
+			prepare: func(ctx context.Context, c *controllers) {
+				expired := pending(t)
+				expired.ExpiresAt = timeutil.AsiaTokyo(t, "2024-03-01 09:59:59")
+
+				c.invitationRepo.EXPECT().
+					GetByTokenHash(database.WithAllCompanies(ctx), security.HashToken(token)).
+					Return(expired, nil)
+			},
+			wantErr: invitation.ErrInvalidInvitation,
+		},
+		{
+			name: "accepted concurrently",
+			prepare: func(ctx context.Context, c *controllers) {
+				c.invitationRepo.EXPECT().
+					GetByTokenHash(database.WithAllCompanies(ctx), security.HashToken(token)).
+					Return(pending(t), nil)
+				c.userRepo.EXPECT().
+					GetByEmail(database.WithAllCompanies(database.WithCompanyID(ctx, 1)), "new@example.com").
+					Return(nil, domain.ErrNotFound)
+				expectTransaction(c)
+				c.invitationRepo.EXPECT().
+					MarkAccepted(database.WithCompanyID(ctx, 1), int64(5), gomock.Any()).
+					Return(nil, domain.ErrInvalidState)
+			},
+			wantErr: invitation.ErrInvalidInvitation,
+		},
+		{
+			name: "email registered since the invitation",
+			prepare: func(ctx context.Context, c *controllers) {
+				c.invitationRepo.EXPECT().
+					GetByTokenHash(database.WithAllCompanies(ctx), security.HashToken(token)).
+					Return(pending(t), nil)
+				c.userRepo.EXPECT().
+					GetByEmail(database.WithAllCompanies(database.WithCompanyID(ctx, 1)), "new@example.com").
+					Return(nil, domain.ErrNotFound)
+				expectTransaction(c)
+				c.invitationRepo.EXPECT().
+					MarkAccepted(database.WithCompanyID(ctx, 1), int64(5), gomock.Any()).
+					Return(pending(t), nil)
+				c.userRepo.EXPECT().
+					Create(database.WithCompanyID(ctx, 1), gomock.Any()).
+					Return(nil, domain.ErrAlreadyExists)
+			},
+			wantErr: invitation.ErrAlreadyMember,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, uc, c := newUsecase(t)
+			defer c.ctrl.Finish()
+
+			tt.prepare(ctx, c)
+
+			input := &invitation.AcceptInput{
+				Token:    token,
+				Name:     "New User",
+				Password: "password123",
+			}
+			if tt.password != "" {
+				input.Password = tt.password
+			}
+
+			if tt.userName != "" {
+				input.Name = tt.userName
+			}
+
+			got, err := uc.Accept(ctx, input)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// emailedToken extracts the token from the accept link in an invitation email.
+func emailedToken(t *testing.T, body string) string {
+	t.Helper()
+
+	for line := range strings.SplitSeq(body, "\n") {
+		if !strings.HasPrefix(line, "https://app.example.com/accept-invitation?") {
+			continue
+		}
+
+		u, err := url.Parse(line)
+		require.NoError(t, err)
+
+		return u.Query().Get("token")
+	}
+
+	require.Fail(t, "no accept link in the email", body)
+
+	return ""
+}
+
+func expectAdmin(ctx context.Context, c *controllers) {
+	c.userRepo.EXPECT().
+		GetByIDAndCompanyID(ctx, int64(10), int64(1)).
+		Return(&entity.User{ID: 10, CompanyID: 1, Name: "管理者", Role: entity.UserRoleAdmin}, nil)
+}
+
+// expectTransaction runs the unit of work passed to the transactor and returns
+// its error, as the real transactor would after rolling back or committing.
+func expectTransaction(c *controllers) {
+	c.transactor.EXPECT().
+		WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+}
+
+type controllers struct {
+	ctrl           *gomock.Controller
+	ctxProvider    *ctxutiltest.TestContextProvider
+	transactor     *mock.MockTransactor
+	invitationRepo *mock.MockInvitationRepository
+	userRepo       *mock.MockUserRepository
+	membershipRepo *mock.MockMembershipRepository
+	companyRepo    *mock.MockCompanyRepository
+	mailer         *mailmock.MockMailer
+}
+
+func newUsecase(t *testing.T) (context.Context, invitation.Usecase, *controllers) {
+	t.Helper()
+
+	ctxProvider := ctxutiltest.TestContextProvider{}
+	ctxProvider.SetAsiaTokyo(t, "2024-03-01 10:00:00")
+	ctx := ctxutiltest.TestContext(&ctxProvider)
+
+	ctrl := gomock.NewController(t)
+	transactor := mock.NewMockTransactor(ctrl)
+	invitationRepo := mock.NewMockInvitationRepository(ctrl)
+	userRepo := mock.NewMockUserRepository(ctrl)
+	membershipRepo := mock.NewMockMembershipRepository(ctrl)
+	companyRepo := mock.NewMockCompanyRepository(ctrl)
+	mailer := mailmock.NewMockMailer(ctrl)
+
+	uc := invitation.NewUsecase(
+		transactor,
+		invitationRepo,
+		userRepo,
+		membershipRepo,
+		companyRepo,
+		mailer,
+		"https://app.example.com/",
+		7*24*time.Hour,
+	)
+
+	return ctx, uc, &controllers{
+		ctrl:           ctrl,
+		ctxProvider:    &ctxProvider,
+		transactor:     transactor,
+		invitationRepo: invitationRepo,
+		userRepo:       userRepo,
+		membershipRepo: membershipRepo,
+		companyRepo:    companyRepo,
+		mailer:         mailer,
+	}
+}
@@ -13,10 +13,26 @@ import (
 type CreateInput struct {
 	CompanyID           int64
 	VendorID            int64
-	VendorBankAccountID int64
+	VendorBankAccountID int64 // 0=取引先の既定口座
 	IssueDate           time.Time
 	PaymentAmount       int64
 	DueDate             time.Time
+	// RecurringInvoiceScheduleID links the invoice to the schedule that produced it (0=none).
+	RecurringInvoiceScheduleID int64
+	// VendorInvoiceNumber is the number printed on the vendor's invoice (optional).
+	VendorInvoiceNumber string
+	// AllowDuplicate registers the invoice even if it looks like a duplicate.
+	AllowDuplicate bool
+	// CreatedBy is the user creating the invoice (0=created by the system).
+	CreatedBy int64
+}
+
+// RejectInput is the input for rejecting an invoice awaiting approval.
+type RejectInput struct {
+	CompanyID  int64
+	InvoiceID  int64
+	ApproverID int64
+	Reason     string
 }
 
 // ListInput is the input for listing invoices.
@@ -26,12 +42,27 @@ type ListInput struct {
 	EndDate   *time.Time
 }
 
+// WithholdingReport is the yearly per-vendor withholding tax report of a company.
+type WithholdingReport struct {
+	Year    int
+	Vendors []*entity.WithholdingSummary
+}
+
 // Usecase defines invoice operations.
 type Usecase interface {
-	// Create creates a new invoice with calculated amounts.
+	// Create creates a new invoice with calculated amounts. If existing invoices look
+	// identical it returns *domain.DuplicateInvoiceError unless AllowDuplicate is set.
+	// Invoices matching the company's approval policy are created awaiting approval.
 	Create(ctx context.Context, input *CreateInput) (*entity.Invoice, error)
 	// List returns invoices for a company, optionally filtered by date range.
 	List(ctx context.Context, input *ListInput) ([]*entity.Invoice, error)
 	// GetByID returns an invoice by ID (with company authorization check).
 	GetByID(ctx context.Context, companyID, invoiceID int64) (*entity.Invoice, error)
+	// Approve clears an invoice awaiting approval for payment.
+	Approve(ctx context.Context, companyID, invoiceID, approverID int64) (*entity.Invoice, error)
+	// Reject sends an invoice awaiting approval back to its creator.
+	Reject(ctx context.Context, input *RejectInput) (*entity.Invoice, error)
+	// GetWithholdingReport returns withholding tax totals per vendor for the paid
+	// invoices due in the given calendar year.
+	GetWithholdingReport(ctx context.Context, companyID int64, year int) (*WithholdingReport, error)
 }
@@ -2,32 +2,48 @@ package invoice
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	"github.com/harusys/super-shiharai-kun/internal/domain"
 	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
 	"github.com/harusys/super-shiharai-kun/internal/domain/repository"
 	"github.com/harusys/super-shiharai-kun/internal/domain/service"
+	"github.com/harusys/super-shiharai-kun/pkg/ctxutil"
 )
 
 type usecaseImpl struct {
+	transactor        repository.Transactor
 	invoiceRepo       repository.InvoiceRepository
 	vendorRepo        repository.VendorRepository
 	bankAccountRepo   repository.VendorBankAccountRepository
+	policyRepo        repository.ApprovalPolicyRepository
+	userRepo          repository.UserRepository
 	invoiceCalculator *service.InvoiceCalculator
+	withholdingCalc   *service.WithholdingTaxCalculator
 }
 
 // NewUsecase creates a new invoice Usecase.
 func NewUsecase(
+	transactor repository.Transactor,
 	invoiceRepo repository.InvoiceRepository,
 	vendorRepo repository.VendorRepository,
 	bankAccountRepo repository.VendorBankAccountRepository,
+	policyRepo repository.ApprovalPolicyRepository,
+	userRepo repository.UserRepository,
 	invoiceCalculator *service.InvoiceCalculator,
+	withholdingCalc *service.WithholdingTaxCalculator,
 ) Usecase {
 	return &usecaseImpl{
+		transactor:        transactor,
 		invoiceRepo:       invoiceRepo,
 		vendorRepo:        vendorRepo,
 		bankAccountRepo:   bankAccountRepo,
+		policyRepo:        policyRepo,
+		userRepo:          userRepo,
 		invoiceCalculator: invoiceCalculator,
+		withholdingCalc:   withholdingCalc,
 	}
 }
 
@@ -35,14 +51,31 @@ func (u *usecaseImpl) Create(
 	ctx context.Context,
 	input *CreateInput,
 ) (*entity.Invoice, error) {
-	// Verify vendor belongs to company
-	_, err := u.vendorRepo.GetByIDAndCompanyID(ctx, input.VendorID, input.CompanyID)
+	var created *entity.Invoice
+
+	err := u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+
+		created, err = u.create(ctx, input)
+
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	// Verify bank account belongs to vendor
-	_, err = u.bankAccountRepo.GetByIDAndVendorID(ctx, input.VendorBankAccountID, input.VendorID)
+	return created, nil
+}
+
+// create creates the invoice within the transaction carried by ctx. The
+// vendor's invoices are locked first, so that two identical submissions cannot
+// both pass the duplicate check and the bank account cannot change in between.
+func (u *usecaseImpl) create(ctx context.Context, input *CreateInput) (*entity.Invoice, error) {
+	if err := u.invoiceRepo.LockByVendorID(ctx, input.VendorID); err != nil {
+		return nil, err
+	}
+
+	vendor, bankAccount, err := u.resolvePayee(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -50,11 +83,17 @@ func (u *usecaseImpl) Create(
 	// Calculate invoice amounts
 	result := u.invoiceCalculator.Calculate(input.PaymentAmount)
 
+	// Withhold income tax from the transfer for applicable vendors
+	withholding := service.NoWithholding(input.PaymentAmount)
+	if vendor.WithholdingTaxApplicable {
+		withholding = u.withholdingCalc.Calculate(input.PaymentAmount)
+	}
+
 	// Create invoice
 	inv := &entity.Invoice{
 		CompanyID:           input.CompanyID,
 		VendorID:            input.VendorID,
-		VendorBankAccountID: input.VendorBankAccountID,
+		VendorBankAccountID: bankAccount.ID,
 		IssueDate:           input.IssueDate,
 		PaymentAmount:       result.PaymentAmount,
 		Fee:                 result.Fee,
@@ -62,13 +101,193 @@ func (u *usecaseImpl) Create(
 		Tax:                 result.Tax,
 		TaxRate:             result.TaxRate,
 		TotalAmount:         result.TotalAmount,
+		WithholdingTax:      withholding.WithholdingTax,
+		TransferAmount:      withholding.TransferAmount,
 		DueDate:             input.DueDate,
 		Status:              entity.InvoiceStatusPending,
+		// Snapshot the registration number so the input tax credit treatment
+		// of this invoice does not change if the vendor is later updated.
+		VendorRegistrationNumber:   vendor.RegistrationNumber,
+		RecurringInvoiceScheduleID: input.RecurringInvoiceScheduleID,
+		VendorInvoiceNumber:        input.VendorInvoiceNumber,
+		CreatedBy:                  input.CreatedBy,
+	}
+
+	if err := u.checkDuplicates(ctx, inv, input.AllowDuplicate); err != nil {
+		return nil, err
+	}
+
+	requiresApproval, err := u.requiresApproval(ctx, inv)
+	if err != nil {
+		return nil, err
+	}
+
+	if requiresApproval {
+		inv.Status = entity.InvoiceStatusAwaitingApproval
 	}
 
 	return u.invoiceRepo.Create(ctx, inv)
 }
 
+// checkDuplicates returns a DuplicateInvoiceError if the invoice looks identical
+// to an existing one, unless allowed, in which case the override is recorded.
+func (u *usecaseImpl) checkDuplicates(
+	ctx context.Context,
+	inv *entity.Invoice,
+	allow bool,
+) error {
+	duplicates, err := u.invoiceRepo.FindDuplicates(ctx, inv)
+	if err != nil {
+		return err
+	}
+
+	if len(duplicates) == 0 {
+		return nil
+	}
+
+	if !allow {
+		ids := make([]int64, len(duplicates))
+		for i, d := range duplicates {
+			ids[i] = d.ID
+		}
+
+		return &domain.DuplicateInvoiceError{InvoiceIDs: ids}
+	}
+
+	// Keep a record that the duplicate warning was overridden
+	inv.DuplicateOverride = true
+
+	return nil
+}
+
+// resolvePayee returns the vendor and the bank account to transfer to after
+// verifying that both belong to the company, are not archived and that the
+// account is verified and out of its cooling-off period.
+func (u *usecaseImpl) resolvePayee(
+	ctx context.Context,
+	input *CreateInput,
+) (*entity.Vendor, *entity.VendorBankAccount, error) {
+	vendor, err := u.vendorRepo.GetByIDAndCompanyID(ctx, input.VendorID, input.CompanyID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if vendor.IsArchived() {
+		return nil, nil, fmt.Errorf("%w: vendor is archived", domain.ErrInvalidInput)
+	}
+
+	bankAccount, err := u.resolveBankAccount(ctx, input)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if bankAccount.IsArchived() {
+		return nil, nil, fmt.Errorf("%w: bank account is archived", domain.ErrInvalidInput)
+	}
+
+	if !bankAccount.IsPayable(ctxutil.Now(ctx)) {
+		return nil, nil, fmt.Errorf(
+			"%w: bank account is pending verification or in its cooling-off period",
+			domain.ErrInvalidInput,
+		)
+	}
+
+	return vendor, bankAccount, nil
+}
+
+// resolveBankAccount returns the bank account to transfer to: the specified one
+// after verifying it belongs to the vendor, or the vendor's default account.
+func (u *usecaseImpl) resolveBankAccount(
+	ctx context.Context,
+	input *CreateInput,
+) (*entity.VendorBankAccount, error) {
+	if input.VendorBankAccountID != 0 {
+		return u.bankAccountRepo.GetByIDAndVendorID(ctx, input.VendorBankAccountID, input.VendorID)
+	}
+
+	account, err := u.bankAccountRepo.GetDefaultByVendorID(ctx, input.VendorID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("%w: vendor has no default bank account", domain.ErrInvalidInput)
+		}
+
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// requiresApproval evaluates the company's approval policy for a new invoice.
+func (u *usecaseImpl) requiresApproval(ctx context.Context, inv *entity.Invoice) (bool, error) {
+	policy, err := u.policyRepo.GetByCompanyID(ctx, inv.CompanyID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	isNewVendor := false
+
+	if policy.RequireForNewVendors {
+		approved, err := u.invoiceRepo.ExistsApprovedByVendorID(ctx, inv.VendorID)
+		if err != nil {
+			return false, err
+		}
+
+		isNewVendor = !approved
+	}
+
+	return policy.RequiresApproval(inv.PaymentAmount, isNewVendor), nil
+}
+
+func (u *usecaseImpl) Approve(
+	ctx context.Context,
+	companyID, invoiceID, approverID int64,
+) (*entity.Invoice, error) {
+	inv, err := u.getForReview(ctx, companyID, invoiceID, approverID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := inv.Approve(approverID, ctxutil.Now(ctx)); err != nil {
+		return nil, err
+	}
+
+	return u.invoiceRepo.Review(ctx, inv)
+}
+
+func (u *usecaseImpl) Reject(ctx context.Context, input *RejectInput) (*entity.Invoice, error) {
+	inv, err := u.getForReview(ctx, input.CompanyID, input.InvoiceID, input.ApproverID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := inv.Reject(input.ApproverID, ctxutil.Now(ctx), input.Reason); err != nil {
+		return nil, err
+	}
+
+	return u.invoiceRepo.Review(ctx, inv)
+}
+
+// getForReview returns the invoice after verifying that the user may review it.
+func (u *usecaseImpl) getForReview(
+	ctx context.Context,
+	companyID, invoiceID, approverID int64,
+) (*entity.Invoice, error) {
+	approver, err := u.userRepo.GetByIDAndCompanyID(ctx, approverID, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if approver.CompanyID != companyID || !approver.CanApproveInvoices() {
+		return nil, fmt.Errorf("%w: approver role is required", domain.ErrForbidden)
+	}
+
+	return u.invoiceRepo.GetByIDAndCompanyID(ctx, invoiceID, companyID)
+}
+
 func (u *usecaseImpl) List(
 	ctx context.Context,
 	input *ListInput,
@@ -103,3 +322,27 @@ func (u *usecaseImpl) GetByID(
 
 	return inv, nil
 }
+
+func (u *usecaseImpl) GetWithholdingReport(
+	ctx context.Context,
+	companyID int64,
+	year int,
+) (*WithholdingReport, error) {
+	startDate := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+	summaries, err := u.invoiceRepo.GetWithholdingSummariesByCompanyIDAndDateRange(
+		ctx,
+		companyID,
+		startDate,
+		endDate,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WithholdingReport{
+		Year:    year,
+		Vendors: summaries,
+	}, nil
+}
@@ -3,6 +3,7 @@ package invoice_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/harusys/super-shiharai-kun/internal/domain"
 	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
@@ -51,7 +52,13 @@ func TestUsecaseImpl_Create(t *testing.T) {
 					Return(&entity.Vendor{ID: 1, CompanyID: 1}, nil)
 				c.bankAccountRepo.EXPECT().
 					GetByIDAndVendorID(ctx, int64(1), int64(1)).
-					Return(&entity.VendorBankAccount{ID: 1, VendorID: 1}, nil)
+					Return(&entity.VendorBankAccount{ID: 1, VendorID: 1, Status: entity.BankAccountStatusVerified}, nil)
+				c.invoiceRepo.EXPECT().
+					FindDuplicates(ctx, gomock.Any()).
+					Return(nil, nil)
+				c.policyRepo.EXPECT().
+					GetByCompanyID(ctx, int64(1)).
+					Return(nil, domain.ErrNotFound)
 				c.invoiceRepo.EXPECT().
 					Create(ctx, &entity.Invoice{
 						CompanyID:           1,
@@ -64,6 +71,7 @@ func TestUsecaseImpl_Create(t *testing.T) {
 						Tax:                 40,
 						TaxRate:             taxRate(),
 						TotalAmount:         10440,
+						TransferAmount:      10000,
 						DueDate:             timeutil.AsiaTokyo(t, "2024-02-15 00:00:00"),
 						Status:              entity.InvoiceStatusPending,
 					}).
@@ -79,6 +87,7 @@ func TestUsecaseImpl_Create(t *testing.T) {
 						Tax:                 40,
 						TaxRate:             taxRate(),
 						TotalAmount:         10440,
+						TransferAmount:      10000,
 						DueDate:             timeutil.AsiaTokyo(t, "2024-02-15 00:00:00"),
 						Status:              entity.InvoiceStatusPending,
 						CreatedAt:           timeutil.AsiaTokyo(t, "2024-01-15 10:00:00"),
@@ -97,6 +106,7 @@ func TestUsecaseImpl_Create(t *testing.T) {
 				Tax:                 40,
 				TaxRate:             taxRate(),
 				TotalAmount:         10440,
+				TransferAmount:      10000,
 				DueDate:             timeutil.AsiaTokyo(t, "2024-02-15 00:00:00"),
 				Status:              entity.InvoiceStatusPending,
 				CreatedAt:           timeutil.AsiaTokyo(t, "2024-01-15 10:00:00"),
@@ -104,6 +114,247 @@ func TestUsecaseImpl_Create(t *testing.T) {
 			},
 			wantErr: nil,
 		},
+		{
+			name: "success - snapshots vendor registration number",
+			input: &invoice.CreateInput{
+				CompanyID:           1,
+				VendorID:            1,
+				VendorBankAccountID: 1,
+				IssueDate:           timeutil.AsiaTokyo(t, "2024-01-15 00:00:00"),
+				PaymentAmount:       10000,
+				DueDate:             timeutil.AsiaTokyo(t, "2024-02-15 00:00:00"),
+			},
+			prepare: func(ctx context.Context, c *controllers) {
+				c.vendorRepo.EXPECT().
+					GetByIDAndCompanyID(ctx, int64(1), int64(1)).
+					Return(&entity.Vendor{
+						ID:                 1,
+						CompanyID:          1,
+						RegistrationNumber: "T7000012050002",
+					}, nil)
+				c.bankAccountRepo.EXPECT().
+					GetByIDAndVendorID(ctx, int64(1), int64(1)).
+					Return(&entity.VendorBankAccount{ID: 1, VendorID: 1, Status: entity.BankAccountStatusVerified}, nil)
+				c.invoiceRepo.EXPECT().
+					FindDuplicates(ctx, gomock.Any()).
+					Return(nil, nil)
+				c.policyRepo.EXPECT().
+					GetByCompanyID(ctx, int64(1)).
+					Return(nil, domain.ErrNotFound)
+				c.invoiceRepo.EXPECT().
+					Create(ctx, &entity.Invoice{
+						CompanyID:                1,
+						VendorID:                 1,
+						VendorBankAccountID:      1,
+						IssueDate:                timeutil.AsiaTokyo(t, "2024-01-15 00:00:00"),
+						PaymentAmount:            10000,
+						Fee:                      400,
+						FeeRate:                  feeRate(),
+						Tax:                      40,
+						TaxRate:                  taxRate(),
+						TotalAmount:              10440,
+						TransferAmount:           10000,
+						DueDate:                  timeutil.AsiaTokyo(t, "2024-02-15 00:00:00"),
+						Status:                   entity.InvoiceStatusPending,
+						VendorRegistrationNumber: "T7000012050002",
+					}).
+					Return(&entity.Invoice{
+						ID:                       1,
+						VendorRegistrationNumber: "T7000012050002",
+					}, nil)
+			},
+			want: &entity.Invoice{
+				ID:                       1,
+				VendorRegistrationNumber: "T7000012050002",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "success - withholds tax for applicable vendor",
+			input: &invoice.CreateInput{
+				CompanyID:           1,
+				VendorID:            1,
+				VendorBankAccountID: 1,
+				IssueDate:           timeutil.AsiaTokyo(t, "2024-01-15 00:00:00"),
+				PaymentAmount:       100000,
+				DueDate:             timeutil.AsiaTokyo(t, "2024-02-15 00:00:00"),
+			},
+			prepare: func(ctx context.Context, c *controllers) {
+				c.vendorRepo.EXPECT().
+					GetByIDAndCompanyID(ctx, int64(1), int64(1)).
+					Return(&entity.Vendor{
+						ID:                       1,
+						CompanyID:                1,
+						WithholdingTaxApplicable: true,
+					}, nil)
+				c.bankAccountRepo.EXPECT().
+					GetByIDAndVendorID(ctx, int64(1), int64(1)).
+					Return(&entity.VendorBankAccount{ID: 1, VendorID: 1, Status: entity.BankAccountStatusVerified}, nil)
+				c.invoiceRepo.EXPECT().
+					FindDuplicates(ctx, gomock.Any()).
+					Return(nil, nil)
+				c.policyRepo.EXPECT().
+					GetByCompanyID(ctx, int64(1)).
+					Return(nil, domain.ErrNotFound)
+				c.invoiceRepo.EXPECT().
+					Create(ctx, &entity.Invoice{
+						CompanyID:           1,
+						VendorID:            1,
+						VendorBankAccountID: 1,
+						IssueDate:           timeutil.AsiaTokyo(t, "2024-01-15 00:00:00"),
+						PaymentAmount:       100000,
+						Fee:                 4000,
+						FeeRate:             feeRate(),
+						Tax:                 400,
+						TaxRate:             taxRate(),
+						TotalAmount:         104400,
+						WithholdingTax:      10210,
+						TransferAmount:      89790,
+						DueDate:             timeutil.AsiaTokyo(t, "2024-02-15 00:00:00"),
+						Status:              entity.InvoiceStatusPending,
+					}).
+					Return(&entity.Invoice{
+						ID:             1,
+						WithholdingTax: 10210,
+						TransferAmount: 89790,
+					}, nil)
+			},
+			want: &entity.Invoice{
+				ID:             1,
+				WithholdingTax: 10210,
+				TransferAmount: 89790,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "duplicate invoice",
+			input: &invoice.CreateInput{
+				CompanyID:           1,
+				VendorID:            1,
+				VendorBankAccountID: 1,
+				IssueDate:           timeutil.AsiaTokyo(t, "2024-01-15 00:00:00"),
+				PaymentAmount:       10000,
+				DueDate:             timeutil.AsiaTokyo(t, "2024-02-15 00:00:00"),
+				VendorInvoiceNumber: "INV-001",
+			},
+			prepare: func(ctx context.Context, c *controllers) {
+				c.vendorRepo.EXPECT().
+					GetByIDAndCompanyID(ctx, int64(1), int64(1)).
+					Return(&entity.Vendor{ID: 1, CompanyID: 1}, nil)
+				c.bankAccountRepo.EXPECT().
+					GetByIDAndVendorID(ctx, int64(1), int64(1)).
+					Return(&entity.VendorBankAccount{ID: 1, VendorID: 1, Status: entity.BankAccountStatusVerified}, nil)
+				c.invoiceRepo.EXPECT().
+					FindDuplicates(ctx, gomock.Cond(func(inv *entity.Invoice) bool {
+						return inv.VendorInvoiceNumber == "INV-001"
+					})).
+					Return([]*entity.Invoice{{ID: 5}, {ID: 8}}, nil)
+			},
+			want:    nil,
+			wantErr: domain.ErrDuplicateInvoice,
+		},
+		{
+			name: "success - duplicate override is recorded",
+			input: &invoice.CreateInput{
+				CompanyID:           1,
+				VendorID:            1,
+				VendorBankAccountID: 1,
+				IssueDate:           timeutil.AsiaTokyo(t, "2024-01-15 00:00:00"),
+				PaymentAmount:       10000,
+				DueDate:             timeutil.AsiaTokyo(t, "2024-02-15 00:00:00"),
+				AllowDuplicate:      true,
+			},
+			prepare: func(ctx context.Context, c *controllers) {
+				c.vendorRepo.EXPECT().
+					GetByIDAndCompanyID(ctx, int64(1), int64(1)).
+					Return(&entity.Vendor{ID: 1, CompanyID: 1}, nil)
+				c.bankAccountRepo.EXPECT().
+					GetByIDAndVendorID(ctx, int64(1), int64(1)).
+					Return(&entity.VendorBankAccount{ID: 1, VendorID: 1, Status: entity.BankAccountStatusVerified}, nil)
+				c.invoiceRepo.EXPECT().
+					FindDuplicates(ctx, gomock.Any()).
+					Return([]*entity.Invoice{{ID: 5}}, nil)
+				c.policyRepo.EXPECT().
+					GetByCompanyID(ctx, int64(1)).
+					Return(nil, domain.ErrNotFound)
+				c.invoiceRepo.EXPECT().
+					Create(ctx, gomock.Cond(func(inv *entity.Invoice) bool {
+						return inv.DuplicateOverride
+					})).
+					Return(&entity.Invoice{ID: 6, DuplicateOverride: true}, nil)
+			},
+			want:    &entity.Invoice{ID: 6, DuplicateOverride: true},
+			wantErr: nil,
+		},
+		{
+			name: "success - awaiting approval over amount threshold",
+			input: &invoice.CreateInput{
+				CompanyID:           1,
+				VendorID:            1,
+				VendorBankAccountID: 1,
+				IssueDate:           timeutil.AsiaTokyo(t, "2024-01-15 00:00:00"),
+				PaymentAmount:       1000000,
+				DueDate:             timeutil.AsiaTokyo(t, "2024-02-15 00:00:00"),
+				CreatedBy:           10,
+			},
+			prepare: func(ctx context.Context, c *controllers) {
+				c.vendorRepo.EXPECT().
+					GetByIDAndCompanyID(ctx, int64(1), int64(1)).
+					Return(&entity.Vendor{ID: 1, CompanyID: 1}, nil)
+				c.bankAccountRepo.EXPECT().
+					GetByIDAndVendorID(ctx, int64(1), int64(1)).
+					Return(&entity.VendorBankAccount{ID: 1, VendorID: 1, Status: entity.BankAccountStatusVerified}, nil)
+				c.invoiceRepo.EXPECT().
+					FindDuplicates(ctx, gomock.Any()).
+					Return(nil, nil)
+				c.policyRepo.EXPECT().
+					GetByCompanyID(ctx, int64(1)).
+					Return(&entity.ApprovalPolicy{CompanyID: 1, AmountThreshold: 1000000}, nil)
+				c.invoiceRepo.EXPECT().
+					Create(ctx, gomock.Cond(func(inv *entity.Invoice) bool {
+						return inv.Status == entity.InvoiceStatusAwaitingApproval &&
+							inv.CreatedBy == 10
+					})).
+					Return(&entity.Invoice{ID: 7, Status: entity.InvoiceStatusAwaitingApproval}, nil)
+			},
+			want:    &entity.Invoice{ID: 7, Status: entity.InvoiceStatusAwaitingApproval},
+			wantErr: nil,
+		},
+		{
+			name: "success - awaiting approval for new vendor",
+			input: &invoice.CreateInput{
+				CompanyID:           1,
+				VendorID:            1,
+				VendorBankAccountID: 1,
+				IssueDate:           timeutil.AsiaTokyo(t, "2024-01-15 00:00:00"),
+				PaymentAmount:       10000,
+				DueDate:             timeutil.AsiaTokyo(t, "2024-02-15 00:00:00"),
+			},
+			prepare: func(ctx context.Context, c *controllers) {
+				c.vendorRepo.EXPECT().
+					GetByIDAndCompanyID(ctx, int64(1), int64(1)).
+					Return(&entity.Vendor{ID: 1, CompanyID: 1}, nil)
+				c.bankAccountRepo.EXPECT().
+					GetByIDAndVendorID(ctx, int64(1), int64(1)).
+					Return(&entity.VendorBankAccount{ID: 1, VendorID: 1, Status: entity.BankAccountStatusVerified}, nil)
+				c.invoiceRepo.EXPECT().
+					FindDuplicates(ctx, gomock.Any()).
+					Return(nil, nil)
+				c.policyRepo.EXPECT().
+					GetByCompanyID(ctx, int64(1)).
+					Return(&entity.ApprovalPolicy{CompanyID: 1, RequireForNewVendors: true}, nil)
+				c.invoiceRepo.EXPECT().
+					ExistsApprovedByVendorID(ctx, int64(1)).
+					Return(false, nil)
+				c.invoiceRepo.EXPECT().
+					Create(ctx, gomock.Cond(func(inv *entity.Invoice) bool {
+						return inv.Status == entity.InvoiceStatusAwaitingApproval
+					})).
+					Return(&entity.Invoice{ID: 7, Status: entity.InvoiceStatusAwaitingApproval}, nil)
+			},
+			want:    &entity.Invoice{ID: 7, Status: entity.InvoiceStatusAwaitingApproval},
+			wantErr: nil,
+		},
 		{
 			name: "vendor not found",
 			input: &invoice.CreateInput{
@@ -143,6 +394,171 @@ func TestUsecaseImpl_Create(t *testing.T) {
 			want:    nil,
 			wantErr: domain.ErrNotFound,
 		},
+		{
+			name: "bank account omitted - vendor default account is used",
+			input: &invoice.CreateInput{
+				CompanyID:     1,
+				VendorID:      1,
+				IssueDate:     timeutil.AsiaTokyo(t, "2024-01-15 00:00:00"),
+				PaymentAmount: 10000,
+				DueDate:       timeutil.AsiaTokyo(t, "2024-02-15 00:00:00"),
+			},
+			prepare: func(ctx context.Context, c *controllers) {
+				c.vendorRepo.EXPECT().
+					GetByIDAndCompanyID(ctx, int64(1), int64(1)).
+					Return(&entity.Vendor{ID: 1, CompanyID: 1}, nil)
+				c.bankAccountRepo.EXPECT().
+					GetDefaultByVendorID(ctx, int64(1)).
+					Return(&entity.VendorBankAccount{
+						ID:        3,
+						VendorID:  1,
+						IsDefault: true,
+						Status:    entity.BankAccountStatusVerified,
+					}, nil)
+				c.invoiceRepo.EXPECT().
+					FindDuplicates(ctx, gomock.Any()).
+					Return(nil, nil)
+				c.policyRepo.EXPECT().
+					GetByCompanyID(ctx, int64(1)).
+					Return(nil, domain.ErrNotFound)
+				c.invoiceRepo.EXPECT().
+					Create(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, inv *entity.Invoice) (*entity.Invoice, error) {
+						return inv, nil
+					})
+			},
+			want: &entity.Invoice{
+				CompanyID:           1,
+				VendorID:            1,
+				VendorBankAccountID: 3,
+				IssueDate:           timeutil.AsiaTokyo(t, "2024-01-15 00:00:00"),
+				PaymentAmount:       10000,
+				Fee:                 400,
+				FeeRate:             feeRate(),
+				Tax:                 40,
+				TaxRate:             taxRate(),
+				TotalAmount:         10440,
+				TransferAmount:      10000,
+				DueDate:             timeutil.AsiaTokyo(t, "2024-02-15 00:00:00"),
+				Status:              entity.InvoiceStatusPending,
+			},
+		},
+		{
+			name: "archived vendor",
+			input: &invoice.CreateInput{
+				CompanyID:           1,
+				VendorID:            1,
+				VendorBankAccountID: 1,
+				IssueDate:           timeutil.AsiaTokyo(t, "2024-01-15 00:00:00"),
+				PaymentAmount:       10000,
+				DueDate:             timeutil.AsiaTokyo(t, "2024-02-15 00:00:00"),
+			},
+			prepare: func(ctx context.Context, c *controllers) {
+				archivedAt := timeutil.AsiaTokyo(t, "2024-01-01 00:00:00")
+
+				c.vendorRepo.EXPECT().
+					GetByIDAndCompanyID(ctx, int64(1), int64(1)).
+					Return(&entity.Vendor{ID: 1, CompanyID: 1, ArchivedAt: &archivedAt}, nil)
+			},
+			want:    nil,
+			wantErr: domain.ErrInvalidInput,
+		},
+		{
+			name: "archived bank account",
+			input: &invoice.CreateInput{
+				CompanyID:           1,
+				VendorID:            1,
+				VendorBankAccountID: 1,
+				IssueDate:           timeutil.AsiaTokyo(t, "2024-01-15 00:00:00"),
+				PaymentAmount:       10000,
+				DueDate:             timeutil.AsiaTokyo(t, "2024-02-15 00:00:00"),
+			},
+			prepare: func(ctx context.Context, c *controllers) {
+				archivedAt := timeutil.AsiaTokyo(t, "2024-01-01 00:00:00")
+
+				c.vendorRepo.EXPECT().
+					GetByIDAndCompanyID(ctx, int64(1), int64(1)).
+					Return(&entity.Vendor{ID: 1, CompanyID: 1}, nil)
+				c.bankAccountRepo.EXPECT().
+					GetByIDAndVendorID(ctx, int64(1), int64(1)).
+					Return(&entity.VendorBankAccount{ID: 1, VendorID: 1, ArchivedAt: &archivedAt}, nil)
+			},
+			want:    nil,
+			wantErr: domain.ErrInvalidInput,
+		},
+		{
+			name: "bank account pending verification",
+			input: &invoice.CreateInput{
+				CompanyID:           1,
+				VendorID:            1,
+				VendorBankAccountID: 1,
+				IssueDate:           timeutil.AsiaTokyo(t, "2024-01-15 00:00:00"),
+				PaymentAmount:       10000,
+				DueDate:             timeutil.AsiaTokyo(t, "2024-02-15 00:00:00"),
+			},
+			prepare: func(ctx context.Context, c *controllers) {
+				c.vendorRepo.EXPECT().
+					GetByIDAndCompanyID(ctx, int64(1), int64(1)).
+					Return(&entity.Vendor{ID: 1, CompanyID: 1}, nil)
+				c.bankAccountRepo.EXPECT().
+					GetByIDAndVendorID(ctx, int64(1), int64(1)).
+					Return(&entity.VendorBankAccount{
+						ID: 1, VendorID: 1, Status: entity.BankAccountStatusPendingVerification,
+					}, nil)
+			},
+			want:    nil,
+			wantErr: domain.ErrInvalidInput,
+		},
+		{
+			name: "bank account in cooling-off period",
+			input: &invoice.CreateInput{
+				CompanyID:           1,
+				VendorID:            1,
+				VendorBankAccountID: 1,
+				IssueDate:           timeutil.AsiaTokyo(t, "2024-01-15 00:00:00"),
+				PaymentAmount:       10000,
+				DueDate:             timeutil.AsiaTokyo(t, "2024-02-15 00:00:00"),
+			},
+			prepare: func(ctx context.Context, c *controllers) {
+				c.ctxProvider.SetAsiaTokyo(t, "2024-01-15 09:00:00")
+
+				coolingOffUntil := timeutil.AsiaTokyo(t, "2024-01-16 00:00:00")
+
+				c.vendorRepo.EXPECT().
+					GetByIDAndCompanyID(ctx, int64(1), int64(1)).
+					Return(&entity.Vendor{ID: 1, CompanyID: 1}, nil)
+				c.bankAccountRepo.EXPECT().
+					GetByIDAndVendorID(ctx, int64(1), int64(1)).
+					Return(&entity.VendorBankAccount{
+						ID:              1,
+						VendorID:        1,
+						Status:          entity.BankAccountStatusVerified,
+						CoolingOffUntil: &coolingOffUntil,
+					}, nil)
+			},
+			want:    nil,
+			wantErr: domain.ErrInvalidInput,
+		},
+		{
+			name: "bank account omitted - vendor has no default account",
+			input: &invoice.CreateInput{
+				CompanyID:     1,
+				VendorID:      1,
+				IssueDate:     timeutil.AsiaTokyo(t, "2024-01-15 00:00:00"),
+				PaymentAmount: 10000,
+				DueDate:       timeutil.AsiaTokyo(t, "2024-02-15 00:00:00"),
+			},
+			prepare: func(ctx context.Context, c *controllers) {
+				c.vendorRepo.EXPECT().
+					GetByIDAndCompanyID(ctx, int64(1), int64(1)).
+					Return(&entity.Vendor{ID: 1, CompanyID: 1}, nil)
+				c.bankAccountRepo.EXPECT().
+					GetDefaultByVendorID(ctx, int64(1)).
+					Return(nil, domain.ErrNotFound)
+			},
+			want:    nil,
+			wantErr: domain.ErrInvalidInput,
+		},
 	}
 
 	for _, tt := range tests {
@@ -152,6 +568,9 @@ func TestUsecaseImpl_Create(t *testing.T) {
 			ctx, uc, c := newUsecase(t)
 			defer c.ctrl.Finish()
 
+			expectTransaction(c)
+			c.invoiceRepo.EXPECT().LockByVendorID(ctx, tt.input.VendorID).Return(nil)
+
 			if tt.prepare != nil {
 				tt.prepare(ctx, c)
 			}
@@ -323,12 +742,201 @@ func TestUsecaseImpl_GetByID(t *testing.T) {
 	}
 }
 
+func TestUsecaseImpl_GetWithholdingReport(t *testing.T) {
+	t.Parallel()
+
+	summaries := []*entity.WithholdingSummary{
+		{
+			VendorID:       1,
+			VendorName:     "山田デザイン",
+			InvoiceCount:   2,
+			PaymentAmount:  200000,
+			WithholdingTax: 20420,
+		},
+	}
+
+	ctx, uc, c := newUsecase(t)
+	defer c.ctrl.Finish()
+
+	c.invoiceRepo.EXPECT().
+		GetWithholdingSummariesByCompanyIDAndDateRange(
+			ctx,
+			int64(1),
+			time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC),
+		).
+		Return(summaries, nil)
+
+	got, err := uc.GetWithholdingReport(ctx, 1, 2024)
+
+	require.NoError(t, err)
+	assert.Equal(t, &invoice.WithholdingReport{Year: 2024, Vendors: summaries}, got)
+}
+
+func TestUsecaseImpl_Approve(t *testing.T) {
+	t.Parallel()
+
+	reviewedAt := timeutil.AsiaTokyo(t, "2024-01-16 10:00:00")
+
+	tests := []struct {
+		name    string
+		prepare func(ctx context.Context, c *controllers)
+		want    *entity.Invoice
+		wantErr error
+	}{
+		{
+			name: "success",
+			prepare: func(ctx context.Context, c *controllers) {
+				c.ctxProvider.SetAsiaTokyo(t, "2024-01-16 10:00:00")
+
+				c.userRepo.EXPECT().
+					GetByIDAndCompanyID(ctx, int64(20), int64(1)).
+					Return(&entity.User{ID: 20, CompanyID: 1, Role: entity.UserRoleApprover}, nil)
+				c.invoiceRepo.EXPECT().
+					GetByIDAndCompanyID(ctx, int64(1), int64(1)).
+					Return(&entity.Invoice{
+						ID:        1,
+						CompanyID: 1,
+						Status:    entity.InvoiceStatusAwaitingApproval,
+						CreatedBy: 10,
+					}, nil)
+				c.invoiceRepo.EXPECT().
+					Review(ctx, &entity.Invoice{
+						ID:         1,
+						CompanyID:  1,
+						Status:     entity.InvoiceStatusPending,
+						CreatedBy:  10,
+						ReviewedBy: 20,
+						ReviewedAt: &reviewedAt,
+					}).
+					Return(&entity.Invoice{ID: 1, Status: entity.InvoiceStatusPending}, nil)
+			},
+			want: &entity.Invoice{ID: 1, Status: entity.InvoiceStatusPending},
+		},
+		{
+			name: "creator cannot approve own invoice",
+			prepare: func(ctx context.Context, c *controllers) {
+				c.userRepo.EXPECT().
+					GetByIDAndCompanyID(ctx, int64(20), int64(1)).
+					Return(&entity.User{ID: 20, CompanyID: 1, Role: entity.UserRoleAdmin}, nil)
+				c.invoiceRepo.EXPECT().
+					GetByIDAndCompanyID(ctx, int64(1), int64(1)).
+					Return(&entity.Invoice{
+						ID:        1,
+						CompanyID: 1,
+						Status:    entity.InvoiceStatusAwaitingApproval,
+						CreatedBy: 20,
+					}, nil)
+			},
+			wantErr: domain.ErrForbidden,
+		},
+		{
+			name: "user without approver role",
+			prepare: func(ctx context.Context, c *controllers) {
+				c.userRepo.EXPECT().
+					GetByIDAndCompanyID(ctx, int64(20), int64(1)).
+					Return(&entity.User{ID: 20, CompanyID: 1, Role: entity.UserRoleAccountant}, nil)
+			},
+			wantErr: domain.ErrForbidden,
+		},
+		{
+			name: "invoice not awaiting approval",
+			prepare: func(ctx context.Context, c *controllers) {
+				c.userRepo.EXPECT().
+					GetByIDAndCompanyID(ctx, int64(20), int64(1)).
+					Return(&entity.User{ID: 20, CompanyID: 1, Role: entity.UserRoleApprover}, nil)
+				c.invoiceRepo.EXPECT().
+					GetByIDAndCompanyID(ctx, int64(1), int64(1)).
+					Return(&entity.Invoice{ID: 1, CompanyID: 1, Status: entity.InvoiceStatusPaid}, nil)
+			},
+			wantErr: domain.ErrInvalidState,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, uc, c := newUsecase(t)
+			defer c.ctrl.Finish()
+
+			tt.prepare(ctx, c)
+
+			got, err := uc.Approve(ctx, 1, 1, 20)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUsecaseImpl_Reject(t *testing.T) {
+	t.Parallel()
+
+	ctx, uc, c := newUsecase(t)
+	defer c.ctrl.Finish()
+
+	c.ctxProvider.SetAsiaTokyo(t, "2024-01-16 10:00:00")
+	reviewedAt := timeutil.AsiaTokyo(t, "2024-01-16 10:00:00")
+
+	c.userRepo.EXPECT().
+		GetByIDAndCompanyID(ctx, int64(20), int64(1)).
+		Return(&entity.User{ID: 20, CompanyID: 1, Role: entity.UserRoleApprover}, nil)
+	c.invoiceRepo.EXPECT().
+		GetByIDAndCompanyID(ctx, int64(1), int64(1)).
+		Return(&entity.Invoice{
+			ID:        1,
+			CompanyID: 1,
+			Status:    entity.InvoiceStatusAwaitingApproval,
+			CreatedBy: 10,
+		}, nil)
+	c.invoiceRepo.EXPECT().
+		Review(ctx, &entity.Invoice{
+			ID:              1,
+			CompanyID:       1,
+			Status:          entity.InvoiceStatusRejected,
+			CreatedBy:       10,
+			ReviewedBy:      20,
+			ReviewedAt:      &reviewedAt,
+			RejectionReason: "wrong amount",
+		}).
+		Return(&entity.Invoice{ID: 1, Status: entity.InvoiceStatusRejected}, nil)
+
+	got, err := uc.Reject(ctx, &invoice.RejectInput{
+		CompanyID:  1,
+		InvoiceID:  1,
+		ApproverID: 20,
+		Reason:     "wrong amount",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, &entity.Invoice{ID: 1, Status: entity.InvoiceStatusRejected}, got)
+}
+
+func expectTransaction(c *controllers) {
+	c.transactor.EXPECT().
+		WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+}
+
 type controllers struct {
 	ctrl            *gomock.Controller
 	ctxProvider     *ctxutiltest.TestContextProvider
+	transactor      *mock.MockTransactor
 	invoiceRepo     *mock.MockInvoiceRepository
 	vendorRepo      *mock.MockVendorRepository
 	bankAccountRepo *mock.MockVendorBankAccountRepository
+	policyRepo      *mock.MockApprovalPolicyRepository
+	userRepo        *mock.MockUserRepository
 }
 
 func newUsecase(t *testing.T) (context.Context, invoice.Usecase, *controllers) {
@@ -338,23 +946,34 @@ func newUsecase(t *testing.T) (context.Context, invoice.Usecase, *controllers) {
 	ctx := ctxutiltest.TestContext(&ctxProvider)
 
 	ctrl := gomock.NewController(t)
+	transactor := mock.NewMockTransactor(ctrl)
 	invoiceRepo := mock.NewMockInvoiceRepository(ctrl)
 	vendorRepo := mock.NewMockVendorRepository(ctrl)
 	bankAccountRepo := mock.NewMockVendorBankAccountRepository(ctrl)
+	policyRepo := mock.NewMockApprovalPolicyRepository(ctrl)
+	userRepo := mock.NewMockUserRepository(ctrl)
 	calculator := service.NewInvoiceCalculator()
+	withholdingCalc := service.NewWithholdingTaxCalculator()
 
 	uc := invoice.NewUsecase(
+		transactor,
 		invoiceRepo,
 		vendorRepo,
 		bankAccountRepo,
+		policyRepo,
+		userRepo,
 		calculator,
+		withholdingCalc,
 	)
 
 	return ctx, uc, &controllers{
 		ctrl:            ctrl,
 		ctxProvider:     &ctxProvider,
+		transactor:      transactor,
 		invoiceRepo:     invoiceRepo,
 		vendorRepo:      vendorRepo,
 		bankAccountRepo: bankAccountRepo,
+		policyRepo:      policyRepo,
+		userRepo:        userRepo,
 	}
 }
@@ -0,0 +1,87 @@
+//go:generate mockgen -source=$GOFILE -destination=mock/mock_usecase.go -package=mock
+
+package mfa
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+)
+
+var (
+	// ErrInvalidCode is returned for a wrong, expired or already used TOTP code
+	// or recovery code.
+	ErrInvalidCode = fmt.Errorf(
+		"%w: two-factor authentication code is invalid",
+		domain.ErrInvalidInput,
+	)
+	// ErrLocked is returned while verification is locked after too many wrong
+	// codes in a row.
+	ErrLocked = errors.New("too many failed two-factor authentication attempts")
+	// ErrAlreadyEnabled is returned when enrolling a user whose two-factor
+	// authentication is already enabled. An admin has to reset it first.
+	ErrAlreadyEnabled = fmt.Errorf(
+		"%w: two-factor authentication is already enabled",
+		domain.ErrAlreadyExists,
+	)
+	// ErrMemberOfOtherCompanies is returned when resetting the two-factor
+	// authentication of a user who also belongs to other companies, which the
+	// admin of one company cannot weaken the sign-in of.
+	ErrMemberOfOtherCompanies = fmt.Errorf(
+		"%w: the user also belongs to other companies",
+		domain.ErrForbidden,
+	)
+	// ErrNotEnrolled is returned when activating or verifying two-factor
+	// authentication the user has not enrolled in.
+	ErrNotEnrolled = fmt.Errorf(
+		"%w: two-factor authentication has not been enrolled",
+		domain.ErrInvalidState,
+	)
+)
+
+// Status is a user's two-factor authentication as seen from one company.
+type Status struct {
+	// Enabled tells that the user has confirmed their enrolment, so logging in
+	// takes a code.
+	Enabled bool
+	// Required tells that the company requires its members to use two-factor
+	// authentication.
+	Required bool
+}
+
+// MustEnroll reports whether the user has to enrol before using the company.
+func (s *Status) MustEnroll() bool {
+	return s.Required && !s.Enabled
+}
+
+// Enrollment is a pending TOTP enrolment. The provisioning URI is usually
+// shown as a QR code for the authenticator app to scan.
+type Enrollment struct {
+	Secret          string
+	ProvisioningURI string
+}
+
+// Usecase defines two-factor authentication operations.
+type Usecase interface {
+	// Status returns the user's two-factor authentication in the company.
+	Status(ctx context.Context, userID, companyID int64) (*Status, error)
+	// Enroll starts enrolment with a new TOTP secret, replacing a pending
+	// enrolment. The secret is not used until Activate confirms it.
+	Enroll(ctx context.Context, userID, companyID int64) (*Enrollment, error)
+	// Activate confirms a pending enrolment with a code from the
+	// authenticator and returns the user's recovery codes. The codes are
+	// stored only as hashes, so this is the only time they can be shown.
+	Activate(ctx context.Context, userID int64, code string) ([]string, error)
+	// Verify checks a TOTP code or an unused recovery code of a user whose
+	// two-factor authentication is enabled. Each code is accepted only once,
+	// and too many wrong codes in a row lock verification for a while.
+	Verify(ctx context.Context, userID int64, code string) error
+	// Reset removes the two-factor authentication of a member of the company,
+	// such as one who lost their authenticator, so that they can enrol again.
+	// The user resetting it must be an admin, and the member must belong to no
+	// other company. The member's tokens are revoked and the reset is recorded
+	// in the company's audit logs.
+	Reset(ctx context.Context, companyID, adminID, userID int64) error
+}
@@ -0,0 +1,324 @@
+package mfa
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+	"github.com/harusys/super-shiharai-kun/internal/domain/repository"
+	"github.com/harusys/super-shiharai-kun/internal/infrastructure"
+	"github.com/harusys/super-shiharai-kun/internal/infrastructure/database"
+	"github.com/harusys/super-shiharai-kun/internal/infrastructure/security"
+	"github.com/harusys/super-shiharai-kun/pkg/ctxutil"
+)
+
+type usecaseImpl struct {
+	transactor     repository.Transactor
+	mfaRepo        repository.UserMFARepository
+	userRepo       repository.UserRepository
+	membershipRepo repository.MembershipRepository
+	companyRepo    repository.CompanyRepository
+	auditLogRepo   repository.CompanyAuditLogRepository
+	tokenRepo      repository.RefreshTokenRepository
+	revocations    repository.TokenRevocationRepository
+}
+
+// NewUsecase creates a new two-factor authentication Usecase.
+func NewUsecase(
+	transactor repository.Transactor,
+	mfaRepo repository.UserMFARepository,
+	userRepo repository.UserRepository,
+	membershipRepo repository.MembershipRepository,
+	companyRepo repository.CompanyRepository,
+	auditLogRepo repository.CompanyAuditLogRepository,
+	tokenRepo repository.RefreshTokenRepository,
+	revocations repository.TokenRevocationRepository,
+) Usecase {
+	return &usecaseImpl{
+		transactor:     transactor,
+		mfaRepo:        mfaRepo,
+		userRepo:       userRepo,
+		membershipRepo: membershipRepo,
+		companyRepo:    companyRepo,
+		auditLogRepo:   auditLogRepo,
+		tokenRepo:      tokenRepo,
+		revocations:    revocations,
+	}
+}
+
+func (u *usecaseImpl) Status(ctx context.Context, userID, companyID int64) (*Status, error) {
+	company, err := u.companyRepo.GetByID(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	mfa, err := u.mfaRepo.GetByUserID(ctx, userID)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return nil, err
+	}
+
+	return &Status{
+		Enabled:  mfa != nil && mfa.IsEnabled(),
+		Required: company.MFARequired,
+	}, nil
+}
+
+func (u *usecaseImpl) Enroll(ctx context.Context, userID, companyID int64) (*Enrollment, error) {
+	user, err := u.userRepo.GetByIDAndCompanyID(ctx, userID, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := security.GenerateTOTPKey(infrastructure.MFAIssuer, user.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := u.mfaRepo.StartEnrollment(ctx, userID, key.Secret); err != nil {
+		if errors.Is(err, domain.ErrAlreadyExists) {
+			return nil, ErrAlreadyEnabled
+		}
+
+		return nil, err
+	}
+
+	return &Enrollment{Secret: key.Secret, ProvisioningURI: key.ProvisioningURI}, nil
+}
+
+func (u *usecaseImpl) Activate(ctx context.Context, userID int64, code string) ([]string, error) {
+	mfa, err := u.get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if mfa.IsEnabled() {
+		return nil, ErrAlreadyEnabled
+	}
+
+	now := ctxutil.Now(ctx)
+
+	step, ok := security.MatchTOTP(mfa.Secret, code, now)
+	if !ok {
+		return nil, ErrInvalidCode
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := u.mfaRepo.Enable(ctx, userID, step, now); err != nil {
+			if errors.Is(err, domain.ErrInvalidState) {
+				// Another request confirmed the enrolment first
+				return ErrAlreadyEnabled
+			}
+
+			return err
+		}
+
+		return u.mfaRepo.ReplaceRecoveryCodes(ctx, userID, hashes)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+func (u *usecaseImpl) Verify(ctx context.Context, userID int64, code string) error {
+	mfa, err := u.get(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if !mfa.IsEnabled() {
+		return ErrNotEnrolled
+	}
+
+	now := ctxutil.Now(ctx)
+	if mfa.IsLocked(now) {
+		return ErrLocked
+	}
+
+	// The attempt is counted as failed before the code is checked so that
+	// concurrent attempts cannot get past the lock; an accepted code clears it
+	mfa, err = u.mfaRepo.ReserveAttempt(
+		ctx,
+		userID,
+		now,
+		infrastructure.MFAMaxFailedAttempts,
+		now.Add(infrastructure.MFALockDuration),
+	)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidState) {
+			// Locked by a concurrent attempt, or reset in the meantime
+			return ErrLocked
+		}
+
+		return err
+	}
+
+	return u.check(ctx, mfa, code, now)
+}
+
+func (u *usecaseImpl) Reset(ctx context.Context, companyID, adminID, userID int64) error {
+	if err := u.requireAdmin(ctx, companyID, adminID); err != nil {
+		return err
+	}
+
+	// The second factor also protects the user's other companies, so the
+	// transaction has to see their memberships of every company
+	return u.transactor.WithinTransaction(
+		database.WithAllCompanies(ctx),
+		func(ctx context.Context) error {
+			// Locking the user keeps them from joining another company meanwhile
+			if err := u.userRepo.LockByID(ctx, userID); err != nil {
+				return err
+			}
+
+			if err := u.requireSoleMember(ctx, companyID, userID); err != nil {
+				return err
+			}
+
+			if err := u.mfaRepo.Delete(ctx, userID); err != nil {
+				return err
+			}
+
+			// Sessions signed in with the lost second factor end with it
+			if err := u.revokeUser(ctx, userID); err != nil {
+				return err
+			}
+
+			_, err := u.auditLogRepo.Create(ctx, &entity.CompanyAuditLog{
+				CompanyID: companyID,
+				UserID:    adminID,
+				Changes: []*entity.CompanyFieldChange{{
+					Field: entity.AuditFieldMFAReset,
+					After: strconv.FormatInt(userID, 10),
+				}},
+			})
+
+			return err
+		},
+	)
+}
+
+// requireSoleMember returns ErrNotFound if the user is not a member of the
+// company, or ErrMemberOfOtherCompanies if they are also a member of others.
+func (u *usecaseImpl) requireSoleMember(ctx context.Context, companyID, userID int64) error {
+	memberships, err := u.membershipRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if !slices.ContainsFunc(memberships, func(m *entity.Membership) bool {
+		return m.CompanyID == companyID
+	}) {
+		return domain.ErrNotFound
+	}
+
+	if len(memberships) > 1 {
+		return ErrMemberOfOtherCompanies
+	}
+
+	return nil
+}
+
+// revokeUser revokes the access and refresh tokens issued to the user until
+// the current second, the precision of token issue times.
+func (u *usecaseImpl) revokeUser(ctx context.Context, userID int64) error {
+	now := ctxutil.Now(ctx).Truncate(time.Second)
+
+	if err := u.revocations.RevokeUser(ctx, userID, now); err != nil {
+		return err
+	}
+
+	return u.tokenRepo.RevokeByUserID(ctx, userID, now)
+}
+
+// get returns the user's two-factor authentication, or ErrNotEnrolled.
+func (u *usecaseImpl) get(ctx context.Context, userID int64) (*entity.UserMFA, error) {
+	mfa, err := u.mfaRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrNotEnrolled
+		}
+
+		return nil, err
+	}
+
+	return mfa, nil
+}
+
+// check uses up a TOTP code or a recovery code and clears the failed attempts,
+// returning ErrInvalidCode if it is wrong or has been used.
+func (u *usecaseImpl) check(
+	ctx context.Context,
+	mfa *entity.UserMFA,
+	code string,
+	now time.Time,
+) error {
+	if security.IsTOTPCode(code) {
+		step, ok := security.MatchTOTP(mfa.Secret, code, now)
+		if !ok || step <= mfa.LastUsedStep {
+			return ErrInvalidCode
+		}
+
+		err := u.mfaRepo.UseStep(ctx, mfa.UserID, step)
+		if errors.Is(err, domain.ErrInvalidState) {
+			// Another request used the code first
+			return ErrInvalidCode
+		}
+
+		return err
+	}
+
+	codeHash := security.HashToken(security.NormalizeRecoveryCode(code))
+
+	if err := u.mfaRepo.UseRecoveryCode(ctx, mfa.UserID, codeHash, now); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return ErrInvalidCode
+		}
+
+		return err
+	}
+
+	return u.mfaRepo.ClearFailures(ctx, mfa.UserID)
+}
+
+func (u *usecaseImpl) requireAdmin(ctx context.Context, companyID, userID int64) error {
+	user, err := u.userRepo.GetByIDAndCompanyID(ctx, userID, companyID)
+	if err != nil {
+		return err
+	}
+
+	if user.CompanyID != companyID || !user.IsAdmin() {
+		return fmt.Errorf("%w: admin role is required", domain.ErrForbidden)
+	}
+
+	return nil
+}
+
+// generateRecoveryCodes returns new recovery codes and their hashes.
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, infrastructure.MFARecoveryCodeCount)
+	hashes := make([]string, infrastructure.MFARecoveryCodeCount)
+
+	for i := range codes {
+		code, err := security.GenerateRecoveryCode()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		codes[i] = code
+		hashes[i] = security.HashToken(security.NormalizeRecoveryCode(code))
+	}
+
+	return codes, hashes, nil
+}
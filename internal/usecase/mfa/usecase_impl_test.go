@@ -0,0 +1,548 @@
+package mfa_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+	"github.com/harusys/super-shiharai-kun/internal/domain/repository/mock"
+	"github.com/harusys/super-shiharai-kun/internal/infrastructure/database"
+	"github.com/harusys/super-shiharai-kun/internal/infrastructure/security"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/mfa"
+	"github.com/harusys/super-shiharai-kun/pkg/ctxutil/ctxutiltest"
+	"github.com/harusys/super-shiharai-kun/pkg/timeutil"
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// testSecret is the TOTP secret of the user in the tests.
+const testSecret = "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP" //nolint:gosec // not a real secret
+
+func TestUsecaseImpl_Status(t *testing.T) {
+	t.Parallel()
+
+	enabledAt := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		required bool
+		mfa      *entity.UserMFA
+		mfaErr   error
+		want     *mfa.Status
+	}{
+		{
+			name:   "not enrolled",
+			mfaErr: domain.ErrNotFound,
+			want:   &mfa.Status{},
+		},
+		{
+			name:     "enrolment pending in a company that requires it",
+			required: true,
+			mfa:      &entity.UserMFA{UserID: 1, Secret: testSecret},
+			want:     &mfa.Status{Required: true},
+		},
+		{
+			name:     "enabled",
+			required: true,
+			mfa:      &entity.UserMFA{UserID: 1, Secret: testSecret, EnabledAt: &enabledAt},
+			want:     &mfa.Status{Enabled: true, Required: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, uc, c := newUsecase(t)
+			defer c.ctrl.Finish()
+
+			c.companyRepo.EXPECT().
+				GetByID(ctx, int64(1)).
+				Return(&entity.Company{ID: 1, MFARequired: tt.required}, nil)
+			c.mfaRepo.EXPECT().GetByUserID(ctx, int64(1)).Return(tt.mfa, tt.mfaErr)
+
+			got, err := uc.Status(ctx, 1, 1)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.required && !tt.want.Enabled, got.MustEnroll())
+		})
+	}
+}
+
+func TestUsecaseImpl_Enroll(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, uc, c := newUsecase(t)
+		defer c.ctrl.Finish()
+
+		var secret string
+
+		c.userRepo.EXPECT().
+			GetByIDAndCompanyID(ctx, int64(1), int64(1)).
+			Return(&entity.User{ID: 1, CompanyID: 1, Email: "test@example.com"}, nil)
+		c.mfaRepo.EXPECT().
+			StartEnrollment(ctx, int64(1), gomock.Any()).
+			DoAndReturn(func(_ context.Context, userID int64, s string) (*entity.UserMFA, error) {
+				secret = s
+
+				return &entity.UserMFA{UserID: userID, Secret: s}, nil
+			})
+
+		got, err := uc.Enroll(ctx, 1, 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, secret, got.Secret)
+
+		// The provisioning URI carries the stored secret for the authenticator app.
+		uri, err := url.Parse(got.ProvisioningURI)
+		require.NoError(t, err)
+		assert.Equal(t, "otpauth", uri.Scheme)
+		assert.Equal(t, "totp", uri.Host)
+		assert.Contains(t, uri.Path, "test@example.com")
+		assert.Equal(t, secret, uri.Query().Get("secret"))
+	})
+
+	t.Run("already enabled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, uc, c := newUsecase(t)
+		defer c.ctrl.Finish()
+
+		c.userRepo.EXPECT().
+			GetByIDAndCompanyID(ctx, int64(1), int64(1)).
+			Return(&entity.User{ID: 1, CompanyID: 1, Email: "test@example.com"}, nil)
+		c.mfaRepo.EXPECT().
+			StartEnrollment(ctx, int64(1), gomock.Any()).
+			Return(nil, domain.ErrAlreadyExists)
+
+		got, err := uc.Enroll(ctx, 1, 1)
+
+		require.ErrorIs(t, err, mfa.ErrAlreadyEnabled)
+		assert.Nil(t, got)
+	})
+}
+
+func TestUsecaseImpl_Activate(t *testing.T) {
+	t.Parallel()
+
+	pending := func() *entity.UserMFA {
+		return &entity.UserMFA{UserID: 1, Secret: testSecret}
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, uc, c := newUsecase(t)
+		defer c.ctrl.Finish()
+
+		var hashes []string
+
+		now := timeutil.AsiaTokyo(t, "2024-03-01 10:00:00")
+
+		c.mfaRepo.EXPECT().GetByUserID(ctx, int64(1)).Return(pending(), nil)
+		expectTransaction(c)
+		c.mfaRepo.EXPECT().Enable(ctx, int64(1), now.Unix()/30, now).Return(nil)
+		c.mfaRepo.EXPECT().
+			ReplaceRecoveryCodes(ctx, int64(1), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ int64, h []string) error {
+				hashes = h
+
+				return nil
+			})
+
+		got, err := uc.Activate(ctx, 1, code(t, now))
+
+		require.NoError(t, err)
+		require.Len(t, got, 10)
+		assert.Len(t, hashes, 10)
+
+		// Recovery codes are stored only as hashes.
+		for i, recoveryCode := range got {
+			assert.Regexp(t, `^[a-z2-7]{5}-[a-z2-7]{5}$`, recoveryCode)
+			assert.Equal(
+				t,
+				security.HashToken(security.NormalizeRecoveryCode(recoveryCode)),
+				hashes[i],
+			)
+		}
+	})
+
+	t.Run("wrong code", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, uc, c := newUsecase(t)
+		defer c.ctrl.Finish()
+
+		c.mfaRepo.EXPECT().GetByUserID(ctx, int64(1)).Return(pending(), nil)
+
+		got, err := uc.Activate(ctx, 1, code(t, timeutil.AsiaTokyo(t, "2024-03-01 09:00:00")))
+
+		require.ErrorIs(t, err, mfa.ErrInvalidCode)
+		assert.Nil(t, got)
+	})
+
+	t.Run("not enrolled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, uc, c := newUsecase(t)
+		defer c.ctrl.Finish()
+
+		c.mfaRepo.EXPECT().GetByUserID(ctx, int64(1)).Return(nil, domain.ErrNotFound)
+
+		got, err := uc.Activate(ctx, 1, "123456")
+
+		require.ErrorIs(t, err, mfa.ErrNotEnrolled)
+		assert.Nil(t, got)
+	})
+
+	t.Run("already enabled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, uc, c := newUsecase(t)
+		defer c.ctrl.Finish()
+
+		enabled := pending()
+		enabledAt := timeutil.AsiaTokyo(t, "2024-02-01 10:00:00")
+		enabled.EnabledAt = &enabledAt
+
+		c.mfaRepo.EXPECT().GetByUserID(ctx, int64(1)).Return(enabled, nil)
+
+		got, err := uc.Activate(ctx, 1, "123456")
+
+		require.ErrorIs(t, err, mfa.ErrAlreadyEnabled)
+		assert.Nil(t, got)
+	})
+}
+
+func TestUsecaseImpl_Verify(t *testing.T) {
+	t.Parallel()
+
+	now := timeutil.AsiaTokyo(t, "2024-03-01 10:00:00")
+	step := now.Unix() / 30
+	enabled := func() *entity.UserMFA {
+		enabledAt := timeutil.AsiaTokyo(t, "2024-02-01 10:00:00")
+
+		return &entity.UserMFA{UserID: 1, Secret: testSecret, EnabledAt: &enabledAt}
+	}
+	expectReserve := func(ctx context.Context, c *controllers, reserved *entity.UserMFA) {
+		c.mfaRepo.EXPECT().
+			ReserveAttempt(ctx, int64(1), now, 5, timeutil.AsiaTokyo(t, "2024-03-01 10:15:00")).
+			Return(reserved, nil)
+	}
+
+	tests := []struct {
+		name    string
+		code    string
+		prepare func(ctx context.Context, c *controllers)
+		wantErr error
+	}{
+		{
+			name: "TOTP code",
+			code: code(t, now),
+			prepare: func(ctx context.Context, c *controllers) {
+				c.mfaRepo.EXPECT().GetByUserID(ctx, int64(1)).Return(enabled(), nil)
+				expectReserve(ctx, c, enabled())
+				c.mfaRepo.EXPECT().UseStep(ctx, int64(1), step).Return(nil)
+			},
+		},
+		{
+			name: "TOTP code of the previous step",
+			code: code(t, now.Add(-30*time.Second)),
+			prepare: func(ctx context.Context, c *controllers) {
+				c.mfaRepo.EXPECT().GetByUserID(ctx, int64(1)).Return(enabled(), nil)
+				expectReserve(ctx, c, enabled())
+				c.mfaRepo.EXPECT().UseStep(ctx, int64(1), step-1).Return(nil)
+			},
+		},
+		{
+			name: "used TOTP code",
+			code: code(t, now),
+			prepare: func(ctx context.Context, c *controllers) {
+				used := enabled()
+				used.LastUsedStep = step
+
+				c.mfaRepo.EXPECT().GetByUserID(ctx, int64(1)).Return(used, nil)
+				expectReserve(ctx, c, used)
+			},
+			wantErr: mfa.ErrInvalidCode,
+		},
+		{
+			name: "TOTP code used concurrently",
+			code: code(t, now),
+			prepare: func(ctx context.Context, c *controllers) {
+				c.mfaRepo.EXPECT().GetByUserID(ctx, int64(1)).Return(enabled(), nil)
+				expectReserve(ctx, c, enabled())
+				c.mfaRepo.EXPECT().UseStep(ctx, int64(1), step).Return(domain.ErrInvalidState)
+			},
+			wantErr: mfa.ErrInvalidCode,
+		},
+		{
+			name: "wrong TOTP code",
+			code: code(t, now.Add(-time.Hour)),
+			prepare: func(ctx context.Context, c *controllers) {
+				c.mfaRepo.EXPECT().GetByUserID(ctx, int64(1)).Return(enabled(), nil)
+				expectReserve(ctx, c, enabled())
+			},
+			wantErr: mfa.ErrInvalidCode,
+		},
+		{
+			name: "recovery code clears failures",
+			code: " K7D2M-Q4XPA ",
+			prepare: func(ctx context.Context, c *controllers) {
+				c.mfaRepo.EXPECT().GetByUserID(ctx, int64(1)).Return(enabled(), nil)
+				expectReserve(ctx, c, enabled())
+				c.mfaRepo.EXPECT().
+					UseRecoveryCode(ctx, int64(1), security.HashToken("k7d2mq4xpa"), now).
+					Return(nil)
+				c.mfaRepo.EXPECT().ClearFailures(ctx, int64(1)).Return(nil)
+			},
+		},
+		{
+			name: "used recovery code",
+			code: "k7d2m-q4xpa",
+			prepare: func(ctx context.Context, c *controllers) {
+				c.mfaRepo.EXPECT().GetByUserID(ctx, int64(1)).Return(enabled(), nil)
+				expectReserve(ctx, c, enabled())
+				c.mfaRepo.EXPECT().
+					UseRecoveryCode(ctx, int64(1), security.HashToken("k7d2mq4xpa"), now).
+					Return(domain.ErrNotFound)
+			},
+			wantErr: mfa.ErrInvalidCode,
+		},
+		{
+			name: "locked",
+			code: code(t, now),
+			prepare: func(ctx context.Context, c *controllers) {
+				locked := enabled()
+				lockedUntil := timeutil.AsiaTokyo(t, "2024-03-01 10:05:00")
+				locked.LockedUntil = &lockedUntil
+
+				c.mfaRepo.EXPECT().GetByUserID(ctx, int64(1)).Return(locked, nil)
+			},
+			wantErr: mfa.ErrLocked,
+		},
+		{
+			name: "locked by a concurrent attempt",
+			code: code(t, now),
+			prepare: func(ctx context.Context, c *controllers) {
+				c.mfaRepo.EXPECT().GetByUserID(ctx, int64(1)).Return(enabled(), nil)
+				c.mfaRepo.EXPECT().
+					ReserveAttempt(ctx, int64(1), now, 5, timeutil.AsiaTokyo(t, "2024-03-01 10:15:00")).
+					Return(nil, domain.ErrInvalidState)
+			},
+			wantErr: mfa.ErrLocked,
+		},
+		{
+			name: "enrolment pending",
+			code: code(t, now),
+			prepare: func(ctx context.Context, c *controllers) {
+				c.mfaRepo.EXPECT().
+					GetByUserID(ctx, int64(1)).
+					Return(&entity.UserMFA{UserID: 1, Secret: testSecret}, nil)
+			},
+			wantErr: mfa.ErrNotEnrolled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, uc, c := newUsecase(t)
+			defer c.ctrl.Finish()
+
+			tt.prepare(ctx, c)
+
+			err := uc.Verify(ctx, 1, tt.code)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestUsecaseImpl_Reset(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		memberships []*entity.Membership
+		wantErr     error
+	}{
+		{
+			name:        "success",
+			memberships: []*entity.Membership{{UserID: 2, CompanyID: 1}},
+		},
+		{
+			name:        "user of another company",
+			memberships: []*entity.Membership{{UserID: 2, CompanyID: 3}},
+			wantErr:     domain.ErrNotFound,
+		},
+		{
+			name: "user who also belongs to another company",
+			memberships: []*entity.Membership{
+				{UserID: 2, CompanyID: 3},
+				{UserID: 2, CompanyID: 1},
+			},
+			wantErr: mfa.ErrMemberOfOtherCompanies,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, uc, c := newUsecase(t)
+			defer c.ctrl.Finish()
+
+			txCtx := database.WithAllCompanies(ctx)
+
+			expectAdmin(ctx, c)
+			expectTransaction(c)
+			c.userRepo.EXPECT().LockByID(txCtx, int64(2)).Return(nil)
+			c.membershipRepo.EXPECT().GetByUserID(txCtx, int64(2)).Return(tt.memberships, nil)
+
+			if tt.wantErr == nil {
+				now := timeutil.AsiaTokyo(t, "2024-03-01 10:00:00")
+
+				c.mfaRepo.EXPECT().Delete(txCtx, int64(2)).Return(nil)
+				c.revocations.EXPECT().RevokeUser(txCtx, int64(2), now).Return(nil)
+				c.tokenRepo.EXPECT().RevokeByUserID(txCtx, int64(2), now).Return(nil)
+				c.auditLogRepo.EXPECT().
+					Create(txCtx, &entity.CompanyAuditLog{
+						CompanyID: 1,
+						UserID:    10,
+						Changes: []*entity.CompanyFieldChange{
+							{Field: entity.AuditFieldMFAReset, After: "2"},
+						},
+					}).
+					Return(&entity.CompanyAuditLog{ID: 1}, nil)
+			}
+
+			err := uc.Reset(ctx, 1, 10, 2)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+
+	t.Run("not an admin", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, uc, c := newUsecase(t)
+		defer c.ctrl.Finish()
+
+		c.userRepo.EXPECT().
+			GetByIDAndCompanyID(ctx, int64(10), int64(1)).
+			Return(&entity.User{ID: 10, CompanyID: 1, Role: entity.UserRoleAccountant}, nil)
+
+		err := uc.Reset(ctx, 1, 10, 2)
+
+		require.ErrorIs(t, err, domain.ErrForbidden)
+	})
+}
+
+// code returns the TOTP code of testSecret at the time.
+func code(t *testing.T, at time.Time) string {
+	t.Helper()
+
+	got, err := totp.GenerateCodeCustom(testSecret, at, totp.ValidateOpts{
+		Period:    30,
+		Digits:    otp.DigitsSix,
+		Algorithm: otp.AlgorithmSHA1,
+	})
+	require.NoError(t, err)
+
+	return got
+}
+
+func expectAdmin(ctx context.Context, c *controllers) {
+	c.userRepo.EXPECT().
+		GetByIDAndCompanyID(ctx, int64(10), int64(1)).
+		Return(&entity.User{ID: 10, CompanyID: 1, Role: entity.UserRoleAdmin}, nil)
+}
+
+// expectTransaction makes the mocked transactor run the function and return
+// its error, as the real transactor would after rolling back or committing.
+func expectTransaction(c *controllers) {
+	c.transactor.EXPECT().
+		WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+}
+
+type controllers struct {
+	ctrl           *gomock.Controller
+	ctxProvider    *ctxutiltest.TestContextProvider
+	transactor     *mock.MockTransactor
+	mfaRepo        *mock.MockUserMFARepository
+	userRepo       *mock.MockUserRepository
+	membershipRepo *mock.MockMembershipRepository
+	companyRepo    *mock.MockCompanyRepository
+	auditLogRepo   *mock.MockCompanyAuditLogRepository
+	tokenRepo      *mock.MockRefreshTokenRepository
+	revocations    *mock.MockTokenRevocationRepository
+}
+
+func newUsecase(t *testing.T) (context.Context, mfa.Usecase, *controllers) {
+	t.Helper()
+
+	ctxProvider := ctxutiltest.TestContextProvider{}
+	ctxProvider.SetAsiaTokyo(t, "2024-03-01 10:00:00")
+	ctx := ctxutiltest.TestContext(&ctxProvider)
+
+	ctrl := gomock.NewController(t)
+	transactor := mock.NewMockTransactor(ctrl)
+	mfaRepo := mock.NewMockUserMFARepository(ctrl)
+	userRepo := mock.NewMockUserRepository(ctrl)
+	membershipRepo := mock.NewMockMembershipRepository(ctrl)
+	companyRepo := mock.NewMockCompanyRepository(ctrl)
+	auditLogRepo := mock.NewMockCompanyAuditLogRepository(ctrl)
+	tokenRepo := mock.NewMockRefreshTokenRepository(ctrl)
+	revocations := mock.NewMockTokenRevocationRepository(ctrl)
+
+	uc := mfa.NewUsecase(
+		transactor,
+		mfaRepo,
+		userRepo,
+		membershipRepo,
+		companyRepo,
+		auditLogRepo,
+		tokenRepo,
+		revocations,
+	)
+
+	return ctx, uc, &controllers{
+		ctrl:           ctrl,
+		ctxProvider:    &ctxProvider,
+		transactor:     transactor,
+		mfaRepo:        mfaRepo,
+		userRepo:       userRepo,
+		membershipRepo: membershipRepo,
+		companyRepo:    companyRepo,
+		auditLogRepo:   auditLogRepo,
+		tokenRepo:      tokenRepo,
+		revocations:    revocations,
+	}
+}
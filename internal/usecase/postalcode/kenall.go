@@ -0,0 +1,130 @@
+package postalcode
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+	"github.com/harusys/super-shiharai-kun/internal/domain/valueobject"
+	"github.com/harusys/super-shiharai-kun/pkg/textutil"
+	"golang.org/x/text/unicode/norm"
+	"golang.org/x/text/width"
+)
+
+// Columns of KEN_ALL.CSV. The kana columns are half-width katakana.
+const (
+	kenAllColumns          = 15
+	kenAllCode             = 2
+	kenAllPrefectureKana   = 3
+	kenAllCityKana         = 4
+	kenAllTownKana         = 5
+	kenAllPrefecture       = 6
+	kenAllCity             = 7
+	kenAllTown             = 8
+	kenAllNoTownSuffix     = "以下に掲載がない場合"
+	kenAllBanchiNextSuffix = "の次に番地がくる場合"
+)
+
+// parseKenAll reads Japan Post's KEN_ALL.CSV. Town names too long for one row
+// are split across consecutive rows of the same code and are joined back.
+func parseKenAll(data []byte) ([]*entity.PostalCode, error) {
+	reader := csv.NewReader(bytes.NewReader(textutil.ToUTF8(data)))
+	reader.FieldsPerRecord = kenAllColumns
+
+	var (
+		codes   []*entity.PostalCode
+		pending *entity.PostalCode
+	)
+
+	for {
+		fields, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", domain.ErrInvalidInput, err)
+		}
+
+		code := fields[kenAllCode]
+		if _, err := valueobject.NewZipCode(code); err != nil {
+			line, _ := reader.FieldPos(kenAllCode)
+
+			return nil, fmt.Errorf(
+				"%w: line %d: invalid zip code %q",
+				domain.ErrInvalidInput,
+				line,
+				code,
+			)
+		}
+
+		if pending != nil && pending.Code == code {
+			appendTown(pending, fields)
+		} else {
+			pending = newArea(fields)
+			codes = append(codes, pending)
+		}
+
+		// The row is complete once its parentheses are balanced.
+		if strings.Count(pending.Town, "（") == strings.Count(pending.Town, "）") {
+			cleanTown(pending)
+			pending = nil
+		}
+	}
+
+	if pending != nil {
+		return nil, fmt.Errorf(
+			"%w: town of %s is not terminated",
+			domain.ErrInvalidInput,
+			pending.Code,
+		)
+	}
+
+	if len(codes) == 0 {
+		return nil, fmt.Errorf("%w: file has no rows", domain.ErrInvalidInput)
+	}
+
+	return codes, nil
+}
+
+func newArea(fields []string) *entity.PostalCode {
+	return &entity.PostalCode{
+		Code:           fields[kenAllCode],
+		Prefecture:     fields[kenAllPrefecture],
+		City:           fields[kenAllCity],
+		Town:           fields[kenAllTown],
+		PrefectureKana: widenKana(fields[kenAllPrefectureKana]),
+		CityKana:       widenKana(fields[kenAllCityKana]),
+		TownKana:       widenKana(fields[kenAllTownKana]),
+	}
+}
+
+// appendTown joins a continuation row to the town of c. Continuation rows
+// sometimes repeat the whole town kana instead of continuing it.
+func appendTown(c *entity.PostalCode, fields []string) {
+	c.Town += fields[kenAllTown]
+
+	if kana := widenKana(fields[kenAllTownKana]); !strings.HasSuffix(c.TownKana, kana) {
+		c.TownKana += kana
+	}
+}
+
+// cleanTown removes the notes KEN_ALL uses in place of a town name, such as
+// "以下に掲載がない場合" for a code covering the rest of the city.
+func cleanTown(c *entity.PostalCode) {
+	if c.Town == kenAllNoTownSuffix || strings.HasSuffix(c.Town, kenAllBanchiNextSuffix) {
+		c.Town = ""
+		c.TownKana = ""
+	}
+}
+
+// widenKana converts half-width katakana to full-width, composing voiced sound
+// marks, so that "ﾀﾞ" becomes "ダ" rather than "タ゛".
+func widenKana(s string) string {
+	return width.Widen.String(norm.NFKC.String(s))
+}
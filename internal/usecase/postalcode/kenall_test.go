@@ -0,0 +1,92 @@
+package postalcode
+
+import (
+	"testing"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/encoding/japanese"
+)
+
+func TestParseKenAll(t *testing.T) {
+	t.Parallel()
+
+	data := `13101,"100  ","1000000","ﾄｳｷｮｳﾄ","ﾁﾖﾀﾞｸ","ｲｶﾆｹｲｻｲｶﾞﾅｲﾊﾞｱｲ","東京都","千代田区","以下に掲載がない場合",0,0,0,0,0,0
+13101,"100  ","1000001","ﾄｳｷｮｳﾄ","ﾁﾖﾀﾞｸ","ﾁﾖﾀﾞ","東京都","千代田区","千代田",0,0,0,0,0,0
+01101,"060  ","0600042","ﾎｯｶｲﾄﾞｳ","ｻｯﾎﾟﾛｼﾁｭｳｵｳｸ","ｵｵﾄﾞｵﾘﾆｼ(1-19ﾁｮｳﾒ)","北海道","札幌市中央区","大通西（１～１９丁目",1,0,1,0,0,0
+01101,"060  ","0600042","ﾎｯｶｲﾄﾞｳ","ｻｯﾎﾟﾛｼﾁｭｳｵｳｸ","ｵｵﾄﾞｵﾘﾆｼ(1-19ﾁｮｳﾒ)","北海道","札幌市中央区","）",1,0,1,0,0,0
+08546,"30604","3060433","ｲﾊﾞﾗｷｹﾝ","ｻｼﾏｸﾞﾝｻｶｲﾏﾁ","ｻｶｲﾏﾁﾉﾂｷﾞﾆﾊﾞﾝﾁｶﾞｸﾙﾊﾞｱｲ","茨城県","猿島郡境町","境町の次に番地がくる場合",0,0,0,0,0,0
+`
+
+	sjis, err := japanese.ShiftJIS.NewEncoder().Bytes([]byte(data))
+	require.NoError(t, err)
+
+	codes, err := parseKenAll(sjis)
+	require.NoError(t, err)
+
+	assert.Equal(t, []*entity.PostalCode{
+		{
+			Code:           "1000000",
+			Prefecture:     "東京都",
+			City:           "千代田区",
+			PrefectureKana: "トウキョウト",
+			CityKana:       "チヨダク",
+		},
+		{
+			Code:           "1000001",
+			Prefecture:     "東京都",
+			City:           "千代田区",
+			Town:           "千代田",
+			PrefectureKana: "トウキョウト",
+			CityKana:       "チヨダク",
+			TownKana:       "チヨダ",
+		},
+		{
+			Code:           "0600042",
+			Prefecture:     "北海道",
+			City:           "札幌市中央区",
+			Town:           "大通西（１～１９丁目）",
+			PrefectureKana: "ホッカイドウ",
+			CityKana:       "サッポロシチュウオウク",
+			TownKana:       "オオドオリニシ（１－１９チョウメ）",
+		},
+		{
+			Code:           "3060433",
+			Prefecture:     "茨城県",
+			City:           "猿島郡境町",
+			PrefectureKana: "イバラキケン",
+			CityKana:       "サシマグンサカイマチ",
+		},
+	}, codes)
+}
+
+func TestParseKenAll_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "empty", data: ""},
+		{name: "wrong column count", data: "13101,\"1000001\"\n"},
+		{
+			name: "invalid zip code",
+			data: `13101,"100  ","100000","ﾄｳｷｮｳﾄ","ﾁﾖﾀﾞｸ","ﾁﾖﾀﾞ","東京都","千代田区","千代田",0,0,0,0,0,0` + "\n",
+		},
+		{
+			name: "unterminated town",
+			data: `01101,"060  ","0600042","ﾎｯｶｲﾄﾞｳ","ｻｯﾎﾟﾛｼﾁｭｳｵｳｸ","ｵｵﾄﾞｵﾘﾆｼ","北海道","札幌市中央区","大通西（１",1,0,1,0,0,0` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := parseKenAll([]byte(tt.data))
+			assert.ErrorIs(t, err, domain.ErrInvalidInput)
+		})
+	}
+}
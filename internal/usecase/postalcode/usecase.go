@@ -0,0 +1,35 @@
+//go:generate mockgen -source=$GOFILE -destination=mock/mock_usecase.go -package=mock
+
+package postalcode
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+	"github.com/harusys/super-shiharai-kun/internal/domain/valueobject"
+)
+
+// ErrUnknownZipCode is returned when a well-formed postal code is not in the dataset.
+var ErrUnknownZipCode = fmt.Errorf("%w: zip code does not exist", domain.ErrInvalidInput)
+
+// LookupResult is the areas of a postal code.
+type LookupResult struct {
+	ZipCode valueobject.ZipCode
+	Areas   []*entity.PostalCode
+}
+
+// Usecase defines postal code operations.
+type Usecase interface {
+	// Lookup returns the areas of a postal code. It returns domain.ErrInvalidInput
+	// for a malformed code and domain.ErrNotFound for one not in the dataset.
+	Lookup(ctx context.Context, code string) (*LookupResult, error)
+	// Validate parses a postal code and checks that it is in the dataset,
+	// returning ErrUnknownZipCode if not. Until the dataset has been imported only
+	// the format is checked.
+	Validate(ctx context.Context, code string) (valueobject.ZipCode, error)
+	// Import replaces the dataset with Japan Post's KEN_ALL.CSV (Shift_JIS or
+	// UTF-8) and returns the number of areas imported.
+	Import(ctx context.Context, data []byte) (int64, error)
+}
@@ -0,0 +1,76 @@
+package postalcode
+
+import (
+	"context"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/harusys/super-shiharai-kun/internal/domain/repository"
+	"github.com/harusys/super-shiharai-kun/internal/domain/valueobject"
+)
+
+type usecaseImpl struct {
+	postalCodeRepo repository.PostalCodeRepository
+}
+
+// NewUsecase creates a new postal code Usecase.
+func NewUsecase(postalCodeRepo repository.PostalCodeRepository) Usecase {
+	return &usecaseImpl{
+		postalCodeRepo: postalCodeRepo,
+	}
+}
+
+func (u *usecaseImpl) Lookup(ctx context.Context, code string) (*LookupResult, error) {
+	zipCode, err := valueobject.NewZipCode(code)
+	if err != nil {
+		return nil, err
+	}
+
+	areas, err := u.postalCodeRepo.GetByCode(ctx, zipCode.Digits())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(areas) == 0 {
+		return nil, domain.ErrNotFound
+	}
+
+	return &LookupResult{ZipCode: zipCode, Areas: areas}, nil
+}
+
+func (u *usecaseImpl) Validate(ctx context.Context, code string) (valueobject.ZipCode, error) {
+	zipCode, err := valueobject.NewZipCode(code)
+	if err != nil {
+		return "", err
+	}
+
+	exists, err := u.postalCodeRepo.Exists(ctx, zipCode.Digits())
+	if err != nil {
+		return "", err
+	}
+
+	if exists {
+		return zipCode, nil
+	}
+
+	// Environments that have not imported the dataset yet, e.g. a fresh
+	// development database, only get the format check.
+	count, err := u.postalCodeRepo.Count(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if count == 0 {
+		return zipCode, nil
+	}
+
+	return "", ErrUnknownZipCode
+}
+
+func (u *usecaseImpl) Import(ctx context.Context, data []byte) (int64, error) {
+	codes, err := parseKenAll(data)
+	if err != nil {
+		return 0, err
+	}
+
+	return u.postalCodeRepo.ReplaceAll(ctx, codes)
+}
@@ -0,0 +1,170 @@
+package postalcode_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+	"github.com/harusys/super-shiharai-kun/internal/domain/repository/mock"
+	"github.com/harusys/super-shiharai-kun/internal/domain/valueobject"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/postalcode"
+	"github.com/harusys/super-shiharai-kun/pkg/ctxutil/ctxutiltest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUsecaseImpl_Lookup(t *testing.T) {
+	t.Parallel()
+
+	t.Run("found", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, uc, c := newUsecase(t)
+		defer c.ctrl.Finish()
+
+		areas := []*entity.PostalCode{
+			{Code: "1000001", Prefecture: "東京都", City: "千代田区", Town: "千代田"},
+		}
+		c.postalCodeRepo.EXPECT().GetByCode(ctx, "1000001").Return(areas, nil)
+
+		got, err := uc.Lookup(ctx, "１００－０００１")
+		require.NoError(t, err)
+		assert.Equal(t, &postalcode.LookupResult{ZipCode: "100-0001", Areas: areas}, got)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, uc, c := newUsecase(t)
+		defer c.ctrl.Finish()
+
+		c.postalCodeRepo.EXPECT().GetByCode(ctx, "9999999").Return(nil, nil)
+
+		_, err := uc.Lookup(ctx, "9999999")
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, uc, c := newUsecase(t)
+		defer c.ctrl.Finish()
+
+		_, err := uc.Lookup(ctx, "100-001")
+		assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	})
+}
+
+func TestUsecaseImpl_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		code    string
+		prepare func(ctx context.Context, c *controllers)
+		want    valueobject.ZipCode
+		wantErr error
+	}{
+		{
+			name: "exists",
+			code: "1000001",
+			prepare: func(ctx context.Context, c *controllers) {
+				c.postalCodeRepo.EXPECT().Exists(ctx, "1000001").Return(true, nil)
+			},
+			want: "100-0001",
+		},
+		{
+			name: "does not exist",
+			code: "999-9999",
+			prepare: func(ctx context.Context, c *controllers) {
+				c.postalCodeRepo.EXPECT().Exists(ctx, "9999999").Return(false, nil)
+				c.postalCodeRepo.EXPECT().Count(ctx).Return(int64(120000), nil)
+			},
+			wantErr: postalcode.ErrUnknownZipCode,
+		},
+		{
+			name: "dataset not imported",
+			code: "999-9999",
+			prepare: func(ctx context.Context, c *controllers) {
+				c.postalCodeRepo.EXPECT().Exists(ctx, "9999999").Return(false, nil)
+				c.postalCodeRepo.EXPECT().Count(ctx).Return(int64(0), nil)
+			},
+			want: "999-9999",
+		},
+		{
+			name:    "malformed",
+			code:    "12345",
+			prepare: func(_ context.Context, _ *controllers) {},
+			wantErr: valueobject.ErrInvalidZipCode,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, uc, c := newUsecase(t)
+			defer c.ctrl.Finish()
+
+			tt.prepare(ctx, c)
+
+			got, err := uc.Validate(ctx, tt.code)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUsecaseImpl_Import(t *testing.T) {
+	t.Parallel()
+
+	ctx, uc, c := newUsecase(t)
+	defer c.ctrl.Finish()
+
+	data := `13101,"100  ","1000001","ﾄｳｷｮｳﾄ","ﾁﾖﾀﾞｸ","ﾁﾖﾀﾞ","東京都","千代田区","千代田",0,0,0,0,0,0` + "\n"
+
+	c.postalCodeRepo.EXPECT().
+		ReplaceAll(ctx, []*entity.PostalCode{{
+			Code:           "1000001",
+			Prefecture:     "東京都",
+			City:           "千代田区",
+			Town:           "千代田",
+			PrefectureKana: "トウキョウト",
+			CityKana:       "チヨダク",
+			TownKana:       "チヨダ",
+		}}).
+		Return(int64(1), nil)
+
+	count, err := uc.Import(ctx, []byte(data))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
+
+type controllers struct {
+	ctrl           *gomock.Controller
+	postalCodeRepo *mock.MockPostalCodeRepository
+}
+
+func newUsecase(t *testing.T) (context.Context, postalcode.Usecase, *controllers) {
+	t.Helper()
+
+	ctxProvider := ctxutiltest.TestContextProvider{}
+	ctxProvider.SetAsiaTokyo(t, "2024-03-01 10:00:00")
+	ctx := ctxutiltest.TestContext(&ctxProvider)
+
+	ctrl := gomock.NewController(t)
+	postalCodeRepo := mock.NewMockPostalCodeRepository(ctrl)
+
+	return ctx, postalcode.NewUsecase(postalCodeRepo), &controllers{
+		ctrl:           ctrl,
+		postalCodeRepo: postalCodeRepo,
+	}
+}
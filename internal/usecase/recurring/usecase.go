@@ -0,0 +1,50 @@
+//go:generate mockgen -source=$GOFILE -destination=mock/mock_usecase.go -package=mock
+
+package recurring
+
+import (
+	"context"
+	"time"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+)
+
+// ScheduleInput is the input for creating or updating a recurring invoice schedule.
+type ScheduleInput struct {
+	CompanyID           int64
+	VendorID            int64
+	VendorBankAccountID int64
+	PaymentAmount       int64
+	DayOfMonth          int
+	EndOfMonth          bool
+	StartDate           time.Time
+	EndDate             *time.Time
+}
+
+// MaterializeResult holds the invoices produced by a materialization run.
+type MaterializeResult struct {
+	Invoices []*entity.Invoice
+}
+
+// Usecase defines recurring invoice schedule operations.
+type Usecase interface {
+	// Create creates a new recurring invoice schedule.
+	Create(ctx context.Context, input *ScheduleInput) (*entity.RecurringInvoiceSchedule, error)
+	// List returns the recurring invoice schedules of a company.
+	List(ctx context.Context, companyID int64) ([]*entity.RecurringInvoiceSchedule, error)
+	// GetByID returns a recurring invoice schedule by ID (with company authorization check).
+	GetByID(
+		ctx context.Context,
+		companyID, scheduleID int64,
+	) (*entity.RecurringInvoiceSchedule, error)
+	// Update replaces a recurring invoice schedule.
+	Update(
+		ctx context.Context,
+		scheduleID int64,
+		input *ScheduleInput,
+	) (*entity.RecurringInvoiceSchedule, error)
+	// Delete deletes a recurring invoice schedule. Invoices already produced are kept.
+	Delete(ctx context.Context, companyID, scheduleID int64) error
+	// Materialize creates the invoices of all schedules due within the lead time.
+	Materialize(ctx context.Context) (*MaterializeResult, error)
+}
@@ -0,0 +1,237 @@
+package recurring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+	"github.com/harusys/super-shiharai-kun/internal/domain/repository"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/invoice"
+	"github.com/harusys/super-shiharai-kun/pkg/ctxutil"
+)
+
+// jstOffset is the UTC offset used to decide the current business date.
+const jstOffset = 9 * 60 * 60
+
+type usecaseImpl struct {
+	scheduleRepo    repository.RecurringInvoiceScheduleRepository
+	vendorRepo      repository.VendorRepository
+	bankAccountRepo repository.VendorBankAccountRepository
+	invoiceUsecase  invoice.Usecase
+	leadDays        int
+}
+
+// NewUsecase creates a new recurring invoice schedule Usecase.
+// leadDays is how many days before the due date an invoice is materialized.
+func NewUsecase(
+	scheduleRepo repository.RecurringInvoiceScheduleRepository,
+	vendorRepo repository.VendorRepository,
+	bankAccountRepo repository.VendorBankAccountRepository,
+	invoiceUsecase invoice.Usecase,
+	leadDays int,
+) Usecase {
+	return &usecaseImpl{
+		scheduleRepo:    scheduleRepo,
+		vendorRepo:      vendorRepo,
+		bankAccountRepo: bankAccountRepo,
+		invoiceUsecase:  invoiceUsecase,
+		leadDays:        leadDays,
+	}
+}
+
+func (u *usecaseImpl) Create(
+	ctx context.Context,
+	input *ScheduleInput,
+) (*entity.RecurringInvoiceSchedule, error) {
+	if err := u.validate(ctx, input); err != nil {
+		return nil, err
+	}
+
+	return u.scheduleRepo.Create(ctx, u.toEntity(ctx, input))
+}
+
+func (u *usecaseImpl) List(
+	ctx context.Context,
+	companyID int64,
+) ([]*entity.RecurringInvoiceSchedule, error) {
+	return u.scheduleRepo.GetByCompanyID(ctx, companyID)
+}
+
+func (u *usecaseImpl) GetByID(
+	ctx context.Context,
+	companyID, scheduleID int64,
+) (*entity.RecurringInvoiceSchedule, error) {
+	return u.scheduleRepo.GetByIDAndCompanyID(ctx, scheduleID, companyID)
+}
+
+func (u *usecaseImpl) Update(
+	ctx context.Context,
+	scheduleID int64,
+	input *ScheduleInput,
+) (*entity.RecurringInvoiceSchedule, error) {
+	// Verify schedule belongs to company
+	_, err := u.scheduleRepo.GetByIDAndCompanyID(ctx, scheduleID, input.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.validate(ctx, input); err != nil {
+		return nil, err
+	}
+
+	schedule := u.toEntity(ctx, input)
+	schedule.ID = scheduleID
+
+	return u.scheduleRepo.Update(ctx, schedule)
+}
+
+func (u *usecaseImpl) Delete(ctx context.Context, companyID, scheduleID int64) error {
+	return u.scheduleRepo.Delete(ctx, scheduleID, companyID)
+}
+
+func (u *usecaseImpl) Materialize(ctx context.Context) (*MaterializeResult, error) {
+	today := currentDate(ctx)
+	horizon := today.AddDate(0, 0, u.leadDays)
+
+	schedules, err := u.scheduleRepo.GetDue(ctx, horizon)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &MaterializeResult{Invoices: []*entity.Invoice{}}
+
+	var errs []error
+
+	for _, schedule := range schedules {
+		invoices, err := u.materializeSchedule(ctx, schedule, today, horizon)
+		result.Invoices = append(result.Invoices, invoices...)
+
+		if err != nil {
+			slog.Error("failed to materialize recurring invoice",
+				slog.Int64("schedule_id", schedule.ID),
+				slog.Int64("company_id", schedule.CompanyID),
+				slog.String("error", err.Error()),
+			)
+
+			errs = append(errs, fmt.Errorf("schedule %d: %w", schedule.ID, err))
+		}
+	}
+
+	return result, errors.Join(errs...)
+}
+
+// materializeSchedule creates every invoice of the schedule due on or before horizon
+// and advances its next due date. Invoices go through the invoice usecase so that
+// amounts and validation are identical to manually created invoices.
+func (u *usecaseImpl) materializeSchedule(
+	ctx context.Context,
+	schedule *entity.RecurringInvoiceSchedule,
+	today, horizon time.Time,
+) ([]*entity.Invoice, error) {
+	var created []*entity.Invoice
+
+	for !schedule.NextDueDate.After(horizon) && schedule.IsActiveOn(schedule.NextDueDate) {
+		inv, err := u.invoiceUsecase.Create(ctx, &invoice.CreateInput{
+			CompanyID:                  schedule.CompanyID,
+			VendorID:                   schedule.VendorID,
+			VendorBankAccountID:        schedule.VendorBankAccountID,
+			IssueDate:                  today,
+			PaymentAmount:              schedule.PaymentAmount,
+			DueDate:                    schedule.NextDueDate,
+			RecurringInvoiceScheduleID: schedule.ID,
+		})
+
+		switch {
+		case err == nil:
+			slog.Info("recurring invoice materialized",
+				slog.Int64("id", inv.ID),
+				slog.Int64("schedule_id", schedule.ID),
+				slog.Int64("company_id", schedule.CompanyID),
+			)
+
+			created = append(created, inv)
+		case errors.Is(err, domain.ErrAlreadyExists):
+			// Already materialized by a previous run that failed to advance the schedule
+		case errors.Is(err, domain.ErrDuplicateInvoice):
+			// The same invoice was already registered manually
+			slog.Warn("recurring invoice skipped as duplicate",
+				slog.Int64("schedule_id", schedule.ID),
+				slog.Int64("company_id", schedule.CompanyID),
+				slog.String("error", err.Error()),
+			)
+		default:
+			return created, err
+		}
+
+		next := schedule.DueDateAfter(schedule.NextDueDate)
+		if err := u.scheduleRepo.UpdateNextDueDate(ctx, schedule.ID, next); err != nil {
+			return created, err
+		}
+
+		schedule.NextDueDate = next
+	}
+
+	return created, nil
+}
+
+func (u *usecaseImpl) validate(ctx context.Context, input *ScheduleInput) error {
+	if input.EndOfMonth == (input.DayOfMonth != 0) {
+		return fmt.Errorf(
+			"%w: exactly one of day_of_month and end_of_month must be specified",
+			domain.ErrInvalidInput,
+		)
+	}
+
+	if input.EndDate != nil && input.EndDate.Before(input.StartDate) {
+		return fmt.Errorf("%w: end_date must not be before start_date", domain.ErrInvalidInput)
+	}
+
+	// Verify vendor belongs to company
+	_, err := u.vendorRepo.GetByIDAndCompanyID(ctx, input.VendorID, input.CompanyID)
+	if err != nil {
+		return err
+	}
+
+	// Verify bank account belongs to vendor
+	_, err = u.bankAccountRepo.GetByIDAndVendorID(ctx, input.VendorBankAccountID, input.VendorID)
+
+	return err
+}
+
+func (u *usecaseImpl) toEntity(
+	ctx context.Context,
+	input *ScheduleInput,
+) *entity.RecurringInvoiceSchedule {
+	schedule := &entity.RecurringInvoiceSchedule{
+		CompanyID:           input.CompanyID,
+		VendorID:            input.VendorID,
+		VendorBankAccountID: input.VendorBankAccountID,
+		PaymentAmount:       input.PaymentAmount,
+		DayOfMonth:          input.DayOfMonth,
+		EndOfMonth:          input.EndOfMonth,
+		StartDate:           input.StartDate,
+		EndDate:             input.EndDate,
+	}
+
+	// Past occurrences are not backfilled
+	from := input.StartDate
+	if today := currentDate(ctx); today.After(from) {
+		from = today
+	}
+
+	schedule.NextDueDate = schedule.DueDateOnOrAfter(from)
+
+	return schedule
+}
+
+// currentDate returns today's date in Japan as a UTC midnight, matching how
+// dates are parsed from requests and stored in DATE columns.
+func currentDate(ctx context.Context) time.Time {
+	now := ctxutil.Now(ctx).In(time.FixedZone("JST", jstOffset))
+
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,290 @@
+package recurring_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+	"github.com/harusys/super-shiharai-kun/internal/domain/repository/mock"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/invoice"
+	invoicemock "github.com/harusys/super-shiharai-kun/internal/usecase/invoice/mock"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/recurring"
+	"github.com/harusys/super-shiharai-kun/pkg/ctxutil/ctxutiltest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const leadDays = 7
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestUsecaseImpl_Create(t *testing.T) {
+	t.Parallel()
+
+	endBeforeStart := date(2023, time.December, 31)
+
+	tests := []struct {
+		name    string
+		input   *recurring.ScheduleInput
+		prepare func(ctx context.Context, c *controllers)
+		want    *entity.RecurringInvoiceSchedule
+		wantErr error
+	}{
+		{
+			name: "success - past start date is not backfilled",
+			input: &recurring.ScheduleInput{
+				CompanyID:           1,
+				VendorID:            1,
+				VendorBankAccountID: 1,
+				PaymentAmount:       100000,
+				DayOfMonth:          25,
+				StartDate:           date(2024, time.January, 1),
+			},
+			prepare: func(ctx context.Context, c *controllers) {
+				c.ctxProvider.SetAsiaTokyo(t, "2024-03-26 09:00:00")
+
+				c.vendorRepo.EXPECT().
+					GetByIDAndCompanyID(ctx, int64(1), int64(1)).
+					Return(&entity.Vendor{ID: 1, CompanyID: 1}, nil)
+				c.bankAccountRepo.EXPECT().
+					GetByIDAndVendorID(ctx, int64(1), int64(1)).
+					Return(&entity.VendorBankAccount{ID: 1, VendorID: 1}, nil)
+				c.scheduleRepo.EXPECT().
+					Create(ctx, &entity.RecurringInvoiceSchedule{
+						CompanyID:           1,
+						VendorID:            1,
+						VendorBankAccountID: 1,
+						PaymentAmount:       100000,
+						DayOfMonth:          25,
+						StartDate:           date(2024, time.January, 1),
+						NextDueDate:         date(2024, time.April, 25),
+					}).
+					Return(&entity.RecurringInvoiceSchedule{ID: 1}, nil)
+			},
+			want: &entity.RecurringInvoiceSchedule{ID: 1},
+		},
+		{
+			name: "both day of month and end of month",
+			input: &recurring.ScheduleInput{
+				CompanyID:           1,
+				VendorID:            1,
+				VendorBankAccountID: 1,
+				PaymentAmount:       100000,
+				DayOfMonth:          25,
+				EndOfMonth:          true,
+				StartDate:           date(2024, time.January, 1),
+			},
+			wantErr: domain.ErrInvalidInput,
+		},
+		{
+			name: "end date before start date",
+			input: &recurring.ScheduleInput{
+				CompanyID:           1,
+				VendorID:            1,
+				VendorBankAccountID: 1,
+				PaymentAmount:       100000,
+				EndOfMonth:          true,
+				StartDate:           date(2024, time.January, 1),
+				EndDate:             &endBeforeStart,
+			},
+			wantErr: domain.ErrInvalidInput,
+		},
+		{
+			name: "bank account not found",
+			input: &recurring.ScheduleInput{
+				CompanyID:           1,
+				VendorID:            1,
+				VendorBankAccountID: 999,
+				PaymentAmount:       100000,
+				EndOfMonth:          true,
+				StartDate:           date(2024, time.January, 1),
+			},
+			prepare: func(ctx context.Context, c *controllers) {
+				c.vendorRepo.EXPECT().
+					GetByIDAndCompanyID(ctx, int64(1), int64(1)).
+					Return(&entity.Vendor{ID: 1, CompanyID: 1}, nil)
+				c.bankAccountRepo.EXPECT().
+					GetByIDAndVendorID(ctx, int64(999), int64(1)).
+					Return(nil, domain.ErrNotFound)
+			},
+			wantErr: domain.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, uc, c := newUsecase(t)
+			defer c.ctrl.Finish()
+
+			if tt.prepare != nil {
+				tt.prepare(ctx, c)
+			}
+
+			got, err := uc.Create(ctx, tt.input)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUsecaseImpl_Materialize(t *testing.T) {
+	t.Parallel()
+
+	ctx, uc, c := newUsecase(t)
+	defer c.ctrl.Finish()
+
+	c.ctxProvider.SetAsiaTokyo(t, "2024-01-28 03:00:00")
+
+	// Catching up after downtime: both 2024-01-01 and 2024-02-01 are within the horizon
+	schedule := &entity.RecurringInvoiceSchedule{
+		ID:                  1,
+		CompanyID:           1,
+		VendorID:            2,
+		VendorBankAccountID: 3,
+		PaymentAmount:       50000,
+		DayOfMonth:          1,
+		StartDate:           date(2023, time.December, 1),
+		NextDueDate:         date(2024, time.January, 1),
+	}
+
+	c.scheduleRepo.EXPECT().
+		GetDue(ctx, date(2024, time.February, 4)).
+		Return([]*entity.RecurringInvoiceSchedule{schedule}, nil)
+
+	gomock.InOrder(
+		// The January invoice was created by a previous run that failed to advance
+		c.invoiceUsecase.EXPECT().
+			Create(ctx, &invoice.CreateInput{
+				CompanyID:                  1,
+				VendorID:                   2,
+				VendorBankAccountID:        3,
+				IssueDate:                  date(2024, time.January, 28),
+				PaymentAmount:              50000,
+				DueDate:                    date(2024, time.January, 1),
+				RecurringInvoiceScheduleID: 1,
+			}).
+			Return(nil, domain.ErrAlreadyExists),
+		c.scheduleRepo.EXPECT().
+			UpdateNextDueDate(ctx, int64(1), date(2024, time.February, 1)).
+			Return(nil),
+		c.invoiceUsecase.EXPECT().
+			Create(ctx, &invoice.CreateInput{
+				CompanyID:                  1,
+				VendorID:                   2,
+				VendorBankAccountID:        3,
+				IssueDate:                  date(2024, time.January, 28),
+				PaymentAmount:              50000,
+				DueDate:                    date(2024, time.February, 1),
+				RecurringInvoiceScheduleID: 1,
+			}).
+			Return(&entity.Invoice{ID: 10}, nil),
+		c.scheduleRepo.EXPECT().
+			UpdateNextDueDate(ctx, int64(1), date(2024, time.March, 1)).
+			Return(nil),
+	)
+
+	got, err := uc.Materialize(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, []*entity.Invoice{{ID: 10}}, got.Invoices)
+}
+
+func TestUsecaseImpl_Materialize_ContinuesAfterFailure(t *testing.T) {
+	t.Parallel()
+
+	ctx, uc, c := newUsecase(t)
+	defer c.ctrl.Finish()
+
+	c.ctxProvider.SetAsiaTokyo(t, "2024-01-28 03:00:00")
+
+	failing := &entity.RecurringInvoiceSchedule{
+		ID:          1,
+		CompanyID:   1,
+		EndOfMonth:  true,
+		StartDate:   date(2024, time.January, 1),
+		NextDueDate: date(2024, time.January, 31),
+	}
+	succeeding := &entity.RecurringInvoiceSchedule{
+		ID:          2,
+		CompanyID:   1,
+		EndOfMonth:  true,
+		StartDate:   date(2024, time.January, 1),
+		NextDueDate: date(2024, time.January, 31),
+	}
+
+	c.scheduleRepo.EXPECT().
+		GetDue(ctx, gomock.Any()).
+		Return([]*entity.RecurringInvoiceSchedule{failing, succeeding}, nil)
+	c.invoiceUsecase.EXPECT().
+		Create(ctx, gomock.Cond(func(in *invoice.CreateInput) bool {
+			return in.RecurringInvoiceScheduleID == 1
+		})).
+		Return(nil, domain.ErrNotFound)
+	c.invoiceUsecase.EXPECT().
+		Create(ctx, gomock.Cond(func(in *invoice.CreateInput) bool {
+			return in.RecurringInvoiceScheduleID == 2
+		})).
+		Return(&entity.Invoice{ID: 20}, nil)
+	c.scheduleRepo.EXPECT().
+		UpdateNextDueDate(ctx, int64(2), date(2024, time.February, 29)).
+		Return(nil)
+
+	got, err := uc.Materialize(ctx)
+
+	require.ErrorIs(t, err, domain.ErrNotFound)
+	assert.Equal(t, []*entity.Invoice{{ID: 20}}, got.Invoices)
+}
+
+type controllers struct {
+	ctrl            *gomock.Controller
+	ctxProvider     *ctxutiltest.TestContextProvider
+	scheduleRepo    *mock.MockRecurringInvoiceScheduleRepository
+	vendorRepo      *mock.MockVendorRepository
+	bankAccountRepo *mock.MockVendorBankAccountRepository
+	invoiceUsecase  *invoicemock.MockUsecase
+}
+
+func newUsecase(t *testing.T) (context.Context, recurring.Usecase, *controllers) {
+	t.Helper()
+
+	ctxProvider := ctxutiltest.TestContextProvider{}
+	ctx := ctxutiltest.TestContext(&ctxProvider)
+
+	ctrl := gomock.NewController(t)
+	scheduleRepo := mock.NewMockRecurringInvoiceScheduleRepository(ctrl)
+	vendorRepo := mock.NewMockVendorRepository(ctrl)
+	bankAccountRepo := mock.NewMockVendorBankAccountRepository(ctrl)
+	invoiceUsecase := invoicemock.NewMockUsecase(ctrl)
+
+	uc := recurring.NewUsecase(
+		scheduleRepo,
+		vendorRepo,
+		bankAccountRepo,
+		invoiceUsecase,
+		leadDays,
+	)
+
+	return ctx, uc, &controllers{
+		ctrl:            ctrl,
+		ctxProvider:     &ctxProvider,
+		scheduleRepo:    scheduleRepo,
+		vendorRepo:      vendorRepo,
+		bankAccountRepo: bankAccountRepo,
+		invoiceUsecase:  invoiceUsecase,
+	}
+}
@@ -0,0 +1,18 @@
+//go:generate mockgen -source=$GOFILE -destination=mock/mock_usecase.go -package=mock
+
+package tokenpurge
+
+import "context"
+
+// PurgeResult holds how many rows a purge run deleted.
+type PurgeResult struct {
+	RevokedTokens int64
+	RefreshTokens int64
+}
+
+// Usecase defines the clean-up of token records that are no longer needed.
+type Usecase interface {
+	// Purge deletes the revoked access tokens and the refresh tokens that have
+	// expired. Expired tokens are refused anyway, so their records are not needed.
+	Purge(ctx context.Context) (*PurgeResult, error)
+}
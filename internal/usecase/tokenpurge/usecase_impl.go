@@ -0,0 +1,40 @@
+package tokenpurge
+
+import (
+	"context"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain/repository"
+	"github.com/harusys/super-shiharai-kun/pkg/ctxutil"
+)
+
+type usecaseImpl struct {
+	revocations repository.TokenRevocationRepository
+	tokenRepo   repository.RefreshTokenRepository
+}
+
+// NewUsecase creates a new token purge Usecase.
+func NewUsecase(
+	revocations repository.TokenRevocationRepository,
+	tokenRepo repository.RefreshTokenRepository,
+) Usecase {
+	return &usecaseImpl{
+		revocations: revocations,
+		tokenRepo:   tokenRepo,
+	}
+}
+
+func (u *usecaseImpl) Purge(ctx context.Context) (*PurgeResult, error) {
+	now := ctxutil.Now(ctx)
+
+	revoked, err := u.revocations.DeleteExpired(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+
+	refresh, err := u.tokenRepo.DeleteExpired(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PurgeResult{RevokedTokens: revoked, RefreshTokens: refresh}, nil
+}
@@ -0,0 +1,78 @@
+package tokenpurge_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain/repository/mock"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/tokenpurge"
+	"github.com/harusys/super-shiharai-kun/pkg/ctxutil/ctxutiltest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUsecaseImpl_Purge(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, uc, c := newUsecase(t)
+		defer c.ctrl.Finish()
+
+		now := c.ctxProvider.SetAsiaTokyo(t, "2024-03-01 03:00:00")
+
+		c.revocations.EXPECT().DeleteExpired(ctx, now).Return(int64(3), nil)
+		c.tokenRepo.EXPECT().DeleteExpired(ctx, now).Return(int64(5), nil)
+
+		got, err := uc.Purge(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, &tokenpurge.PurgeResult{RevokedTokens: 3, RefreshTokens: 5}, got)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, uc, c := newUsecase(t)
+		defer c.ctrl.Finish()
+
+		now := c.ctxProvider.SetAsiaTokyo(t, "2024-03-01 03:00:00")
+
+		c.revocations.EXPECT().DeleteExpired(ctx, now).Return(int64(0), errors.New("db error"))
+
+		got, err := uc.Purge(ctx)
+
+		require.EqualError(t, err, "db error")
+		assert.Nil(t, got)
+	})
+}
+
+type controllers struct {
+	ctrl        *gomock.Controller
+	ctxProvider *ctxutiltest.TestContextProvider
+	revocations *mock.MockTokenRevocationRepository
+	tokenRepo   *mock.MockRefreshTokenRepository
+}
+
+func newUsecase(t *testing.T) (context.Context, tokenpurge.Usecase, *controllers) {
+	t.Helper()
+
+	ctxProvider := ctxutiltest.TestContextProvider{}
+	ctx := ctxutiltest.TestContext(&ctxProvider)
+
+	ctrl := gomock.NewController(t)
+	revocations := mock.NewMockTokenRevocationRepository(ctrl)
+	tokenRepo := mock.NewMockRefreshTokenRepository(ctrl)
+
+	uc := tokenpurge.NewUsecase(revocations, tokenRepo)
+
+	return ctx, uc, &controllers{
+		ctrl:        ctrl,
+		ctxProvider: &ctxProvider,
+		revocations: revocations,
+		tokenRepo:   tokenRepo,
+	}
+}
@@ -0,0 +1,388 @@
+package vendorimport
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+	"github.com/harusys/super-shiharai-kun/internal/domain/valueobject"
+	"github.com/harusys/super-shiharai-kun/pkg/textutil"
+)
+
+// Column names of the import file.
+const (
+	columnExternalCode             = "external_code"
+	columnName                     = "name"
+	columnNameKana                 = "name_kana"
+	columnRepresentativeName       = "representative_name"
+	columnPhoneNumber              = "phone_number"
+	columnZipCode                  = "zip_code"
+	columnAddress                  = "address"
+	columnRegistrationNumber       = "registration_number"
+	columnWithholdingTaxApplicable = "withholding_tax_applicable"
+	columnBankCode                 = "bank_code"
+	columnBankName                 = "bank_name"
+	columnBranchCode               = "branch_code"
+	columnBranchName               = "branch_name"
+	columnAccountNumber            = "account_number"
+	columnAccountHolderName        = "account_holder_name"
+)
+
+// Field limits, matching the vendor and bank account API.
+const (
+	maxExternalCodeLength  = 50
+	maxNameLength          = 255
+	maxAddressLength       = 500
+	maxAccountNumberLength = 20
+	bankCodeDigits         = 4
+	branchCodeDigits       = 3
+)
+
+// record is a data row of the import file, keyed by column name.
+type record struct {
+	line   int
+	fields map[string]string
+}
+
+// row is a validated data row.
+type row struct {
+	line    int
+	vendor  *entity.Vendor
+	account *entity.VendorBankAccount // nil if the row has no bank account
+}
+
+// parse reads and validates the import file. It returns an error wrapping
+// domain.ErrInvalidInput if the file cannot be read at all.
+func parse(data []byte, companyID int64) ([]*row, []*RowError, error) {
+	records, err := readRecords(textutil.ToUTF8(data))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		rows    []*row
+		rowErrs []*RowError
+	)
+
+	seen := make(map[string]int, len(records))
+
+	for _, rec := range records {
+		c := &rowChecker{rec: rec}
+		r := c.row(companyID)
+
+		if code := r.vendor.ExternalCode; code != "" {
+			if first, ok := seen[code]; ok {
+				c.fail(columnExternalCode, fmt.Sprintf("duplicates row %d", first))
+			} else {
+				seen[code] = rec.line
+			}
+		}
+
+		rows = append(rows, r)
+		rowErrs = append(rowErrs, c.errs...)
+	}
+
+	return rows, rowErrs, nil
+}
+
+func readRecords(data []byte) ([]*record, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: file is empty", domain.ErrInvalidInput)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrInvalidInput, err)
+	}
+
+	columns, err := parseHeader(header)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []*record
+
+	for {
+		fields, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", domain.ErrInvalidInput, err)
+		}
+
+		if len(records) == MaxRows {
+			return nil, fmt.Errorf("%w: more than %d rows", domain.ErrInvalidInput, MaxRows)
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		rec := &record{line: line, fields: make(map[string]string, len(columns))}
+		for i, name := range columns {
+			rec.fields[name] = strings.TrimSpace(fields[i])
+		}
+
+		records = append(records, rec)
+	}
+
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%w: file has no rows", domain.ErrInvalidInput)
+	}
+
+	return records, nil
+}
+
+// parseHeader returns the column names in file order.
+func parseHeader(header []string) ([]string, error) {
+	known := map[string]bool{
+		columnExternalCode:             true,
+		columnName:                     true,
+		columnRepresentativeName:       true,
+		columnPhoneNumber:              true,
+		columnZipCode:                  true,
+		columnAddress:                  true,
+		columnNameKana:                 false,
+		columnRegistrationNumber:       false,
+		columnWithholdingTaxApplicable: false,
+		columnBankCode:                 false,
+		columnBankName:                 false,
+		columnBranchCode:               false,
+		columnBranchName:               false,
+		columnAccountNumber:            false,
+		columnAccountHolderName:        false,
+	}
+
+	columns := make([]string, len(header))
+	present := make(map[string]bool, len(header))
+
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+
+		if _, ok := known[name]; !ok {
+			return nil, fmt.Errorf("%w: unknown column %q", domain.ErrInvalidInput, name)
+		}
+
+		if present[name] {
+			return nil, fmt.Errorf("%w: duplicate column %q", domain.ErrInvalidInput, name)
+		}
+
+		columns[i] = name
+		present[name] = true
+	}
+
+	for name, required := range known {
+		if required && !present[name] {
+			return nil, fmt.Errorf("%w: missing column %q", domain.ErrInvalidInput, name)
+		}
+	}
+
+	return columns, nil
+}
+
+// rowChecker validates the fields of a record and collects the errors.
+type rowChecker struct {
+	rec  *record
+	errs []*RowError
+}
+
+func (c *rowChecker) fail(column, message string) {
+	c.errs = append(c.errs, &RowError{Row: c.rec.line, Column: column, Message: message})
+}
+
+func (c *rowChecker) row(companyID int64) *row {
+	r := &row{
+		line: c.rec.line,
+		vendor: &entity.Vendor{
+			CompanyID:                companyID,
+			ExternalCode:             c.text(columnExternalCode, maxExternalCodeLength),
+			Name:                     c.name(columnName),
+			NameKana:                 c.nameKana(),
+			RepresentativeName:       c.name(columnRepresentativeName),
+			PhoneNumber:              c.phoneNumber(),
+			ZipCode:                  c.zipCode(),
+			Address:                  c.text(columnAddress, maxAddressLength),
+			RegistrationNumber:       c.registrationNumber(),
+			WithholdingTaxApplicable: c.boolean(columnWithholdingTaxApplicable),
+		},
+	}
+
+	if c.hasBankAccount() {
+		r.account = &entity.VendorBankAccount{
+			BankCode:          c.digits(columnBankCode, bankCodeDigits),
+			BankName:          c.text(columnBankName, maxNameLength),
+			BranchCode:        c.digits(columnBranchCode, branchCodeDigits),
+			BranchName:        c.text(columnBranchName, maxNameLength),
+			AccountNumber:     c.accountNumber(),
+			AccountHolderName: c.text(columnAccountHolderName, maxNameLength),
+		}
+	}
+
+	return r
+}
+
+// hasBankAccount reports whether any bank account column is filled in. A row
+// with a bank account must fill in all of them.
+func (c *rowChecker) hasBankAccount() bool {
+	for _, column := range []string{
+		columnBankCode,
+		columnBankName,
+		columnBranchCode,
+		columnBranchName,
+		columnAccountNumber,
+		columnAccountHolderName,
+	} {
+		if c.rec.fields[column] != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// text returns a required text field of at most maxLen characters.
+func (c *rowChecker) text(column string, maxLen int) string {
+	return c.checkText(column, c.rec.fields[column], maxLen)
+}
+
+// name returns a required name, normalized like names saved through the API.
+func (c *rowChecker) name(column string) string {
+	return c.checkText(column, textutil.NormalizeName(c.rec.fields[column]), maxNameLength)
+}
+
+func (c *rowChecker) checkText(column, value string, maxLen int) string {
+	switch {
+	case value == "":
+		c.fail(column, "is required")
+	case utf8.RuneCountInString(value) > maxLen:
+		c.fail(column, fmt.Sprintf("must be at most %d characters", maxLen))
+	}
+
+	return value
+}
+
+// nameKana returns the optional kana reading of the name in katakana.
+func (c *rowChecker) nameKana() string {
+	value := c.rec.fields[columnNameKana]
+	if value == "" {
+		return ""
+	}
+
+	kana, err := valueobject.NewKana(value)
+	if err != nil {
+		c.fail(columnNameKana, "must be katakana or hiragana")
+
+		return value
+	}
+
+	return kana.String()
+}
+
+// digits returns a required field of exactly n digits.
+func (c *rowChecker) digits(column string, n int) string {
+	value := c.rec.fields[column]
+	if len(value) != n || !isDigits(value) {
+		c.fail(column, fmt.Sprintf("must be %d digits", n))
+	}
+
+	return value
+}
+
+// phoneNumber returns a required phone number normalized to E.164 format, e.g.
+// "03-1234-5678" becomes "+81312345678".
+func (c *rowChecker) phoneNumber() string {
+	value := c.rec.fields[columnPhoneNumber]
+
+	phoneNumber, err := valueobject.NewPhoneNumber(value)
+	if err != nil {
+		c.fail(columnPhoneNumber, "must be a phone number, e.g. 03-1234-5678 or +81312345678")
+
+		return value
+	}
+
+	return phoneNumber.String()
+}
+
+// zipCode returns a required postal code normalized to the "100-0001" format.
+// Whether it exists is checked against the postal code dataset after parsing.
+func (c *rowChecker) zipCode() string {
+	value := c.rec.fields[columnZipCode]
+
+	zipCode, err := valueobject.NewZipCode(value)
+	if err != nil {
+		c.fail(columnZipCode, "must be 7 digits, e.g. 100-0001")
+
+		return value
+	}
+
+	return zipCode.String()
+}
+
+// registrationNumber returns the optional qualified invoice issuer number.
+func (c *rowChecker) registrationNumber() string {
+	value := c.rec.fields[columnRegistrationNumber]
+	if value == "" {
+		return ""
+	}
+
+	number, err := valueobject.NewRegistrationNumber(value)
+	if err != nil {
+		c.fail(columnRegistrationNumber, "must be T followed by a valid 13-digit number")
+
+		return value
+	}
+
+	return number.String()
+}
+
+// accountNumber returns a required bank account number.
+func (c *rowChecker) accountNumber() string {
+	value := c.rec.fields[columnAccountNumber]
+	if len(value) > maxAccountNumberLength || !isDigits(value) {
+		c.fail(
+			columnAccountNumber,
+			fmt.Sprintf("must be 1 to %d digits", maxAccountNumberLength),
+		)
+	}
+
+	return value
+}
+
+// boolean returns an optional boolean field; empty means false.
+func (c *rowChecker) boolean(column string) bool {
+	value := c.rec.fields[column]
+	if value == "" {
+		return false
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		c.fail(column, "must be true or false")
+	}
+
+	return b
+}
+
+// isDigits reports whether s is a non-empty string of ASCII digits.
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
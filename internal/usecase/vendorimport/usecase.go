@@ -0,0 +1,66 @@
+//go:generate mockgen -source=$GOFILE -destination=mock/mock_usecase.go -package=mock
+
+package vendorimport
+
+import (
+	"context"
+)
+
+// MaxRows is the maximum number of data rows in an import file.
+const MaxRows = 5000
+
+// Action is what an import did, or would do in a dry run, with a vendor or bank account.
+type Action string
+
+const (
+	ActionCreated   Action = "created"
+	ActionUpdated   Action = "updated"
+	ActionUnchanged Action = "unchanged"
+)
+
+// Input is the input for importing vendors from CSV.
+type Input struct {
+	CompanyID int64
+	UserID    int64 // 取り込んだユーザー (登録・変更された口座をこのユーザーは確認できない)
+	// CSV is the file content, encoded in UTF-8 (with or without BOM) or Shift_JIS.
+	CSV []byte
+	// DryRun validates the file and reports what would change without saving anything.
+	DryRun bool
+}
+
+// RowResult reports what happened to the vendor and bank account of a row.
+type RowResult struct {
+	Row          int // 行番号 (ヘッダー行=1)
+	ExternalCode string
+	VendorID     int64 // 0 for a vendor that a dry run would create
+	Vendor       Action
+	// BankAccount is empty if the row has no bank account.
+	BankAccount Action
+}
+
+// RowError is a validation error in a row.
+type RowError struct {
+	Row     int // 行番号 (ヘッダー行=1)
+	Column  string
+	Message string
+}
+
+// Result is the outcome of an import. If Errors is not empty nothing was saved
+// and Rows is empty.
+type Result struct {
+	DryRun bool
+	Rows   []*RowResult
+	Errors []*RowError
+}
+
+// Usecase defines vendor import operations.
+type Usecase interface {
+	// Import creates or updates the company's vendors and their bank accounts
+	// from CSV, matching vendors by external code. All rows are saved in a single
+	// transaction, and only if every row is valid. New or changed bank accounts
+	// are pending verification and company admins are notified.
+	//
+	// It returns domain.ErrInvalidInput if the file itself cannot be read, e.g. a
+	// required column is missing; row-level problems are reported in Result.Errors.
+	Import(ctx context.Context, input *Input) (*Result, error)
+}
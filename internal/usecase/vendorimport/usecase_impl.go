@@ -0,0 +1,402 @@
+package vendorimport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+	"github.com/harusys/super-shiharai-kun/internal/domain/repository"
+	"github.com/harusys/super-shiharai-kun/internal/domain/valueobject"
+	"github.com/harusys/super-shiharai-kun/internal/infrastructure/mail"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/postalcode"
+	"github.com/harusys/super-shiharai-kun/pkg/ctxutil"
+)
+
+// errDryRun rolls back the transaction of a dry run.
+var errDryRun = errors.New("dry run")
+
+type usecaseImpl struct {
+	transactor      repository.Transactor
+	vendorRepo      repository.VendorRepository
+	bankAccountRepo repository.VendorBankAccountRepository
+	userRepo        repository.UserRepository
+	postalCodes     postalcode.Usecase
+	mailer          mail.Mailer
+	coolingOff      time.Duration
+}
+
+// NewUsecase creates a new vendor import Usecase. Imported bank accounts cannot
+// be paid into until coolingOff has passed.
+func NewUsecase(
+	transactor repository.Transactor,
+	vendorRepo repository.VendorRepository,
+	bankAccountRepo repository.VendorBankAccountRepository,
+	userRepo repository.UserRepository,
+	postalCodes postalcode.Usecase,
+	mailer mail.Mailer,
+	coolingOff time.Duration,
+) Usecase {
+	return &usecaseImpl{
+		transactor:      transactor,
+		vendorRepo:      vendorRepo,
+		bankAccountRepo: bankAccountRepo,
+		userRepo:        userRepo,
+		postalCodes:     postalCodes,
+		mailer:          mailer,
+		coolingOff:      coolingOff,
+	}
+}
+
+// bankAccountChange is a bank account created or changed by an import.
+type bankAccountChange struct {
+	vendor  *entity.Vendor
+	account *entity.VendorBankAccount
+}
+
+func (u *usecaseImpl) Import(ctx context.Context, input *Input) (*Result, error) {
+	rows, rowErrs, err := u.parse(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rowErrs) > 0 {
+		return &Result{DryRun: input.DryRun, Errors: rowErrs}, nil
+	}
+
+	var (
+		results []*RowResult
+		changes []*bankAccountChange
+	)
+
+	// A dry run performs the same writes so that the preview reflects the
+	// database exactly, then rolls them back.
+	err = u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		results, changes, err = u.importRows(ctx, rows, input.UserID)
+		if err != nil {
+			return err
+		}
+
+		if input.DryRun {
+			return errDryRun
+		}
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return nil, err
+	}
+
+	if input.DryRun {
+		for _, result := range results {
+			if result.Vendor == ActionCreated {
+				result.VendorID = 0
+			}
+		}
+	} else {
+		u.notifyBankAccountChanges(ctx, input.CompanyID, changes)
+	}
+
+	return &Result{DryRun: input.DryRun, Rows: results}, nil
+}
+
+// parse reads and validates the import file, including that the postal codes
+// exist. The row errors are ordered by row.
+func (u *usecaseImpl) parse(ctx context.Context, input *Input) ([]*row, []*RowError, error) {
+	rows, rowErrs, err := parse(input.CSV, input.CompanyID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	zipCodeErrs, err := u.checkZipCodes(ctx, rows)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(zipCodeErrs) > 0 {
+		rowErrs = append(rowErrs, zipCodeErrs...)
+		slices.SortStableFunc(rowErrs, func(a, b *RowError) int {
+			return a.Row - b.Row
+		})
+	}
+
+	return rows, rowErrs, nil
+}
+
+// checkZipCodes reports the rows whose postal code is not in the postal code
+// dataset. Malformed codes have already been reported by parse.
+func (u *usecaseImpl) checkZipCodes(ctx context.Context, rows []*row) ([]*RowError, error) {
+	var rowErrs []*RowError
+
+	known := make(map[string]bool, len(rows))
+
+	for _, r := range rows {
+		code := r.vendor.ZipCode
+
+		ok, checked := known[code]
+		if !checked {
+			_, err := u.postalCodes.Validate(ctx, code)
+
+			switch {
+			case err == nil:
+				ok = true
+			case errors.Is(err, valueobject.ErrInvalidZipCode):
+				ok = true
+			case errors.Is(err, postalcode.ErrUnknownZipCode):
+				ok = false
+			default:
+				return nil, err
+			}
+
+			known[code] = ok
+		}
+
+		if !ok {
+			rowErrs = append(rowErrs, &RowError{
+				Row:     r.line,
+				Column:  columnZipCode,
+				Message: "does not exist",
+			})
+		}
+	}
+
+	return rowErrs, nil
+}
+
+// importRows saves the rows in order and returns their results along with the
+// bank accounts created or changed.
+func (u *usecaseImpl) importRows(
+	ctx context.Context,
+	rows []*row,
+	userID int64,
+) ([]*RowResult, []*bankAccountChange, error) {
+	results := make([]*RowResult, 0, len(rows))
+
+	var changes []*bankAccountChange
+
+	for _, r := range rows {
+		result, change, err := u.importRow(ctx, r, userID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("row %d: %w", r.line, err)
+		}
+
+		results = append(results, result)
+
+		if change != nil {
+			changes = append(changes, change)
+		}
+	}
+
+	return results, changes, nil
+}
+
+// importRow saves the vendor and bank account of a row. The returned change is
+// nil unless a bank account was created or changed.
+func (u *usecaseImpl) importRow(
+	ctx context.Context,
+	r *row,
+	userID int64,
+) (*RowResult, *bankAccountChange, error) {
+	vendor, vendorAction, err := u.upsertVendor(ctx, r.vendor)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := &RowResult{
+		Row:          r.line,
+		ExternalCode: vendor.ExternalCode,
+		VendorID:     vendor.ID,
+		Vendor:       vendorAction,
+	}
+
+	if r.account == nil {
+		return result, nil, nil
+	}
+
+	account, accountAction, err := u.upsertBankAccount(ctx, vendor.ID, r.account, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result.BankAccount = accountAction
+
+	if accountAction == ActionUnchanged {
+		return result, nil, nil
+	}
+
+	return result, &bankAccountChange{vendor: vendor, account: account}, nil
+}
+
+// upsertVendor creates the vendor, or updates the company's vendor with the same
+// external code. An archived vendor stays archived.
+func (u *usecaseImpl) upsertVendor(
+	ctx context.Context,
+	vendor *entity.Vendor,
+) (*entity.Vendor, Action, error) {
+	existing, err := u.vendorRepo.GetByExternalCode(ctx, vendor.CompanyID, vendor.ExternalCode)
+	if errors.Is(err, domain.ErrNotFound) {
+		created, err := u.vendorRepo.Create(ctx, vendor)
+
+		return created, ActionCreated, err
+	}
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	vendor.ID = existing.ID
+
+	if sameVendor(existing, vendor) {
+		return existing, ActionUnchanged, nil
+	}
+
+	updated, err := u.vendorRepo.Update(ctx, vendor)
+
+	return updated, ActionUpdated, err
+}
+
+// upsertBankAccount adds the bank account to the vendor, or updates the vendor's
+// account with the same account number. A new or changed account is pending
+// verification, and a new account becomes the default if the vendor has none.
+func (u *usecaseImpl) upsertBankAccount(
+	ctx context.Context,
+	vendorID int64,
+	account *entity.VendorBankAccount,
+	userID int64,
+) (*entity.VendorBankAccount, Action, error) {
+	accounts, err := u.bankAccountRepo.GetByVendorID(ctx, vendorID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	account.VendorID = vendorID
+
+	if i := slices.IndexFunc(accounts, func(a *entity.VendorBankAccount) bool {
+		return sameAccount(a, account)
+	}); i >= 0 {
+		existing := accounts[i]
+		if existing.BankCode == account.BankCode &&
+			existing.BranchCode == account.BranchCode &&
+			existing.BankName == account.BankName &&
+			existing.BranchName == account.BranchName &&
+			existing.AccountHolderName == account.AccountHolderName {
+			return existing, ActionUnchanged, nil
+		}
+
+		account.ID = existing.ID
+		account.MarkChanged(userID, ctxutil.Now(ctx), u.coolingOff)
+
+		updated, err := u.bankAccountRepo.Update(ctx, account)
+
+		return updated, ActionUpdated, err
+	}
+
+	account.IsDefault = !slices.ContainsFunc(accounts, func(a *entity.VendorBankAccount) bool {
+		return a.IsDefault
+	})
+	account.MarkChanged(userID, ctxutil.Now(ctx), u.coolingOff)
+
+	created, err := u.bankAccountRepo.Create(ctx, account)
+
+	return created, ActionCreated, err
+}
+
+// sameVendor reports whether importing b would leave a unchanged.
+func sameVendor(a, b *entity.Vendor) bool {
+	return a.Name == b.Name &&
+		a.NameKana == b.NameKana &&
+		a.RepresentativeName == b.RepresentativeName &&
+		a.PhoneNumber == b.PhoneNumber &&
+		a.ZipCode == b.ZipCode &&
+		a.Address == b.Address &&
+		a.RegistrationNumber == b.RegistrationNumber &&
+		a.WithholdingTaxApplicable == b.WithholdingTaxApplicable
+}
+
+// sameAccount reports whether existing and imported refer to the same account.
+// Accounts registered without bank codes are matched by bank and branch name.
+func sameAccount(existing, imported *entity.VendorBankAccount) bool {
+	if existing.AccountNumber != imported.AccountNumber {
+		return false
+	}
+
+	if existing.BankCode == "" || existing.BranchCode == "" {
+		return existing.BankName == imported.BankName &&
+			existing.BranchName == imported.BranchName
+	}
+
+	return existing.BankCode == imported.BankCode && existing.BranchCode == imported.BranchCode
+}
+
+// notifyBankAccountChanges emails the company admins a single summary of the
+// bank accounts created or changed by an import. The import is already saved,
+// so a failure is logged rather than returned.
+func (u *usecaseImpl) notifyBankAccountChanges(
+	ctx context.Context,
+	companyID int64,
+	changes []*bankAccountChange,
+) {
+	if len(changes) == 0 {
+		return
+	}
+
+	users, err := u.userRepo.GetByCompanyID(ctx, companyID)
+	if err != nil {
+		logNotifyError(companyID, err)
+
+		return
+	}
+
+	var to []string
+
+	for _, user := range users {
+		if user.IsAdmin() {
+			to = append(to, user.Email)
+		}
+	}
+
+	if len(to) == 0 {
+		return
+	}
+
+	if err := u.mailer.Send(ctx, bankAccountChangesMessage(to, changes)); err != nil {
+		logNotifyError(companyID, err)
+	}
+}
+
+func logNotifyError(companyID int64, err error) {
+	slog.Error("failed to notify imported bank account changes",
+		slog.Int64("company_id", companyID),
+		slog.String("error", err.Error()),
+	)
+}
+
+func bankAccountChangesMessage(to []string, changes []*bankAccountChange) *mail.Message {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "CSV取込により、%d件の振込先口座が登録・変更されました。\n\n", len(changes))
+
+	for _, change := range changes {
+		fmt.Fprintf(&b, "- %s: %s %s %s %s\n",
+			change.vendor.Name,
+			change.account.BankName,
+			change.account.BranchName,
+			entity.MaskAccountNumber(change.account.AccountNumber),
+			change.account.AccountHolderName,
+		)
+	}
+
+	b.WriteString("\n取り込んだ担当者以外のユーザーが、登録済みの電話番号など別の手段で取引先に確認した上で、各口座を確認済みにしてください。\n")
+	b.WriteString("心当たりのない変更の場合は、口座を確認済みにせず速やかに調査してください。\n")
+
+	return &mail.Message{
+		To:      to,
+		Subject: "【要確認】CSV取込で取引先の振込先口座が登録・変更されました",
+		Body:    b.String(),
+	}
+}
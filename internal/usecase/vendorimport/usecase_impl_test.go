@@ -0,0 +1,513 @@
+package vendorimport_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+	"github.com/harusys/super-shiharai-kun/internal/domain/repository/mock"
+	"github.com/harusys/super-shiharai-kun/internal/domain/valueobject"
+	"github.com/harusys/super-shiharai-kun/internal/infrastructure/mail"
+	mailmock "github.com/harusys/super-shiharai-kun/internal/infrastructure/mail/mock"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/postalcode"
+	postalcodemock "github.com/harusys/super-shiharai-kun/internal/usecase/postalcode/mock"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/vendorimport"
+	"github.com/harusys/super-shiharai-kun/pkg/ctxutil"
+	"github.com/harusys/super-shiharai-kun/pkg/ctxutil/ctxutiltest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/text/encoding/japanese"
+)
+
+const header = "external_code,name,representative_name,phone_number,zip_code,address," +
+	"registration_number,withholding_tax_applicable," +
+	"bank_code,bank_name,branch_code,branch_name,account_number,account_holder_name\n"
+
+func TestUsecaseImpl_Import(t *testing.T) {
+	t.Parallel()
+
+	csv := header +
+		"V001,株式会社テスト,山田太郎,+81312345678,100-0001,東京都千代田区1-1,,false," +
+		"0001,テスト銀行,001,本店,1234567,カ）テスト\n" +
+		"V002,既存商事,佐藤花子,+81612345678,5300001,大阪府大阪市北区1-1,,," +
+		"0005,サンプル銀行,123,梅田支店,7654321,カ）キゾンシヨウジ\n"
+
+	tests := []struct {
+		name   string
+		dryRun bool
+	}{
+		{name: "import"},
+		{name: "dry run", dryRun: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, uc, c := newUsecase(t)
+			defer c.ctrl.Finish()
+
+			now := ctxutil.Now(ctx)
+			coolingOffUntil := now.Add(72 * time.Hour)
+
+			expectZipCodes(c)
+			expectTransaction(t, c, tt.dryRun)
+
+			// V001 is a new vendor with a new bank account.
+			c.vendorRepo.EXPECT().
+				GetByExternalCode(gomock.Any(), int64(1), "V001").
+				Return(nil, domain.ErrNotFound)
+			c.vendorRepo.EXPECT().
+				Create(gomock.Any(), &entity.Vendor{
+					CompanyID:          1,
+					ExternalCode:       "V001",
+					Name:               "株式会社テスト",
+					RepresentativeName: "山田太郎",
+					PhoneNumber:        "+81312345678",
+					ZipCode:            "100-0001",
+					Address:            "東京都千代田区1-1",
+				}).
+				DoAndReturn(func(_ context.Context, v *entity.Vendor) (*entity.Vendor, error) {
+					created := *v
+					created.ID = 5
+
+					return &created, nil
+				})
+			c.bankAccountRepo.EXPECT().GetByVendorID(gomock.Any(), int64(5)).Return(nil, nil)
+			c.bankAccountRepo.EXPECT().
+				Create(gomock.Any(), &entity.VendorBankAccount{
+					VendorID:          5,
+					BankCode:          "0001",
+					BankName:          "テスト銀行",
+					BranchCode:        "001",
+					BranchName:        "本店",
+					AccountNumber:     "1234567",
+					AccountHolderName: "カ）テスト",
+					IsDefault:         true,
+					Status:            entity.BankAccountStatusPendingVerification,
+					ChangedBy:         20,
+					CoolingOffUntil:   &coolingOffUntil,
+				}).
+				DoAndReturn(func(_ context.Context, a *entity.VendorBankAccount) (*entity.VendorBankAccount, error) {
+					created := *a
+					created.ID = 10
+
+					return &created, nil
+				})
+
+			// V002 exists with a different address and the same bank account.
+			c.vendorRepo.EXPECT().
+				GetByExternalCode(gomock.Any(), int64(1), "V002").
+				Return(&entity.Vendor{
+					ID:                 6,
+					CompanyID:          1,
+					ExternalCode:       "V002",
+					Name:               "既存商事",
+					RepresentativeName: "佐藤花子",
+					PhoneNumber:        "+81612345678",
+					ZipCode:            "5300001",
+					Address:            "大阪府大阪市北区",
+				}, nil)
+			c.vendorRepo.EXPECT().
+				Update(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, v *entity.Vendor) (*entity.Vendor, error) {
+					assert.Equal(t, int64(6), v.ID)
+					assert.Equal(t, "大阪府大阪市北区1-1", v.Address)
+
+					return v, nil
+				})
+			c.bankAccountRepo.EXPECT().
+				GetByVendorID(gomock.Any(), int64(6)).
+				Return([]*entity.VendorBankAccount{{
+					ID:                11,
+					VendorID:          6,
+					BankName:          "サンプル銀行",
+					BranchName:        "梅田支店",
+					AccountNumber:     "7654321",
+					AccountHolderName: "カ）キゾンシヨウジ",
+					IsDefault:         true,
+					Status:            entity.BankAccountStatusVerified,
+				}}, nil)
+			c.bankAccountRepo.EXPECT().
+				Update(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, a *entity.VendorBankAccount) (*entity.VendorBankAccount, error) {
+					assert.Equal(t, int64(11), a.ID)
+					assert.Equal(t, "0005", a.BankCode)
+					assert.Equal(t, entity.BankAccountStatusPendingVerification, a.Status)
+
+					return a, nil
+				})
+
+			if !tt.dryRun {
+				c.userRepo.EXPECT().
+					GetByCompanyID(ctx, int64(1)).
+					Return([]*entity.User{
+						{
+							ID:        20,
+							CompanyID: 1,
+							Email:     "admin@example.com",
+							Role:      entity.UserRoleAdmin,
+						},
+					}, nil)
+				c.mailer.EXPECT().
+					Send(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, msg *mail.Message) error {
+						assert.Equal(t, []string{"admin@example.com"}, msg.To)
+						assert.Contains(t, msg.Body, "2件")
+						assert.Contains(t, msg.Body, "****567")
+						assert.NotContains(t, msg.Body, "1234567")
+
+						return nil
+					})
+			}
+
+			got, err := uc.Import(ctx, &vendorimport.Input{
+				CompanyID: 1,
+				UserID:    20,
+				CSV:       []byte(csv),
+				DryRun:    tt.dryRun,
+			})
+
+			require.NoError(t, err)
+			assert.Empty(t, got.Errors)
+
+			wantVendorID := int64(5)
+			if tt.dryRun {
+				wantVendorID = 0
+			}
+
+			assert.Equal(t, []*vendorimport.RowResult{
+				{
+					Row:          2,
+					ExternalCode: "V001",
+					VendorID:     wantVendorID,
+					Vendor:       vendorimport.ActionCreated,
+					BankAccount:  vendorimport.ActionCreated,
+				},
+				{
+					Row:          3,
+					ExternalCode: "V002",
+					VendorID:     6,
+					Vendor:       vendorimport.ActionUpdated,
+					BankAccount:  vendorimport.ActionUpdated,
+				},
+			}, got.Rows)
+		})
+	}
+}
+
+func TestUsecaseImpl_Import_Unchanged(t *testing.T) {
+	t.Parallel()
+
+	ctx, uc, c := newUsecase(t)
+	defer c.ctrl.Finish()
+
+	vendor := &entity.Vendor{
+		ID:                 6,
+		CompanyID:          1,
+		ExternalCode:       "V002",
+		Name:               "既存商事",
+		RepresentativeName: "佐藤花子",
+		PhoneNumber:        "+81612345678",
+		ZipCode:            "530-0001",
+		Address:            "大阪府大阪市北区1-1",
+	}
+
+	expectZipCodes(c)
+	expectTransaction(t, c, false)
+	c.vendorRepo.EXPECT().GetByExternalCode(gomock.Any(), int64(1), "V002").Return(vendor, nil)
+	c.bankAccountRepo.EXPECT().
+		GetByVendorID(gomock.Any(), int64(6)).
+		Return([]*entity.VendorBankAccount{{
+			ID:                11,
+			VendorID:          6,
+			BankCode:          "0005",
+			BankName:          "サンプル銀行",
+			BranchCode:        "123",
+			BranchName:        "梅田支店",
+			AccountNumber:     "7654321",
+			AccountHolderName: "カ）キゾンシヨウジ",
+		}}, nil)
+
+	got, err := uc.Import(ctx, &vendorimport.Input{
+		CompanyID: 1,
+		UserID:    20,
+		CSV: []byte(header +
+			"V002,既存商事,佐藤花子,+81612345678,530-0001,大阪府大阪市北区1-1,,false," +
+			"0005,サンプル銀行,123,梅田支店,7654321,カ）キゾンシヨウジ\n"),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []*vendorimport.RowResult{{
+		Row:          2,
+		ExternalCode: "V002",
+		VendorID:     6,
+		Vendor:       vendorimport.ActionUnchanged,
+		BankAccount:  vendorimport.ActionUnchanged,
+	}}, got.Rows)
+}
+
+func TestUsecaseImpl_Import_ShiftJISAndNormalization(t *testing.T) {
+	t.Parallel()
+
+	ctx, uc, c := newUsecase(t)
+	defer c.ctrl.Finish()
+
+	csv, err := japanese.ShiftJIS.NewEncoder().String(
+		"external_code,name,name_kana,representative_name,phone_number,zip_code,address\n" +
+			"V001,株式会社ＴＥＳＴ,ｶﾌﾞｼｷｶﾞｲｼｬﾃｽﾄ,山田　太郎,03-1234-5678,〒100-0001,東京都千代田区1-1\n",
+	)
+	require.NoError(t, err)
+
+	expectZipCodes(c)
+	expectTransaction(t, c, false)
+	c.vendorRepo.EXPECT().
+		GetByExternalCode(gomock.Any(), int64(1), "V001").
+		Return(nil, domain.ErrNotFound)
+	c.vendorRepo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, v *entity.Vendor) (*entity.Vendor, error) {
+			// Full-width letters and half-width kana typed in Excel are normalized.
+			assert.Equal(t, "株式会社TEST", v.Name)
+			assert.Equal(t, "カブシキガイシャテスト", v.NameKana)
+			assert.Equal(t, "山田 太郎", v.RepresentativeName)
+			assert.Equal(t, "+81312345678", v.PhoneNumber)
+			assert.Equal(t, "100-0001", v.ZipCode)
+			assert.Equal(t, "東京都千代田区1-1", v.Address)
+
+			created := *v
+			created.ID = 5
+
+			return &created, nil
+		})
+
+	got, err := uc.Import(ctx, &vendorimport.Input{CompanyID: 1, UserID: 20, CSV: []byte(csv)})
+
+	require.NoError(t, err)
+	require.Len(t, got.Rows, 1)
+	assert.Empty(t, got.Rows[0].BankAccount, "the row has no bank account")
+}
+
+func TestUsecaseImpl_Import_RowErrors(t *testing.T) {
+	t.Parallel()
+
+	valid := "V001,株式会社テスト,山田太郎,+81312345678,100-0001,東京都千代田区1-1,,false," +
+		"0001,テスト銀行,001,本店,1234567,カ）テスト\n"
+
+	tests := []struct {
+		name       string
+		rows       string
+		wantColumn string
+		wantRow    int
+	}{
+		{
+			name:       "missing name",
+			rows:       "V001,,山田太郎,+81312345678,100-0001,東京都千代田区1-1,,,,,,,,\n",
+			wantColumn: "name",
+			wantRow:    2,
+		},
+		{
+			name:       "malformed phone number",
+			rows:       "V001,株式会社テスト,山田太郎,03-1234,100-0001,東京都千代田区1-1,,,,,,,,\n",
+			wantColumn: "phone_number",
+			wantRow:    2,
+		},
+		{
+			name:       "malformed zip code",
+			rows:       "V001,株式会社テスト,山田太郎,+81312345678,1000-001,東京都千代田区1-1,,,,,,,,\n",
+			wantColumn: "zip_code",
+			wantRow:    2,
+		},
+		{
+			name:       "invalid registration number",
+			rows:       "V001,株式会社テスト,山田太郎,+81312345678,100-0001,東京都千代田区1-1,T123,,,,,,,\n",
+			wantColumn: "registration_number",
+			wantRow:    2,
+		},
+		{
+			name: "three-digit bank code",
+			rows: "V001,株式会社テスト,山田太郎,+81312345678,100-0001,東京都千代田区1-1,,," +
+				"001,テスト銀行,001,本店,1234567,カ）テスト\n",
+			wantColumn: "bank_code",
+			wantRow:    2,
+		},
+		{
+			name: "incomplete bank account",
+			rows: "V001,株式会社テスト,山田太郎,+81312345678,100-0001,東京都千代田区1-1,,," +
+				",,,,1234567,\n",
+			wantColumn: "bank_code",
+			wantRow:    2,
+		},
+		{
+			name:       "duplicate external code",
+			rows:       valid + valid,
+			wantColumn: "external_code",
+			wantRow:    3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, uc, c := newUsecase(t)
+			defer c.ctrl.Finish()
+
+			expectZipCodes(c)
+
+			got, err := uc.Import(ctx, &vendorimport.Input{
+				CompanyID: 1,
+				UserID:    20,
+				CSV:       []byte(header + tt.rows),
+			})
+
+			require.NoError(t, err)
+			assert.Empty(t, got.Rows)
+			require.NotEmpty(t, got.Errors)
+			assert.Equal(t, tt.wantColumn, got.Errors[0].Column)
+			assert.Equal(t, tt.wantRow, got.Errors[0].Row)
+		})
+	}
+}
+
+func TestUsecaseImpl_Import_UnknownZipCode(t *testing.T) {
+	t.Parallel()
+
+	ctx, uc, c := newUsecase(t)
+	defer c.ctrl.Finish()
+
+	rows := "V001,株式会社テスト,山田太郎,+81312345678,999-9999,東京都千代田区1-1,,,,,,,,\n" +
+		"V002,テスト商事,佐藤花子,+81312345678,1000001,東京都千代田区1-1,,,,,,,,\n" +
+		"V003,サンプル商事,鈴木一郎,+81312345678,999-9999,東京都千代田区1-1,,,,,,,,\n"
+
+	// Each distinct code is looked up once.
+	c.postalCodes.EXPECT().
+		Validate(gomock.Any(), "999-9999").
+		Return(valueobject.ZipCode(""), postalcode.ErrUnknownZipCode)
+	c.postalCodes.EXPECT().
+		Validate(gomock.Any(), "100-0001").
+		Return(valueobject.ZipCode("100-0001"), nil)
+
+	got, err := uc.Import(ctx, &vendorimport.Input{
+		CompanyID: 1,
+		UserID:    20,
+		CSV:       []byte(header + rows),
+	})
+
+	require.NoError(t, err)
+	assert.Empty(t, got.Rows)
+	assert.Equal(t, []*vendorimport.RowError{
+		{Row: 2, Column: "zip_code", Message: "does not exist"},
+		{Row: 4, Column: "zip_code", Message: "does not exist"},
+	}, got.Errors)
+}
+
+func TestUsecaseImpl_Import_InvalidFile(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		csv  string
+	}{
+		{name: "empty", csv: ""},
+		{name: "header only", csv: header},
+		{name: "missing column", csv: "external_code,name\nV001,株式会社テスト\n"},
+		{name: "unknown column", csv: strings.TrimSuffix(header, "\n") + ",memo\n"},
+		{name: "wrong number of fields", csv: header + "V001,株式会社テスト\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, uc, c := newUsecase(t)
+			defer c.ctrl.Finish()
+
+			_, err := uc.Import(ctx, &vendorimport.Input{
+				CompanyID: 1,
+				UserID:    20,
+				CSV:       []byte(tt.csv),
+			})
+
+			require.ErrorIs(t, err, domain.ErrInvalidInput)
+		})
+	}
+}
+
+// expectZipCodes accepts every well-formed postal code, as the postal code
+// usecase does before the dataset has been imported.
+func expectZipCodes(c *controllers) {
+	c.postalCodes.EXPECT().
+		Validate(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, code string) (valueobject.ZipCode, error) {
+			return valueobject.NewZipCode(code)
+		}).
+		AnyTimes()
+}
+
+// expectTransaction runs the unit of work passed to the transactor and returns
+// its error, as the real transactor would after rolling back or committing.
+func expectTransaction(t *testing.T, c *controllers, dryRun bool) {
+	t.Helper()
+
+	c.transactor.EXPECT().
+		WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			err := fn(ctx)
+			if dryRun {
+				assert.Error(t, err, "a dry run rolls back")
+			}
+
+			return err
+		})
+}
+
+type controllers struct {
+	ctrl            *gomock.Controller
+	transactor      *mock.MockTransactor
+	vendorRepo      *mock.MockVendorRepository
+	bankAccountRepo *mock.MockVendorBankAccountRepository
+	userRepo        *mock.MockUserRepository
+	postalCodes     *postalcodemock.MockUsecase
+	mailer          *mailmock.MockMailer
+}
+
+func newUsecase(t *testing.T) (context.Context, vendorimport.Usecase, *controllers) {
+	t.Helper()
+
+	ctxProvider := ctxutiltest.TestContextProvider{}
+	ctxProvider.SetAsiaTokyo(t, "2024-03-01 10:00:00")
+	ctx := ctxutiltest.TestContext(&ctxProvider)
+
+	ctrl := gomock.NewController(t)
+	transactor := mock.NewMockTransactor(ctrl)
+	vendorRepo := mock.NewMockVendorRepository(ctrl)
+	bankAccountRepo := mock.NewMockVendorBankAccountRepository(ctrl)
+	userRepo := mock.NewMockUserRepository(ctrl)
+	postalCodes := postalcodemock.NewMockUsecase(ctrl)
+	mailer := mailmock.NewMockMailer(ctrl)
+
+	uc := vendorimport.NewUsecase(
+		transactor,
+		vendorRepo,
+		bankAccountRepo,
+		userRepo,
+		postalCodes,
+		mailer,
+		72*time.Hour,
+	)
+
+	return ctx, uc, &controllers{
+		ctrl:            ctrl,
+		transactor:      transactor,
+		vendorRepo:      vendorRepo,
+		bankAccountRepo: bankAccountRepo,
+		userRepo:        userRepo,
+		postalCodes:     postalCodes,
+		mailer:          mailer,
+	}
+}
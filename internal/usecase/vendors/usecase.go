@@ -0,0 +1,143 @@
+//go:generate mockgen -source=$GOFILE -destination=mock/mock_usecase.go -package=mock
+
+package vendors
+
+import (
+	"context"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+)
+
+// Pagination defaults for listing vendors.
+const (
+	DefaultPerPage = 20
+	MaxPerPage     = 100
+	MaxPage        = 10000
+)
+
+// VendorInput is the input for creating or updating a vendor.
+type VendorInput struct {
+	CompanyID                int64
+	ExternalCode             string // 取引先コード (空文字=未設定)
+	Name                     string
+	NameKana                 string // 法人名フリガナ (空文字=未登録)
+	RepresentativeName       string
+	PhoneNumber              string
+	ZipCode                  string
+	Address                  string
+	RegistrationNumber       string // 適格請求書発行事業者登録番号 (空文字=未登録)
+	WithholdingTaxApplicable bool
+}
+
+// ListInput is the input for listing vendors.
+type ListInput struct {
+	CompanyID int64
+	Name      string // 法人名またはフリガナの部分一致 (空文字=指定なし)
+	Page      int    // 1始まり (0=1ページ目)
+	PerPage   int    // 0=DefaultPerPage
+	// IncludeArchived also returns archived vendors.
+	IncludeArchived bool
+}
+
+// ListResult is a page of vendors.
+type ListResult struct {
+	Vendors []*entity.Vendor
+	Total   int64
+	Page    int
+	PerPage int
+}
+
+// BankAccountQuery identifies the vendor whose bank accounts are read and the
+// user reading them.
+type BankAccountQuery struct {
+	CompanyID int64
+	VendorID  int64
+	UserID    int64
+	// Reveal returns account numbers unmasked. The user must be allowed to see them.
+	Reveal bool
+	// IncludeArchived also lists archived accounts.
+	IncludeArchived bool
+}
+
+// BankAccountInput is the input for creating or updating a vendor bank account.
+type BankAccountInput struct {
+	CompanyID         int64
+	VendorID          int64
+	UserID            int64  // 登録・変更したユーザー (このユーザーは確認できない)
+	BankCode          string // 金融機関コード (空文字=未登録)
+	BankName          string
+	BranchCode        string // 支店コード (空文字=未登録)
+	BranchName        string
+	AccountNumber     string
+	AccountHolderName string
+}
+
+// Usecase defines vendor operations.
+type Usecase interface {
+	// List returns a page of the company's vendors, optionally filtered by name.
+	// Archived vendors are excluded unless input.IncludeArchived is set.
+	List(ctx context.Context, input *ListInput) (*ListResult, error)
+	// GetByID returns a vendor by ID (with company authorization check).
+	GetByID(ctx context.Context, companyID, vendorID int64) (*entity.Vendor, error)
+	// GetStats returns the payment statistics of a vendor (with company
+	// authorization check).
+	GetStats(ctx context.Context, companyID, vendorID int64) (*entity.VendorPaymentStats, error)
+	// Create creates a new vendor.
+	Create(ctx context.Context, input *VendorInput) (*entity.Vendor, error)
+	// Update replaces a vendor.
+	Update(ctx context.Context, vendorID int64, input *VendorInput) (*entity.Vendor, error)
+	// SetArchived archives or restores a vendor. Its existing invoices stay readable.
+	SetArchived(
+		ctx context.Context,
+		companyID, vendorID int64,
+		archived bool,
+	) (*entity.Vendor, error)
+
+	// ListBankAccounts returns the vendor's bank accounts. Account numbers are
+	// masked unless query.Reveal is set, and archived accounts are excluded unless
+	// query.IncludeArchived is set.
+	ListBankAccounts(
+		ctx context.Context,
+		query *BankAccountQuery,
+	) ([]*entity.VendorBankAccount, error)
+	// GetBankAccount returns a bank account of the vendor. The account number is
+	// masked unless query.Reveal is set.
+	GetBankAccount(
+		ctx context.Context,
+		query *BankAccountQuery,
+		bankAccountID int64,
+	) (*entity.VendorBankAccount, error)
+	// CreateBankAccount adds a bank account to the vendor and returns it masked.
+	// The account is pending verification and company admins are notified. It
+	// becomes the default account if the vendor has none.
+	CreateBankAccount(
+		ctx context.Context,
+		input *BankAccountInput,
+	) (*entity.VendorBankAccount, error)
+	// UpdateBankAccount replaces a bank account of the vendor and returns it masked.
+	// The account is pending verification again and company admins are notified.
+	UpdateBankAccount(
+		ctx context.Context,
+		bankAccountID int64,
+		input *BankAccountInput,
+	) (*entity.VendorBankAccount, error)
+	// VerifyBankAccount confirms a bank account pending verification. The user must
+	// not be the one who registered or changed the account.
+	VerifyBankAccount(
+		ctx context.Context,
+		companyID, vendorID, bankAccountID, userID int64,
+	) (*entity.VendorBankAccount, error)
+	// SetBankAccountArchived archives or restores a bank account of the vendor and
+	// returns it masked. An archived account is no longer the default account.
+	SetBankAccountArchived(
+		ctx context.Context,
+		companyID, vendorID, bankAccountID int64,
+		archived bool,
+	) (*entity.VendorBankAccount, error)
+	// SetDefaultBankAccount makes the bank account the vendor's default account,
+	// used for invoices that do not specify one.
+	SetDefaultBankAccount(ctx context.Context, companyID, vendorID, bankAccountID int64) error
+	// DeleteBankAccount deletes a bank account of the vendor. It returns
+	// domain.ErrInUse while invoices still transfer to the account.
+	DeleteBankAccount(ctx context.Context, companyID, vendorID, bankAccountID int64) error
+}
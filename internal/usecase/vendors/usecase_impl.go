@@ -0,0 +1,566 @@
+package vendors
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+	"github.com/harusys/super-shiharai-kun/internal/domain/repository"
+	"github.com/harusys/super-shiharai-kun/internal/domain/valueobject"
+	"github.com/harusys/super-shiharai-kun/internal/infrastructure/mail"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/postalcode"
+	"github.com/harusys/super-shiharai-kun/pkg/ctxutil"
+	"github.com/harusys/super-shiharai-kun/pkg/textutil"
+)
+
+type usecaseImpl struct {
+	transactor      repository.Transactor
+	vendorRepo      repository.VendorRepository
+	bankAccountRepo repository.VendorBankAccountRepository
+	invoiceRepo     repository.InvoiceRepository
+	userRepo        repository.UserRepository
+	postalCodes     postalcode.Usecase
+	mailer          mail.Mailer
+	coolingOff      time.Duration
+}
+
+// NewUsecase creates a new vendor Usecase. New or changed bank accounts cannot
+// be paid into until coolingOff has passed.
+func NewUsecase(
+	transactor repository.Transactor,
+	vendorRepo repository.VendorRepository,
+	bankAccountRepo repository.VendorBankAccountRepository,
+	invoiceRepo repository.InvoiceRepository,
+	userRepo repository.UserRepository,
+	postalCodes postalcode.Usecase,
+	mailer mail.Mailer,
+	coolingOff time.Duration,
+) Usecase {
+	return &usecaseImpl{
+		transactor:      transactor,
+		vendorRepo:      vendorRepo,
+		bankAccountRepo: bankAccountRepo,
+		invoiceRepo:     invoiceRepo,
+		userRepo:        userRepo,
+		postalCodes:     postalCodes,
+		mailer:          mailer,
+		coolingOff:      coolingOff,
+	}
+}
+
+func (u *usecaseImpl) List(ctx context.Context, input *ListInput) (*ListResult, error) {
+	page := min(max(input.Page, 1), MaxPage)
+
+	perPage := input.PerPage
+	if perPage <= 0 {
+		perPage = DefaultPerPage
+	}
+
+	perPage = min(perPage, MaxPerPage)
+
+	// Search terms are normalized like the names they are matched against, and
+	// a term typed in hiragana also matches the katakana reading.
+	name := textutil.NormalizeName(input.Name)
+
+	filter := &repository.VendorFilter{
+		CompanyID:       input.CompanyID,
+		Name:            name,
+		NameKana:        textutil.ToKatakana(name),
+		IncludeArchived: input.IncludeArchived,
+		Limit:           perPage,
+		Offset:          (page - 1) * perPage,
+	}
+
+	vendors, err := u.vendorRepo.Search(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := u.vendorRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListResult{
+		Vendors: vendors,
+		Total:   total,
+		Page:    page,
+		PerPage: perPage,
+	}, nil
+}
+
+func (u *usecaseImpl) GetByID(
+	ctx context.Context,
+	companyID, vendorID int64,
+) (*entity.Vendor, error) {
+	return u.vendorRepo.GetByIDAndCompanyID(ctx, vendorID, companyID)
+}
+
+func (u *usecaseImpl) GetStats(
+	ctx context.Context,
+	companyID, vendorID int64,
+) (*entity.VendorPaymentStats, error) {
+	// Verify vendor belongs to company
+	if _, err := u.vendorRepo.GetByIDAndCompanyID(ctx, vendorID, companyID); err != nil {
+		return nil, err
+	}
+
+	return u.invoiceRepo.GetVendorPaymentStats(ctx, vendorID, companyID)
+}
+
+func (u *usecaseImpl) Create(ctx context.Context, input *VendorInput) (*entity.Vendor, error) {
+	vendor, err := u.toEntity(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.vendorRepo.Create(ctx, vendor)
+}
+
+func (u *usecaseImpl) Update(
+	ctx context.Context,
+	vendorID int64,
+	input *VendorInput,
+) (*entity.Vendor, error) {
+	// Verify vendor belongs to company
+	_, err := u.vendorRepo.GetByIDAndCompanyID(ctx, vendorID, input.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+
+	vendor, err := u.toEntity(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	vendor.ID = vendorID
+
+	return u.vendorRepo.Update(ctx, vendor)
+}
+
+func (u *usecaseImpl) SetArchived(
+	ctx context.Context,
+	companyID, vendorID int64,
+	archived bool,
+) (*entity.Vendor, error) {
+	vendor, err := u.vendorRepo.GetByIDAndCompanyID(ctx, vendorID, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if archived {
+		vendor.Archive(ctxutil.Now(ctx))
+	} else {
+		vendor.Unarchive()
+	}
+
+	return u.vendorRepo.UpdateArchivedAt(ctx, vendor)
+}
+
+func (u *usecaseImpl) ListBankAccounts(
+	ctx context.Context,
+	query *BankAccountQuery,
+) ([]*entity.VendorBankAccount, error) {
+	if err := u.authorizeBankAccountQuery(ctx, query); err != nil {
+		return nil, err
+	}
+
+	accounts, err := u.bankAccountRepo.GetByVendorID(ctx, query.VendorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !query.IncludeArchived {
+		accounts = slices.DeleteFunc(accounts, (*entity.VendorBankAccount).IsArchived)
+	}
+
+	if !query.Reveal {
+		for i, a := range accounts {
+			accounts[i] = a.Masked()
+		}
+	}
+
+	return accounts, nil
+}
+
+func (u *usecaseImpl) GetBankAccount(
+	ctx context.Context,
+	query *BankAccountQuery,
+	bankAccountID int64,
+) (*entity.VendorBankAccount, error) {
+	if err := u.authorizeBankAccountQuery(ctx, query); err != nil {
+		return nil, err
+	}
+
+	account, err := u.bankAccountRepo.GetByIDAndVendorID(ctx, bankAccountID, query.VendorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !query.Reveal {
+		return account.Masked(), nil
+	}
+
+	return account, nil
+}
+
+func (u *usecaseImpl) CreateBankAccount(
+	ctx context.Context,
+	input *BankAccountInput,
+) (*entity.VendorBankAccount, error) {
+	// Verify vendor belongs to company
+	vendor, err := u.vendorRepo.GetByIDAndCompanyID(ctx, input.VendorID, input.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := u.bankAccountRepo.GetByVendorID(ctx, input.VendorID)
+	if err != nil {
+		return nil, err
+	}
+
+	account := toBankAccountEntity(input)
+	account.IsDefault = !slices.ContainsFunc(existing, func(a *entity.VendorBankAccount) bool {
+		return a.IsDefault
+	})
+	account.MarkChanged(input.UserID, ctxutil.Now(ctx), u.coolingOff)
+
+	created, err := u.bankAccountRepo.Create(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+
+	u.notifyBankAccountChange(ctx, vendor, created)
+
+	return created.Masked(), nil
+}
+
+func (u *usecaseImpl) UpdateBankAccount(
+	ctx context.Context,
+	bankAccountID int64,
+	input *BankAccountInput,
+) (*entity.VendorBankAccount, error) {
+	// Verify vendor belongs to company and account belongs to vendor
+	vendor, err := u.vendorRepo.GetByIDAndCompanyID(ctx, input.VendorID, input.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = u.bankAccountRepo.GetByIDAndVendorID(ctx, bankAccountID, input.VendorID)
+	if err != nil {
+		return nil, err
+	}
+
+	account := toBankAccountEntity(input)
+	account.ID = bankAccountID
+	account.MarkChanged(input.UserID, ctxutil.Now(ctx), u.coolingOff)
+
+	var updated *entity.VendorBankAccount
+
+	err = u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Invoices created before the change would otherwise be paid into the
+		// changed, unverified account. Locking the vendor's invoices keeps new
+		// ones from being created against the old details in the meantime.
+		if err := u.invoiceRepo.LockByVendorID(ctx, input.VendorID); err != nil {
+			return err
+		}
+
+		unpaid, err := u.invoiceRepo.ExistsUnpaidByBankAccountID(ctx, bankAccountID)
+		if err != nil {
+			return err
+		}
+
+		if unpaid {
+			return fmt.Errorf(
+				"%w: unpaid invoices transfer to the bank account; register a new account instead",
+				domain.ErrInvalidState,
+			)
+		}
+
+		updated, err = u.bankAccountRepo.Update(ctx, account)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	u.notifyBankAccountChange(ctx, vendor, updated)
+
+	return updated.Masked(), nil
+}
+
+func (u *usecaseImpl) VerifyBankAccount(
+	ctx context.Context,
+	companyID, vendorID, bankAccountID, userID int64,
+) (*entity.VendorBankAccount, error) {
+	user, err := u.userRepo.GetByIDAndCompanyID(ctx, userID, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.CompanyID != companyID || !user.CanVerifyBankAccounts() {
+		return nil, fmt.Errorf("%w: not allowed to verify bank accounts", domain.ErrForbidden)
+	}
+
+	// Verify vendor belongs to company and account belongs to vendor
+	_, err = u.vendorRepo.GetByIDAndCompanyID(ctx, vendorID, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := u.bankAccountRepo.GetByIDAndVendorID(ctx, bankAccountID, vendorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := account.Verify(userID, ctxutil.Now(ctx)); err != nil {
+		return nil, err
+	}
+
+	verified, err := u.bankAccountRepo.Verify(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+
+	return verified.Masked(), nil
+}
+
+func (u *usecaseImpl) SetBankAccountArchived(
+	ctx context.Context,
+	companyID, vendorID, bankAccountID int64,
+	archived bool,
+) (*entity.VendorBankAccount, error) {
+	// Verify vendor belongs to company
+	_, err := u.vendorRepo.GetByIDAndCompanyID(ctx, vendorID, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := u.bankAccountRepo.GetByIDAndVendorID(ctx, bankAccountID, vendorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if archived {
+		account.Archive(ctxutil.Now(ctx))
+	} else {
+		account.Unarchive()
+	}
+
+	updated, err := u.bankAccountRepo.UpdateArchivedAt(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+
+	return updated.Masked(), nil
+}
+
+func (u *usecaseImpl) SetDefaultBankAccount(
+	ctx context.Context,
+	companyID, vendorID, bankAccountID int64,
+) error {
+	// Verify vendor belongs to company and account belongs to vendor
+	_, err := u.vendorRepo.GetByIDAndCompanyID(ctx, vendorID, companyID)
+	if err != nil {
+		return err
+	}
+
+	account, err := u.bankAccountRepo.GetByIDAndVendorID(ctx, bankAccountID, vendorID)
+	if err != nil {
+		return err
+	}
+
+	if account.IsArchived() {
+		return fmt.Errorf("%w: bank account is archived", domain.ErrInvalidState)
+	}
+
+	return u.bankAccountRepo.SetDefault(ctx, bankAccountID, vendorID)
+}
+
+func (u *usecaseImpl) DeleteBankAccount(
+	ctx context.Context,
+	companyID, vendorID, bankAccountID int64,
+) error {
+	// Verify vendor belongs to company and account belongs to vendor
+	_, err := u.vendorRepo.GetByIDAndCompanyID(ctx, vendorID, companyID)
+	if err != nil {
+		return err
+	}
+
+	_, err = u.bankAccountRepo.GetByIDAndVendorID(ctx, bankAccountID, vendorID)
+	if err != nil {
+		return err
+	}
+
+	unpaid, err := u.invoiceRepo.ExistsUnpaidByBankAccountID(ctx, bankAccountID)
+	if err != nil {
+		return err
+	}
+
+	if unpaid {
+		return fmt.Errorf("%w: unpaid invoices transfer to the bank account", domain.ErrInUse)
+	}
+
+	return u.bankAccountRepo.Delete(ctx, bankAccountID, vendorID)
+}
+
+// notifyBankAccountChange emails the company admins about a new or changed bank
+// account so that they can confirm it with the vendor through another channel.
+// The change is already saved, so a failure is logged rather than returned.
+func (u *usecaseImpl) notifyBankAccountChange(
+	ctx context.Context,
+	vendor *entity.Vendor,
+	account *entity.VendorBankAccount,
+) {
+	users, err := u.userRepo.GetByCompanyID(ctx, vendor.CompanyID)
+	if err != nil {
+		logNotifyError(account, err)
+
+		return
+	}
+
+	var to []string
+
+	for _, user := range users {
+		if user.IsAdmin() {
+			to = append(to, user.Email)
+		}
+	}
+
+	if len(to) == 0 {
+		return
+	}
+
+	if err := u.mailer.Send(ctx, bankAccountChangeMessage(to, vendor, account)); err != nil {
+		logNotifyError(account, err)
+	}
+}
+
+func logNotifyError(account *entity.VendorBankAccount, err error) {
+	slog.Error("failed to notify bank account change",
+		slog.Int64("bank_account_id", account.ID),
+		slog.Int64("vendor_id", account.VendorID),
+		slog.String("error", err.Error()),
+	)
+}
+
+func bankAccountChangeMessage(
+	to []string,
+	vendor *entity.Vendor,
+	account *entity.VendorBankAccount,
+) *mail.Message {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "取引先「%s」の振込先口座が登録・変更されました。\n\n", vendor.Name)
+	fmt.Fprintf(&b, "銀行名: %s\n", account.BankName)
+	fmt.Fprintf(&b, "支店名: %s\n", account.BranchName)
+	fmt.Fprintf(&b, "口座番号: %s\n", entity.MaskAccountNumber(account.AccountNumber))
+	fmt.Fprintf(&b, "口座名義: %s\n", account.AccountHolderName)
+
+	if account.CoolingOffUntil != nil {
+		fmt.Fprintf(&b, "支払可能日時: %s 以降（確認後）\n",
+			account.CoolingOffUntil.Format("2006-01-02 15:04 MST"))
+	}
+
+	b.WriteString("\n変更した担当者以外のユーザーが、登録済みの電話番号など別の手段で取引先に確認した上で、口座を確認済みにしてください。\n")
+	b.WriteString("心当たりのない変更の場合は、口座を確認済みにせず速やかに調査してください。\n")
+
+	return &mail.Message{
+		To:      to,
+		Subject: "【要確認】取引先の振込先口座が登録・変更されました",
+		Body:    b.String(),
+	}
+}
+
+// authorizeBankAccountQuery verifies that the vendor belongs to the company and,
+// when account numbers are to be revealed, that the user may see them.
+func (u *usecaseImpl) authorizeBankAccountQuery(
+	ctx context.Context,
+	query *BankAccountQuery,
+) error {
+	_, err := u.vendorRepo.GetByIDAndCompanyID(ctx, query.VendorID, query.CompanyID)
+	if err != nil {
+		return err
+	}
+
+	if !query.Reveal {
+		return nil
+	}
+
+	user, err := u.userRepo.GetByIDAndCompanyID(ctx, query.UserID, query.CompanyID)
+	if err != nil {
+		return err
+	}
+
+	if user.CompanyID != query.CompanyID || !user.CanRevealBankAccountNumbers() {
+		return fmt.Errorf("%w: not allowed to reveal account numbers", domain.ErrForbidden)
+	}
+
+	return nil
+}
+
+func (u *usecaseImpl) toEntity(ctx context.Context, input *VendorInput) (*entity.Vendor, error) {
+	name := textutil.NormalizeName(input.Name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", domain.ErrInvalidInput)
+	}
+
+	phoneNumber, err := valueobject.NewPhoneNumber(input.PhoneNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	zipCode, err := u.postalCodes.Validate(ctx, input.ZipCode)
+	if err != nil {
+		return nil, err
+	}
+
+	vendor := &entity.Vendor{
+		CompanyID:                input.CompanyID,
+		ExternalCode:             input.ExternalCode,
+		Name:                     name,
+		RepresentativeName:       textutil.NormalizeName(input.RepresentativeName),
+		PhoneNumber:              phoneNumber.String(),
+		ZipCode:                  zipCode.String(),
+		Address:                  input.Address,
+		WithholdingTaxApplicable: input.WithholdingTaxApplicable,
+	}
+
+	if input.NameKana != "" {
+		kana, err := valueobject.NewKana(input.NameKana)
+		if err != nil {
+			return nil, err
+		}
+
+		vendor.NameKana = kana.String()
+	}
+
+	if input.RegistrationNumber != "" {
+		number, err := valueobject.NewRegistrationNumber(input.RegistrationNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		vendor.RegistrationNumber = number.String()
+	}
+
+	return vendor, nil
+}
+
+func toBankAccountEntity(input *BankAccountInput) *entity.VendorBankAccount {
+	return &entity.VendorBankAccount{
+		VendorID:          input.VendorID,
+		BankCode:          input.BankCode,
+		BankName:          input.BankName,
+		BranchCode:        input.BranchCode,
+		BranchName:        input.BranchName,
+		AccountNumber:     input.AccountNumber,
+		AccountHolderName: input.AccountHolderName,
+	}
+}
@@ -0,0 +1,848 @@
+package vendors_test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
+	"github.com/harusys/super-shiharai-kun/internal/domain/repository"
+	"github.com/harusys/super-shiharai-kun/internal/domain/repository/mock"
+	"github.com/harusys/super-shiharai-kun/internal/domain/valueobject"
+	"github.com/harusys/super-shiharai-kun/internal/infrastructure/mail"
+	mailmock "github.com/harusys/super-shiharai-kun/internal/infrastructure/mail/mock"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/postalcode"
+	postalcodemock "github.com/harusys/super-shiharai-kun/internal/usecase/postalcode/mock"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/vendors"
+	"github.com/harusys/super-shiharai-kun/pkg/ctxutil"
+	"github.com/harusys/super-shiharai-kun/pkg/ctxutil/ctxutiltest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUsecaseImpl_List(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		input      *vendors.ListInput
+		wantFilter *repository.VendorFilter
+		wantPage   int
+		wantPer    int
+	}{
+		{
+			name:  "defaults",
+			input: &vendors.ListInput{CompanyID: 1},
+			wantFilter: &repository.VendorFilter{
+				CompanyID: 1,
+				Limit:     vendors.DefaultPerPage,
+				Offset:    0,
+			},
+			wantPage: 1,
+			wantPer:  vendors.DefaultPerPage,
+		},
+		{
+			name:  "second page filtered by name",
+			input: &vendors.ListInput{CompanyID: 1, Name: "山田", Page: 2, PerPage: 10},
+			wantFilter: &repository.VendorFilter{
+				CompanyID: 1,
+				Name:      "山田",
+				NameKana:  "山田",
+				Limit:     10,
+				Offset:    10,
+			},
+			wantPage: 2,
+			wantPer:  10,
+		},
+		{
+			name:  "hiragana term also matches kana reading",
+			input: &vendors.ListInput{CompanyID: 1, Name: "　やまだ　"},
+			wantFilter: &repository.VendorFilter{
+				CompanyID: 1,
+				Name:      "やまだ",
+				NameKana:  "ヤマダ",
+				Limit:     vendors.DefaultPerPage,
+				Offset:    0,
+			},
+			wantPage: 1,
+			wantPer:  vendors.DefaultPerPage,
+		},
+		{
+			name:  "per page is capped",
+			input: &vendors.ListInput{CompanyID: 1, PerPage: 1000},
+			wantFilter: &repository.VendorFilter{
+				CompanyID: 1,
+				Limit:     vendors.MaxPerPage,
+				Offset:    0,
+			},
+			wantPage: 1,
+			wantPer:  vendors.MaxPerPage,
+		},
+		{
+			name: "page is capped",
+			input: &vendors.ListInput{
+				CompanyID: 1,
+				Page:      math.MaxInt32,
+				PerPage:   vendors.MaxPerPage,
+			},
+			wantFilter: &repository.VendorFilter{
+				CompanyID: 1,
+				Limit:     vendors.MaxPerPage,
+				Offset:    (vendors.MaxPage - 1) * vendors.MaxPerPage,
+			},
+			wantPage: vendors.MaxPage,
+			wantPer:  vendors.MaxPerPage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, uc, c := newUsecase(t)
+			defer c.ctrl.Finish()
+
+			found := []*entity.Vendor{{ID: 1, CompanyID: 1}}
+			c.vendorRepo.EXPECT().Search(ctx, tt.wantFilter).Return(found, nil)
+			c.vendorRepo.EXPECT().Count(ctx, tt.wantFilter).Return(int64(31), nil)
+
+			got, err := uc.List(ctx, tt.input)
+
+			require.NoError(t, err)
+			assert.Equal(t, &vendors.ListResult{
+				Vendors: found,
+				Total:   31,
+				Page:    tt.wantPage,
+				PerPage: tt.wantPer,
+			}, got)
+		})
+	}
+}
+
+func TestUsecaseImpl_Create(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   *vendors.VendorInput
+		prepare func(ctx context.Context, c *controllers)
+		want    *entity.Vendor
+		wantErr error
+	}{
+		{
+			name: "success - names, phone number, zip code and registration number are normalized",
+			input: &vendors.VendorInput{
+				CompanyID:          1,
+				Name:               "株式会社ＴＥＳＴ　ｼｮｳｼﾞ",
+				NameKana:           "かぶしきがいしゃてすとしょうじ",
+				RepresentativeName: "山田　太郎",
+				PhoneNumber:        "03-1234-5678",
+				ZipCode:            "1000001",
+				Address:            "東京都千代田区千代田1-1",
+				RegistrationNumber: "t7000-0120-50002",
+			},
+			prepare: func(ctx context.Context, c *controllers) {
+				c.postalCodes.EXPECT().
+					Validate(ctx, "1000001").
+					Return(valueobject.ZipCode("100-0001"), nil)
+				c.vendorRepo.EXPECT().
+					Create(ctx, &entity.Vendor{
+						CompanyID:          1,
+						Name:               "株式会社TEST ショウジ",
+						NameKana:           "カブシキガイシャテストショウジ",
+						RepresentativeName: "山田 太郎",
+						PhoneNumber:        "+81312345678",
+						ZipCode:            "100-0001",
+						Address:            "東京都千代田区千代田1-1",
+						RegistrationNumber: "T7000012050002",
+					}).
+					Return(&entity.Vendor{ID: 1}, nil)
+			},
+			want: &entity.Vendor{ID: 1},
+		},
+		{
+			name: "invalid registration number",
+			input: &vendors.VendorInput{
+				CompanyID:          1,
+				Name:               "株式会社テスト",
+				PhoneNumber:        "+81312345678",
+				ZipCode:            "100-0001",
+				RegistrationNumber: "T7000012050003",
+			},
+			prepare: func(ctx context.Context, c *controllers) {
+				c.postalCodes.EXPECT().
+					Validate(ctx, "100-0001").
+					Return(valueobject.ZipCode("100-0001"), nil)
+			},
+			wantErr: domain.ErrInvalidInput,
+		},
+		{
+			name: "invalid kana reading",
+			input: &vendors.VendorInput{
+				CompanyID:   1,
+				Name:        "株式会社テスト",
+				NameKana:    "株式会社テスト",
+				PhoneNumber: "+81312345678",
+				ZipCode:     "100-0001",
+			},
+			prepare: func(ctx context.Context, c *controllers) {
+				c.postalCodes.EXPECT().
+					Validate(ctx, "100-0001").
+					Return(valueobject.ZipCode("100-0001"), nil)
+			},
+			wantErr: valueobject.ErrInvalidKana,
+		},
+		{
+			name: "blank name",
+			input: &vendors.VendorInput{
+				CompanyID: 1,
+				Name:      "　",
+			},
+			wantErr: domain.ErrInvalidInput,
+		},
+		{
+			name: "invalid phone number",
+			input: &vendors.VendorInput{
+				CompanyID:   1,
+				Name:        "株式会社テスト",
+				PhoneNumber: "312345678",
+				ZipCode:     "100-0001",
+			},
+			wantErr: valueobject.ErrInvalidPhoneNumber,
+		},
+		{
+			name: "unknown zip code",
+			input: &vendors.VendorInput{
+				CompanyID:   1,
+				Name:        "株式会社テスト",
+				PhoneNumber: "+81312345678",
+				ZipCode:     "999-9999",
+			},
+			prepare: func(ctx context.Context, c *controllers) {
+				c.postalCodes.EXPECT().
+					Validate(ctx, "999-9999").
+					Return(valueobject.ZipCode(""), postalcode.ErrUnknownZipCode)
+			},
+			wantErr: domain.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, uc, c := newUsecase(t)
+			defer c.ctrl.Finish()
+
+			if tt.prepare != nil {
+				tt.prepare(ctx, c)
+			}
+
+			got, err := uc.Create(ctx, tt.input)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUsecaseImpl_Update_OtherCompany(t *testing.T) {
+	t.Parallel()
+
+	ctx, uc, c := newUsecase(t)
+	defer c.ctrl.Finish()
+
+	c.vendorRepo.EXPECT().
+		GetByIDAndCompanyID(ctx, int64(5), int64(1)).
+		Return(nil, domain.ErrNotFound)
+
+	got, err := uc.Update(ctx, 5, &vendors.VendorInput{CompanyID: 1, Name: "株式会社テスト"})
+
+	require.ErrorIs(t, err, domain.ErrNotFound)
+	assert.Nil(t, got)
+}
+
+func TestUsecaseImpl_GetStats(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, uc, c := newUsecase(t)
+		defer c.ctrl.Finish()
+
+		stats := &entity.VendorPaymentStats{PaidInvoiceCount: 2, PaymentAmount: 200000}
+
+		c.vendorRepo.EXPECT().
+			GetByIDAndCompanyID(ctx, int64(5), int64(1)).
+			Return(&entity.Vendor{ID: 5, CompanyID: 1}, nil)
+		c.invoiceRepo.EXPECT().GetVendorPaymentStats(ctx, int64(5), int64(1)).Return(stats, nil)
+
+		got, err := uc.GetStats(ctx, 1, 5)
+
+		require.NoError(t, err)
+		assert.Equal(t, stats, got)
+	})
+
+	t.Run("other company", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, uc, c := newUsecase(t)
+		defer c.ctrl.Finish()
+
+		c.vendorRepo.EXPECT().
+			GetByIDAndCompanyID(ctx, int64(5), int64(1)).
+			Return(nil, domain.ErrNotFound)
+
+		got, err := uc.GetStats(ctx, 1, 5)
+
+		require.ErrorIs(t, err, domain.ErrNotFound)
+		assert.Nil(t, got)
+	})
+}
+
+func TestUsecaseImpl_ListBankAccounts(t *testing.T) {
+	t.Parallel()
+
+	accounts := func() []*entity.VendorBankAccount {
+		return []*entity.VendorBankAccount{{ID: 10, VendorID: 5, AccountNumber: "1234567"}}
+	}
+
+	tests := []struct {
+		name    string
+		query   *vendors.BankAccountQuery
+		prepare func(ctx context.Context, c *controllers)
+		want    []*entity.VendorBankAccount
+		wantErr error
+	}{
+		{
+			name:  "masked by default",
+			query: &vendors.BankAccountQuery{CompanyID: 1, VendorID: 5, UserID: 20},
+			prepare: func(ctx context.Context, c *controllers) {
+				c.vendorRepo.EXPECT().
+					GetByIDAndCompanyID(ctx, int64(5), int64(1)).
+					Return(&entity.Vendor{ID: 5, CompanyID: 1}, nil)
+				c.bankAccountRepo.EXPECT().GetByVendorID(ctx, int64(5)).Return(accounts(), nil)
+			},
+			want: []*entity.VendorBankAccount{{ID: 10, VendorID: 5, AccountNumber: "****567"}},
+		},
+		{
+			name: "revealed for accountant",
+			query: &vendors.BankAccountQuery{
+				CompanyID: 1,
+				VendorID:  5,
+				UserID:    20,
+				Reveal:    true,
+			},
+			prepare: func(ctx context.Context, c *controllers) {
+				c.vendorRepo.EXPECT().
+					GetByIDAndCompanyID(ctx, int64(5), int64(1)).
+					Return(&entity.Vendor{ID: 5, CompanyID: 1}, nil)
+				c.userRepo.EXPECT().
+					GetByIDAndCompanyID(ctx, int64(20), int64(1)).
+					Return(&entity.User{ID: 20, CompanyID: 1, Role: entity.UserRoleAccountant}, nil)
+				c.bankAccountRepo.EXPECT().GetByVendorID(ctx, int64(5)).Return(accounts(), nil)
+			},
+			want: accounts(),
+		},
+		{
+			name: "viewer cannot reveal",
+			query: &vendors.BankAccountQuery{
+				CompanyID: 1,
+				VendorID:  5,
+				UserID:    20,
+				Reveal:    true,
+			},
+			prepare: func(ctx context.Context, c *controllers) {
+				c.vendorRepo.EXPECT().
+					GetByIDAndCompanyID(ctx, int64(5), int64(1)).
+					Return(&entity.Vendor{ID: 5, CompanyID: 1}, nil)
+				c.userRepo.EXPECT().
+					GetByIDAndCompanyID(ctx, int64(20), int64(1)).
+					Return(&entity.User{ID: 20, CompanyID: 1, Role: entity.UserRoleViewer}, nil)
+			},
+			wantErr: domain.ErrForbidden,
+		},
+		{
+			name:  "vendor of other company",
+			query: &vendors.BankAccountQuery{CompanyID: 1, VendorID: 5, UserID: 20},
+			prepare: func(ctx context.Context, c *controllers) {
+				c.vendorRepo.EXPECT().
+					GetByIDAndCompanyID(ctx, int64(5), int64(1)).
+					Return(nil, domain.ErrNotFound)
+			},
+			wantErr: domain.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, uc, c := newUsecase(t)
+			defer c.ctrl.Finish()
+
+			tt.prepare(ctx, c)
+
+			got, err := uc.ListBankAccounts(ctx, tt.query)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUsecaseImpl_DeleteBankAccount(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		prepare func(ctx context.Context, c *controllers)
+		wantErr error
+	}{
+		{
+			name: "success",
+			prepare: func(ctx context.Context, c *controllers) {
+				c.bankAccountRepo.EXPECT().
+					GetByIDAndVendorID(ctx, int64(10), int64(5)).
+					Return(&entity.VendorBankAccount{ID: 10, VendorID: 5}, nil)
+				c.invoiceRepo.EXPECT().
+					ExistsUnpaidByBankAccountID(ctx, int64(10)).
+					Return(false, nil)
+				c.bankAccountRepo.EXPECT().Delete(ctx, int64(10), int64(5)).Return(nil)
+			},
+		},
+		{
+			name: "referenced by unpaid invoices",
+			prepare: func(ctx context.Context, c *controllers) {
+				c.bankAccountRepo.EXPECT().
+					GetByIDAndVendorID(ctx, int64(10), int64(5)).
+					Return(&entity.VendorBankAccount{ID: 10, VendorID: 5}, nil)
+				c.invoiceRepo.EXPECT().ExistsUnpaidByBankAccountID(ctx, int64(10)).Return(true, nil)
+			},
+			wantErr: domain.ErrInUse,
+		},
+		{
+			name: "account of other vendor",
+			prepare: func(ctx context.Context, c *controllers) {
+				c.bankAccountRepo.EXPECT().
+					GetByIDAndVendorID(ctx, int64(10), int64(5)).
+					Return(nil, domain.ErrNotFound)
+			},
+			wantErr: domain.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, uc, c := newUsecase(t)
+			defer c.ctrl.Finish()
+
+			c.vendorRepo.EXPECT().
+				GetByIDAndCompanyID(ctx, int64(5), int64(1)).
+				Return(&entity.Vendor{ID: 5, CompanyID: 1}, nil)
+			tt.prepare(ctx, c)
+
+			err := uc.DeleteBankAccount(ctx, 1, 5, 10)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestUsecaseImpl_CreateBankAccount(t *testing.T) {
+	t.Parallel()
+
+	ctx, uc, c := newUsecase(t)
+	defer c.ctrl.Finish()
+
+	now := ctxutil.Now(ctx)
+	coolingOffUntil := now.Add(72 * time.Hour)
+
+	c.vendorRepo.EXPECT().
+		GetByIDAndCompanyID(ctx, int64(5), int64(1)).
+		Return(&entity.Vendor{ID: 5, CompanyID: 1, Name: "株式会社テスト"}, nil)
+	c.bankAccountRepo.EXPECT().GetByVendorID(ctx, int64(5)).Return(nil, nil)
+	c.bankAccountRepo.EXPECT().
+		Create(ctx, &entity.VendorBankAccount{
+			VendorID:          5,
+			BankName:          "テスト銀行",
+			BranchName:        "本店",
+			AccountNumber:     "1234567",
+			AccountHolderName: "カ）テスト",
+			IsDefault:         true,
+			Status:            entity.BankAccountStatusPendingVerification,
+			ChangedBy:         20,
+			CoolingOffUntil:   &coolingOffUntil,
+		}).
+		DoAndReturn(func(_ context.Context, a *entity.VendorBankAccount) (*entity.VendorBankAccount, error) {
+			created := *a
+			created.ID = 10
+
+			return &created, nil
+		})
+	c.userRepo.EXPECT().
+		GetByCompanyID(ctx, int64(1)).
+		Return([]*entity.User{
+			{
+				ID:        20,
+				CompanyID: 1,
+				Email:     "accountant@example.com",
+				Role:      entity.UserRoleAccountant,
+			},
+			{ID: 21, CompanyID: 1, Email: "admin@example.com", Role: entity.UserRoleAdmin},
+		}, nil)
+	c.mailer.EXPECT().
+		Send(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, msg *mail.Message) error {
+			assert.Equal(t, []string{"admin@example.com"}, msg.To)
+			assert.Contains(t, msg.Body, "株式会社テスト")
+			assert.Contains(t, msg.Body, "****567")
+			assert.NotContains(t, msg.Body, "1234567")
+
+			return nil
+		})
+
+	got, err := uc.CreateBankAccount(ctx, &vendors.BankAccountInput{
+		CompanyID:         1,
+		VendorID:          5,
+		UserID:            20,
+		BankName:          "テスト銀行",
+		BranchName:        "本店",
+		AccountNumber:     "1234567",
+		AccountHolderName: "カ）テスト",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), got.ID)
+	assert.Equal(t, "****567", got.AccountNumber)
+	assert.Equal(t, entity.BankAccountStatusPendingVerification, got.Status)
+	assert.True(t, got.IsDefault, "the first account becomes the default")
+}
+
+func TestUsecaseImpl_UpdateBankAccount(t *testing.T) {
+	t.Parallel()
+
+	input := &vendors.BankAccountInput{
+		CompanyID:         1,
+		VendorID:          5,
+		UserID:            20,
+		BankName:          "テスト銀行",
+		BranchName:        "本店",
+		AccountNumber:     "7654321",
+		AccountHolderName: "カ）テスト",
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, uc, c := newUsecase(t)
+		defer c.ctrl.Finish()
+
+		c.vendorRepo.EXPECT().
+			GetByIDAndCompanyID(ctx, int64(5), int64(1)).
+			Return(&entity.Vendor{ID: 5, CompanyID: 1}, nil)
+		c.bankAccountRepo.EXPECT().
+			GetByIDAndVendorID(ctx, int64(10), int64(5)).
+			Return(&entity.VendorBankAccount{ID: 10, VendorID: 5}, nil)
+		expectTransaction(c)
+		c.invoiceRepo.EXPECT().LockByVendorID(ctx, int64(5)).Return(nil)
+		c.invoiceRepo.EXPECT().ExistsUnpaidByBankAccountID(ctx, int64(10)).Return(false, nil)
+		c.bankAccountRepo.EXPECT().
+			Update(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, a *entity.VendorBankAccount) (*entity.VendorBankAccount, error) {
+				return a, nil
+			})
+		c.userRepo.EXPECT().GetByCompanyID(ctx, int64(1)).Return(nil, nil)
+
+		got, err := uc.UpdateBankAccount(ctx, 10, input)
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.BankAccountStatusPendingVerification, got.Status)
+		assert.Equal(t, "****321", got.AccountNumber)
+	})
+
+	t.Run("invoices created before the change are unpaid", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, uc, c := newUsecase(t)
+		defer c.ctrl.Finish()
+
+		c.vendorRepo.EXPECT().
+			GetByIDAndCompanyID(ctx, int64(5), int64(1)).
+			Return(&entity.Vendor{ID: 5, CompanyID: 1}, nil)
+		c.bankAccountRepo.EXPECT().
+			GetByIDAndVendorID(ctx, int64(10), int64(5)).
+			Return(&entity.VendorBankAccount{ID: 10, VendorID: 5}, nil)
+		expectTransaction(c)
+		c.invoiceRepo.EXPECT().LockByVendorID(ctx, int64(5)).Return(nil)
+		c.invoiceRepo.EXPECT().ExistsUnpaidByBankAccountID(ctx, int64(10)).Return(true, nil)
+
+		got, err := uc.UpdateBankAccount(ctx, 10, input)
+
+		require.ErrorIs(t, err, domain.ErrInvalidState)
+		assert.Nil(t, got)
+	})
+}
+
+func TestUsecaseImpl_VerifyBankAccount(t *testing.T) {
+	t.Parallel()
+
+	pending := func() *entity.VendorBankAccount {
+		return &entity.VendorBankAccount{
+			ID:            10,
+			VendorID:      5,
+			AccountNumber: "1234567",
+			Status:        entity.BankAccountStatusPendingVerification,
+			ChangedBy:     20,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		user    *entity.User
+		prepare func(ctx context.Context, c *controllers)
+		wantErr error
+	}{
+		{
+			name: "success - verified by another accountant",
+			user: &entity.User{ID: 21, CompanyID: 1, Role: entity.UserRoleAccountant},
+			prepare: func(ctx context.Context, c *controllers) {
+				c.vendorRepo.EXPECT().
+					GetByIDAndCompanyID(ctx, int64(5), int64(1)).
+					Return(&entity.Vendor{ID: 5, CompanyID: 1}, nil)
+				c.bankAccountRepo.EXPECT().
+					GetByIDAndVendorID(ctx, int64(10), int64(5)).
+					Return(pending(), nil)
+				c.bankAccountRepo.EXPECT().
+					Verify(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, a *entity.VendorBankAccount) (*entity.VendorBankAccount, error) {
+						return a, nil
+					})
+			},
+		},
+		{
+			name: "account changed since it was read",
+			user: &entity.User{ID: 21, CompanyID: 1, Role: entity.UserRoleAccountant},
+			prepare: func(ctx context.Context, c *controllers) {
+				c.vendorRepo.EXPECT().
+					GetByIDAndCompanyID(ctx, int64(5), int64(1)).
+					Return(&entity.Vendor{ID: 5, CompanyID: 1}, nil)
+				c.bankAccountRepo.EXPECT().
+					GetByIDAndVendorID(ctx, int64(10), int64(5)).
+					Return(pending(), nil)
+				c.bankAccountRepo.EXPECT().
+					Verify(ctx, gomock.Any()).
+					Return(nil, domain.ErrInvalidState)
+			},
+			wantErr: domain.ErrInvalidState,
+		},
+		{
+			name: "the user who changed the account",
+			user: &entity.User{ID: 20, CompanyID: 1, Role: entity.UserRoleAdmin},
+			prepare: func(ctx context.Context, c *controllers) {
+				c.vendorRepo.EXPECT().
+					GetByIDAndCompanyID(ctx, int64(5), int64(1)).
+					Return(&entity.Vendor{ID: 5, CompanyID: 1}, nil)
+				c.bankAccountRepo.EXPECT().
+					GetByIDAndVendorID(ctx, int64(10), int64(5)).
+					Return(pending(), nil)
+			},
+			wantErr: domain.ErrForbidden,
+		},
+		{
+			name:    "viewer",
+			user:    &entity.User{ID: 21, CompanyID: 1, Role: entity.UserRoleViewer},
+			prepare: func(context.Context, *controllers) {},
+			wantErr: domain.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, uc, c := newUsecase(t)
+			defer c.ctrl.Finish()
+
+			c.userRepo.EXPECT().GetByIDAndCompanyID(ctx, tt.user.ID, int64(1)).Return(tt.user, nil)
+			tt.prepare(ctx, c)
+
+			got, err := uc.VerifyBankAccount(ctx, 1, 5, 10, tt.user.ID)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, entity.BankAccountStatusVerified, got.Status)
+			assert.Equal(t, tt.user.ID, got.VerifiedBy)
+			assert.Equal(t, "****567", got.AccountNumber)
+		})
+	}
+}
+
+func TestUsecaseImpl_SetArchived(t *testing.T) {
+	t.Parallel()
+
+	for _, archived := range []bool{true, false} {
+		t.Run(map[bool]string{true: "archive", false: "unarchive"}[archived], func(t *testing.T) {
+			t.Parallel()
+
+			ctx, uc, c := newUsecase(t)
+			defer c.ctrl.Finish()
+
+			archivedAt := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+			now := ctxutil.Now(ctx)
+
+			c.vendorRepo.EXPECT().
+				GetByIDAndCompanyID(ctx, int64(5), int64(1)).
+				Return(&entity.Vendor{ID: 5, CompanyID: 1, ArchivedAt: &archivedAt}, nil)
+			c.vendorRepo.EXPECT().
+				UpdateArchivedAt(ctx, gomock.Any()).
+				DoAndReturn(func(_ context.Context, v *entity.Vendor) (*entity.Vendor, error) {
+					return v, nil
+				})
+
+			got, err := uc.SetArchived(ctx, 1, 5, archived)
+
+			require.NoError(t, err)
+			assert.Equal(t, archived, got.IsArchived())
+
+			if archived {
+				assert.Equal(t, now, *got.ArchivedAt)
+			}
+		})
+	}
+}
+
+func TestUsecaseImpl_ListBankAccounts_ExcludesArchived(t *testing.T) {
+	t.Parallel()
+
+	ctx, uc, c := newUsecase(t)
+	defer c.ctrl.Finish()
+
+	archivedAt := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	c.vendorRepo.EXPECT().
+		GetByIDAndCompanyID(ctx, int64(5), int64(1)).
+		Return(&entity.Vendor{ID: 5, CompanyID: 1}, nil)
+	c.bankAccountRepo.EXPECT().
+		GetByVendorID(ctx, int64(5)).
+		Return([]*entity.VendorBankAccount{
+			{ID: 10, VendorID: 5, AccountNumber: "1234567", ArchivedAt: &archivedAt},
+			{ID: 11, VendorID: 5, AccountNumber: "7654321"},
+		}, nil)
+
+	got, err := uc.ListBankAccounts(ctx, &vendors.BankAccountQuery{CompanyID: 1, VendorID: 5})
+
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, int64(11), got[0].ID)
+}
+
+func TestUsecaseImpl_SetDefaultBankAccount_Archived(t *testing.T) {
+	t.Parallel()
+
+	ctx, uc, c := newUsecase(t)
+	defer c.ctrl.Finish()
+
+	archivedAt := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	c.vendorRepo.EXPECT().
+		GetByIDAndCompanyID(ctx, int64(5), int64(1)).
+		Return(&entity.Vendor{ID: 5, CompanyID: 1}, nil)
+	c.bankAccountRepo.EXPECT().
+		GetByIDAndVendorID(ctx, int64(10), int64(5)).
+		Return(&entity.VendorBankAccount{ID: 10, VendorID: 5, ArchivedAt: &archivedAt}, nil)
+
+	err := uc.SetDefaultBankAccount(ctx, 1, 5, 10)
+
+	require.ErrorIs(t, err, domain.ErrInvalidState)
+}
+
+func expectTransaction(c *controllers) {
+	c.transactor.EXPECT().
+		WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+}
+
+type controllers struct {
+	ctrl            *gomock.Controller
+	transactor      *mock.MockTransactor
+	vendorRepo      *mock.MockVendorRepository
+	bankAccountRepo *mock.MockVendorBankAccountRepository
+	invoiceRepo     *mock.MockInvoiceRepository
+	userRepo        *mock.MockUserRepository
+	postalCodes     *postalcodemock.MockUsecase
+	mailer          *mailmock.MockMailer
+}
+
+func newUsecase(t *testing.T) (context.Context, vendors.Usecase, *controllers) {
+	t.Helper()
+
+	ctxProvider := ctxutiltest.TestContextProvider{}
+	ctxProvider.SetAsiaTokyo(t, "2024-03-01 10:00:00")
+	ctx := ctxutiltest.TestContext(&ctxProvider)
+
+	ctrl := gomock.NewController(t)
+	transactor := mock.NewMockTransactor(ctrl)
+	vendorRepo := mock.NewMockVendorRepository(ctrl)
+	bankAccountRepo := mock.NewMockVendorBankAccountRepository(ctrl)
+	invoiceRepo := mock.NewMockInvoiceRepository(ctrl)
+	userRepo := mock.NewMockUserRepository(ctrl)
+	postalCodes := postalcodemock.NewMockUsecase(ctrl)
+	mailer := mailmock.NewMockMailer(ctrl)
+
+	uc := vendors.NewUsecase(
+		transactor,
+		vendorRepo,
+		bankAccountRepo,
+		invoiceRepo,
+		userRepo,
+		postalCodes,
+		mailer,
+		72*time.Hour,
+	)
+
+	return ctx, uc, &controllers{
+		ctrl:            ctrl,
+		transactor:      transactor,
+		vendorRepo:      vendorRepo,
+		bankAccountRepo: bankAccountRepo,
+		invoiceRepo:     invoiceRepo,
+		userRepo:        userRepo,
+		postalCodes:     postalCodes,
+		mailer:          mailer,
+	}
+}
@@ -0,0 +1,35 @@
+// Package textutil provides helpers for Japanese text: reading files exchanged
+// with Japanese software and normalizing names typed in mixed character widths.
+package textutil
+
+import (
+	"bytes"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/japanese"
+)
+
+// utf8BOM is the byte order mark that spreadsheet software prepends to UTF-8 CSV.
+const utf8BOM = "\xef\xbb\xbf"
+
+// ToUTF8 converts a file to UTF-8, dropping a UTF-8 byte order mark. Files that
+// are not valid UTF-8 are read as Shift_JIS, the encoding Excel uses for CSV on
+// Japanese Windows and Japan Post uses for its postal code data.
+//
+// Bytes that are not valid Shift_JIS either are replaced with U+FFFD.
+func ToUTF8(data []byte) []byte {
+	if rest, ok := bytes.CutPrefix(data, []byte(utf8BOM)); ok {
+		return rest
+	}
+
+	if utf8.Valid(data) {
+		return data
+	}
+
+	decoded, err := japanese.ShiftJIS.NewDecoder().Bytes(data)
+	if err != nil {
+		return data
+	}
+
+	return decoded
+}
@@ -0,0 +1,36 @@
+package textutil_test
+
+import (
+	"testing"
+
+	"github.com/harusys/super-shiharai-kun/pkg/textutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/encoding/japanese"
+)
+
+func TestToUTF8(t *testing.T) {
+	t.Parallel()
+
+	shiftJIS, err := japanese.ShiftJIS.NewEncoder().String("株式会社テスト")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		input []byte
+		want  string
+	}{
+		{name: "UTF-8", input: []byte("株式会社テスト"), want: "株式会社テスト"},
+		{name: "UTF-8 with BOM", input: []byte("\xef\xbb\xbf株式会社テスト"), want: "株式会社テスト"},
+		{name: "Shift_JIS", input: []byte(shiftJIS), want: "株式会社テスト"},
+		{name: "empty", input: nil, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, string(textutil.ToUTF8(tt.input)))
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package textutil
+
+import (
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// hiraganaToKatakana is the offset from a hiragana code point to its katakana.
+const hiraganaToKatakana = 'ア' - 'あ'
+
+// NormalizeName normalizes a name for storage and comparison: NFKC folds
+// full-width letters and digits to ASCII and half-width katakana to full-width
+// (e.g. "ＡＢＣｶﾌﾞｼｷｶﾞｲｼｬ" becomes "ABCカブシキガイシャ"), then runs of
+// whitespace are collapsed to a single space and the ends are trimmed.
+func NormalizeName(s string) string {
+	return strings.Join(strings.Fields(norm.NFKC.String(s)), " ")
+}
+
+// ToKatakana converts hiragana to katakana, leaving other characters as they are.
+func ToKatakana(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'ぁ' && r <= 'ゖ' {
+			return r + hiraganaToKatakana
+		}
+
+		return r
+	}, s)
+}
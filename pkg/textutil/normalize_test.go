@@ -0,0 +1,39 @@
+package textutil_test
+
+import (
+	"testing"
+
+	"github.com/harusys/super-shiharai-kun/pkg/textutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "full-width alphanumerics", input: "ＡＢＣ商事１号店", want: "ABC商事1号店"},
+		{name: "half-width katakana", input: "ｶﾌﾞｼｷｶﾞｲｼｬﾃｽﾄ", want: "カブシキガイシャテスト"},
+		{name: "full-width spaces", input: "　株式会社　　テスト　", want: "株式会社 テスト"},
+		{name: "enclosed abbreviation", input: "㈱テスト", want: "(株)テスト"},
+		{name: "already normalized", input: "株式会社テスト", want: "株式会社テスト"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, textutil.NormalizeName(tt.input))
+		})
+	}
+}
+
+func TestToKatakana(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "カブシキガイシャテスト", textutil.ToKatakana("かぶしきがいしゃテスト"))
+	assert.Equal(t, "ヴァ ABC", textutil.ToKatakana("ゔぁ ABC"))
+}
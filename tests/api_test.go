@@ -3,22 +3,37 @@ package tests
 import (
 	"bytes"
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/harusys/super-shiharai-kun/internal/controller"
+	"github.com/harusys/super-shiharai-kun/internal/domain/entity"
 	"github.com/harusys/super-shiharai-kun/internal/domain/service"
 	"github.com/harusys/super-shiharai-kun/internal/infrastructure/database"
+	"github.com/harusys/super-shiharai-kun/internal/infrastructure/mail"
 	"github.com/harusys/super-shiharai-kun/internal/infrastructure/persistence"
 	"github.com/harusys/super-shiharai-kun/internal/infrastructure/security"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/approval"
 	"github.com/harusys/super-shiharai-kun/internal/usecase/auth"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/company"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/invitation"
 	"github.com/harusys/super-shiharai-kun/internal/usecase/invoice"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/mfa"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/postalcode"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/recurring"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/vendorimport"
+	"github.com/harusys/super-shiharai-kun/internal/usecase/vendors"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pquerna/otp/totp"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -44,22 +59,113 @@ func (s *APITestSuite) SetupSuite() {
 	s.pool = pool
 
 	// Initialize repositories
+	transactor := persistence.NewTransactor(pool)
 	userRepo := persistence.NewUserRepository(pool)
 	vendorRepo := persistence.NewVendorRepository(pool)
 	bankAccountRepo := persistence.NewVendorBankAccountRepository(pool)
 	invoiceRepo := persistence.NewInvoiceRepository(pool)
+	approvalPolicyRepo := persistence.NewApprovalPolicyRepository(pool)
+	scheduleRepo := persistence.NewRecurringInvoiceScheduleRepository(pool)
+	postalCodeRepo := persistence.NewPostalCodeRepository(pool)
+	companyRepo := persistence.NewCompanyRepository(pool)
+	companyAuditLogRepo := persistence.NewCompanyAuditLogRepository(pool)
+	invitationRepo := persistence.NewInvitationRepository(pool)
+	membershipRepo := persistence.NewMembershipRepository(pool)
+	refreshTokenRepo := persistence.NewRefreshTokenRepository(pool)
+	mfaRepo := persistence.NewUserMFARepository(pool)
+	revocationRepo := persistence.NewCachedTokenRevocationRepository(
+		persistence.NewTokenRevocationRepository(pool),
+		0,
+	)
 
 	// Initialize services
-	s.jwtService = security.NewJWTService("test-secret-key")
+	_, signingKey, err := ed25519.GenerateKey(rand.Reader)
+	s.Require().NoError(err)
+
+	jwtKeys, err := security.NewKeySet(signingKey)
+	s.Require().NoError(err)
+
+	s.jwtService = security.NewJWTService(jwtKeys)
 	calculator := service.NewInvoiceCalculator()
+	withholdingCalc := service.NewWithholdingTaxCalculator()
+	mailer := mail.NewFileOutbox(s.T().TempDir(), "noreply@example.com")
 
 	// Initialize usecases
-	authUsecase := auth.NewUsecase(userRepo, s.jwtService)
 	invoiceUsecase := invoice.NewUsecase(
+		transactor,
 		invoiceRepo,
 		vendorRepo,
 		bankAccountRepo,
+		approvalPolicyRepo,
+		userRepo,
 		calculator,
+		withholdingCalc,
+	)
+	approvalUsecase := approval.NewUsecase(approvalPolicyRepo, userRepo)
+	postalCodeUsecase := postalcode.NewUsecase(postalCodeRepo)
+	companyUsecase := company.NewUsecase(
+		transactor,
+		companyRepo,
+		companyAuditLogRepo,
+		userRepo,
+		postalCodeUsecase,
+	)
+	invitationUsecase := invitation.NewUsecase(
+		transactor,
+		invitationRepo,
+		userRepo,
+		membershipRepo,
+		companyRepo,
+		mailer,
+		"http://localhost:3000",
+		7*24*time.Hour,
+	)
+	mfaUsecase := mfa.NewUsecase(
+		transactor,
+		mfaRepo,
+		userRepo,
+		membershipRepo,
+		companyRepo,
+		companyAuditLogRepo,
+		refreshTokenRepo,
+		revocationRepo,
+	)
+	authUsecase := auth.NewUsecase(
+		transactor,
+		userRepo,
+		membershipRepo,
+		refreshTokenRepo,
+		revocationRepo,
+		companyUsecase,
+		invitationUsecase,
+		mfaUsecase,
+		s.jwtService,
+	)
+	vendorUsecase := vendors.NewUsecase(
+		transactor,
+		vendorRepo,
+		bankAccountRepo,
+		invoiceRepo,
+		userRepo,
+		postalCodeUsecase,
+		mailer,
+		72*time.Hour,
+	)
+	importUsecase := vendorimport.NewUsecase(
+		transactor,
+		vendorRepo,
+		bankAccountRepo,
+		userRepo,
+		postalCodeUsecase,
+		mailer,
+		72*time.Hour,
+	)
+	recurringUsecase := recurring.NewUsecase(
+		scheduleRepo,
+		vendorRepo,
+		bankAccountRepo,
+		invoiceUsecase,
+		7,
 	)
 
 	// Setup router
@@ -67,9 +173,16 @@ func (s *APITestSuite) SetupSuite() {
 
 	s.router = gin.New()
 	controller.SetupRoutes(s.router, &controller.RouterConfig{
-		AuthUsecase:    authUsecase,
-		InvoiceUsecase: invoiceUsecase,
-		JWTService:     s.jwtService,
+		AuthUsecase:       authUsecase,
+		InvoiceUsecase:    invoiceUsecase,
+		RecurringUsecase:  recurringUsecase,
+		ApprovalUsecase:   approvalUsecase,
+		VendorUsecase:     vendorUsecase,
+		ImportUsecase:     importUsecase,
+		PostalCodeUsecase: postalCodeUsecase,
+		CompanyUsecase:    companyUsecase,
+		InvitationUsecase: invitationUsecase,
+		MFAUsecase:        mfaUsecase,
 	})
 }
 
@@ -82,11 +195,26 @@ func (s *APITestSuite) TearDownSuite() {
 func (s *APITestSuite) SetupTest() {
 	// Clean up test data before each test
 	if s.pool != nil {
-		ctx := context.Background()
+		ctx := database.WithAllCompanies(context.Background())
 		_, _ = s.pool.Exec(ctx, "DELETE FROM invoices")
+		_, _ = s.pool.Exec(ctx, "DELETE FROM recurring_invoice_schedules")
 		_, _ = s.pool.Exec(ctx, "DELETE FROM vendor_bank_accounts")
 		_, _ = s.pool.Exec(ctx, "DELETE FROM vendors")
+		_, _ = s.pool.Exec(ctx, "DELETE FROM invitations")
 		_, _ = s.pool.Exec(ctx, "DELETE FROM users")
+		_, _ = s.pool.Exec(ctx, "DELETE FROM approval_policies")
+		_, _ = s.pool.Exec(ctx, "DELETE FROM companies")
+	}
+}
+
+// testCompany returns the company profile used to sign up in tests.
+func testCompany() map[string]any {
+	return map[string]any{
+		"name":                "テスト株式会社",
+		"representative_name": "山田太郎",
+		"phone_number":        "03-1234-5678",
+		"zip_code":            "100-0001",
+		"address":             "東京都千代田区千代田1-1",
 	}
 }
 
@@ -105,13 +233,40 @@ func (s *APITestSuite) TestHealthCheck() {
 	s.Equal(http.StatusOK, w.Code)
 }
 
+func (s *APITestSuite) TestJWKS() {
+	accessToken, _, err := s.jwtService.GenerateAccessToken(
+		context.Background(),
+		1,
+		1,
+		entity.UserRoleAdmin,
+		"session-1",
+	)
+	s.Require().NoError(err)
+
+	token, _, err := jwt.NewParser().ParseUnverified(accessToken, &security.Claims{})
+	s.Require().NoError(err)
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil))
+
+	s.Equal(http.StatusOK, w.Code)
+
+	var jwks security.JWKS
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &jwks))
+
+	// Tokens name the published key they are signed with
+	s.Require().Len(jwks.Keys, 1)
+	s.Equal(token.Header["kid"], jwks.Keys[0].KeyID)
+	s.Equal("EdDSA", jwks.Keys[0].Algorithm)
+}
+
 func (s *APITestSuite) TestAuthFlow() {
 	// 1. Register a new user
 	registerBody := map[string]any{
-		"company_id": 1,
-		"name":       "Test User",
-		"email":      "test@example.com",
-		"password":   "password123",
+		"company":  testCompany(),
+		"name":     "Test User",
+		"email":    "test@example.com",
+		"password": "password123",
 	}
 	body, _ := json.Marshal(registerBody)
 	req := httptest.NewRequest(http.MethodPost, "/api/auth/register", bytes.NewReader(body))
@@ -130,6 +285,22 @@ func (s *APITestSuite) TestAuthFlow() {
 	s.NotEmpty(registerResp["access_token"])
 	s.NotEmpty(registerResp["refresh_token"])
 
+	// The user is the first admin of a new company
+	claims, err := s.jwtService.ValidateAccessToken(registerResp["access_token"].(string))
+	s.Require().NoError(err)
+
+	var role string
+
+	err = s.pool.QueryRow(
+		context.Background(),
+		"SELECT role FROM company_memberships WHERE user_id = $1 AND company_id = $2",
+		claims.UserID,
+		claims.CompanyID,
+	).Scan(&role)
+	s.Require().NoError(err)
+	s.Equal("admin", role)
+	s.Equal(entity.UserRoleAdmin, claims.Role)
+
 	// 2. Login with same credentials
 	loginBody := map[string]any{
 		"email":    "test@example.com",
@@ -166,6 +337,109 @@ func (s *APITestSuite) TestAuthFlow() {
 	s.Equal(http.StatusOK, w.Code)
 }
 
+func (s *APITestSuite) TestMFAFlow() {
+	request := func(method, path, accessToken string, reqBody any) (int, map[string]any) {
+		body, _ := json.Marshal(reqBody)
+		req := httptest.NewRequest(method, path, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		if accessToken != "" {
+			req.Header.Set("Authorization", "Bearer "+accessToken)
+		}
+
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, req)
+
+		var resp map[string]any
+
+		_ = json.Unmarshal(w.Body.Bytes(), &resp)
+
+		return w.Code, resp
+	}
+
+	// 1. Register and enrol with an authenticator app
+	code, resp := request(http.MethodPost, "/api/auth/register", "", map[string]any{
+		"company":  testCompany(),
+		"name":     "Test User",
+		"email":    "mfa-test@example.com",
+		"password": "password123",
+	})
+	s.Require().Equal(http.StatusCreated, code)
+
+	accessToken := resp["access_token"].(string)
+
+	code, resp = request(http.MethodPost, "/api/me/mfa/enroll", accessToken, nil)
+	s.Require().Equal(http.StatusOK, code)
+	s.Contains(resp["provisioning_uri"], "otpauth://totp/")
+
+	totpCode, err := totp.GenerateCode(resp["secret"].(string), time.Now())
+	s.Require().NoError(err)
+
+	code, resp = request(http.MethodPost, "/api/me/mfa/activate", accessToken, map[string]any{
+		"code": totpCode,
+	})
+	s.Require().Equal(http.StatusOK, code)
+
+	recoveryCodes := resp["recovery_codes"].([]any)
+	s.Len(recoveryCodes, 10)
+
+	// 2. Logging in now takes a second factor
+	loginBody := map[string]any{"email": "mfa-test@example.com", "password": "password123"}
+	code, resp = request(http.MethodPost, "/api/auth/login", "", loginBody)
+	s.Require().Equal(http.StatusOK, code)
+	s.Equal(true, resp["mfa_required"])
+	s.Nil(resp["access_token"])
+
+	mfaToken := resp["mfa_token"]
+
+	// The code used to activate cannot be used again
+	code, _ = request(http.MethodPost, "/api/auth/mfa/verify", "", map[string]any{
+		"mfa_token": mfaToken,
+		"code":      totpCode,
+	})
+	s.Equal(http.StatusUnauthorized, code)
+
+	code, resp = request(http.MethodPost, "/api/auth/mfa/verify", "", map[string]any{
+		"mfa_token": mfaToken,
+		"code":      recoveryCodes[0],
+	})
+	s.Require().Equal(http.StatusOK, code)
+	s.NotEmpty(resp["access_token"])
+
+	// 3. The MFA token and the recovery code work only once
+	code, _ = request(http.MethodPost, "/api/auth/mfa/verify", "", map[string]any{
+		"mfa_token": mfaToken,
+		"code":      recoveryCodes[1],
+	})
+	s.Equal(http.StatusUnauthorized, code)
+
+	code, resp = request(http.MethodPost, "/api/auth/login", "", loginBody)
+	s.Require().Equal(http.StatusOK, code)
+
+	code, _ = request(http.MethodPost, "/api/auth/mfa/verify", "", map[string]any{
+		"mfa_token": resp["mfa_token"],
+		"code":      recoveryCodes[0],
+	})
+	s.Equal(http.StatusUnauthorized, code)
+
+	// 4. An admin reset lets the user log in with their password again
+	claims, err := s.jwtService.ValidateAccessToken(accessToken)
+	s.Require().NoError(err)
+
+	code, _ = request(
+		http.MethodDelete,
+		fmt.Sprintf("/api/company/users/%d/mfa", claims.UserID),
+		accessToken,
+		nil,
+	)
+	s.Require().Equal(http.StatusNoContent, code)
+
+	code, resp = request(http.MethodPost, "/api/auth/login", "", loginBody)
+	s.Require().Equal(http.StatusOK, code)
+	s.Equal(false, resp["mfa_required"])
+	s.NotEmpty(resp["access_token"])
+}
+
 func (s *APITestSuite) TestProtectedRouteWithoutToken() {
 	req := httptest.NewRequest(http.MethodGet, "/api/invoices", nil)
 	w := httptest.NewRecorder()
@@ -189,10 +463,10 @@ func (s *APITestSuite) TestProtectedRouteWithInvalidToken() {
 func (s *APITestSuite) TestInvoiceFlow() {
 	// 1. Register and get token
 	registerBody := map[string]any{
-		"company_id": 1,
-		"name":       "Test User",
-		"email":      "invoice-test@example.com",
-		"password":   "password123",
+		"company":  testCompany(),
+		"name":     "Test User",
+		"email":    "invoice-test@example.com",
+		"password": "password123",
 	}
 	body, _ := json.Marshal(registerBody)
 	req := httptest.NewRequest(http.MethodPost, "/api/auth/register", bytes.NewReader(body))
@@ -210,20 +484,23 @@ func (s *APITestSuite) TestInvoiceFlow() {
 	accessToken := authResp["access_token"].(string)
 
 	// 2. Create vendor and bank account (direct DB insert for test setup)
-	ctx := context.Background()
+	claims, err := s.jwtService.ValidateAccessToken(accessToken)
+	s.Require().NoError(err)
+
+	ctx := database.WithCompanyID(context.Background(), claims.CompanyID)
 
 	var vendorID, bankAccountID int64
 
-	err := s.pool.QueryRow(ctx, `
+	err = s.pool.QueryRow(ctx, `
 		INSERT INTO vendors (company_id, name, representative_name, phone_number, zip_code, address)
-		VALUES (1, 'Test Vendor', 'Rep Name', '03-1234-5678', '100-0001', 'Tokyo')
+		VALUES ($1, 'Test Vendor', 'Rep Name', '03-1234-5678', '100-0001', 'Tokyo')
 		RETURNING id
-	`).Scan(&vendorID)
+	`, claims.CompanyID).Scan(&vendorID)
 	s.Require().NoError(err)
 
 	err = s.pool.QueryRow(ctx, `
-		INSERT INTO vendor_bank_accounts (vendor_id, bank_name, branch_name, account_number, account_holder_name)
-		VALUES ($1, 'Test Bank', 'Test Branch', '1234567', 'Test Holder')
+		INSERT INTO vendor_bank_accounts (vendor_id, bank_name, branch_name, account_number, account_holder_name, status)
+		VALUES ($1, 'Test Bank', 'Test Branch', '1234567', 'Test Holder', 'verified')
 		RETURNING id
 	`, vendorID).Scan(&bankAccountID)
 	s.Require().NoError(err)
@@ -299,10 +576,10 @@ func (s *APITestSuite) TestInvoiceFlow() {
 func (s *APITestSuite) TestInvoiceListWithDateFilter() {
 	// 1. Register and get token
 	registerBody := map[string]any{
-		"company_id": 1,
-		"name":       "Test User",
-		"email":      "filter-test@example.com",
-		"password":   "password123",
+		"company":  testCompany(),
+		"name":     "Test User",
+		"email":    "filter-test@example.com",
+		"password": "password123",
 	}
 	body, _ := json.Marshal(registerBody)
 	req := httptest.NewRequest(http.MethodPost, "/api/auth/register", bytes.NewReader(body))
@@ -339,6 +616,88 @@ func (s *APITestSuite) TestInvoiceListWithDateFilter() {
 	s.Empty(listResp)
 }
 
+func (s *APITestSuite) TestWithholdingReportExcludesUnpaidInvoices() {
+	// 1. Register and get token
+	registerBody := map[string]any{
+		"company":  testCompany(),
+		"name":     "Test User",
+		"email":    "withholding-test@example.com",
+		"password": "password123",
+	}
+	body, _ := json.Marshal(registerBody)
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/register", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+	s.Require().Equal(http.StatusCreated, w.Code)
+
+	var authResp map[string]any
+
+	_ = json.Unmarshal(w.Body.Bytes(), &authResp)
+	accessToken := authResp["access_token"].(string)
+
+	// 2. Create a paid and an unpaid invoice with withholding tax (direct DB
+	// insert, since nothing in the API marks invoices paid)
+	claims, err := s.jwtService.ValidateAccessToken(accessToken)
+	s.Require().NoError(err)
+
+	ctx := database.WithCompanyID(context.Background(), claims.CompanyID)
+
+	var vendorID, bankAccountID int64
+
+	err = s.pool.QueryRow(ctx, `
+		INSERT INTO vendors (company_id, name, representative_name, phone_number, zip_code, address)
+		VALUES ($1, 'Test Vendor', 'Rep Name', '03-1234-5678', '100-0001', 'Tokyo')
+		RETURNING id
+	`, claims.CompanyID).Scan(&vendorID)
+	s.Require().NoError(err)
+
+	err = s.pool.QueryRow(ctx, `
+		INSERT INTO vendor_bank_accounts (vendor_id, bank_name, branch_name, account_number, account_holder_name, status)
+		VALUES ($1, 'Test Bank', 'Test Branch', '1234567', 'Test Holder', 'verified')
+		RETURNING id
+	`, vendorID).Scan(&bankAccountID)
+	s.Require().NoError(err)
+
+	for _, status := range []string{"paid", "pending"} {
+		_, err = s.pool.Exec(ctx, `
+			INSERT INTO invoices (
+				company_id, vendor_id, vendor_bank_account_id, issue_date, payment_amount,
+				fee, tax, total_amount, withholding_tax, transfer_amount, due_date, status
+			)
+			VALUES ($1, $2, $3, '2024-01-15', 10000, 400, 40, 10440, 1021, 8979, '2024-02-15', $4)
+		`, claims.CompanyID, vendorID, bankAccountID, status)
+		s.Require().NoError(err)
+	}
+
+	// 3. Only the paid invoice is in the report
+	req = httptest.NewRequest(http.MethodGet, "/api/reports/withholding-tax?year=2024", nil)
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	w = httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Require().Equal(http.StatusOK, w.Code)
+
+	var reportResp struct {
+		TotalWithholdingTax int64 `json:"total_withholding_tax"`
+		Vendors             []struct {
+			InvoiceCount   int64 `json:"invoice_count"`
+			WithholdingTax int64 `json:"withholding_tax"`
+		} `json:"vendors"`
+	}
+
+	err = json.Unmarshal(w.Body.Bytes(), &reportResp)
+	s.Require().NoError(err)
+	s.Equal(int64(1021), reportResp.TotalWithholdingTax)
+	s.Require().Len(reportResp.Vendors, 1)
+	s.Equal(int64(1), reportResp.Vendors[0].InvoiceCount)
+	s.Equal(int64(1021), reportResp.Vendors[0].WithholdingTax)
+}
+
 func TestAPITestSuite(t *testing.T) {
 	suite.Run(t, new(APITestSuite))
 }
@@ -0,0 +1,358 @@
+package tests
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/harusys/super-shiharai-kun/internal/domain"
+	"github.com/harusys/super-shiharai-kun/internal/infrastructure/database"
+	"github.com/harusys/super-shiharai-kun/internal/infrastructure/persistence"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/suite"
+)
+
+// rlsRole is the role the application pool connects as. Unlike the role the
+// tests usually run as, it is subject to row-level security.
+const (
+	rlsRole     = "rls_test_app"
+	rlsPassword = "rls_test_app" //nolint:gosec // a throwaway role created and dropped by the tests
+)
+
+// tenantData is the data one company owns.
+type tenantData struct {
+	companyID     int64
+	userID        int64
+	vendorID      int64
+	bankAccountID int64
+	invoiceID     int64
+}
+
+type RLSTestSuite struct {
+	suite.Suite
+	admin *pgxpool.Pool
+	app   *pgxpool.Pool
+	a     tenantData
+	b     tenantData
+}
+
+func (s *RLSTestSuite) SetupSuite() {
+	// Skip if no database URL is set
+	dbURL := os.Getenv("TEST_DATABASE_URL")
+	if dbURL == "" {
+		s.T().Skip("TEST_DATABASE_URL not set, skipping integration tests")
+	}
+
+	ctx := context.Background()
+
+	admin, err := pgxpool.New(ctx, dbURL)
+	s.Require().NoError(err)
+
+	s.admin = admin
+
+	_, err = admin.Exec(ctx, `
+		DO $$
+		BEGIN
+			IF NOT EXISTS (SELECT 1 FROM pg_roles WHERE rolname = '`+rlsRole+`') THEN
+				CREATE ROLE `+rlsRole+` LOGIN PASSWORD '`+rlsPassword+`' NOSUPERUSER NOBYPASSRLS;
+			END IF;
+		END
+		$$`)
+	s.Require().NoError(err)
+
+	_, err = admin.Exec(ctx, "GRANT USAGE ON SCHEMA public TO "+rlsRole)
+	s.Require().NoError(err)
+	_, err = admin.Exec(
+		ctx,
+		"GRANT SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA public TO "+rlsRole,
+	)
+	s.Require().NoError(err)
+	_, err = admin.Exec(ctx, "GRANT USAGE, SELECT ON ALL SEQUENCES IN SCHEMA public TO "+rlsRole)
+	s.Require().NoError(err)
+
+	appURL, err := url.Parse(dbURL)
+	s.Require().NoError(err)
+
+	appURL.User = url.UserPassword(rlsRole, rlsPassword)
+
+	app, err := database.NewPool(ctx, appURL.String())
+	s.Require().NoError(err)
+
+	s.app = app
+}
+
+func (s *RLSTestSuite) TearDownSuite() {
+	if s.app != nil {
+		s.app.Close()
+	}
+
+	if s.admin != nil {
+		s.cleanup()
+
+		ctx := context.Background()
+		_, _ = s.admin.Exec(ctx, "DROP OWNED BY "+rlsRole)
+		_, _ = s.admin.Exec(ctx, "DROP ROLE IF EXISTS "+rlsRole)
+
+		s.admin.Close()
+	}
+}
+
+func (s *RLSTestSuite) SetupTest() {
+	s.cleanup()
+
+	s.a = s.seedTenant("Company A")
+	s.b = s.seedTenant("Company B")
+}
+
+func (s *RLSTestSuite) cleanup() {
+	ctx := database.WithAllCompanies(context.Background())
+	_, _ = s.admin.Exec(ctx, "DELETE FROM invoices")
+	_, _ = s.admin.Exec(ctx, "DELETE FROM vendor_bank_accounts")
+	_, _ = s.admin.Exec(ctx, "DELETE FROM vendors")
+	_, _ = s.admin.Exec(ctx, "DELETE FROM companies")
+	_, _ = s.admin.Exec(ctx, "DELETE FROM users")
+}
+
+// seedTenant creates a company with a member, a vendor, a bank account and an
+// invoice, bypassing row-level security.
+func (s *RLSTestSuite) seedTenant(name string) tenantData {
+	ctx := context.Background()
+
+	var data tenantData
+
+	err := s.admin.QueryRow(ctx, `
+		INSERT INTO companies (name, representative_name, phone_number, zip_code, address)
+		VALUES ($1, 'Rep Name', '+81312345678', '100-0001', 'Tokyo')
+		RETURNING id
+	`, name).Scan(&data.companyID)
+	s.Require().NoError(err)
+
+	err = s.admin.QueryRow(ctx, `
+		INSERT INTO users (name, email, password_hash)
+		VALUES ('Test User', 'user-' || $1::BIGINT || '@example.com', 'hashed')
+		RETURNING id
+	`, data.companyID).Scan(&data.userID)
+	s.Require().NoError(err)
+
+	_, err = s.admin.Exec(ctx, `
+		INSERT INTO company_memberships (user_id, company_id, role)
+		VALUES ($1, $2, 'admin')
+	`, data.userID, data.companyID)
+	s.Require().NoError(err)
+
+	err = s.admin.QueryRow(ctx, `
+		INSERT INTO vendors (company_id, name, representative_name, phone_number, zip_code, address)
+		VALUES ($1, 'Test Vendor', 'Rep Name', '+81312345678', '100-0001', 'Tokyo')
+		RETURNING id
+	`, data.companyID).Scan(&data.vendorID)
+	s.Require().NoError(err)
+
+	err = s.admin.QueryRow(ctx, `
+		INSERT INTO vendor_bank_accounts (vendor_id, bank_name, branch_name, account_number, account_holder_name)
+		VALUES ($1, 'Test Bank', 'Test Branch', '1234567', 'Test Holder')
+		RETURNING id
+	`, data.vendorID).Scan(&data.bankAccountID)
+	s.Require().NoError(err)
+
+	err = s.admin.QueryRow(ctx, `
+		INSERT INTO invoices (
+			company_id, vendor_id, vendor_bank_account_id, issue_date, payment_amount,
+			fee, tax, total_amount, transfer_amount, due_date
+		)
+		VALUES ($1, $2, $3, '2024-01-15', 10000, 400, 40, 10440, 10000, '2024-02-15')
+		RETURNING id
+	`, data.companyID, data.vendorID, data.bankAccountID).Scan(&data.invoiceID)
+	s.Require().NoError(err)
+
+	return data
+}
+
+func (s *RLSTestSuite) TestRepositoryCannotReadAnotherTenant() {
+	ctx := database.WithCompanyID(context.Background(), s.a.companyID)
+
+	// GetByID has no company condition at all; row-level security is the only
+	// thing keeping company B's rows out.
+	invoice, err := persistence.NewInvoiceRepository(s.app).GetByID(ctx, s.a.invoiceID)
+	s.Require().NoError(err)
+	s.Equal(s.a.companyID, invoice.CompanyID)
+
+	_, err = persistence.NewInvoiceRepository(s.app).GetByID(ctx, s.b.invoiceID)
+	s.Require().ErrorIs(err, domain.ErrNotFound)
+
+	_, err = persistence.NewVendorRepository(s.app).GetByID(ctx, s.b.vendorID)
+	s.Require().ErrorIs(err, domain.ErrNotFound)
+
+	_, err = persistence.NewVendorBankAccountRepository(s.app).GetByID(ctx, s.b.bankAccountID)
+	s.Require().ErrorIs(err, domain.ErrNotFound)
+
+	// Even asking for company B explicitly returns nothing
+	invoices, err := persistence.NewInvoiceRepository(s.app).GetByCompanyID(ctx, s.b.companyID)
+	s.Require().NoError(err)
+	s.Empty(invoices)
+}
+
+func (s *RLSTestSuite) TestQueryWithoutWhereSeesOnlyOwnTenant() {
+	ctx := database.WithCompanyID(context.Background(), s.a.companyID)
+
+	for _, table := range []string{
+		"invoices",
+		"vendors",
+		"vendor_bank_accounts",
+		"company_memberships",
+	} {
+		var count int
+
+		err := s.app.QueryRow(ctx, "SELECT count(*) FROM "+table).Scan(&count)
+		s.Require().NoError(err)
+		s.Equal(1, count, table)
+	}
+
+	var companyID int64
+
+	err := s.app.QueryRow(ctx, "SELECT company_id FROM invoices").Scan(&companyID)
+	s.Require().NoError(err)
+	s.Equal(s.a.companyID, companyID)
+}
+
+func (s *RLSTestSuite) TestNoTenantSeesNothing() {
+	ctx := context.Background()
+
+	var count int
+
+	err := s.app.QueryRow(ctx, "SELECT count(*) FROM invoices").Scan(&count)
+	s.Require().NoError(err)
+	s.Zero(count)
+
+	err = s.app.QueryRow(ctx, "SELECT count(*) FROM vendors").Scan(&count)
+	s.Require().NoError(err)
+	s.Zero(count)
+
+	err = s.app.QueryRow(ctx, "SELECT count(*) FROM company_memberships").Scan(&count)
+	s.Require().NoError(err)
+	s.Zero(count)
+}
+
+func (s *RLSTestSuite) TestAllCompaniesSeesEveryTenant() {
+	ctx := database.WithAllCompanies(context.Background())
+
+	var count int
+
+	err := s.app.QueryRow(ctx, "SELECT count(*) FROM invoices").Scan(&count)
+	s.Require().NoError(err)
+	s.Equal(2, count)
+}
+
+func (s *RLSTestSuite) TestCannotWriteAnotherTenant() {
+	ctx := database.WithCompanyID(context.Background(), s.a.companyID)
+
+	// Updates and deletes of company B's rows match nothing
+	tag, err := s.app.Exec(ctx, "UPDATE invoices SET status = 'paid' WHERE id = $1", s.b.invoiceID)
+	s.Require().NoError(err)
+	s.Zero(tag.RowsAffected())
+
+	tag, err = s.app.Exec(ctx, "DELETE FROM vendors WHERE id = $1", s.b.vendorID)
+	s.Require().NoError(err)
+	s.Zero(tag.RowsAffected())
+
+	// Rows cannot be created for company B
+	_, err = s.app.Exec(ctx, `
+		INSERT INTO vendors (company_id, name, representative_name, phone_number, zip_code, address)
+		VALUES ($1, 'Injected Vendor', 'Rep Name', '+81312345678', '100-0001', 'Tokyo')
+	`, s.b.companyID)
+	s.Require().Error(err)
+
+	// Nor can company A's rows be moved to company B
+	_, err = s.app.Exec(
+		ctx,
+		"UPDATE vendors SET company_id = $1 WHERE id = $2",
+		s.b.companyID,
+		s.a.vendorID,
+	)
+	s.Require().Error(err)
+}
+
+func (s *RLSTestSuite) TestCannotReadOrAddAnotherTenantsMembers() {
+	ctx := database.WithCompanyID(context.Background(), s.a.companyID)
+
+	// Company B's member is not visible from company A
+	memberships, err := persistence.NewMembershipRepository(s.app).GetByUserID(ctx, s.b.userID)
+	s.Require().NoError(err)
+	s.Empty(memberships)
+
+	_, err = persistence.NewUserRepository(s.app).
+		GetByIDAndCompanyID(ctx, s.b.userID, s.b.companyID)
+	s.Require().ErrorIs(err, domain.ErrNotFound)
+
+	// Nor can company A's member be added to company B
+	_, err = s.app.Exec(ctx, `
+		INSERT INTO company_memberships (user_id, company_id, role)
+		VALUES ($1, $2, 'admin')
+	`, s.a.userID, s.b.companyID)
+	s.Require().Error(err)
+}
+
+func (s *RLSTestSuite) TestUserSeesOwnMembershipsOfEveryCompany() {
+	// User A also belongs to company B
+	_, err := s.admin.Exec(context.Background(), `
+		INSERT INTO company_memberships (user_id, company_id, role)
+		VALUES ($1, $2, 'viewer')
+	`, s.a.userID, s.b.companyID)
+	s.Require().NoError(err)
+
+	ctx := database.WithUserID(
+		database.WithCompanyID(context.Background(), s.a.companyID),
+		s.a.userID,
+	)
+
+	memberships, err := persistence.NewMembershipRepository(s.app).GetByUserID(ctx, s.a.userID)
+	s.Require().NoError(err)
+	s.Len(memberships, 2)
+
+	user, err := persistence.NewUserRepository(s.app).
+		GetByIDAndCompanyID(ctx, s.a.userID, s.b.companyID)
+	s.Require().NoError(err)
+	s.Equal(s.b.companyID, user.CompanyID)
+
+	// The other members of company B stay hidden
+	var count int
+
+	err = s.app.QueryRow(
+		ctx,
+		"SELECT count(*) FROM company_memberships WHERE company_id = $1",
+		s.b.companyID,
+	).Scan(&count)
+	s.Require().NoError(err)
+	s.Equal(1, count)
+
+	// Seeing a membership does not allow changing it
+	_, err = s.app.Exec(
+		ctx,
+		"UPDATE company_memberships SET role = 'admin' WHERE user_id = $1 AND company_id = $2",
+		s.a.userID,
+		s.b.companyID,
+	)
+	s.Require().Error(err)
+}
+
+func (s *RLSTestSuite) TestTenantDoesNotLeakAcrossAcquires() {
+	// The pool reuses connections, so each acquire must replace the tenant the
+	// previous query set.
+	for range 10 {
+		var companyID int64
+
+		ctx := database.WithCompanyID(context.Background(), s.a.companyID)
+		err := s.app.QueryRow(ctx, "SELECT company_id FROM invoices").Scan(&companyID)
+		s.Require().NoError(err)
+		s.Equal(s.a.companyID, companyID)
+
+		ctx = database.WithCompanyID(context.Background(), s.b.companyID)
+		err = s.app.QueryRow(ctx, "SELECT company_id FROM invoices").Scan(&companyID)
+		s.Require().NoError(err)
+		s.Equal(s.b.companyID, companyID)
+	}
+}
+
+func TestRLSTestSuite(t *testing.T) {
+	suite.Run(t, new(RLSTestSuite))
+}